@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"golang/app/middlewares/session"
+	"golang/models/dto"
+	"golang/util"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// JwtClaims is the claims of every access token, the role decides what the user can access
+type JwtClaims struct {
+	ID   string `json:"id"`
+	Role string `json:"role"`
+	jwt.StandardClaims
+}
+
+// GenerateToken creates an access token for the user that belongs to the refresh token family
+func GenerateToken(userID, role, familyID string) (string, time.Time, error) {
+	issuedAt := time.Now().Local()
+	expiresAt := issuedAt.Add(session.AccessTokenDuration)
+	claims := JwtClaims{
+		userID,
+		role,
+		jwt.StandardClaims{
+			Id:        uuid.NewString(),
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+
+	// Create token with claims
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token, err := t.SignedString([]byte(util.GetConfig("TOKEN_SECRET")))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	// record the token so it can be checked and revoked later
+	err = session.Create(claims.Id, userID, role, familyID, issuedAt, expiresAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// GenerateTokenPair creates an access token and a refresh token in a new token family
+func GenerateTokenPair(userID, role string) (dto.TokenPair, error) {
+	familyID, familyIssuedAt := session.NewFamily()
+	return generateTokenPair(userID, role, familyID, familyIssuedAt)
+}
+
+// RefreshToken exchanges a refresh token of the role for a new token pair in the same family
+func RefreshToken(refreshToken, role string) (dto.TokenPair, error) {
+	oldToken, err := session.RotateRefreshToken(refreshToken, role)
+	if err != nil {
+		return dto.TokenPair{}, err
+	}
+	return generateTokenPair(oldToken.UserID, role, oldToken.FamilyID, oldToken.FamilyIssuedAt)
+}
+
+func generateTokenPair(userID, role, familyID string, familyIssuedAt time.Time) (dto.TokenPair, error) {
+	accessToken, accessExpiresAt, err := GenerateToken(userID, role, familyID)
+	if err != nil {
+		return dto.TokenPair{}, err
+	}
+
+	refreshToken, refreshExpiresAt, err := session.IssueRefreshToken(userID, role, familyID, familyIssuedAt)
+	if err != nil {
+		return dto.TokenPair{}, err
+	}
+
+	return dto.TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refreshExpiresAt,
+	}, nil
+}
+
+// GetUser returns the claims of the token parsed by the jwt middleware
+func GetUser(c echo.Context) *JwtClaims {
+	user, ok := c.Get("user").(*jwt.Token)
+	if !ok {
+		return nil
+	}
+
+	claims, ok := user.Claims.(*JwtClaims)
+	if !ok {
+		return nil
+	}
+	return claims
+}
+
+// CheckToken check if the token id belongs to an active session of the role
+func CheckToken(tokenID, role string) bool {
+	return session.IsActive(tokenID, role)
+}
+
+// Logout revokes the session of the token id
+func Logout(tokenID string) bool {
+	err := session.Revoke(tokenID)
+	return err == nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"golang/app/middlewares/session"
+	"golang/constant/constantRole"
+	"golang/repository/sessionRepository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteAuth struct {
+	suite.Suite
+}
+
+func (s *suiteAuth) SetupTest() {
+	session.SetStore(sessionRepository.NewMemorySessionStore())
+	now := time.Now()
+	s.NoError(session.Create("customer-token", "abcde", constantRole.RoleCustomer, "", now, now.Add(time.Hour)))
+	s.NoError(session.Create("admin-token", "abcde", constantRole.RoleAdmin, "", now, now.Add(time.Hour)))
+}
+
+func (s *suiteAuth) TestRequireRoleAndPermission() {
+	testCase := []struct {
+		Name               string
+		Middleware         echo.MiddlewareFunc
+		Claims             *JwtClaims
+		ExpectedStatusCode int
+	}{
+		{
+			"success customer role",
+			RequireRole(constantRole.RoleCustomer),
+			&JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer, StandardClaims: jwt.StandardClaims{Id: "customer-token"}},
+			http.StatusOK,
+		},
+		{
+			"fail customer on instructor route",
+			RequireRole(constantRole.RoleInstructor),
+			&JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer, StandardClaims: jwt.StandardClaims{Id: "customer-token"}},
+			http.StatusForbidden,
+		},
+		{
+			"fail role not matching the session",
+			RequireRole(constantRole.RoleAdmin),
+			&JwtClaims{ID: "abcde", Role: constantRole.RoleAdmin, StandardClaims: jwt.StandardClaims{Id: "customer-token"}},
+			http.StatusUnauthorized,
+		},
+		{
+			"fail token without session",
+			RequireRole(constantRole.RoleCustomer),
+			&JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer, StandardClaims: jwt.StandardClaims{Id: "unknown"}},
+			http.StatusUnauthorized,
+		},
+		{
+			"success admin manage category",
+			RequirePermission(PermissionManageCategory),
+			&JwtClaims{ID: "abcde", Role: constantRole.RoleAdmin, StandardClaims: jwt.StandardClaims{Id: "admin-token"}},
+			http.StatusOK,
+		},
+		{
+			"fail customer manage category",
+			RequirePermission(PermissionManageCategory),
+			&JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer, StandardClaims: jwt.StandardClaims{Id: "customer-token"}},
+			http.StatusForbidden,
+		},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			w := httptest.NewRecorder()
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.Set("user", &jwt.Token{Claims: v.Claims})
+
+			handler := v.Middleware(func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			})
+			err := handler(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestSuiteAuth(t *testing.T) {
+	suite.Run(t, new(suiteAuth))
+}
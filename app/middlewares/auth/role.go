@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"golang/constant/constantRole"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Permission string
+
+const (
+	// PermissionManageCategory allows to create, update and delete categories
+	PermissionManageCategory Permission = "category:manage"
+	// PermissionManageInstructor allows to list, approve, suspend and delete instructors
+	PermissionManageInstructor Permission = "instructor:manage"
+	// PermissionManageCustomer allows to list and delete customers
+	PermissionManageCustomer Permission = "customer:manage"
+	// PermissionReviewCourse allows to publish or reject the courses sent to review and archive courses
+	PermissionReviewCourse Permission = "course:review"
+	// PermissionManageCourse allows to create and edit own courses and its content
+	PermissionManageCourse Permission = "course:manage"
+	// PermissionLearnCourse allows to enroll and learn courses
+	PermissionLearnCourse Permission = "course:learn"
+)
+
+// rolePermissions is the list of permission of every role
+var rolePermissions = map[string][]Permission{
+	constantRole.RoleAdmin: {
+		PermissionManageCategory,
+		PermissionManageInstructor,
+		PermissionManageCustomer,
+		PermissionReviewCourse,
+	},
+	constantRole.RoleInstructor: {
+		PermissionManageCourse,
+	},
+	constantRole.RoleCustomer: {
+		PermissionLearnCourse,
+	},
+}
+
+// HasPermission check if the role is granted the permission
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireRole only lets through requests with an active session of one of the roles
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims := GetUser(c)
+			if claims == nil || !CheckToken(claims.Id, claims.Role) {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"message": "invalid create token",
+				})
+			}
+
+			for _, role := range roles {
+				if claims.Role == role {
+					return next(c)
+				}
+			}
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"message": "you are not authorized",
+			})
+		}
+	}
+}
+
+// RequirePermission only lets through requests with an active session of a role that has every permission
+func RequirePermission(permissions ...Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims := GetUser(c)
+			if claims == nil || !CheckToken(claims.Id, claims.Role) {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"message": "invalid create token",
+				})
+			}
+
+			for _, permission := range permissions {
+				if !HasPermission(claims.Role, permission) {
+					return c.JSON(http.StatusForbidden, map[string]string{
+						"message": "you are not authorized",
+					})
+				}
+			}
+			return next(c)
+		}
+	}
+}
@@ -1,10 +1,6 @@
 package middlewares
 
 import (
-	customer "golang/app/middlewares/costumer"
-	instructor "golang/app/middlewares/instructor"
-	"net/http"
-
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -18,28 +14,3 @@ func (c *ConfigLogger) Init() echo.MiddlewareFunc {
 		Format: c.Format,
 	})
 }
-
-func CheckTokenMiddlewareCustomer(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		customerID := customer.GetUserCustomer(c)
-
-		if customerID == nil {
-			return c.JSON(http.StatusUnauthorized, map[string]string{
-				"messege": "invalid create token",
-			})
-		}
-		return next(c)
-	}
-}
-func CheckTokenMiddlewareInstructor(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		InstructorID := instructor.GetUserInstructor(c)
-
-		if InstructorID == nil {
-			return c.JSON(http.StatusUnauthorized, map[string]string{
-				"messege": "invalid create token",
-			})
-		}
-		return next(c)
-	}
-}
@@ -0,0 +1,195 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"golang/repository/sessionRepository"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AccessTokenDuration is how long an access token can be used
+	AccessTokenDuration = time.Hour * 2
+	// RefreshTokenDuration is how long a refresh token can be used, every rotation slides it forward
+	RefreshTokenDuration = time.Hour * 24 * 7
+	// RefreshFamilyDuration is the absolute lifetime of a token family, after that the user has to login again
+	RefreshFamilyDuration = time.Hour * 24 * 30
+)
+
+var (
+	mu    sync.RWMutex
+	store sessionRepository.SessionStore = sessionRepository.NewMemorySessionStore()
+)
+
+// SetStore replaces the store used to record issued tokens
+func SetStore(s sessionRepository.SessionStore) {
+	mu.Lock()
+	defer mu.Unlock()
+	store = s
+}
+
+// Store returns the store used to record issued tokens
+func Store() sessionRepository.SessionStore {
+	mu.RLock()
+	defer mu.RUnlock()
+	return store
+}
+
+// Create records a new session for the token id
+func Create(id, userID, role, familyID string, issuedAt, expiresAt time.Time) error {
+	return Store().CreateSession(dto.Session{
+		ID:        id,
+		UserID:    userID,
+		Role:      role,
+		FamilyID:  familyID,
+		IssuedAt:  issuedAt,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// IsActive check if the token id belongs to a session of the role that is not revoked or expired
+func IsActive(id, role string) bool {
+	if id == "" {
+		return false
+	}
+	session, err := Store().GetSession(id)
+	if err != nil {
+		return false
+	}
+	if session.Role != role {
+		return false
+	}
+	return session.IsActive(time.Now())
+}
+
+// Revoke revokes the session of the token id together with its refresh token family
+func Revoke(id string) error {
+	session, err := Store().GetSession(id)
+	if err != nil {
+		return err
+	}
+	if session.FamilyID != "" {
+		return Store().RevokeTokenFamily(session.FamilyID)
+	}
+	return Store().RevokeSession(id)
+}
+
+// RevokeUser revokes every session of the user
+func RevokeUser(userID, role string) error {
+	return Store().RevokeUserSessions(userID, role)
+}
+
+// NewFamily starts a new refresh token family
+func NewFamily() (familyID string, issuedAt time.Time) {
+	return uuid.NewString(), time.Now()
+}
+
+// IssueRefreshToken creates a refresh token in the family and returns the raw token,
+// only the hash of the token is stored
+func IssueRefreshToken(userID, role, familyID string, familyIssuedAt time.Time) (string, time.Time, error) {
+	raw := make([]byte, 32)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	// the refresh token slides forward but never outlives the family
+	expiresAt := time.Now().Add(RefreshTokenDuration)
+	familyExpiresAt := familyIssuedAt.Add(RefreshFamilyDuration)
+	if expiresAt.After(familyExpiresAt) {
+		expiresAt = familyExpiresAt
+	}
+
+	err = Store().CreateRefreshToken(dto.RefreshToken{
+		ID:             uuid.NewString(),
+		TokenHash:      hashToken(token),
+		FamilyID:       familyID,
+		FamilyIssuedAt: familyIssuedAt,
+		UserID:         userID,
+		Role:           role,
+		ExpiresAt:      expiresAt,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// RotateRefreshToken marks the refresh token as used and returns it so a new pair can be issued
+// in the same family. Using a token that was already rotated revokes the whole family.
+func RotateRefreshToken(token, role string) (dto.RefreshToken, error) {
+	refreshToken, err := Store().GetRefreshToken(hashToken(token))
+	if err != nil || refreshToken.Role != role || refreshToken.RevokedAt != nil {
+		return dto.RefreshToken{}, errors.New(constantError.ErrorInvalidRefreshToken)
+	}
+
+	// a rotated token that comes back means it was stolen, stop the whole family
+	if refreshToken.RotatedAt != nil {
+		err = Store().RevokeTokenFamily(refreshToken.FamilyID)
+		if err != nil {
+			return dto.RefreshToken{}, err
+		}
+		return dto.RefreshToken{}, errors.New(constantError.ErrorRefreshTokenReused)
+	}
+
+	if !time.Now().Before(refreshToken.ExpiresAt) {
+		return dto.RefreshToken{}, errors.New(constantError.ErrorRefreshTokenExpired)
+	}
+
+	err = Store().RotateRefreshToken(refreshToken.ID)
+	if err != nil {
+		// another request rotated the token first
+		errRevoke := Store().RevokeTokenFamily(refreshToken.FamilyID)
+		if errRevoke != nil {
+			return dto.RefreshToken{}, errRevoke
+		}
+		return dto.RefreshToken{}, errors.New(constantError.ErrorRefreshTokenReused)
+	}
+	return refreshToken, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// StartPruning deletes expired sessions every interval until the returned function is called
+func StartPruning(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				deleted, err := Store().DeleteExpiredSessions(time.Now())
+				if err != nil {
+					log.Printf("fail prune expired sessions: %s", err)
+					continue
+				}
+				if deleted > 0 {
+					log.Printf("pruned %d expired sessions", deleted)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package session
+
+import (
+	"golang/constant/constantError"
+	"golang/repository/sessionRepository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteSession struct {
+	suite.Suite
+}
+
+func (s *suiteSession) SetupTest() {
+	SetStore(sessionRepository.NewMemorySessionStore())
+}
+
+func (s *suiteSession) TestIsActive() {
+	now := time.Now()
+	s.NoError(Create("token", "abcde", "customer", "", now, now.Add(time.Hour)))
+	s.NoError(Create("expired", "abcde", "customer", "", now.Add(-2*time.Hour), now.Add(-time.Hour)))
+
+	testCase := []struct {
+		Name     string
+		ID       string
+		Role     string
+		Expected bool
+	}{
+		{"success active session", "token", "customer", true},
+		{"fail session of other role", "token", "instructor", false},
+		{"fail unknown session", "unknown", "customer", false},
+		{"fail empty session id", "", "customer", false},
+		{"fail expired session", "expired", "customer", false},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			s.Equal(v.Expected, IsActive(v.ID, v.Role))
+		})
+	}
+}
+
+func (s *suiteSession) TestRevoke() {
+	now := time.Now()
+	s.NoError(Create("token", "abcde", "customer", "", now, now.Add(time.Hour)))
+	s.True(IsActive("token", "customer"))
+
+	s.NoError(Revoke("token"))
+	s.False(IsActive("token", "customer"))
+}
+
+func (s *suiteSession) TestRevokeUser() {
+	now := time.Now()
+	s.NoError(Create("first", "abcde", "customer", "", now, now.Add(time.Hour)))
+	s.NoError(Create("second", "abcde", "customer", "", now, now.Add(time.Hour)))
+	s.NoError(Create("instructor", "abcde", "instructor", "", now, now.Add(time.Hour)))
+	s.NoError(Create("other", "other", "customer", "", now, now.Add(time.Hour)))
+
+	s.NoError(RevokeUser("abcde", "customer"))
+	s.False(IsActive("first", "customer"))
+	s.False(IsActive("second", "customer"))
+	// only the sessions of the user with the role are revoked
+	s.True(IsActive("instructor", "instructor"))
+	s.True(IsActive("other", "customer"))
+}
+
+func (s *suiteSession) TestStartPruning() {
+	now := time.Now()
+	s.NoError(Create("token", "abcde", "customer", "", now, now.Add(time.Hour)))
+	s.NoError(Create("expired", "abcde", "customer", "", now.Add(-2*time.Hour), now.Add(-time.Hour)))
+
+	stop := StartPruning(10 * time.Millisecond)
+	defer stop()
+	s.Eventually(func() bool {
+		_, err := Store().GetSession("expired")
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// the sessions that are not expired are kept
+	_, err := Store().GetSession("token")
+	s.NoError(err)
+}
+
+func (s *suiteSession) TestRotateRefreshToken() {
+	familyID, familyIssuedAt := NewFamily()
+	now := time.Now()
+	err := Create("access", "abcde", "customer", familyID, now, now.Add(time.Hour))
+	s.NoError(err)
+	token, _, err := IssueRefreshToken("abcde", "customer", familyID, familyIssuedAt)
+	s.NoError(err)
+
+	testCase := []struct {
+		Name          string
+		Token         string
+		Role          string
+		HasReturnErr  bool
+		ExpectedError string
+	}{
+		{"fail wrong role", token, "instructor", true, constantError.ErrorInvalidRefreshToken},
+		{"fail unknown token", "unknown", "customer", true, constantError.ErrorInvalidRefreshToken},
+		{"success rotate token", token, "customer", false, ""},
+		{"fail reuse rotated token", token, "customer", true, constantError.ErrorRefreshTokenReused},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			refreshToken, err := RotateRefreshToken(v.Token, v.Role)
+			if v.HasReturnErr {
+				s.EqualError(err, v.ExpectedError)
+			} else {
+				s.NoError(err)
+				s.Equal("abcde", refreshToken.UserID)
+				s.Equal(familyID, refreshToken.FamilyID)
+			}
+		})
+	}
+
+	// reuse revokes every token of the family
+	s.False(IsActive("access", "customer"))
+}
+
+func (s *suiteSession) TestRefreshTokenNeverOutlivesFamily() {
+	familyIssuedAt := time.Now().Add(-RefreshFamilyDuration + time.Hour)
+	_, expiresAt, err := IssueRefreshToken("abcde", "customer", "family", familyIssuedAt)
+	s.NoError(err)
+	s.Equal(familyIssuedAt.Add(RefreshFamilyDuration), expiresAt)
+}
+
+func TestSuiteSession(t *testing.T) {
+	suite.Run(t, new(suiteSession))
+}
@@ -2,46 +2,84 @@ package routes
 
 import (
 	middlewares "golang/app/middlewares"
-	middlewareCostumer "golang/app/middlewares/costumer"
-	middlewareInstructor "golang/app/middlewares/instructor"
+	"golang/app/middlewares/auth"
+	"golang/app/middlewares/session"
+	"golang/constant/constantRole"
+	"golang/controllers/adminController"
 	assignmentcontroller "golang/controllers/assignmentController"
 	"golang/controllers/categoryController"
 	"golang/controllers/costumerController"
 	"golang/controllers/courseController"
+	"golang/controllers/coursePackageController"
+	"golang/controllers/courseVersionController"
 	customerassignmentcontroller "golang/controllers/customerAssignmentController"
 	"golang/controllers/customerCourseController"
 	"golang/controllers/favoriteController"
 	instructorController "golang/controllers/instructorController"
+	"golang/controllers/learningPathController"
 	mediamodulecontroller "golang/controllers/mediaModuleController"
 	"golang/controllers/moduleController"
+	"golang/controllers/progressionController"
+	"golang/controllers/questionBankController"
 	quizcontroller "golang/controllers/quizController"
 	"golang/controllers/ratingController"
+	"golang/controllers/sectionController"
+	"golang/drivers/mailer"
+	"golang/drivers/search"
+	"golang/drivers/storage"
 	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/adminRepository"
 	assignmentrepository "golang/repository/assignmentRepository"
 	"golang/repository/categoryRepository"
 	"golang/repository/courseRepository"
+	"golang/repository/courseVersionRepository"
 	customerassignmentrepository "golang/repository/customerAssignmentRepository"
 	"golang/repository/customerCourseRepository"
 	"golang/repository/customerRepository"
+	"golang/repository/emailChangeRepository"
 	"golang/repository/favoriteRepository"
 	instructorrepository "golang/repository/instructorRepository"
+	"golang/repository/learningPathRepository"
+	"golang/repository/mailOutboxRepository"
 	mediamodulerepository "golang/repository/mediaModuleRepository"
 	modulerepository "golang/repository/moduleRepository"
+	"golang/repository/ownershipRepository"
+	"golang/repository/passwordResetRepository"
+	"golang/repository/progressionRepository"
+	"golang/repository/questionBankRepository"
 	quizrepository "golang/repository/quizRepository"
 	"golang/repository/ratingRepository"
+	"golang/repository/sectionRepository"
+	"golang/repository/sessionRepository"
+	"golang/service/adminService"
 	assignmentservice "golang/service/assignmentService"
 	"golang/service/categoryService"
 	"golang/service/costumerService"
+	"golang/service/coursePackageService"
 	"golang/service/courseService"
+	"golang/service/courseVersionService"
 	"golang/service/customerAssignmentService"
 	"golang/service/customerCourseService"
+	"golang/service/emailChangeService"
 	"golang/service/favoriteService"
 	instructorservice "golang/service/instructorService"
+	"golang/service/learningPathService"
+	"golang/service/mailService"
 	mediamoduleservice "golang/service/mediaModuleService"
 	moduleservice "golang/service/moduleService"
+	"golang/service/ownershipService"
+	"golang/service/passwordResetService"
+	"golang/service/progressionService"
+	"golang/service/questionBankService"
 	quizservice "golang/service/quizService"
 	"golang/service/ratingService"
+	"golang/service/searchService"
+	"golang/service/sectionService"
 	"golang/util"
+	"log"
+	"strconv"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
@@ -54,12 +92,18 @@ func New(db *gorm.DB) *echo.Echo {
 	/*
 		Repositories
 	*/
+	adminRepository := adminRepository.NewAdminRepository(db)
 	quizRepository := quizrepository.NewQuizRepository(db)
+	questionBankRepository := questionBankRepository.NewQuestionBankRepository(db)
 	customerRepository := customerRepository.NewCustomerRepository(db)
 	instructorRepository := instructorrepository.Newinstructorrepository(db)
 	categoryRepository := categoryRepository.NewCategoryRepository(db)
 	courseRepository := courseRepository.NewCourseRepository(db)
+	courseVersionRepository := courseVersionRepository.NewCourseVersionRepository(db)
 	moduleRepository := modulerepository.NewModuleRepository(db)
+	sectionRepository := sectionRepository.NewSectionRepository(db)
+	progressionRepository := progressionRepository.NewProgressionRepository(db)
+	learningPathRepository := learningPathRepository.NewLearningPathRepository(db)
 	mediamodulerepository := mediamodulerepository.NewMediaModuleRepository(db)
 	assignmentRepository := assignmentrepository.NewAssignmentRepository(db)
 	customerAssignmentRepository := customerassignmentrepository.NewcustomerAssignmentRepository(db)
@@ -67,29 +111,109 @@ func New(db *gorm.DB) *echo.Echo {
 
 	favoriteRepository := favoriteRepository.NewFavoriteRepository(db)
 	ratingRepository := ratingRepository.NewRatingRepository(db)
+	sessionRepository := sessionRepository.NewSessionRepository(db)
+	ownershipRepository := ownershipRepository.NewOwnershipRepository(db)
+	mailOutboxRepository := mailOutboxRepository.NewMailOutboxRepository(db)
+	passwordResetRepository := passwordResetRepository.NewPasswordResetRepository(db)
+	emailChangeRepository := emailChangeRepository.NewEmailChangeRepository(db)
+
+	/*
+		Sessions
+	*/
+	session.SetStore(sessionRepository)
+	session.StartPruning(time.Hour)
+
+	/*
+		Mails
+	*/
+	mailSender := mailer.New(mailer.Config{
+		Driver:   util.GetConfig("MAIL_DRIVER"),
+		Host:     util.GetConfig("SMTP_HOST"),
+		Port:     util.GetConfig("SMTP_PORT"),
+		Username: util.GetConfig("AUTH_EMAIL"),
+		Password: util.GetConfig("AUTH_PASSWORD"),
+		From:     util.GetConfig("SENDER_NAME"),
+		Dir:      util.GetConfig("MAIL_DIR"),
+	})
+
+	/*
+		Storage
+	*/
+	storageConfig := storage.Config{
+		Dir:     util.GetConfig("STORAGE_DIR"),
+		BaseURL: util.GetConfig("STORAGE_BASE_URL"),
+	}
+	fileStorage := storage.New(storageConfig)
+
+	/*
+		Search
+	*/
+	searchIndex := search.New(db, util.GetConfig("SEARCH_DRIVER"))
+
+	// new instructors wait for an admin approval before they can publish courses
+	instructorApprovalRequired, _ := strconv.ParseBool(util.GetConfig("INSTRUCTOR_APPROVAL_REQUIRED"))
 
 	/*
 		Services
 	*/
-	quizService := quizservice.NewQuizService(quizRepository)
-	costumerService := costumerService.NewcostumerService(customerRepository)
-	instructorService := instructorservice.NewinstructorService(instructorRepository)
+	ownershipService := ownershipService.NewOwnershipService(ownershipRepository)
+	mailService := mailService.NewMailService(mailOutboxRepository, mailSender)
+	passwordResetService := passwordResetService.NewPasswordResetService(passwordResetRepository)
+	emailChangeService := emailChangeService.NewEmailChangeService(emailChangeRepository, mailService, helper.GetVerificationPolicy())
+	adminService := adminService.NewAdminService(adminRepository)
+	quizService := quizservice.NewQuizService(quizRepository, customerCourseRepository, ownershipService)
+	questionBankService := questionBankService.NewQuestionBankService(questionBankRepository, ownershipService)
+	costumerService := costumerService.NewcostumerService(customerRepository, mailService, passwordResetService, emailChangeService, fileStorage, helper.GetVerificationPolicy(), helper.GetRetentionPolicy())
+	instructorService := instructorservice.NewinstructorService(instructorRepository, courseRepository, mailService, passwordResetService, emailChangeService, fileStorage, helper.GetVerificationPolicy(), instructorApprovalRequired)
 	categoryService := categoryService.NewCategoryService(categoryRepository)
-	courseService := courseService.NewCourseService(courseRepository, categoryRepository)
-	moduleService := moduleservice.NewModuleService(moduleRepository)
-	mediamoduleservice := mediamoduleservice.NewMediaModuleService(mediamodulerepository)
-	assignmentService := assignmentservice.NewAssignmentService(assignmentRepository)
-	customerAssignmentService := customerAssignmentService.NewcustomerAssignmentService(customerAssignmentRepository)
-	customerCourseService := customerCourseService.NewCustomerCourseService(customerCourseRepository, courseRepository)
+	searchService := searchService.NewSearchService(courseRepository, searchIndex)
+	courseService := courseService.NewCourseService(courseRepository, categoryRepository, instructorRepository, courseVersionRepository, searchService)
+	courseVersionService := courseVersionService.NewCourseVersionService(courseRepository, courseVersionRepository, customerCourseRepository)
+	coursePackageService := coursePackageService.NewCoursePackageService(courseRepository, categoryRepository, instructorRepository)
+	moduleService := moduleservice.NewModuleService(moduleRepository, sectionRepository, ownershipService, searchService)
+	sectionService := sectionService.NewSectionService(sectionRepository, ownershipService)
+	progressionService := progressionService.NewProgressionService(progressionRepository, moduleRepository, ownershipService)
+	learningPathService := learningPathService.NewLearningPathService(learningPathRepository, ownershipService)
+	mediamoduleservice := mediamoduleservice.NewMediaModuleService(mediamodulerepository, ownershipService)
+	assignmentService := assignmentservice.NewAssignmentService(assignmentRepository, ownershipService)
+	customerAssignmentService := customerAssignmentService.NewcustomerAssignmentService(customerAssignmentRepository, customerCourseRepository, ownershipService)
+	customerCourseService := customerCourseService.NewCustomerCourseService(customerCourseRepository, courseRepository, customerRepository, mailService)
 	favoriteService := favoriteService.NewFavoriteService(favoriteRepository, courseRepository)
 	ratingService := ratingService.NewRatingService(ratingRepository, courseRepository)
 
+	// deliver the mails queued in the outbox
+	mailService.StartOutboxWorker(10 * time.Second)
+
+	// the memory index starts empty and the courses made before the search existed are not in the mysql index
+	err := searchService.Reindex()
+	if err != nil {
+		log.Printf("fail index courses: %s", err)
+	}
+
+	// create the first admin account from the config
+	if adminEmail := util.GetConfig("ADMIN_EMAIL"); adminEmail != "" {
+		err := adminService.EnsureAdmin(dto.AdminRegister{
+			Name:     "admin",
+			Email:    adminEmail,
+			Password: util.GetConfig("ADMIN_PASSWORD"),
+		})
+		if err != nil {
+			log.Printf("fail create admin account: %s", err)
+		}
+	}
+
 	/*
 		Controllers
 	*/
+	adminController := adminController.AdminController{
+		AdminService: adminService,
+	}
 	quizController := quizcontroller.QuizController{
 		QuizService: quizService,
 	}
+	questionBankController := questionBankController.QuestionBankController{
+		QuestionBankService: questionBankService,
+	}
 
 	costumerController := costumerController.CostumerController{
 		CostumerService: costumerService,
@@ -102,12 +226,21 @@ func New(db *gorm.DB) *echo.Echo {
 	}
 	courseController := courseController.CourseController{
 		CourseService: courseService,
+		SearchService: searchService,
 	}
 
 	moduleController := moduleController.ModuleController{
 		ModuleService: moduleService,
 	}
 
+	sectionController := sectionController.SectionController{
+		SectionService: sectionService,
+	}
+
+	progressionController := progressionController.ProgressionController{
+		ProgressionService: progressionService,
+	}
+
 	mediaModuleController := mediamodulecontroller.MediaModuleController{
 		MediaModuleService: mediamoduleservice,
 	}
@@ -129,6 +262,15 @@ func New(db *gorm.DB) *echo.Echo {
 	ratingController := ratingController.RatingController{
 		RatingService: ratingService,
 	}
+	courseVersionController := courseVersionController.CourseVersionController{
+		CourseVersionService: courseVersionService,
+	}
+	coursePackageController := coursePackageController.CoursePackageController{
+		CoursePackageService: coursePackageService,
+	}
+	learningPathController := learningPathController.LearningPathController{
+		LearningPathService: learningPathService,
+	}
 
 	/*
 		API Routes
@@ -146,18 +288,17 @@ func New(db *gorm.DB) *echo.Echo {
 	configLogger := middlewares.ConfigLogger{
 		Format: "[${time_rfc3339}] ${status} ${method} ${host} ${path} ${latency_human}" + "\n",
 	}
-	configCostumer := middleware.JWTConfig{
-		Claims:     &middlewareCostumer.JwtCostumerClaims{},
-		SigningKey: []byte(util.GetConfig("TOKEN_SECRET")),
-	}
-	configInstructor := middleware.JWTConfig{
-		Claims:     &middlewareInstructor.JwtInstructorClaims{},
+	configJWT := middleware.JWTConfig{
+		Claims:     &auth.JwtClaims{},
 		SigningKey: []byte(util.GetConfig("TOKEN_SECRET")),
 	}
 
 	app.Use(configLogger.Init())
 	app.Use(middleware.CORS())
 
+	// serve the uploaded files, like profile images
+	app.Static(storage.LocalPath, storageConfig.LocalDir())
+
 	// auto tls
 	// app.GET("/", func(c echo.Context) error {
 	// 	return c.HTML(http.StatusOK, `
@@ -170,26 +311,69 @@ func New(db *gorm.DB) *echo.Echo {
 	costumer := app.Group("/customer")
 	costumer.POST("/register", costumerController.Register)
 	costumer.POST("/verifikasi", costumerController.Verifikasi)
+	costumer.POST("/verifikasi/resend", costumerController.ResendCode)
 	costumer.POST("/login", costumerController.Login)
+	costumer.POST("/refresh", costumerController.Refresh)
+	costumer.POST("/forgot-password", costumerController.ForgotPassword)
+	costumer.POST("/reset-password", costumerController.ResetPassword)
 
-	privateCostumer := app.Group("/customer", middleware.JWTWithConfig(configCostumer))
-	privateCostumer.Use(middlewares.CheckTokenMiddlewareCustomer)
+	privateCostumer := app.Group("/customer", middleware.JWTWithConfig(configJWT))
+	privateCostumer.Use(auth.RequireRole(constantRole.RoleCustomer))
 	// private costumer access
 	privateCostumer.POST("/logout", costumerController.Logout)
+	privateCostumer.PUT("/change-password", costumerController.ChangePassword)
+	privateCostumer.GET("/profile", costumerController.GetProfile)
+	privateCostumer.PUT("/profile", costumerController.UpdateProfile)
+	privateCostumer.PUT("/profile/email", costumerController.ChangeEmail)
+	privateCostumer.POST("/profile/email/verify", costumerController.VerifyEmailChange)
+	privateCostumer.PUT("/profile/image", costumerController.UpdateProfileImage)
+	privateCostumer.DELETE("/account", costumerController.DeleteAccount)
+	privateCostumer.GET("/account/export", costumerController.ExportAccount)
+	privateCostumer.GET("/instructor/:id/profile", instructorController.GetPublicProfile)
 
 	// -->
 
 	// instructor
 	instructor := app.Group("/instructor")
 	instructor.POST("/register", instructorController.Register)
+	instructor.POST("/verifikasi", instructorController.Verifikasi)
+	instructor.POST("/verifikasi/resend", instructorController.ResendCode)
 	instructor.POST("/login", instructorController.Login)
+	instructor.POST("/refresh", instructorController.Refresh)
+	instructor.POST("/forgot-password", instructorController.ForgotPassword)
+	instructor.POST("/reset-password", instructorController.ResetPassword)
 
-	privateInstructor := app.Group("/instructor", middleware.JWTWithConfig(configInstructor))
-	privateInstructor.Use(middlewares.CheckTokenMiddlewareInstructor)
+	privateInstructor := app.Group("/instructor", middleware.JWTWithConfig(configJWT))
+	privateInstructor.Use(auth.RequireRole(constantRole.RoleInstructor))
 	/*
 		private instructor access
 	*/
 	privateInstructor.POST("/logout", instructorController.Logout)
+	privateInstructor.PUT("/change-password", instructorController.ChangePassword)
+	privateInstructor.GET("/profile", instructorController.GetProfile)
+	privateInstructor.PUT("/profile", instructorController.UpdateProfile)
+	privateInstructor.PUT("/profile/email", instructorController.ChangeEmail)
+	privateInstructor.POST("/profile/email/verify", instructorController.VerifyEmailChange)
+	privateInstructor.PUT("/profile/image", instructorController.UpdateProfileImage)
+
+	// -->
+
+	// admin
+	admin := app.Group("/admin")
+	admin.POST("/login", adminController.Login)
+	admin.POST("/refresh", adminController.Refresh)
+
+	privateAdmin := app.Group("/admin", middleware.JWTWithConfig(configJWT))
+	privateAdmin.Use(auth.RequireRole(constantRole.RoleAdmin))
+	/*
+		private admin access
+	*/
+	privateAdmin.POST("/logout", adminController.Logout)
+	privateAdmin.GET("/instructor/get_all", instructorController.GetAllInstructor, auth.RequirePermission(auth.PermissionManageInstructor))
+	privateAdmin.PUT("/instructor/status/:id", instructorController.UpdateInstructorStatus, auth.RequirePermission(auth.PermissionManageInstructor))
+	privateAdmin.DELETE("/instructor/delete/:id", instructorController.DeleteInstructor, auth.RequirePermission(auth.PermissionManageInstructor))
+	privateAdmin.GET("/customer/get_all", costumerController.GetAllCustomer, auth.RequirePermission(auth.PermissionManageCustomer))
+	privateAdmin.DELETE("/customer/delete/:id", costumerController.DeleteCustomer, auth.RequirePermission(auth.PermissionManageCustomer))
 
 	//quiz
 
@@ -197,17 +381,35 @@ func New(db *gorm.DB) *echo.Echo {
 	privateInstructor.POST("/quiz/create", quizController.CreateQuiz)
 	privateInstructor.GET("/quiz/get_all", quizController.GetAllQuiz)
 	privateInstructor.DELETE("/quiz/delete/:id", quizController.DeleteQuiz)
+	privateInstructor.GET("/quiz/get_by_id/:id", quizController.GetQuizByID)
+	privateInstructor.GET("/quiz/get_by_course_id/:id", quizController.GetQuizzesByCourseID)
+	privateInstructor.PUT("/quiz/update/:id", quizController.UpdateQuiz)
+	privateInstructor.GET("/quiz/results/:id", quizController.GetQuizResults)
+	privateInstructor.POST("/quiz/question/create", quizController.CreateQuizQuestion)
+	privateInstructor.PUT("/quiz/question/update/:id", quizController.UpdateQuizQuestion)
+	privateInstructor.DELETE("/quiz/question/delete/:id", quizController.DeleteQuizQuestion)
+	privateInstructor.POST("/quiz/attempt/regrade/:id", quizController.RegradeQuizAttempt)
+	privateInstructor.POST("/quiz/import/:id", quizController.ImportQuizQuestions)
+	privateInstructor.GET("/quiz/export/:id", quizController.ExportQuizQuestions)
 	// customer access
 	privateCostumer.GET("/quiz/take_quiz", quizController.TakeQuiz)
+	privateCostumer.GET("/quiz/get_by_course_id/:id", quizController.GetQuizzesByCourseID)
+	privateCostumer.POST("/quiz/start/:id", quizController.StartQuiz)
+	privateCostumer.POST("/quiz/submit/:id", quizController.SubmitQuiz)
+	privateCostumer.GET("/quiz/attempt/get_all/:id", quizController.GetQuizAttempts)
+	privateCostumer.GET("/quiz/attempt/get_by_id/:id", quizController.GetQuizAttemptByID)
 
 	// category
 
+	//admin access
+	privateAdmin.POST("/category/create", categoryController.CreateCategory, auth.RequirePermission(auth.PermissionManageCategory))
+	privateAdmin.DELETE("/category/delete/:id", categoryController.DeleteCategory, auth.RequirePermission(auth.PermissionManageCategory))
+	privateAdmin.GET("/category/get_all", categoryController.GetAllCategory)
+	privateAdmin.GET("/category/get_by_id/:id", categoryController.GetCategoryByID)
+	privateAdmin.PUT("/category/update/:id", categoryController.UpdateCategory, auth.RequirePermission(auth.PermissionManageCategory))
 	//instructor access
-	privateInstructor.POST("/category/create", categoryController.CreateCategory)
-	privateInstructor.DELETE("/category/delete/:id", categoryController.DeleteCategory)
 	privateInstructor.GET("/category/get_all", categoryController.GetAllCategory)
 	privateInstructor.GET("/category/get_by_id/:id", categoryController.GetCategoryByID)
-	privateInstructor.PUT("/category/update/:id", categoryController.UpdateCategory)
 	//costumer access
 	privateCostumer.GET("/category/get_all", categoryController.GetAllCategory)
 	privateCostumer.GET("/category/get_by_id/:id", categoryController.GetCategoryByID)
@@ -222,6 +424,12 @@ func New(db *gorm.DB) *echo.Echo {
 	privateInstructor.GET("/course/get_by_id/:id", courseController.GetCourseByID)
 	privateInstructor.GET("/course/get_all", courseController.GetAllCourse)
 	privateInstructor.PUT("/course/update/:id", courseController.UpdateCourse)
+	privateInstructor.PUT("/course/status/:id", courseController.UpdateCourseStatus)
+	privateInstructor.POST("/course/:id/duplicate", courseController.DuplicateCourse)
+	privateInstructor.POST("/course/:id/template", courseController.CreateCourseTemplate)
+	privateInstructor.GET("/course/template/get_all", courseController.GetCourseTemplates)
+	privateInstructor.GET("/course/:id/export", coursePackageController.ExportCourse)
+	privateInstructor.POST("/course/import", coursePackageController.ImportCourse)
 	// customer course
 	privateInstructor.GET("/course/get_by_course_id/:courseId/enroll", courseController.GetCourseEnrollByID)
 	privateInstructor.GET("/course/get_by_id/:id/enroll", customerCourseController.GetCustomerCourseEnrollByID)
@@ -229,10 +437,23 @@ func New(db *gorm.DB) *echo.Echo {
 	// rating
 	privateInstructor.GET("/course/get_by_id/:courseId/rating", ratingController.GetRatingByCourseID)
 	privateInstructor.PUT("/course/rating/update/:ratingId", ratingController.UpdateRating)
+	// version
+	privateInstructor.POST("/course/version/publish/:id", courseVersionController.PublishCourseVersion)
+	privateInstructor.GET("/course/version/get_all/:id", courseVersionController.GetCourseVersions)
+	// prerequisite
+	privateInstructor.POST("/course/:id/prerequisite", courseController.AddCoursePrerequisite)
+	privateInstructor.DELETE("/course/:id/prerequisite/:prerequisiteId", courseController.DeleteCoursePrerequisite)
+	privateInstructor.GET("/course/:id/prerequisite", courseController.GetCoursePrerequisites)
+
+	//admin access
+	privateAdmin.GET("/course/get_all", courseController.GetAllCourse, auth.RequirePermission(auth.PermissionReviewCourse))
+	privateAdmin.GET("/course/get_by_id/:id", courseController.GetCourseByID, auth.RequirePermission(auth.PermissionReviewCourse))
+	privateAdmin.PUT("/course/status/:id", courseController.UpdateCourseStatus, auth.RequirePermission(auth.PermissionReviewCourse))
 
 	//costumer access
 	privateCostumer.GET("/course/get_by_id/:id", courseController.GetCourseByID)
 	privateCostumer.GET("/course/get_all", courseController.GetAllCourse)
+	privateCostumer.GET("/course/search", courseController.SearchCourse)
 	// customer course
 	privateCostumer.POST("/course/enroll/take/:courseId", customerCourseController.TakeCourse)
 	privateCostumer.GET("/course/history", customerCourseController.GetHistoryCourseByCustomerID)
@@ -245,6 +466,35 @@ func New(db *gorm.DB) *echo.Echo {
 	privateCostumer.POST("/course/rating/add/:courseId", ratingController.AddRating)
 	privateCostumer.DELETE("/course/rating/delete/:courseId", ratingController.DeleteRating)
 	privateCostumer.GET("/course/rating/get_by_id/:courseId", ratingController.GetRatingByCourseIDCustomerID)
+	// version
+	privateCostumer.GET("/course/version/:courseId", courseVersionController.GetEnrollmentVersion)
+	privateCostumer.POST("/course/version/migrate/:courseId", courseVersionController.MigrateEnrollment)
+	// prerequisite
+	privateCostumer.GET("/course/:id/prerequisite", courseController.GetCoursePrerequisites)
+
+	//question bank
+	//instructor access
+	privateInstructor.POST("/question_bank/create", questionBankController.CreateQuestionBank)
+	privateInstructor.DELETE("/question_bank/delete/:id", questionBankController.DeleteQuestionBank)
+	privateInstructor.GET("/question_bank/get_by_id/:id", questionBankController.GetQuestionBankByID)
+	privateInstructor.GET("/question_bank/get_by_course_id/:id", questionBankController.GetQuestionBanksByCourseID)
+	privateInstructor.PUT("/question_bank/update/:id", questionBankController.UpdateQuestionBank)
+	privateInstructor.POST("/question_bank/question/create", questionBankController.CreateBankQuestion)
+	privateInstructor.PUT("/question_bank/question/update/:id", questionBankController.UpdateBankQuestion)
+	privateInstructor.DELETE("/question_bank/question/delete/:id", questionBankController.DeleteBankQuestion)
+	privateInstructor.POST("/question_bank/import/:id", questionBankController.ImportBankQuestions)
+	privateInstructor.GET("/question_bank/export/:id", questionBankController.ExportBankQuestions)
+
+	//learning path
+	//instructor access
+	privateInstructor.POST("/learning_path/create", learningPathController.CreateLearningPath)
+	privateInstructor.DELETE("/learning_path/delete/:id", learningPathController.DeleteLearningPath)
+	privateInstructor.GET("/learning_path/get_all", learningPathController.GetLearningPaths)
+	privateInstructor.GET("/learning_path/get_by_id/:id", learningPathController.GetLearningPathByID)
+	privateInstructor.PUT("/learning_path/update/:id", learningPathController.UpdateLearningPath)
+	//costumer access
+	privateCostumer.GET("/learning_path/get_all", learningPathController.GetLearningPaths)
+	privateCostumer.GET("/learning_path/get_by_id/:id", learningPathController.GetLearningPathByID)
 
 	//module
 	//instructor access
@@ -254,11 +504,28 @@ func New(db *gorm.DB) *echo.Echo {
 	privateInstructor.GET("/module/get_by_id/:id", moduleController.GetModuleByIDifInstructor)
 	privateInstructor.GET("/module/get_by_course_id/:id", moduleController.GetModuleByCourseIDifInstructror)
 	privateInstructor.PUT("/module/update/:id", moduleController.UpdateModule)
+	privateInstructor.PUT("/module/reorder/:courseId", moduleController.ReorderModules)
+	privateInstructor.GET("/module/rules/:id", progressionController.GetModuleRules)
+	privateInstructor.PUT("/module/rules/:id", progressionController.UpdateModuleRules)
 	//costumer access
 	privateCostumer.GET("/module/get_all", moduleController.GetAllModule)
 	privateCostumer.GET("/module/get_by_id/:id", moduleController.GetModuleByID)
 	privateCostumer.GET("/module/get_by_course_id", moduleController.GetModuleByCourseID)
 
+	//progress
+	//costumer access
+	privateCostumer.POST("/progress/view/:id", progressionController.ViewModule)
+	privateCostumer.POST("/progress/media/:id", progressionController.WatchMedia)
+	privateCostumer.GET("/progress/module/:id", progressionController.GetModuleProgress)
+	privateCostumer.GET("/progress/events", progressionController.GetProgressEvents)
+
+	//section
+	//instructor access
+	privateInstructor.POST("/section/create", sectionController.CreateSection)
+	privateInstructor.DELETE("/section/delete/:id", sectionController.DeleteSection)
+	privateInstructor.GET("/section/get_by_course_id/:id", sectionController.GetSectionsByCourseID)
+	privateInstructor.PUT("/section/update/:id", sectionController.UpdateSection)
+
 	//media module
 	//instructor access
 	privateInstructor.POST("/media_module/create", mediaModuleController.CreateMediaModule)
@@ -284,17 +551,17 @@ func New(db *gorm.DB) *echo.Echo {
 
 	//customer assignment
 	//instructor access
-	privateInstructor.POST("/customer_assignment/create", customerAssignmentController.CreateCustomerAssignment)
-	privateInstructor.DELETE("/customer_assignment/delete/:id", customerAssignmentController.DeleteCustomerAssignment)
-	privateInstructor.GET("/customer_assignment/get_all", customerAssignmentController.GetAllCustomerAssignment)
+	privateInstructor.GET("/customer_assignment/get_by_assignment_id/:id", customerAssignmentController.GetCustomerAssignmentsByAssignmentID)
 	privateInstructor.GET("/customer_assignment/get_by_id/:id", customerAssignmentController.GetCustomerAssignmentByID)
-	privateInstructor.PUT("/customer_assignment/update/:id", customerAssignmentController.UpdateCustomerAssignment)
-	//costumer access
-	privateCostumer.POST("/customer_assignment/create", customerAssignmentController.CreateCustomerAssignment)
+	privateInstructor.POST("/customer_assignment/grade/:id", customerAssignmentController.GradeCustomerAssignment)
+	privateInstructor.POST("/customer_assignment/return/:id", customerAssignmentController.ReturnCustomerAssignment)
+	privateInstructor.DELETE("/customer_assignment/delete/:id", customerAssignmentController.DeleteCustomerAssignment)
+
+	privateCostumer.POST("/customer_assignment/draft", customerAssignmentController.SaveDraft)
+	privateCostumer.POST("/customer_assignment/submit", customerAssignmentController.SubmitAssignment)
 	privateCostumer.DELETE("/customer_assignment/delete/:id", customerAssignmentController.DeleteCustomerAssignment)
 	privateCostumer.GET("/customer_assignment/get_all", customerAssignmentController.GetAllCustomerAssignment)
 	privateCostumer.GET("/customer_assignment/get_by_id/:id", customerAssignmentController.GetCustomerAssignmentByID)
-	privateCostumer.PUT("/customer_assignment/update/:id", customerAssignmentController.UpdateCustomerAssignment)
 
 	return app
 }
@@ -25,6 +25,122 @@ const (
 	ErrorDuplicateAssignmentCustomer   = "there is duplicate data in the customer assignment"
 	ErrorAssignmentNotFoud             = "assignment not found"
 	ErrorNoActive                      = "email not verifikasi"
+	// ErrorInvalidRefreshToken is error message when refresh token is unknown or revoked
+	ErrorInvalidRefreshToken = "invalid refresh token"
+	// ErrorRefreshTokenExpired is error message when refresh token is expired
+	ErrorRefreshTokenExpired = "refresh token expired"
+	// ErrorRefreshTokenReused is error message when a rotated refresh token is used again
+	ErrorRefreshTokenReused = "refresh token already used"
+	// ErrorVerificationCodeExpired is error message when the verification code is expired
+	ErrorVerificationCodeExpired = "code expired"
+	// ErrorVerificationCodeInvalid is error message when the verification code does not match
+	ErrorVerificationCodeInvalid = "code invalid"
+	// ErrorTooManyAttempts is error message when the verification code is locked after too many wrong guesses
+	ErrorTooManyAttempts = "too many attempts"
+	// ErrorResendCooldown is error message when a new code is requested too soon
+	ErrorResendCooldown = "code recently sent, try again later"
+	// ErrorInvalidResetToken is error message when the password reset token is unknown or already used
+	ErrorInvalidResetToken = "invalid reset token"
+	// ErrorResetTokenExpired is error message when the password reset token is expired
+	ErrorResetTokenExpired = "reset token expired"
+	// ErrorOldPasswordNotMatch is error message when the current password is wrong on change password
+	ErrorOldPasswordNotMatch = "old password not match"
+	// ErrorInstructorNotApproved is error message when the instructor account is not approved by an admin yet
+	ErrorInstructorNotApproved = "instructor not approved"
+	// ErrorInstructorSuspended is error message when the instructor account is suspended by an admin
+	ErrorInstructorSuspended = "instructor suspended"
+	// ErrorEmailAlreadyUsed is error message when the email belongs to another account
+	ErrorEmailAlreadyUsed = "email already used"
+	// ErrorInvalidImage is error message when the uploaded file is not a supported image
+	ErrorInvalidImage = "invalid image"
+	// ErrorImageTooLarge is error message when the uploaded image is larger than allowed
+	ErrorImageTooLarge = "image too large"
+	// ErrorPasswordNotMatch is error message when the password confirming an action is wrong
+	ErrorPasswordNotMatch = "password not match"
+	// ErrorInvalidCursor is error message when the cursor of a paginated list can't be read
+	ErrorInvalidCursor = "invalid cursor"
+	// ErrorCourseStatus is error message when the course can't move from its status to the new one
+	ErrorCourseStatus = "invalid course status change"
+	// ErrorCourseNoModule is error message when a course without module is sent to review or published
+	ErrorCourseNoModule = "course has no module"
+	// ErrorCourseNoMedia is error message when a course without media is sent to review or published
+	ErrorCourseNoMedia = "course has no media"
+	// ErrorCourseArchived is error message when a customer enrolls an archived course
+	ErrorCourseArchived = "course is archived"
+	// ErrorCourseNotPublished is error message when a version is published for a course that is not published
+	ErrorCourseNotPublished = "course is not published"
+	// ErrorEnrollmentUpToDate is error message when the customer migrates to the version they already learn
+	ErrorEnrollmentUpToDate = "enrollment already on latest version"
+	// ErrorCourseNameUsed is error message when the name of a course is already used by another course
+	ErrorCourseNameUsed = "course name already used"
+	// ErrorCourseTemplate is error message when the status of a course template is changed
+	ErrorCourseTemplate = "course template cannot be published"
+	// ErrorCoursePackageInvalid is error message when the course package cannot be read
+	ErrorCoursePackageInvalid = "invalid course package"
+	// ErrorCoursePackageFormat is error message when the course is exported to a format that is not supported
+	ErrorCoursePackageFormat = "unsupported course package format"
+	// ErrorCoursePackageConflict is error message when the course package cannot be imported, the conflicts are in the response
+	ErrorCoursePackageConflict = "course package has conflicts"
+	// ErrorModuleOrder is error message when the modules to reorder are not exactly the modules of the course
+	ErrorModuleOrder = "module order does not match course modules"
+	// ErrorSectionNotFound is error message when the section is not found in the course of the module
+	ErrorSectionNotFound = "section not found"
+	// ErrorPrerequisiteNotCompleted is error message when the customer takes a course before they finished its prerequisites
+	ErrorPrerequisiteNotCompleted = "prerequisite not completed"
+	// ErrorPrerequisiteCycle is error message when a prerequisite would make the course a prerequisite of itself
+	ErrorPrerequisiteCycle = "prerequisite makes a course cycle"
+	// ErrorLearningPathCourses is error message when a course is more than once in the learning path
+	ErrorLearningPathCourses = "learning path has duplicate courses"
+	// ErrorQuizAttemptLimit is error message when the customer has no attempt of the quiz left
+	ErrorQuizAttemptLimit = "quiz attempt limit reached"
+	// ErrorQuizAttemptSubmitted is error message when an attempt that is not in progress is submitted
+	ErrorQuizAttemptSubmitted = "quiz attempt already submitted"
+	// ErrorQuizNoQuestion is error message when the customer starts a quiz without question
+	ErrorQuizNoQuestion = "quiz has no question"
+	// ErrorQuizQuestion is error message when the options or the answer do not fit the type of the question
+	ErrorQuizQuestion = "invalid quiz question"
+	// ErrorQuizModule is error message when the quiz is attached to a module of another course
+	ErrorQuizModule = "module is not in the course of the quiz"
+	// ErrorQuizAttemptNotSubmitted is error message when an attempt that is not submitted is graded again
+	ErrorQuizAttemptNotSubmitted = "quiz attempt is not submitted"
+	// ErrorQuestionBankNotFound is error message when the question bank of a draw is not a bank of the course of the quiz
+	ErrorQuestionBankNotFound = "question bank not found"
+	// ErrorQuizPoolQuestions is error message when a bank has fewer questions than the quiz draws from it
+	ErrorQuizPoolQuestions = "not enough questions in question bank"
+	// ErrorQuizFormat is error message when the questions are imported or exported in a format that is not supported
+	ErrorQuizFormat = "unsupported quiz format"
+	// ErrorQuizFileInvalid is error message when the file of the questions cannot be read at all
+	ErrorQuizFileInvalid = "invalid quiz file"
+	// ErrorQuizImport is error message when every question of the file has an error
+	ErrorQuizImport = "no question in the file can be imported"
+	// ErrorAssignmentDates is error message when the assignment opens after it is due or is due after it closes
+	ErrorAssignmentDates = "assignment dates are not in order"
+	// ErrorAssignmentPolicy is error message when the points, the resubmissions or the late penalty of the assignment are out of range
+	ErrorAssignmentPolicy = "invalid assignment policy"
+	// ErrorAssignmentNotOpen is error message when the customer submits before the assignment opens
+	ErrorAssignmentNotOpen = "assignment is not open yet"
+	// ErrorAssignmentClosed is error message when the customer submits after the assignment closes
+	ErrorAssignmentClosed = "assignment is closed"
+	// ErrorAssignmentNoFile is error message when the customer submits without file and without draft
+	ErrorAssignmentNoFile = "submission has no file"
+	// ErrorAssignmentSubmitted is error message when the customer saves a draft of a submitted assignment
+	ErrorAssignmentSubmitted = "assignment already submitted"
+	// ErrorAssignmentResubmission is error message when the customer has no resubmission of the assignment left
+	ErrorAssignmentResubmission = "no resubmission left"
+	// ErrorAssignmentGraded is error message when a graded submission is submitted again
+	ErrorAssignmentGraded = "submission already graded"
+	// ErrorAssignmentNotSubmitted is error message when a draft or a returned submission is graded or returned
+	ErrorAssignmentNotSubmitted = "submission is not submitted"
+	// ErrorAssignmentGrade is error message when the grade is higher than the max points of the assignment
+	ErrorAssignmentGrade = "grade is higher than max points"
+	// ErrorAssignmentChanged is error message when the submission is changed by another request at the same time
+	ErrorAssignmentChanged = "submission changed, try again"
+	// ErrorModuleRule is error message when a completion rule of the module has a wrong type, target or minimum
+	ErrorModuleRule = "invalid module completion rule"
+	// ErrorModuleRuleTarget is error message when the media, the quiz or the assignment of a rule is not in the module
+	ErrorModuleRuleTarget = "rule target is not in the module"
+	// ErrorAdminPassword is error message when the admin account from the config has no password or a short one
+	ErrorAdminPassword = "admin password is too short"
 )
 
 var ErrorCode = map[string]int{
@@ -43,4 +159,61 @@ var ErrorCode = map[string]int{
 	"capacity lower than zero":                   400,
 	"the customer is not finished the course":    400,
 	"email not verifikasi":                       500,
+	"invalid refresh token":                      401,
+	"refresh token expired":                      401,
+	"refresh token already used":                 401,
+	"code expired":                               400,
+	"code invalid":                               400,
+	"too many attempts":                          429,
+	"code recently sent, try again later":        429,
+	"invalid reset token":                        400,
+	"reset token expired":                        400,
+	"old password not match":                     400,
+	"instructor not approved":                    403,
+	"instructor suspended":                       403,
+	"email already used":                         409,
+	"invalid image":                              400,
+	"image too large":                            413,
+	"password not match":                         400,
+	"invalid cursor":                             400,
+	"invalid course status change":               400,
+	"course has no module":                       400,
+	"course has no media":                        400,
+	"course is archived":                         400,
+	"course is not published":                    400,
+	"enrollment already on latest version":       400,
+	"course name already used":                   409,
+	"course template cannot be published":        400,
+	"invalid course package":                     400,
+	"unsupported course package format":          400,
+	"course package has conflicts":               409,
+	"module order does not match course modules": 400,
+	"section not found":                          404,
+	"prerequisite not completed":                 400,
+	"prerequisite makes a course cycle":          400,
+	"learning path has duplicate courses":        400,
+	"quiz attempt limit reached":                 400,
+	"quiz attempt already submitted":             400,
+	"quiz has no question":                       400,
+	"invalid quiz question":                      400,
+	"module is not in the course of the quiz":    400,
+	"quiz attempt is not submitted":              400,
+	"question bank not found":                    404,
+	"not enough questions in question bank":      400,
+	"unsupported quiz format":                    400,
+	"invalid quiz file":                          400,
+	"no question in the file can be imported":    400,
+	"assignment dates are not in order":          400,
+	"invalid assignment policy":                  400,
+	"assignment is not open yet":                 400,
+	"assignment is closed":                       400,
+	"submission has no file":                     400,
+	"assignment already submitted":               400,
+	"no resubmission left":                       400,
+	"submission already graded":                  400,
+	"submission is not submitted":                400,
+	"grade is higher than max points":            400,
+	"submission changed, try again":              409,
+	"invalid module completion rule":             400,
+	"rule target is not in the module":           400,
 }
@@ -0,0 +1,10 @@
+package constantRole
+
+const (
+	// RoleCustomer is the role of the customers that learn the courses
+	RoleCustomer = "customer"
+	// RoleInstructor is the role of the instructors that make the courses
+	RoleInstructor = "instructor"
+	// RoleAdmin is the role of the admins that manage the platform
+	RoleAdmin = "admin"
+)
@@ -0,0 +1,126 @@
+package adminController
+
+import (
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/models/dto"
+	"golang/service/adminService"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AdminController struct {
+	AdminService adminService.AdminService
+}
+
+func (ac *AdminController) Login(c echo.Context) error {
+	var adminLogin dto.AdminLogin
+	err := c.Bind(&adminLogin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(adminLogin)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	admin, err := ac.AdminService.LoginAdmin(adminLogin)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail login",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail login",
+			"error":   err.Error(),
+		})
+	}
+
+	tokens, err := auth.GenerateTokenPair(admin.ID, constantRole.RoleAdmin)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail create token",
+			"error":   err.Error(),
+		})
+	}
+
+	adminResponse := dto.AdminResponse{
+		ID:           admin.ID,
+		Name:         admin.Name,
+		Email:        admin.Email,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success login",
+		"user":    adminResponse,
+	})
+}
+
+func (ac *AdminController) Refresh(c echo.Context) error {
+	var input dto.RefreshTokenRequest
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	tokens, err := auth.RefreshToken(input.RefreshToken, constantRole.RoleAdmin)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail refresh token",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail refresh token",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success refresh token",
+		"token":   tokens,
+	})
+}
+
+func (ac *AdminController) Logout(c echo.Context) error {
+	claims := auth.GetUser(c)
+
+	if claims == nil || !auth.CheckToken(claims.Id, constantRole.RoleAdmin) {
+		return c.JSON(http.StatusUnauthorized, map[string]string{
+			"message": "invalid token",
+		})
+	}
+
+	auth.Logout(claims.Id)
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "logout success",
+	})
+}
@@ -2,6 +2,7 @@ package assignmentcontroller
 
 import (
 	"golang/constant/constantError"
+	"golang/helper"
 	"golang/models/dto"
 	assignmentService "golang/service/assignmentService"
 	"net/http"
@@ -33,9 +34,18 @@ func (ac *AssignmentController) CreateAssignment(c echo.Context) error {
 		})
 	}
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to create assignment
-	err = ac.AssignmentService.CreateAssignment(assignment)
+	err = ac.AssignmentService.CreateAssignment(assignment, instructorID)
 	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create assignment",
+				"error":   err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail create assignment",
 			"error":   err.Error(),
@@ -53,11 +63,14 @@ func (ac *AssignmentController) DeleteAssignment(c echo.Context) error {
 	// Get id from url
 	id := c.Param("id")
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to delete assignment
-	err := ac.AssignmentService.DeleteAssignment(id)
+	err := ac.AssignmentService.DeleteAssignment(id, instructorID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
 				"message": "fail delete assignment",
 				"error":   err.Error(),
 			})
@@ -161,8 +174,11 @@ func (ac *AssignmentController) UpdateAssignment(c echo.Context) error {
 	id := c.Param("id")
 	assignment.ID = id
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to update assignment
-	err = ac.AssignmentService.UpdateAssignment(assignment)
+	err = ac.AssignmentService.UpdateAssignment(assignment, instructorID)
 	if err != nil {
 		if val, ok := constantError.ErrorCode[err.Error()]; ok {
 			return c.JSON(val, echo.Map{
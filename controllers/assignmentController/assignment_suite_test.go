@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/service/assignmentService/assignmentMockService"
@@ -12,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -89,7 +92,7 @@ func (s *suiteAssignment) TestCreateAssignment() {
 		},
 	}
 	for i, v := range testCase {
-		mockCall := s.mock.On("CreateAssignment", v.Body).Return(v.MockReturnError)
+		mockCall := s.mock.On("CreateAssignment", v.Body, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			res, _ := json.Marshal(v.Body)
 			// Create request
@@ -108,6 +111,8 @@ func (s *suiteAssignment) TestCreateAssignment() {
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/assignment/create")
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.assignmentController.CreateAssignment(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
@@ -145,7 +150,7 @@ func (s *suiteAssignment) TestDeleteAssignment() {
 			"DELETE",
 			"abcde",
 			gorm.ErrRecordNotFound,
-			http.StatusInternalServerError,
+			http.StatusNotFound,
 			"fail delete assignment",
 		},
 		{
@@ -157,9 +162,17 @@ func (s *suiteAssignment) TestDeleteAssignment() {
 			http.StatusInternalServerError,
 			"fail delete assignment",
 		},
+		{
+			"fail delete assignment not owner",
+			"DELETE",
+			"abcde",
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail delete assignment",
+		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("DeleteAssignment", v.ParamID).Return(v.MockReturnError)
+		mockCall := s.mock.On("DeleteAssignment", v.ParamID, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			// Create request
 			r := httptest.NewRequest(v.Method, "/assignment/"+v.ParamID, nil)
@@ -176,6 +189,8 @@ func (s *suiteAssignment) TestDeleteAssignment() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.assignmentController.DeleteAssignment(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
@@ -456,7 +471,7 @@ func (s *suiteAssignment) TestUpdateAssignment() {
 		},
 	}
 	for i, v := range testCase {
-		mockCall := s.mock.On("UpdateAssignment", v.Body).Return(v.MockReturnError)
+		mockCall := s.mock.On("UpdateAssignment", v.Body, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			res, _ := json.Marshal(v.Body)
 			// Create request
@@ -477,6 +492,8 @@ func (s *suiteAssignment) TestUpdateAssignment() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.assignmentController.UpdateAssignment(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
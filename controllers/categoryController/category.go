@@ -2,6 +2,7 @@ package categoryController
 
 import (
 	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/service/categoryService"
@@ -117,7 +118,7 @@ func (cc *CategoryController) GetCategoryByID(c echo.Context) error {
 		})
 	}
 
-	if user.Role == "instructor" {
+	if user.Role == constantRole.RoleInstructor {
 		var categoryInstructor dto.GetCategoryInstructor
 		_ = copier.Copy(&categoryInstructor, &getCategory)
 		// Return response if success
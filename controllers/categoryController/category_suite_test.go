@@ -4,8 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	middlewareCustomer "golang/app/middlewares/costumer"
-	middlewareInstructor "golang/app/middlewares/instructor"
+	"golang/app/middlewares/auth"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/service/categoryService/categoryMockService"
@@ -123,7 +122,7 @@ func (s *suiteCategory) TestCreateCategory() {
 			}
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/categories")
-			ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.categoryController.CreateCategory(ctx)
 			s.NoError(err)
@@ -204,7 +203,7 @@ func (s *suiteCategory) TestDeleteCategory() {
 			ctx.SetPath("/categories/:id")
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.categoryController.DeleteCategory(ctx)
 			s.NoError(err)
@@ -297,9 +296,9 @@ func (s *suiteCategory) TestGetAllCategory() {
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/categories")
 			if v.User.Role == "customer" {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 			} else {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 			}
 
 			err := s.categoryController.GetAllCategory(ctx)
@@ -497,9 +496,9 @@ func (s *suiteCategory) TestGetCategoryByID() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 			if v.ParamUser.Role == "customer" {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
 			} else {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
 			}
 
 			err := s.categoryController.GetCategoryByID(ctx)
@@ -631,7 +630,7 @@ func (s *suiteCategory) TestUpdateCategory() {
 			ctx.SetPath("/categories/:id")
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.categoryController.UpdateCategory(ctx)
 			s.NoError(err)
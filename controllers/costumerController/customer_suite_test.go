@@ -1,19 +1,27 @@
 package costumerController
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/json"
 	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	customerMockService "golang/service/costumerService/customerMockService"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 type suiteCustomer struct {
@@ -174,6 +182,38 @@ func (s *suiteCustomer) TestVerifikasiCustomer() {
 			http.StatusInternalServerError,
 			"fail verif user",
 		},
+		{
+			"fail verif user code expired",
+			"POST",
+			dto.CustomerVerif{
+				Email: "tes@gmail.com",
+				Code:  "1234",
+			},
+			errors.New(constantError.ErrorVerificationCodeExpired),
+			http.StatusBadRequest,
+			"fail verif user",
+		},
+		{
+			"fail verif user too many attempts",
+			"POST",
+			dto.CustomerVerif{
+				Email: "tes@gmail.com",
+				Code:  "1234",
+			},
+			errors.New(constantError.ErrorTooManyAttempts),
+			http.StatusTooManyRequests,
+			"fail verif user",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.CustomerVerif{
+				Email: "tes@gmail.com",
+			},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
 	}
 	for i, v := range testCase {
 		mockCall := s.mock.On("VerifikasiCustomer", v.Body).Return(v.MockReturnError)
@@ -210,6 +250,555 @@ func (s *suiteCustomer) TestVerifikasiCustomer() {
 	}
 }
 
+func (s *suiteCustomer) TestResendCode() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.ResendCode
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success resend code",
+			"POST",
+			dto.ResendCode{
+				Email: "tes@gmail.com",
+			},
+			nil,
+			http.StatusOK,
+			"success resend code",
+		},
+		{
+			"fail bind data",
+			"POST",
+			dto.ResendCode{
+				Email: "tes@gmail.com",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.ResendCode{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail resend code cooldown",
+			"POST",
+			dto.ResendCode{
+				Email: "tes@gmail.com",
+			},
+			errors.New(constantError.ErrorResendCooldown),
+			http.StatusTooManyRequests,
+			"fail resend code",
+		},
+		{
+			"fail resend code",
+			"POST",
+			dto.ResendCode{
+				Email: "tes@gmail.com",
+			},
+			errors.New("fail resend code"),
+			http.StatusInternalServerError,
+			"fail resend code",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("ResendVerificationCode", v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/customer/verifikasi/resend", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/verifikasi/resend")
+
+			err := s.customerController.ResendCode(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestResetPassword() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.ResetPassword
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success reset password",
+			"POST",
+			dto.ResetPassword{
+				Token:    "token",
+				Password: "newpassword",
+			},
+			nil,
+			http.StatusOK,
+			"success reset password",
+		},
+		{
+			"fail bind data",
+			"POST",
+			dto.ResetPassword{
+				Token:    "token",
+				Password: "newpassword",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.ResetPassword{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail reset password token expired",
+			"POST",
+			dto.ResetPassword{
+				Token:    "token",
+				Password: "newpassword",
+			},
+			errors.New(constantError.ErrorResetTokenExpired),
+			http.StatusBadRequest,
+			"fail reset password",
+		},
+		{
+			"fail reset password",
+			"POST",
+			dto.ResetPassword{
+				Token:    "token",
+				Password: "newpassword",
+			},
+			errors.New("fail reset password"),
+			http.StatusInternalServerError,
+			"fail reset password",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("ResetPassword", v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/customer/reset-password", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/reset-password")
+
+			err := s.customerController.ResetPassword(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestChangePassword() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.ChangePassword
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success change password",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "oldpassword",
+				NewPassword: "newpassword",
+			},
+			nil,
+			http.StatusOK,
+			"success change password",
+		},
+		{
+			"fail bind data",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "oldpassword",
+				NewPassword: "newpassword",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"PUT",
+			dto.ChangePassword{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail change password old password not match",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "wrongpassword",
+				NewPassword: "newpassword",
+			},
+			errors.New(constantError.ErrorOldPasswordNotMatch),
+			http.StatusBadRequest,
+			"fail change password",
+		},
+		{
+			"fail change password",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "oldpassword",
+				NewPassword: "newpassword",
+			},
+			errors.New("fail change password"),
+			http.StatusInternalServerError,
+			"fail change password",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("ChangePassword", "abcde", v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/customer/change-password", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/change-password")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer}})
+
+			err := s.customerController.ChangePassword(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestGetProfile() {
+	testCase := []struct {
+		Name               string
+		MockReturnBody     dto.CustomerProfile
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get profile",
+			dto.CustomerProfile{ID: "abcde", Name: "tes", Email: "tes@gmail.com"},
+			nil,
+			http.StatusOK,
+			"success get profile",
+		},
+		{
+			"fail get profile because not found",
+			dto.CustomerProfile{},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail get profile",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetProfile", "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("GET", "/customer/profile", nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/profile")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer}})
+
+			err := s.customerController.GetProfile(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestUpdateProfileImage() {
+	testCase := []struct {
+		Name               string
+		HasImage           bool
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success update profile image",
+			true,
+			nil,
+			http.StatusOK,
+			"success update profile image",
+		},
+		{
+			"There is an empty field",
+			false,
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail update profile image invalid image",
+			true,
+			errors.New(constantError.ErrorInvalidImage),
+			http.StatusBadRequest,
+			"fail update profile image",
+		},
+		{
+			"fail update profile image too large",
+			true,
+			errors.New(constantError.ErrorImageTooLarge),
+			http.StatusRequestEntityTooLarge,
+			"fail update profile image",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("UpdateProfileImage", "abcde", mock.Anything).Return("/uploads/image.png", v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			if v.HasImage {
+				part, _ := writer.CreateFormFile("image", "image.png")
+				part.Write([]byte("image"))
+			}
+			writer.Close()
+			// Create request
+			r := httptest.NewRequest("PUT", "/customer/profile/image", body)
+			r.Header.Set("Content-Type", writer.FormDataContentType())
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/profile/image")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer}})
+
+			err := s.customerController.UpdateProfileImage(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestDeleteAccount() {
+	testCase := []struct {
+		Name               string
+		Body               dto.DeleteAccount
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success delete account",
+			dto.DeleteAccount{Password: "password"},
+			nil,
+			http.StatusOK,
+			"success delete account",
+		},
+		{
+			"There is an empty field",
+			dto.DeleteAccount{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail delete account password not match",
+			dto.DeleteAccount{Password: "wrongpassword"},
+			errors.New(constantError.ErrorPasswordNotMatch),
+			http.StatusBadRequest,
+			"fail delete account",
+		},
+		{
+			"fail delete account",
+			dto.DeleteAccount{Password: "password"},
+			errors.New("fail delete account"),
+			http.StatusInternalServerError,
+			"fail delete account",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteAccount", "abcde", v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest("DELETE", "/customer/account", bytes.NewBuffer(res))
+			r.Header.Set("Content-Type", "application/json")
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/account")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer}})
+
+			err := s.customerController.DeleteAccount(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestExportAccount() {
+	testCase := []struct {
+		Name                string
+		Format              string
+		MockReturnBody      dto.CustomerExport
+		MockReturnError     error
+		ExpectedStatusCode  int
+		ExpectedContentType string
+	}{
+		{
+			"success export account as json",
+			"",
+			dto.CustomerExport{Profile: dto.CustomerProfile{ID: "abcde", Name: "tes", Email: "tes@gmail.com"}},
+			nil,
+			http.StatusOK,
+			echo.MIMEApplicationJSONCharsetUTF8,
+		},
+		{
+			"success export account as zip",
+			"zip",
+			dto.CustomerExport{Profile: dto.CustomerProfile{ID: "abcde", Name: "tes", Email: "tes@gmail.com"}},
+			nil,
+			http.StatusOK,
+			"application/zip",
+		},
+		{
+			"fail export account because not found",
+			"",
+			dto.CustomerExport{},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			echo.MIMEApplicationJSONCharsetUTF8,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ExportAccount", "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("GET", "/customer/account/export?format="+v.Format, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/account/export")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: constantRole.RoleCustomer}})
+
+			err := s.customerController.ExportAccount(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+			s.Equal(v.ExpectedContentType, w.Header().Get(echo.HeaderContentType))
+
+			if v.Format == "zip" {
+				archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+				s.NoError(err)
+				s.Len(archive.File, 11)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
 // func (s *suiteCustomer) TestLoginCustomer() {
 // 	testCase := []struct {
 // 		Name   string
@@ -280,6 +869,123 @@ func (s *suiteCustomer) TestVerifikasiCustomer() {
 // 	}
 // }
 
+func (s *suiteCustomer) TestGetAllCustomer() {
+	testCase := []struct {
+		Name               string
+		MockReturnBody     []dto.CustomerAccount
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get all customer",
+			[]dto.CustomerAccount{
+				{
+					ID:    "abcde",
+					Name:  "tes",
+					Email: "tes@gmail.com",
+				},
+			},
+			nil,
+			http.StatusOK,
+			"success get all customer",
+		},
+		{
+			"fail get all customer",
+			[]dto.CustomerAccount{},
+			errors.New("fail get all customer"),
+			http.StatusInternalServerError,
+			"fail get all customer",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetAllCustomer").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("GET", "/admin/customer/get_all", nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/admin/customer/get_all")
+
+			err := s.customerController.GetAllCustomer(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestDeleteCustomer() {
+	testCase := []struct {
+		Name               string
+		ParamID            string
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success delete customer",
+			"abcde",
+			nil,
+			http.StatusOK,
+			"success delete customer",
+		},
+		{
+			"fail delete customer because not found",
+			"abcde",
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail delete customer",
+		},
+		{
+			"fail delete customer",
+			"abcde",
+			errors.New("fail delete customer"),
+			http.StatusInternalServerError,
+			"fail delete customer",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteCustomer", v.ParamID).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("DELETE", "/admin/customer/delete/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/admin/customer/delete/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			err := s.customerController.DeleteCustomer(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
 func TestSuiteCustomer(t *testing.T) {
 	suite.Run(t, new(suiteCustomer))
 }
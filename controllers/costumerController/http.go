@@ -1,12 +1,15 @@
 package costumerController
 
 import (
-	middlewares "golang/app/middlewares/costumer"
+	"bytes"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/helper"
 	"golang/models/dto"
 	costumerService "golang/service/costumerService"
 	"net/http"
 
-	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
 
@@ -55,9 +58,24 @@ func (u *CostumerController) Verifikasi(c echo.Context) error {
 			"error":   err.Error(),
 		})
 	}
-	err = u.CostumerService.VerifikasiCustomer(customerVerif)
 
+	// validate request body
+	err = c.Validate(customerVerif)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.VerifikasiCustomer(customerVerif)
 	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail verif user",
+				"error":   err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail verif user",
 			"error":   err.Error(),
@@ -69,6 +87,44 @@ func (u *CostumerController) Verifikasi(c echo.Context) error {
 	})
 }
 
+func (u *CostumerController) ResendCode(c echo.Context) error {
+	var input dto.ResendCode
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.ResendVerificationCode(input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail resend code",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail resend code",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success resend code",
+	})
+}
+
 func (u *CostumerController) Login(c echo.Context) error {
 	var costumerLogin dto.CostumerLogin
 	err := c.Bind(&costumerLogin)
@@ -92,7 +148,7 @@ func (u *CostumerController) Login(c echo.Context) error {
 		})
 	}
 
-	token, errToken := middlewares.GenerateTokenCustomer(user.ID)
+	tokens, errToken := auth.GenerateTokenPair(user.ID, constantRole.RoleCustomer)
 
 	if errToken != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -102,10 +158,11 @@ func (u *CostumerController) Login(c echo.Context) error {
 	}
 
 	costumerResponse := dto.CostumerResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		Token: token,
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 
 	return c.JSON(200, echo.Map{
@@ -114,20 +171,454 @@ func (u *CostumerController) Login(c echo.Context) error {
 	})
 }
 
-func (u *CostumerController) Logout(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
+func (u *CostumerController) Refresh(c echo.Context) error {
+	var input dto.RefreshTokenRequest
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	tokens, err := auth.RefreshToken(input.RefreshToken, constantRole.RoleCustomer)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail refresh token",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail refresh token",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success refresh token",
+		"token":   tokens,
+	})
+}
+
+func (u *CostumerController) ForgotPassword(c echo.Context) error {
+	var input dto.ForgotPassword
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.ForgotPassword(input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail forgot password",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail forgot password",
+			"error":   err.Error(),
+		})
+	}
 
-	isListed := middlewares.CheckTokenCustomer(user.Raw)
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success forgot password",
+	})
+}
 
-	if !isListed {
+func (u *CostumerController) ResetPassword(c echo.Context) error {
+	var input dto.ResetPassword
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.ResetPassword(input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail reset password",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail reset password",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success reset password",
+	})
+}
+
+func (u *CostumerController) ChangePassword(c echo.Context) error {
+	var input dto.ChangePassword
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.ChangePassword(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail change password",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail change password",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success change password",
+	})
+}
+
+func (u *CostumerController) GetProfile(c echo.Context) error {
+	profile, err := u.CostumerService.GetProfile(helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get profile",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get profile",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get profile",
+		"profile": profile,
+	})
+}
+
+func (u *CostumerController) UpdateProfile(c echo.Context) error {
+	var input dto.CustomerProfileUpdate
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.UpdateProfile(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update profile",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update profile",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update profile",
+	})
+}
+
+func (u *CostumerController) ChangeEmail(c echo.Context) error {
+	var input dto.ChangeEmail
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.ChangeEmail(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail change email",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail change email",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success change email, check the new email for the verification code",
+	})
+}
+
+func (u *CostumerController) VerifyEmailChange(c echo.Context) error {
+	var input dto.VerifyEmailChange
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.VerifyEmailChange(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail verify email",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail verify email",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success verify email",
+	})
+}
+
+func (u *CostumerController) UpdateProfileImage(c echo.Context) error {
+	file, err := c.FormFile("image")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+	image, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail open image",
+			"error":   err.Error(),
+		})
+	}
+	defer image.Close()
+
+	url, err := u.CostumerService.UpdateProfileImage(helper.GetUser(c).ID, image)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update profile image",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update profile image",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":       "success update profile image",
+		"profile_image": url,
+	})
+}
+
+func (u *CostumerController) DeleteAccount(c echo.Context) error {
+	var input dto.DeleteAccount
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.CostumerService.DeleteAccount(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete account",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete account",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete account",
+	})
+}
+
+func (u *CostumerController) ExportAccount(c echo.Context) error {
+	export, err := u.CostumerService.ExportAccount(helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail export account",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail export account",
+			"error":   err.Error(),
+		})
+	}
+
+	if c.QueryParam("format") == "zip" {
+		var archive bytes.Buffer
+		err = helper.WriteExportArchive(&archive, export)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"message": "fail export account",
+				"error":   err.Error(),
+			})
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="account-export.zip"`)
+		return c.Blob(http.StatusOK, "application/zip", archive.Bytes())
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success export account",
+		"account": export,
+	})
+}
+
+func (u *CostumerController) Logout(c echo.Context) error {
+	claims := auth.GetUser(c)
+
+	if claims == nil || !auth.CheckToken(claims.Id, constantRole.RoleCustomer) {
 		return c.JSON(http.StatusUnauthorized, map[string]string{
 			"message": "invalid token",
 		})
 	}
 
-	middlewares.LogoutCustomer(user.Raw)
+	auth.Logout(claims.Id)
 
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "logout success",
 	})
 }
+
+// GetAllCustomer is a function for admin to get all customer account
+func (u *CostumerController) GetAllCustomer(c echo.Context) error {
+	accounts, err := u.CostumerService.GetAllCustomer()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get all customer",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":   "success get all customer",
+		"customers": accounts,
+	})
+}
+
+// DeleteCustomer is a function for admin to delete customer account
+func (u *CostumerController) DeleteCustomer(c echo.Context) error {
+	id := c.Param("id")
+
+	err := u.CostumerService.DeleteCustomer(id)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete customer",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete customer",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete customer",
+	})
+}
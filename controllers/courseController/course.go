@@ -1,11 +1,12 @@
 package courseController
 
 import (
-	middlewares "golang/app/middlewares/instructor"
 	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/service/courseService"
+	"golang/service/searchService"
 	"net/http"
 
 	"github.com/jinzhu/copier"
@@ -14,6 +15,7 @@ import (
 
 type CourseController struct {
 	CourseService courseService.CourseService
+	SearchService searchService.SearchService
 }
 
 // CreateCourse is a function to create course
@@ -66,8 +68,7 @@ func (cc *CourseController) DeleteCourse(c echo.Context) error {
 	id := c.Param("id")
 
 	// Get instructor id from jwt
-	claims := middlewares.GetUserInstructor(c)
-	instructorId := claims.ID
+	instructorId := helper.GetUser(c).ID
 
 	// Call service to delete course
 	err := cc.CourseService.DeleteCourse(id, instructorId)
@@ -95,6 +96,10 @@ func (cc *CourseController) GetAllCourse(c echo.Context) error {
 	// Get user id from jwt
 	user := helper.GetUser(c)
 
+	if user.Role == constantRole.RoleCustomer {
+		return cc.getCourseCatalog(c, user)
+	}
+
 	// Call service to get all courses
 	getCourses, err := cc.CourseService.GetAllCourse(user)
 	if err != nil {
@@ -104,7 +109,7 @@ func (cc *CourseController) GetAllCourse(c echo.Context) error {
 		})
 	}
 
-	if user.Role == "instructor" {
+	if user.Role == constantRole.RoleInstructor {
 		var coursesInstructor []dto.GetCourseInstructor
 		err = copier.Copy(&coursesInstructor, &getCourses)
 		if err != nil {
@@ -136,6 +141,82 @@ func (cc *CourseController) GetAllCourse(c echo.Context) error {
 	})
 }
 
+func (cc *CourseController) SearchCourse(c echo.Context) error {
+	var query dto.CourseSearchQuery
+	err := c.Bind(&query)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate query
+	err = c.Validate(query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	results, err := cc.SearchService.SearchCourse(helper.GetUser(c), query)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail search courses",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success search courses",
+		"results": results,
+	})
+}
+
+// getCourseCatalog is the course list of customer, paginated and filtered by the query string
+func (cc *CourseController) getCourseCatalog(c echo.Context, user dto.User) error {
+	var query dto.CourseCatalogQuery
+	err := c.Bind(&query)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate query
+	err = c.Validate(query)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	catalog, err := cc.CourseService.GetCourseCatalog(user, query)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get all courses",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get all courses",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":    "success get all courses",
+		"courses":    catalog.Courses,
+		"pagination": catalog.Pagination,
+	})
+}
+
 // GetCourseByID is a function to get course by id
 func (cc *CourseController) GetCourseByID(c echo.Context) error {
 	// get id from url param
@@ -159,7 +240,7 @@ func (cc *CourseController) GetCourseByID(c echo.Context) error {
 		})
 	}
 
-	if user.Role == "instructor" {
+	if user.Role == constantRole.RoleInstructor {
 		var courseInstructor dto.GetCourseInstructorByID
 		err = copier.Copy(&courseInstructor, &getCourses)
 		if err != nil {
@@ -229,8 +310,7 @@ func (cc *CourseController) UpdateCourse(c echo.Context) error {
 	course.ID = id
 
 	// Get user id from jwt
-	claims := middlewares.GetUserInstructor(c)
-	course.InstructorID = claims.ID
+	course.InstructorID = helper.GetUser(c).ID
 
 	// Call service to update course
 	err = cc.CourseService.UpdateCourse(course)
@@ -251,4 +331,238 @@ func (cc *CourseController) UpdateCourse(c echo.Context) error {
 	return c.JSON(http.StatusOK, echo.Map{
 		"message": "success update course",
 	})
-}
\ No newline at end of file
+}
+
+// UpdateCourseStatus is a function to move course through its lifecycle
+func (cc *CourseController) UpdateCourseStatus(c echo.Context) error {
+	var input dto.CourseStatus
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// get id from url
+	id := c.Param("id")
+
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to update course status
+	err = cc.CourseService.UpdateCourseStatus(id, input, user)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update course status",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update course status",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update course status",
+	})
+}
+
+// DuplicateCourse is a function to copy a course with its modules into a new draft course
+func (cc *CourseController) DuplicateCourse(c echo.Context) error {
+	return cc.duplicateCourse(c, false, "duplicate course")
+}
+
+// CreateCourseTemplate is a function to save a course as a template for new courses
+func (cc *CourseController) CreateCourseTemplate(c echo.Context) error {
+	return cc.duplicateCourse(c, true, "create course template")
+}
+
+// duplicateCourse copies the course in the url into a new course or template
+func (cc *CourseController) duplicateCourse(c echo.Context, isTemplate bool, action string) error {
+	var input dto.CourseDuplicate
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+	input.IsTemplate = isTemplate
+
+	// get id from url
+	id := c.Param("id")
+
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to copy the course
+	course, err := cc.CourseService.DuplicateCourse(id, input, user)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail " + action,
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail " + action,
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success " + action,
+		"data":    course,
+	})
+}
+
+// GetCourseTemplates is a function to get all course templates of the instructor
+func (cc *CourseController) GetCourseTemplates(c echo.Context) error {
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to get the templates
+	courses, err := cc.CourseService.GetCourseTemplates(user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get course templates",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get course templates",
+		"data":    courses,
+	})
+}
+
+// AddCoursePrerequisite is a function to add a course the customer must finish before they take the course
+func (cc *CourseController) AddCoursePrerequisite(c echo.Context) error {
+	var input dto.CoursePrerequisiteTransaction
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// get id from url
+	id := c.Param("id")
+
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to add the prerequisite
+	err = cc.CourseService.AddCoursePrerequisite(id, input, user)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail add course prerequisite",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail add course prerequisite",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success add course prerequisite",
+	})
+}
+
+// DeleteCoursePrerequisite is a function to delete a prerequisite of the course
+func (cc *CourseController) DeleteCoursePrerequisite(c echo.Context) error {
+	// get id from url
+	id := c.Param("id")
+	prerequisiteID := c.Param("prerequisiteId")
+
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to delete the prerequisite
+	err := cc.CourseService.DeleteCoursePrerequisite(id, prerequisiteID, user)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete course prerequisite",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete course prerequisite",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete course prerequisite",
+	})
+}
+
+// GetCoursePrerequisites is a function to get the prerequisites of the course
+func (cc *CourseController) GetCoursePrerequisites(c echo.Context) error {
+	// get id from url
+	id := c.Param("id")
+
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to get the prerequisites
+	prerequisites, err := cc.CourseService.GetCoursePrerequisites(id, user)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get course prerequisites",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get course prerequisites",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get course prerequisites",
+		"data":    prerequisites,
+	})
+}
@@ -4,12 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	middlewareCustomer "golang/app/middlewares/costumer"
-	middlewareInstructor "golang/app/middlewares/instructor"
+	"golang/app/middlewares/auth"
 	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/service/courseService/courseMockService"
+	"golang/service/searchService/searchMockService"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -25,13 +25,16 @@ type suiteCourse struct {
 	suite.Suite
 	courseController *CourseController
 	mock             *courseMockService.CourseMock
+	mockSearch       *searchMockService.SearchMock
 }
 
 func (s *suiteCourse) SetupTest() {
 	mock := &courseMockService.CourseMock{}
 	s.mock = mock
+	s.mockSearch = &searchMockService.SearchMock{}
 	s.courseController = &CourseController{
 		CourseService: s.mock,
+		SearchService: s.mockSearch,
 	}
 }
 
@@ -154,7 +157,7 @@ func (s *suiteCourse) TestCreateCourse() {
 			}
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/course")
-			ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.courseController.CreateCourse(ctx)
 			s.NoError(err)
@@ -235,7 +238,7 @@ func (s *suiteCourse) TestDeleteCourse() {
 			ctx.SetPath("/course/:id")
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.courseController.DeleteCourse(ctx)
 			s.NoError(err)
@@ -518,6 +521,7 @@ func (s *suiteCourse) TestGetAllCourse() {
 	}
 	for _, v := range testCase {
 		mockCall := s.mock.On("GetAllCourse", v.User).Return(v.MockReturnBody, v.MockReturnError)
+		mockCallCatalog := s.mock.On("GetCourseCatalog", v.User, dto.CourseCatalogQuery{}).Return(dto.CourseCatalog{Courses: v.MockReturnBody}, v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			// Create request
 			r := httptest.NewRequest(v.Method, "/courses", nil)
@@ -526,12 +530,15 @@ func (s *suiteCourse) TestGetAllCourse() {
 
 			// handler echo
 			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/courses")
 			if v.User.Role == "customer" {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 			} else {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 			}
 
 			err := s.courseController.GetAllCourse(ctx)
@@ -560,6 +567,190 @@ func (s *suiteCourse) TestGetAllCourse() {
 		})
 		// remove mock
 		mockCall.Unset()
+		mockCallCatalog.Unset()
+	}
+}
+
+func (s *suiteCourse) TestGetCourseCatalog() {
+	testCase := []struct {
+		Name               string
+		Query              string
+		ExpectedQuery      dto.CourseCatalogQuery
+		MockReturnBody     dto.CourseCatalog
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get catalog with filter",
+			"?page=2&limit=5&category_id=cat1&min_price=1000&max_price=50000&discounted=true&min_rating=4&sort=top_rated",
+			dto.CourseCatalogQuery{Page: 2, Limit: 5, CategoryID: "cat1", MinPrice: 1000, MaxPrice: 50000, Discounted: true, MinRating: 4, Sort: dto.CatalogSortTopRated},
+			dto.CourseCatalog{
+				Courses:    []dto.GetCourse{{ID: "test1", Name: "test1"}},
+				Pagination: dto.CatalogPagination{Total: 6, Page: 2, Limit: 5},
+			},
+			nil,
+			http.StatusOK,
+			"success get all courses",
+		},
+		{
+			"success get catalog with cursor",
+			"?cursor=abc&sort=popular",
+			dto.CourseCatalogQuery{Cursor: "abc", Sort: dto.CatalogSortPopular},
+			dto.CourseCatalog{
+				Courses:    []dto.GetCourse{{ID: "test1", Name: "test1"}},
+				Pagination: dto.CatalogPagination{Total: 6, Limit: 20, NextCursor: "def"},
+			},
+			nil,
+			http.StatusOK,
+			"success get all courses",
+		},
+		{
+			"fail invalid sort",
+			"?sort=cheapest",
+			dto.CourseCatalogQuery{Sort: "cheapest"},
+			dto.CourseCatalog{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail limit too large",
+			"?limit=1000",
+			dto.CourseCatalogQuery{Limit: 1000},
+			dto.CourseCatalog{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail invalid cursor",
+			"?cursor=abc",
+			dto.CourseCatalogQuery{Cursor: "abc"},
+			dto.CourseCatalog{},
+			errors.New(constantError.ErrorInvalidCursor),
+			http.StatusBadRequest,
+			"fail get all courses",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "customer"}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetCourseCatalog", user, v.ExpectedQuery).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("GET", "/customer/course/get_all"+v.Query, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/course/get_all")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.courseController.GetAllCourse(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+			if v.ExpectedStatusCode == http.StatusOK {
+				pagination := resp["pagination"].(map[string]interface{})
+				s.Equal(float64(v.MockReturnBody.Pagination.Total), pagination["total"])
+				s.Equal(v.MockReturnBody.Pagination.NextCursor, pagination["next_cursor"])
+				s.Len(resp["courses"], len(v.MockReturnBody.Courses))
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCourse) TestSearchCourse() {
+	testCase := []struct {
+		Name               string
+		Query              string
+		ExpectedQuery      dto.CourseSearchQuery
+		MockReturnBody     []dto.CourseSearchResult
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success search courses",
+			"?q=golang&limit=5",
+			dto.CourseSearchQuery{Query: "golang", Limit: 5},
+			[]dto.CourseSearchResult{
+				{
+					Course:     dto.GetCourse{ID: "test1", Name: "Golang"},
+					Score:      1.5,
+					Highlights: map[string]string{"name": "<mark>Golang</mark>"},
+				},
+			},
+			nil,
+			http.StatusOK,
+			"success search courses",
+		},
+		{
+			"fail search without query",
+			"",
+			dto.CourseSearchQuery{},
+			nil,
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail search courses",
+			"?q=golang",
+			dto.CourseSearchQuery{Query: "golang"},
+			[]dto.CourseSearchResult{},
+			errors.New("error"),
+			http.StatusInternalServerError,
+			"fail search courses",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "customer"}
+	for _, v := range testCase {
+		mockCall := s.mockSearch.On("SearchCourse", user, v.ExpectedQuery).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("GET", "/customer/course/search"+v.Query, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/course/search")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.courseController.SearchCourse(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+			if v.ExpectedStatusCode == http.StatusOK {
+				result := resp["results"].([]interface{})[0].(map[string]interface{})
+				s.Equal("Golang", result["course"].(map[string]interface{})["name"])
+				s.Equal("<mark>Golang</mark>", result["highlights"].(map[string]interface{})["name"])
+			}
+		})
+		// remove mock
+		mockCall.Unset()
 	}
 }
 
@@ -706,9 +897,9 @@ func (s *suiteCourse) TestGetCourseByID() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 			if v.ParamUser.Role == "customer" {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
 			} else {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
 			}
 
 			err := s.courseController.GetCourseByID(ctx)
@@ -840,9 +1031,9 @@ func (s *suiteCourse) TestGetCourseEnrollByID() {
 			ctx.SetParamNames("courseId")
 			ctx.SetParamValues(v.ParamID)
 			if v.ParamUser.Role == "customer" {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
 			} else {
-				ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
+				ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.ParamUser.ID, Role: v.ParamUser.Role}})
 			}
 
 			err := s.courseController.GetCourseEnrollByID(ctx)
@@ -984,7 +1175,7 @@ func (s *suiteCourse) TestUpdateCourse() {
 			ctx.SetPath("/categories/:id")
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareInstructor.JwtInstructorClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.courseController.UpdateCourse(ctx)
 			s.NoError(err)
@@ -1001,6 +1192,354 @@ func (s *suiteCourse) TestUpdateCourse() {
 	}
 }
 
+func (s *suiteCourse) TestUpdateCourseStatus() {
+	testCase := []struct {
+		Name               string
+		Body               dto.CourseStatus
+		ContentType        string
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success update course status",
+			dto.CourseStatus{Status: dto.CourseStatusInReview},
+			"application/json",
+			nil,
+			http.StatusOK,
+			"success update course status",
+		},
+		{
+			"fail bind data",
+			dto.CourseStatus{Status: dto.CourseStatusInReview},
+			"",
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"fail unknown status",
+			dto.CourseStatus{Status: "deleted"},
+			"application/json",
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail course without module",
+			dto.CourseStatus{Status: dto.CourseStatusInReview},
+			"application/json",
+			errors.New(constantError.ErrorCourseNoModule),
+			http.StatusBadRequest,
+			"fail update course status",
+		},
+		{
+			"fail update course status",
+			dto.CourseStatus{Status: dto.CourseStatusInReview},
+			"application/json",
+			errors.New("fail update course status"),
+			http.StatusInternalServerError,
+			"fail update course status",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	for _, v := range testCase {
+		mockCall := s.mock.On("UpdateCourseStatus", "abcde", v.Body, user).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(http.MethodPut, "/course/status/abcde", bytes.NewBuffer(res))
+			r.Header.Set("Content-Type", v.ContentType)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/course/status/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("abcde")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.courseController.UpdateCourseStatus(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCourse) TestDuplicateCourse() {
+	testCase := []struct {
+		Name               string
+		Path               string
+		Body               dto.CourseDuplicate
+		ContentType        string
+		IsTemplate         bool
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success duplicate course",
+			"duplicate",
+			dto.CourseDuplicate{Name: "copy", CategoryID: "abcde"},
+			"application/json",
+			false,
+			nil,
+			http.StatusOK,
+			"success duplicate course",
+		},
+		{
+			"success create course template",
+			"template",
+			dto.CourseDuplicate{Name: "template"},
+			"application/json",
+			true,
+			nil,
+			http.StatusOK,
+			"success create course template",
+		},
+		{
+			"fail bind data",
+			"duplicate",
+			dto.CourseDuplicate{Name: "copy"},
+			"",
+			false,
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"fail duplicate course without name",
+			"duplicate",
+			dto.CourseDuplicate{},
+			"application/json",
+			false,
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail duplicate course with used name",
+			"duplicate",
+			dto.CourseDuplicate{Name: "copy"},
+			"application/json",
+			false,
+			errors.New(constantError.ErrorCourseNameUsed),
+			http.StatusConflict,
+			"fail duplicate course",
+		},
+		{
+			"fail create course template",
+			"template",
+			dto.CourseDuplicate{Name: "template"},
+			"application/json",
+			true,
+			errors.New("fail create course template"),
+			http.StatusInternalServerError,
+			"fail create course template",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	for _, v := range testCase {
+		input := v.Body
+		input.IsTemplate = v.IsTemplate
+		mockCall := s.mock.On("DuplicateCourse", "abcde", input, user).Return(input, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(http.MethodPost, "/course/abcde/"+v.Path, bytes.NewBuffer(res))
+			r.Header.Set("Content-Type", v.ContentType)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/course/:id/" + v.Path)
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("abcde")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			var err error
+			if v.IsTemplate {
+				err = s.courseController.CreateCourseTemplate(ctx)
+			} else {
+				err = s.courseController.DuplicateCourse(ctx)
+			}
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCourse) TestAddCoursePrerequisite() {
+	testCase := []struct {
+		Name               string
+		Body               dto.CoursePrerequisiteTransaction
+		ContentType        string
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success add course prerequisite",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			"application/json",
+			nil,
+			http.StatusOK,
+			"success add course prerequisite",
+		},
+		{
+			"fail bind data",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			"",
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"fail add course prerequisite without prerequisite",
+			dto.CoursePrerequisiteTransaction{},
+			"application/json",
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail add course prerequisite making a cycle",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			"application/json",
+			errors.New(constantError.ErrorPrerequisiteCycle),
+			http.StatusBadRequest,
+			"fail add course prerequisite",
+		},
+		{
+			"fail add course prerequisite",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			"application/json",
+			errors.New("fail add course prerequisite"),
+			http.StatusInternalServerError,
+			"fail add course prerequisite",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	for _, v := range testCase {
+		mockCall := s.mock.On("AddCoursePrerequisite", "abcde", v.Body, user).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(http.MethodPost, "/course/abcde/prerequisite", bytes.NewBuffer(res))
+			r.Header.Set("Content-Type", v.ContentType)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/course/:id/prerequisite")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("abcde")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.courseController.AddCoursePrerequisite(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCourse) TestDeleteCoursePrerequisite() {
+	testCase := []struct {
+		Name               string
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success delete course prerequisite",
+			nil,
+			http.StatusOK,
+			"success delete course prerequisite",
+		},
+		{
+			"fail delete course prerequisite not found",
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail delete course prerequisite",
+		},
+		{
+			"fail delete course prerequisite not owner",
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail delete course prerequisite",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteCoursePrerequisite", "abcde", "basic", user).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(http.MethodDelete, "/course/abcde/prerequisite/basic", nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/course/:id/prerequisite/:prerequisiteId")
+			ctx.SetParamNames("id", "prerequisiteId")
+			ctx.SetParamValues("abcde", "basic")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.courseController.DeleteCoursePrerequisite(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
 func TestSuiteCourse(t *testing.T) {
 	suite.Run(t, new(suiteCourse))
 }
@@ -0,0 +1,123 @@
+package coursePackageController
+
+import (
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/coursePackageService"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxCoursePackageSize is the biggest package accepted by the import
+const maxCoursePackageSize = 50 << 20
+
+type CoursePackageController struct {
+	CoursePackageService coursePackageService.CoursePackageService
+}
+
+// ExportCourse is a function to download a course with its content as a json or zip package
+func (cpc *CoursePackageController) ExportCourse(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = dto.CoursePackageJSON
+	}
+	if format != dto.CoursePackageJSON && format != dto.CoursePackageZIP {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "fail export course",
+			"error":   constantError.ErrorCoursePackageFormat,
+		})
+	}
+
+	// get id from url
+	id := c.Param("id")
+
+	// call service to get the package of the course
+	coursePackage, err := cpc.CoursePackageService.ExportCourse(id, helper.GetUser(c))
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail export course",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail export course",
+			"error":   err.Error(),
+		})
+	}
+
+	contentType := echo.MIMEApplicationJSON
+	var data []byte
+	if format == dto.CoursePackageZIP {
+		contentType = "application/zip"
+		data, err = helper.EncodeCoursePackageZip(coursePackage)
+	} else {
+		data, err = helper.EncodeCoursePackage(coursePackage)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail export course",
+			"error":   err.Error(),
+		})
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="course-`+id+`.`+format+`"`)
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// ImportCourse is a function to create a draft course from a package, the package is sent as the file of a form or as the body
+func (cpc *CoursePackageController) ImportCourse(c echo.Context) error {
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxCoursePackageSize)
+	c.Request().Body = body
+	var reader io.Reader = body
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"message": "fail bind data",
+				"error":   err.Error(),
+			})
+		}
+		defer src.Close()
+		reader = src
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	input := dto.CourseImport{
+		Name:       c.QueryParam("name"),
+		CategoryID: c.QueryParam("category_id"),
+	}
+
+	// call service to import the course
+	course, err := cpc.CoursePackageService.ImportCourse(data, input, helper.GetUser(c))
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			response := echo.Map{
+				"message": "fail import course",
+				"error":   err.Error(),
+			}
+			if len(course.Conflicts) > 0 {
+				response["data"] = course
+			}
+			return c.JSON(val, response)
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail import course",
+			"error":   err.Error(),
+		})
+	}
+
+	// return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success import course",
+		"data":    course,
+	})
+}
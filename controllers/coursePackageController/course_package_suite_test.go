@@ -0,0 +1,195 @@
+package coursePackageController
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"golang/service/coursePackageService/coursePackageMockService"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteCoursePackage struct {
+	suite.Suite
+	coursePackageController *CoursePackageController
+	mock                    *coursePackageMockService.CoursePackageMock
+}
+
+func (s *suiteCoursePackage) SetupTest() {
+	mock := &coursePackageMockService.CoursePackageMock{}
+	s.mock = mock
+	s.coursePackageController = &CoursePackageController{
+		CoursePackageService: s.mock,
+	}
+}
+
+func (s *suiteCoursePackage) TestExportCourse() {
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	testCase := []struct {
+		Name                string
+		Format              string
+		MockReturnError     error
+		ExpectedStatusCode  int
+		ExpectedContentType string
+	}{
+		{
+			"success export course as json",
+			"",
+			nil,
+			http.StatusOK,
+			echo.MIMEApplicationJSON,
+		},
+		{
+			"success export course as zip",
+			"zip",
+			nil,
+			http.StatusOK,
+			"application/zip",
+		},
+		{
+			"fail export course to unknown format",
+			"scorm",
+			nil,
+			http.StatusBadRequest,
+			echo.MIMEApplicationJSONCharsetUTF8,
+		},
+		{
+			"fail export course of other instructor",
+			"zip",
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			echo.MIMEApplicationJSONCharsetUTF8,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ExportCourse", "abcde", user).Return(dto.CoursePackage{FormatVersion: dto.CoursePackageVersion}, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(http.MethodGet, "/course/abcde/export?format="+v.Format, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/course/:id/export")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues("abcde")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.coursePackageController.ExportCourse(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+			s.Equal(v.ExpectedContentType, w.Header().Get(echo.HeaderContentType))
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCoursePackage) TestImportCourse() {
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	data := []byte(`{"format_version":1}`)
+	testCase := []struct {
+		Name               string
+		Multipart          bool
+		MockReturnBody     dto.CourseImport
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+		ExpectedConflicts  bool
+	}{
+		{
+			"success import course from body",
+			false,
+			dto.CourseImport{ID: "new", Name: "golang"},
+			nil,
+			http.StatusOK,
+			"success import course",
+			false,
+		},
+		{
+			"success import course from file",
+			true,
+			dto.CourseImport{ID: "new", Name: "golang"},
+			nil,
+			http.StatusOK,
+			"success import course",
+			false,
+		},
+		{
+			"fail import course with conflicts",
+			false,
+			dto.CourseImport{Name: "golang", Conflicts: []string{`course name "golang" already used`}},
+			errors.New(constantError.ErrorCoursePackageConflict),
+			http.StatusConflict,
+			"fail import course",
+			true,
+		},
+		{
+			"fail import course",
+			false,
+			dto.CourseImport{},
+			errors.New("fail import course"),
+			http.StatusInternalServerError,
+			"fail import course",
+			false,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ImportCourse", data, dto.CourseImport{CategoryID: "category"}, user).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			body := bytes.NewBuffer(data)
+			contentType := echo.MIMEApplicationJSON
+			if v.Multipart {
+				body = &bytes.Buffer{}
+				writer := multipart.NewWriter(body)
+				file, err := writer.CreateFormFile("file", "course.json")
+				s.NoError(err)
+				_, err = file.Write(data)
+				s.NoError(err)
+				s.NoError(writer.Close())
+				contentType = writer.FormDataContentType()
+			}
+			r := httptest.NewRequest(http.MethodPost, "/course/import?category_id=category", body)
+			r.Header.Set(echo.HeaderContentType, contentType)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/course/import")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.coursePackageController.ImportCourse(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+			if v.ExpectedConflicts {
+				s.NotNil(resp["data"])
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteCoursePackage(t *testing.T) {
+	suite.Run(t, new(suiteCoursePackage))
+}
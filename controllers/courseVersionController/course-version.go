@@ -0,0 +1,134 @@
+package courseVersionController
+
+import (
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/service/courseVersionService"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CourseVersionController struct {
+	CourseVersionService courseVersionService.CourseVersionService
+}
+
+// PublishCourseVersion is a function to publish the modules of a course as a new version
+func (cvc *CourseVersionController) PublishCourseVersion(c echo.Context) error {
+	// get course id from url
+	courseID := c.Param("id")
+
+	// get instructor id from jwt
+	instructor := helper.GetUser(c)
+
+	// call service to publish the version
+	version, err := cvc.CourseVersionService.PublishCourseVersion(courseID, instructor)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail publish course version",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail publish course version",
+			"error":   err.Error(),
+		})
+	}
+
+	// return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success publish course version",
+		"data":    version,
+	})
+}
+
+// GetCourseVersions is a function to get all versions of a course
+func (cvc *CourseVersionController) GetCourseVersions(c echo.Context) error {
+	// get course id from url
+	courseID := c.Param("id")
+
+	// get instructor id from jwt
+	instructor := helper.GetUser(c)
+
+	// call service to get the versions
+	versions, err := cvc.CourseVersionService.GetCourseVersions(courseID, instructor)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get course versions",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get course versions",
+			"error":   err.Error(),
+		})
+	}
+
+	// return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get course versions",
+		"data":    versions,
+	})
+}
+
+// GetEnrollmentVersion is a function to get the version of the course the customer learns
+func (cvc *CourseVersionController) GetEnrollmentVersion(c echo.Context) error {
+	// get course id from url
+	courseID := c.Param("courseId")
+
+	// get customer id from jwt
+	customer := helper.GetUser(c)
+
+	// call service to get the version of the enrollment
+	version, err := cvc.CourseVersionService.GetEnrollmentVersion(courseID, customer.ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get enrollment version",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get enrollment version",
+			"error":   err.Error(),
+		})
+	}
+
+	// return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get enrollment version",
+		"data":    version,
+	})
+}
+
+// MigrateEnrollment is a function to move the customer to the latest version of the course
+func (cvc *CourseVersionController) MigrateEnrollment(c echo.Context) error {
+	// get course id from url
+	courseID := c.Param("courseId")
+
+	// get customer id from jwt
+	customer := helper.GetUser(c)
+
+	// call service to migrate the enrollment
+	version, err := cvc.CourseVersionService.MigrateEnrollment(courseID, customer.ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail migrate enrollment",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail migrate enrollment",
+			"error":   err.Error(),
+		})
+	}
+
+	// return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success migrate enrollment",
+		"data":    version,
+	})
+}
@@ -0,0 +1,260 @@
+package courseVersionController
+
+import (
+	"encoding/json"
+	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/courseVersionService/courseVersionMockService"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteCourseVersion struct {
+	suite.Suite
+	courseVersionController *CourseVersionController
+	mock                    *courseVersionMockService.CourseVersionMock
+}
+
+func (s *suiteCourseVersion) SetupTest() {
+	mock := &courseVersionMockService.CourseVersionMock{}
+	s.mock = mock
+	s.courseVersionController = &CourseVersionController{
+		CourseVersionService: s.mock,
+	}
+}
+
+func (s *suiteCourseVersion) newContext(method, param, id string, user dto.User) (echo.Context, *httptest.ResponseRecorder) {
+	// Create request
+	r := httptest.NewRequest(method, "/course/version/"+id, nil)
+	// Create response recorder
+	w := httptest.NewRecorder()
+
+	// handler echo
+	e := echo.New()
+	e.Validator = &helper.CustomValidator{
+		Validator: validator.New(),
+	}
+	ctx := e.NewContext(r, w)
+	ctx.SetPath("/course/version/:" + param)
+	ctx.SetParamNames(param)
+	ctx.SetParamValues(id)
+	ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+	return ctx, w
+}
+
+func (s *suiteCourseVersion) TestPublishCourseVersion() {
+	instructor := dto.User{ID: "abcde", Role: "instructor"}
+	testCase := []struct {
+		Name               string
+		CourseID           string
+		MockReturnBody     dto.CourseVersion
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success publish course version",
+			"abcde",
+			dto.CourseVersion{ID: "v2", CourseID: "abcde", Version: 2},
+			nil,
+			http.StatusOK,
+			"success publish course version",
+		},
+		{
+			"fail publish version of course not published",
+			"abcde",
+			dto.CourseVersion{},
+			errors.New(constantError.ErrorCourseNotPublished),
+			http.StatusBadRequest,
+			"fail publish course version",
+		},
+		{
+			"fail publish course version",
+			"abcde",
+			dto.CourseVersion{},
+			errors.New("fail publish course version"),
+			http.StatusInternalServerError,
+			"fail publish course version",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("PublishCourseVersion", v.CourseID, instructor).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			ctx, w := s.newContext("POST", "id", v.CourseID, instructor)
+
+			err := s.courseVersionController.PublishCourseVersion(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCourseVersion) TestGetCourseVersions() {
+	instructor := dto.User{ID: "abcde", Role: "instructor"}
+	testCase := []struct {
+		Name               string
+		CourseID           string
+		MockReturnBody     []dto.CourseVersion
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get course versions",
+			"abcde",
+			[]dto.CourseVersion{{ID: "v1", CourseID: "abcde", Version: 1}},
+			nil,
+			http.StatusOK,
+			"success get course versions",
+		},
+		{
+			"fail get versions of course of other instructor",
+			"abcde",
+			nil,
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail get course versions",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetCourseVersions", v.CourseID, instructor).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			ctx, w := s.newContext("GET", "id", v.CourseID, instructor)
+
+			err := s.courseVersionController.GetCourseVersions(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCourseVersion) TestGetEnrollmentVersion() {
+	customer := dto.User{ID: "abcde", Role: "customer"}
+	testCase := []struct {
+		Name               string
+		CourseID           string
+		MockReturnBody     dto.EnrollmentVersion
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get enrollment version",
+			"abcde",
+			dto.EnrollmentVersion{CourseID: "abcde", PinnedVersion: 1, LatestVersion: 2, UpdateAvailable: true},
+			nil,
+			http.StatusOK,
+			"success get enrollment version",
+		},
+		{
+			"fail get enrollment version of course not enrolled",
+			"abcde",
+			dto.EnrollmentVersion{},
+			errors.New(constantError.ErrorCustomerNotEnrolled),
+			constantError.ErrorCode[constantError.ErrorCustomerNotEnrolled],
+			"fail get enrollment version",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetEnrollmentVersion", v.CourseID, customer.ID).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			ctx, w := s.newContext("GET", "courseId", v.CourseID, customer)
+
+			err := s.courseVersionController.GetEnrollmentVersion(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCourseVersion) TestMigrateEnrollment() {
+	customer := dto.User{ID: "abcde", Role: "customer"}
+	testCase := []struct {
+		Name               string
+		CourseID           string
+		MockReturnBody     dto.EnrollmentVersion
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success migrate enrollment",
+			"abcde",
+			dto.EnrollmentVersion{CourseID: "abcde", PinnedVersion: 2, LatestVersion: 2, NoModule: 2},
+			nil,
+			http.StatusOK,
+			"success migrate enrollment",
+		},
+		{
+			"fail migrate enrollment already on latest version",
+			"abcde",
+			dto.EnrollmentVersion{},
+			errors.New(constantError.ErrorEnrollmentUpToDate),
+			http.StatusBadRequest,
+			"fail migrate enrollment",
+		},
+		{
+			"fail migrate enrollment",
+			"abcde",
+			dto.EnrollmentVersion{},
+			errors.New("fail migrate enrollment"),
+			http.StatusInternalServerError,
+			"fail migrate enrollment",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("MigrateEnrollment", v.CourseID, customer.ID).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			ctx, w := s.newContext("POST", "courseId", v.CourseID, customer)
+
+			err := s.courseVersionController.MigrateEnrollment(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteCourseVersion(t *testing.T) {
+	suite.Run(t, new(suiteCourseVersion))
+}
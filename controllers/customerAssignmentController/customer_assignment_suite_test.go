@@ -4,15 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
 	customerAssignmentMockservice "golang/service/customerAssignmentService/customerAssignmentMockService"
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -32,7 +34,7 @@ func (s *suiteCustomerAssignment) SetupTest() {
 	}
 }
 
-func (s *suiteCustomerAssignment) TestCreateCustomerAssignment() {
+func (s *suiteCustomerAssignment) TestSubmitAssignment() {
 	testCase := []struct {
 		Name               string
 		Method             string
@@ -42,26 +44,22 @@ func (s *suiteCustomerAssignment) TestCreateCustomerAssignment() {
 		ExpectedMesaage    string
 	}{
 		{
-			"success create customer assignment",
+			"success submit customer assignment",
 			"POST",
 			dto.CustomerAssignmentTransaction{
-				File:         "tes",
-				Grade:        1,
 				AssignmentID: "abcde",
-				CustomerID:   "abcde",
+				File:         "tes",
 			},
 			nil,
 			http.StatusOK,
-			"success create customer assignment",
+			"success submit customer assignment",
 		},
 		{
 			"fail bind data",
 			"POST",
 			dto.CustomerAssignmentTransaction{
-				File:         "tes",
-				Grade:        1,
 				AssignmentID: "abcde",
-				CustomerID:   "abcde",
+				File:         "tes",
 			},
 			nil,
 			http.StatusInternalServerError,
@@ -78,26 +76,34 @@ func (s *suiteCustomerAssignment) TestCreateCustomerAssignment() {
 			"There is an empty field",
 		},
 		{
-			"fail create customer assignment",
+			"fail submit customer assignment after close",
 			"POST",
 			dto.CustomerAssignmentTransaction{
+				AssignmentID: "abcde",
 				File:         "tes",
-				Grade:        1,
+			},
+			errors.New(constantError.ErrorAssignmentClosed),
+			http.StatusBadRequest,
+			"fail submit customer assignment",
+		},
+		{
+			"fail submit customer assignment",
+			"POST",
+			dto.CustomerAssignmentTransaction{
 				AssignmentID: "abcde",
-				CustomerID:   "abcde",
+				File:         "tes",
 			},
-
-			errors.New("fail create customer assignment"),
+			errors.New("fail submit customer assignment"),
 			http.StatusInternalServerError,
-			"fail create customer assignment",
+			"fail submit customer assignment",
 		},
 	}
 	for i, v := range testCase {
-		mockCall := s.mock.On("CreateCustomerAssignment", v.Body).Return(v.MockReturnError)
+		mockCall := s.mock.On("SubmitAssignment", v.Body, "abcde").Return(dto.CustomerAssignment{}, v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			res, _ := json.Marshal(v.Body)
 			// Create request
-			r := httptest.NewRequest(v.Method, "/customer_assignment/", bytes.NewBuffer(res))
+			r := httptest.NewRequest(v.Method, "/customer_assignment/submit", bytes.NewBuffer(res))
 			if i != 1 {
 				r.Header.Set("Content-Type", "application/json")
 			}
@@ -110,9 +116,10 @@ func (s *suiteCustomerAssignment) TestCreateCustomerAssignment() {
 				Validator: validator.New(),
 			}
 			ctx := e.NewContext(r, w)
-			ctx.SetPath("/customer_assignment/create")
+			ctx.SetPath("/customer_assignment/submit")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "customer"}})
 
-			err := s.customerAssignmentController.CreateCustomerAssignment(ctx)
+			err := s.customerAssignmentController.SubmitAssignment(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
 
@@ -127,45 +134,84 @@ func (s *suiteCustomerAssignment) TestCreateCustomerAssignment() {
 	}
 }
 
-func (s *suiteCustomerAssignment) TestDeleteCustomerAssignment() {
+func (s *suiteCustomerAssignment) TestGradeCustomerAssignment() {
 	testCase := []struct {
 		Name               string
 		Method             string
 		ParamID            string
+		Body               dto.AssignmentGrade
 		MockReturnError    error
 		ExpectedStatusCode int
 		ExpectedMesaage    string
 	}{
 		{
-			"success delete customer assignment",
-			"DELETE",
+			"success grade customer assignment",
+			"POST",
 			"abcde",
+			dto.AssignmentGrade{
+				Grade:    80,
+				Feedback: "good",
+			},
 			nil,
 			http.StatusOK,
-			"success delete customer assignment",
+			"success grade customer assignment",
 		},
 		{
-			"fail delete customer assignment",
-			"DELETE",
+			"fail bind data",
+			"POST",
 			"abcde",
-			gorm.ErrRecordNotFound,
+			dto.AssignmentGrade{
+				Grade: 80,
+			},
+			nil,
 			http.StatusInternalServerError,
-			"fail delete customer assignment",
+			"fail bind data",
 		},
 		{
-			"fail delete customer assignment",
-			"DELETE",
+			"There is an empty field",
+			"POST",
 			"abcde",
-			errors.New("fail delete customer assignment"),
-			http.StatusInternalServerError,
-			"fail delete customer assignment",
+			dto.AssignmentGrade{
+				Grade: -1,
+			},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail grade customer assignment of other instructor",
+			"POST",
+			"abcde",
+			dto.AssignmentGrade{
+				Grade: 80,
+			},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail grade customer assignment",
+		},
+		{
+			"fail grade customer assignment higher than max points",
+			"POST",
+			"abcde",
+			dto.AssignmentGrade{
+				Grade: 800,
+			},
+			errors.New(constantError.ErrorAssignmentGrade),
+			http.StatusBadRequest,
+			"fail grade customer assignment",
 		},
 	}
-	for _, v := range testCase {
-		mockCall := s.mock.On("DeleteCustomerAssignment", v.ParamID).Return(v.MockReturnError)
+	for i, v := range testCase {
+		input := v.Body
+		input.ID = v.ParamID
+		mockCall := s.mock.On("GradeCustomerAssignment", input, "abcde").Return(dto.CustomerAssignment{}, v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
 			// Create request
-			r := httptest.NewRequest(v.Method, "/customer_assignment/delete/"+v.ParamID, nil)
+			r := httptest.NewRequest(v.Method, "/customer_assignment/grade/"+v.ParamID, bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
 			// Create response recorder
 			w := httptest.NewRecorder()
 
@@ -175,11 +221,12 @@ func (s *suiteCustomerAssignment) TestDeleteCustomerAssignment() {
 				Validator: validator.New(),
 			}
 			ctx := e.NewContext(r, w)
-			ctx.SetPath("/customer_assignment/delete/:id")
+			ctx.SetPath("/customer_assignment/grade/:id")
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
 
-			err := s.customerAssignmentController.DeleteCustomerAssignment(ctx)
+			err := s.customerAssignmentController.GradeCustomerAssignment(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
 
@@ -194,195 +241,153 @@ func (s *suiteCustomerAssignment) TestDeleteCustomerAssignment() {
 	}
 }
 
-func (s *suiteCustomerAssignment) TestGetCustomerAssignmentByID() {
+func (s *suiteCustomerAssignment) TestReturnCustomerAssignment() {
 	testCase := []struct {
 		Name               string
 		Method             string
 		ParamID            string
-		MockReturnBody     dto.CustomerAssignmentAcc
+		Body               dto.AssignmentReturn
 		MockReturnError    error
-		HasReturnBody      bool
-		ExpectedBody       dto.CustomerAssignmentAcc
 		ExpectedStatusCode int
 		ExpectedMesaage    string
 	}{
 		{
-			"success get customer assignment by id",
-			"GET",
+			"success return customer assignment",
+			"POST",
 			"abcde",
-
-			dto.CustomerAssignmentAcc{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "abcde",
-				CreatedAt:    time.Now(),
-				UpdatedAt:    time.Now(),
-				DeletedAt:    gorm.DeletedAt{},
+			dto.AssignmentReturn{
+				Feedback: "add the sources",
 			},
 			nil,
-			true,
-			dto.CustomerAssignmentAcc{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "abcde",
-				CreatedAt:    time.Now(),
-				UpdatedAt:    time.Now(),
-				DeletedAt:    gorm.DeletedAt{},
-			},
 			http.StatusOK,
-			"success get customer assignment by id",
+			"success return customer assignment",
 		},
 		{
-			"fail get customer assignment by id",
-			"GET",
+			"fail bind data",
+			"POST",
 			"abcde",
-
-			dto.CustomerAssignmentAcc{},
-			gorm.ErrRecordNotFound,
-			false,
-			dto.CustomerAssignmentAcc{},
+			dto.AssignmentReturn{
+				Feedback: "add the sources",
+			},
+			nil,
 			http.StatusInternalServerError,
-			"fail get customer assignment by id",
+			"fail bind data",
 		},
 		{
-			"fail get customer assignment by id",
-			"GET",
+			"There is an empty field",
+			"POST",
 			"abcde",
-
-			dto.CustomerAssignmentAcc{},
-			gorm.ErrRecordNotFound,
-			false,
-			dto.CustomerAssignmentAcc{},
-			http.StatusInternalServerError,
-			"fail get customer assignment by id",
+			dto.AssignmentReturn{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail return draft",
+			"POST",
+			"abcde",
+			dto.AssignmentReturn{
+				Feedback: "add the sources",
+			},
+			errors.New(constantError.ErrorAssignmentNotSubmitted),
+			http.StatusBadRequest,
+			"fail return customer assignment",
 		},
 	}
-
-	for _, v := range testCase {
-		mockCall := s.mock.On("GetCustomerAssignmentByID", v.ParamID).Return(v.MockReturnBody, v.MockReturnError)
+	for i, v := range testCase {
+		input := v.Body
+		input.ID = v.ParamID
+		mockCall := s.mock.On("ReturnCustomerAssignment", input, "abcde").Return(dto.CustomerAssignment{}, v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-
+			res, _ := json.Marshal(v.Body)
 			// Create request
-			r := httptest.NewRequest(v.Method, "/customer_assignment/get_by_id/"+v.ParamID, nil)
+			r := httptest.NewRequest(v.Method, "/customer_assignment/return/"+v.ParamID, bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
 			// Create response recorder
 			w := httptest.NewRecorder()
 
 			// handler echo
 			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
 			ctx := e.NewContext(r, w)
-			ctx.SetPath("/customer_assignment/get_by_id/:id")
+			ctx.SetPath("/customer_assignment/return/:id")
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
 
-			err := s.customerAssignmentController.GetCustomerAssignmentByID(ctx)
+			err := s.customerAssignmentController.ReturnCustomerAssignment(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
+
 			var resp map[string]interface{}
 			err = json.NewDecoder(w.Result().Body).Decode(&resp)
 			s.NoError(err)
 
 			s.Equal(v.ExpectedMesaage, resp["message"])
-
 		})
 		// remove mock
 		mockCall.Unset()
 	}
 }
 
-func (s *suiteCustomerAssignment) TestGetAllCustomerAssignment() {
+func (s *suiteCustomerAssignment) TestDeleteCustomerAssignment() {
 	testCase := []struct {
 		Name               string
 		Method             string
-		MockReturnBody     []dto.CustomerAssignmentAcc
+		ParamID            string
 		MockReturnError    error
-		HasReturnBody      bool
-		ExpectedBody       []dto.CustomerAssignmentAcc
 		ExpectedStatusCode int
 		ExpectedMesaage    string
 	}{
 		{
-			"success get all customer assignment",
-			"GET",
-
-			[]dto.CustomerAssignmentAcc{
-				{
-					ID:           "abcde",
-					File:         "tes",
-					Grade:        1,
-					AssignmentID: "abcde",
-					CustomerID:   "abcde",
-					CreatedAt:    time.Now(),
-					UpdatedAt:    time.Now(),
-					DeletedAt:    gorm.DeletedAt{},
-				},
-				{
-					ID:           "abcdef",
-					File:         "tes 2",
-					Grade:        2,
-					AssignmentID: "abcdef",
-					CustomerID:   "abcdef",
-					CreatedAt:    time.Now(),
-					UpdatedAt:    time.Now(),
-					DeletedAt:    gorm.DeletedAt{},
-				},
-			},
+			"success delete customer assignment",
+			"DELETE",
+			"abcde",
 			nil,
-			true,
-			[]dto.CustomerAssignmentAcc{
-				{
-					ID:           "abcde",
-					File:         "tes",
-					Grade:        1,
-					AssignmentID: "abcde",
-					CustomerID:   "abcde",
-					CreatedAt:    time.Now(),
-					UpdatedAt:    time.Now(),
-					DeletedAt:    gorm.DeletedAt{},
-				},
-				{
-					ID:           "abcde",
-					File:         "tes",
-					Grade:        1,
-					AssignmentID: "abcde",
-					CustomerID:   "abcde",
-					CreatedAt:    time.Now(),
-					UpdatedAt:    time.Now(),
-					DeletedAt:    gorm.DeletedAt{},
-				},
-			},
 			http.StatusOK,
-			"success get all customer assignment",
+			"success delete customer assignment",
 		},
 		{
-			"fail get all customer assignment",
-			"GET",
-
-			[]dto.CustomerAssignmentAcc{},
-			errors.New("error"),
-			false,
-			[]dto.CustomerAssignmentAcc{},
+			"fail delete submitted customer assignment",
+			"DELETE",
+			"abcde",
+			errors.New(constantError.ErrorAssignmentSubmitted),
+			http.StatusBadRequest,
+			"fail delete customer assignment",
+		},
+		{
+			"fail delete customer assignment",
+			"DELETE",
+			"abcde",
+			errors.New("fail delete customer assignment"),
 			http.StatusInternalServerError,
-			"fail get all customer assignment",
+			"fail delete customer assignment",
 		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("GetAllCustomerAssignment").Return(v.MockReturnBody, v.MockReturnError)
+		mockCall := s.mock.On("DeleteCustomerAssignment", v.ParamID, dto.User{ID: "abcde", Role: "customer"}).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			// Create request
-			r := httptest.NewRequest(v.Method, "/customer_assignment/get_all", nil)
+			r := httptest.NewRequest(v.Method, "/customer_assignment/delete/"+v.ParamID, nil)
 			// Create response recorder
 			w := httptest.NewRecorder()
 
 			// handler echo
 			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
 			ctx := e.NewContext(r, w)
-			ctx.SetPath("/customer_assignment/get_all")
+			ctx.SetPath("/customer_assignment/delete/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "customer"}})
 
-			err := s.customerAssignmentController.GetAllCustomerAssignment(ctx)
+			err := s.customerAssignmentController.DeleteCustomerAssignment(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
 
@@ -391,107 +396,97 @@ func (s *suiteCustomerAssignment) TestGetAllCustomerAssignment() {
 			s.NoError(err)
 
 			s.Equal(v.ExpectedMesaage, resp["message"])
-
 		})
 		// remove mock
 		mockCall.Unset()
 	}
 }
 
-func (s *suiteCustomerAssignment) TestUpdateCustomerAssignment() {
+func (s *suiteCustomerAssignment) TestGetCustomerAssignmentByID() {
 	testCase := []struct {
 		Name               string
 		Method             string
-		Body               dto.CustomerAssignmentTransaction
 		ParamID            string
+		MockReturnBody     dto.CustomerAssignment
 		MockReturnError    error
+		HasReturnBody      bool
+		ExpectedBody       dto.CustomerAssignment
 		ExpectedStatusCode int
 		ExpectedMesaage    string
 	}{
 		{
-			"success update customer assignment",
-			"POST",
-			dto.CustomerAssignmentTransaction{
+			"success get customer assignment by id",
+			"GET",
+			"abcde",
+			dto.CustomerAssignment{
 				ID:           "abcde",
 				File:         "tes",
-				Grade:        1,
+				Status:       dto.AssignmentGraded,
+				Grade:        80,
+				Score:        72,
 				AssignmentID: "abcde",
 				CustomerID:   "abcde",
+				Versions: []dto.CustomerAssignmentVersion{
+					{ID: "abcde", NoVersion: 1, File: "tes", Status: dto.AssignmentLate, LatePenalty: 10},
+				},
 			},
-			"abcde",
 			nil,
-			http.StatusOK,
-			"success update customer assignment",
-		},
-		{
-			"fail bind data",
-			"POST",
-			dto.CustomerAssignmentTransaction{
+			true,
+			dto.CustomerAssignment{
 				ID:           "abcde",
 				File:         "tes",
-				Grade:        1,
+				Status:       dto.AssignmentGraded,
+				Grade:        80,
+				Score:        72,
 				AssignmentID: "abcde",
 				CustomerID:   "abcde",
+				Versions: []dto.CustomerAssignmentVersion{
+					{ID: "abcde", NoVersion: 1, File: "tes", Status: dto.AssignmentLate, LatePenalty: 10},
+				},
 			},
-			"abcde",
-			nil,
-			http.StatusInternalServerError,
-			"fail bind data",
+			http.StatusOK,
+			"success get customer assignment by id",
 		},
 		{
-			"fail update customer assignment",
-			"POST",
-			dto.CustomerAssignmentTransaction{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "abcde",
-			},
+			"fail get customer assignment by id not found",
+			"GET",
 			"abcde",
+			dto.CustomerAssignment{},
 			gorm.ErrRecordNotFound,
-			http.StatusInternalServerError,
-			"fail update customer assignment",
+			false,
+			dto.CustomerAssignment{},
+			http.StatusNotFound,
+			"fail get customer assignment by id",
 		},
 		{
-			"fail update customer assignment",
-			"POST",
-			dto.CustomerAssignmentTransaction{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "abcde",
-			},
+			"fail get customer assignment by id",
+			"GET",
 			"abcde",
-			errors.New("fail update customer assignment"),
+			dto.CustomerAssignment{},
+			errors.New("fail get customer assignment by id"),
+			false,
+			dto.CustomerAssignment{},
 			http.StatusInternalServerError,
-			"fail update customer assignment",
+			"fail get customer assignment by id",
 		},
 	}
-	for i, v := range testCase {
-		mockCall := s.mock.On("UpdateCustomerAssignment", v.Body).Return(v.MockReturnError)
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetCustomerAssignmentByID", v.ParamID, dto.User{ID: "abcde", Role: "customer"}).Return(v.MockReturnBody, v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			res, _ := json.Marshal(v.Body)
 			// Create request
-			r := httptest.NewRequest(v.Method, "/customer_assignment/update/"+v.ParamID, bytes.NewBuffer(res))
-			if i != 1 {
-				r.Header.Set("Content-Type", "application/json")
-			}
+			r := httptest.NewRequest(v.Method, "/customer_assignment/get_by_id/"+v.ParamID, nil)
 			// Create response recorder
 			w := httptest.NewRecorder()
 
 			// handler echo
 			e := echo.New()
-			e.Validator = &helper.CustomValidator{
-				Validator: validator.New(),
-			}
 			ctx := e.NewContext(r, w)
-			ctx.SetPath("/customer_assignment/update/:id")
+			ctx.SetPath("/customer_assignment/get_by_id/:id")
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "customer"}})
 
-			err := s.customerAssignmentController.UpdateCustomerAssignment(ctx)
+			err := s.customerAssignmentController.GetCustomerAssignmentByID(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
 
@@ -500,6 +495,13 @@ func (s *suiteCustomerAssignment) TestUpdateCustomerAssignment() {
 			s.NoError(err)
 
 			s.Equal(v.ExpectedMesaage, resp["message"])
+
+			if v.HasReturnBody {
+				var customerAssignment dto.CustomerAssignment
+				data, _ := json.Marshal(resp["customer_assignment"])
+				s.NoError(json.Unmarshal(data, &customerAssignment))
+				s.Equal(v.ExpectedBody, customerAssignment)
+			}
 		})
 		// remove mock
 		mockCall.Unset()
@@ -2,6 +2,7 @@ package customerassignmentcontroller
 
 import (
 	"golang/constant/constantError"
+	"golang/helper"
 	"golang/models/dto"
 	customerAssignmentService "golang/service/customerAssignmentService"
 	"net/http"
@@ -13,11 +14,11 @@ type CustomerAssignmentController struct {
 	CustomerAssignmentService customerAssignmentService.CustomerAssignmentService
 }
 
-// CreateCustomerAssignment is a function to create customerAssignment
-func (cac *CustomerAssignmentController) CreateCustomerAssignment(c echo.Context) error {
-	var customerAssignment dto.CustomerAssignmentTransaction
+// SaveDraft is a function to save the file of the assignment of the customer as a draft
+func (cac *CustomerAssignmentController) SaveDraft(c echo.Context) error {
+	var input dto.CustomerAssignmentTransaction
 	// Binding request body to struct
-	err := c.Bind(&customerAssignment)
+	err := c.Bind(&input)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail bind data",
@@ -26,31 +27,164 @@ func (cac *CustomerAssignmentController) CreateCustomerAssignment(c echo.Context
 	}
 
 	// Validate request body
-	if err = c.Validate(customerAssignment); err != nil {
+	if err = c.Validate(input); err != nil {
 		return c.JSON(http.StatusBadRequest, echo.Map{
 			"message": "There is an empty field",
 			"error":   err.Error(),
 		})
 	}
 
-	// Call service to create customerAssignment
-	err = cac.CustomerAssignmentService.CreateCustomerAssignment(customerAssignment)
+	// Call service to save the assignment draft
+	customerAssignment, err := cac.CustomerAssignmentService.SaveDraft(input, helper.GetUser(c).ID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
-				"message": "fail create customer assignment",
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail save customer assignment draft",
 				"error":   err.Error(),
 			})
 		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"message": "fail create customer assignment",
+			"message": "fail save customer assignment draft",
 			"error":   err.Error(),
 		})
 	}
 
 	// Return response if success
 	return c.JSON(http.StatusOK, echo.Map{
-		"message": "success create customer assignment",
+		"message":             "success save customer assignment draft",
+		"customer_assignment": customerAssignment,
+	})
+}
+
+// SubmitAssignment is a function to submit the file or the draft of the assignment of the customer, a submitted assignment is submitted again as a new version
+func (cac *CustomerAssignmentController) SubmitAssignment(c echo.Context) error {
+	var input dto.CustomerAssignmentTransaction
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Call service to submit the assignment
+	customerAssignment, err := cac.CustomerAssignmentService.SubmitAssignment(input, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail submit customer assignment",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail submit customer assignment",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":             "success submit customer assignment",
+		"customer_assignment": customerAssignment,
+	})
+}
+
+// GradeCustomerAssignment is a function to grade a submission of an assignment of the instructor
+func (cac *CustomerAssignmentController) GradeCustomerAssignment(c echo.Context) error {
+	var input dto.AssignmentGrade
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	input.ID = c.Param("id")
+
+	// Call service to grade the submission
+	customerAssignment, err := cac.CustomerAssignmentService.GradeCustomerAssignment(input, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail grade customer assignment",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail grade customer assignment",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":             "success grade customer assignment",
+		"customer_assignment": customerAssignment,
+	})
+}
+
+// ReturnCustomerAssignment is a function to send a submission back to the customer to submit it again
+func (cac *CustomerAssignmentController) ReturnCustomerAssignment(c echo.Context) error {
+	var input dto.AssignmentReturn
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	input.ID = c.Param("id")
+
+	// Call service to return the submission
+	customerAssignment, err := cac.CustomerAssignmentService.ReturnCustomerAssignment(input, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail return customer assignment",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail return customer assignment",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":             "success return customer assignment",
+		"customer_assignment": customerAssignment,
 	})
 }
 
@@ -60,10 +194,10 @@ func (cac *CustomerAssignmentController) DeleteCustomerAssignment(c echo.Context
 	id := c.Param("id")
 
 	// Call service to delete customer assignment
-	err := cac.CustomerAssignmentService.DeleteCustomerAssignment(id)
+	err := cac.CustomerAssignmentService.DeleteCustomerAssignment(id, helper.GetUser(c))
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
 				"message": "fail delete customer assignment",
 				"error":   err.Error(),
 			})
@@ -80,10 +214,10 @@ func (cac *CustomerAssignmentController) DeleteCustomerAssignment(c echo.Context
 	})
 }
 
-// GetAllCustomerAssignment is a function to get all customerAssignment
+// GetAllCustomerAssignment is a function to get all the submissions of the customer
 func (cac *CustomerAssignmentController) GetAllCustomerAssignment(c echo.Context) error {
 	// Call service to get all customerAssignment
-	customerAssignments, err := cac.CustomerAssignmentService.GetAllCustomerAssignment()
+	customerAssignments, err := cac.CustomerAssignmentService.GetCustomerAssignments(helper.GetUser(c).ID)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail get all customer assignment",
@@ -98,66 +232,56 @@ func (cac *CustomerAssignmentController) GetAllCustomerAssignment(c echo.Context
 	})
 }
 
-// GetCustomerAssignmentByID is a function to get customerAssignment by id
-func (cac *CustomerAssignmentController) GetCustomerAssignmentByID(c echo.Context) error {
-	// Get id from url
+// GetCustomerAssignmentsByAssignmentID is a function to get the submissions of an assignment of the instructor
+func (cac *CustomerAssignmentController) GetCustomerAssignmentsByAssignmentID(c echo.Context) error {
+	// Get assignment id from url
 	id := c.Param("id")
 
-	// Call service to get customerAssignment by id
-	customerAssignment, err := cac.CustomerAssignmentService.GetCustomerAssignmentByID(id)
+	// Call service to get the submissions of the assignment
+	customerAssignments, err := cac.CustomerAssignmentService.GetCustomerAssignmentsByAssignmentID(id, helper.GetUser(c).ID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
-				"message": "fail get customer assignment by id",
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get customer assignment by assignment id",
 				"error":   err.Error(),
 			})
 		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"message": "fail get customer assignment by id",
+			"message": "fail get customer assignment by assignment id",
 			"error":   err.Error(),
 		})
 	}
 
 	// Return response if success
 	return c.JSON(http.StatusOK, echo.Map{
-		"message":             "success get customer assignment by id",
-		"customer_assignment": customerAssignment,
+		"message":             "success get customer assignment by assignment id",
+		"customer_assignment": customerAssignments,
 	})
 }
 
-// UpdateCustomerAssignment is a function to update customerAssignment
-func (cac *CustomerAssignmentController) UpdateCustomerAssignment(c echo.Context) error {
-	var customerAssignment dto.CustomerAssignmentTransaction
-	// Binding request body to struct
-	err := c.Bind(&customerAssignment)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"message": "fail bind data",
-			"error":   err.Error(),
-		})
-	}
-
+// GetCustomerAssignmentByID is a function to get customerAssignment by id
+func (cac *CustomerAssignmentController) GetCustomerAssignmentByID(c echo.Context) error {
 	// Get id from url
 	id := c.Param("id")
-	customerAssignment.ID = id
 
-	// Call service to update customerAssignment
-	err = cac.CustomerAssignmentService.UpdateCustomerAssignment(customerAssignment)
+	// Call service to get customerAssignment by id
+	customerAssignment, err := cac.CustomerAssignmentService.GetCustomerAssignmentByID(id, helper.GetUser(c))
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
-				"message": "fail update customer assignment",
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get customer assignment by id",
 				"error":   err.Error(),
 			})
 		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
-			"message": "fail update customer assignment",
+			"message": "fail get customer assignment by id",
 			"error":   err.Error(),
 		})
 	}
 
 	// Return response if success
 	return c.JSON(http.StatusOK, echo.Map{
-		"message": "success update customer assignment",
+		"message":             "success get customer assignment by id",
+		"customer_assignment": customerAssignment,
 	})
 }
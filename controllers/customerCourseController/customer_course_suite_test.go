@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	middlewareCustomer "golang/app/middlewares/costumer"
+	"golang/app/middlewares/auth"
 	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
@@ -98,7 +98,7 @@ func (s *suiteCustomerCourse) TestDeleteCustomerCourse() {
 			ctx.SetPath("/customerCourse/:courseId")
 			ctx.SetParamNames("courseId")
 			ctx.SetParamValues(v.CourseID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.customerCourseController.DeleteCustomerCourse(ctx)
 			s.NoError(err)
@@ -238,7 +238,7 @@ func (s *suiteCustomerCourse) TestGetHistoryCourseByCustomerID() {
 			e := echo.New()
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/courses")
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.customerCourseController.GetHistoryCourseByCustomerID(ctx)
 			s.NoError(err)
@@ -458,7 +458,7 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 				ctx.SetParamNames("courseId")
 				ctx.SetParamValues(v.CourseID)
 			}
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.customerCourseController.TakeCourse(ctx)
 			s.NoError(err)
@@ -590,7 +590,7 @@ func (s *suiteCustomerCourse) TestUpdateEnrollmentStatus() {
 				ctx.SetParamNames("courseId")
 				ctx.SetParamValues(v.ParamID)
 			}
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.customerCourseController.UpdateEnrollmentStatus(ctx)
 			s.NoError(err)
@@ -3,7 +3,7 @@ package favoriteController
 import (
 	"encoding/json"
 	"errors"
-	middlewareCustomer "golang/app/middlewares/costumer"
+	"golang/app/middlewares/auth"
 	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
@@ -127,7 +127,7 @@ func (s *suiteFavorite) TestAddFavorite() {
 				ctx.SetParamNames("courseId")
 				ctx.SetParamValues(v.ParamCourseID)
 			}
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.favoriteController.AddFavorite(ctx)
 			s.NoError(err)
@@ -208,7 +208,7 @@ func (s *suiteFavorite) TestDeleteFavorite() {
 			ctx.SetPath("/favorite/:courseId")
 			ctx.SetParamNames("courseId")
 			ctx.SetParamValues(v.CourseID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.favoriteController.DeleteFavorite(ctx)
 			s.NoError(err)
@@ -348,7 +348,7 @@ func (s *suiteFavorite) TestGetCourseByCustomerID() {
 			e := echo.New()
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/favorite")
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.favoriteController.GetFavoriteCourseByCustomerID(ctx)
 			s.NoError(err)
@@ -1,12 +1,14 @@
 package instructorcontroller
 
 import (
-	middlewares "golang/app/middlewares/instructor"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/helper"
 	"golang/models/dto"
 	instructorService "golang/service/instructorService"
 	"net/http"
 
-	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
 
@@ -46,6 +48,82 @@ func (u *InstructorController) Register(c echo.Context) error {
 	})
 }
 
+func (u *InstructorController) Verifikasi(c echo.Context) error {
+	var instructorVerif dto.InstructorVerif
+	err := c.Bind(&instructorVerif)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(instructorVerif)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.VerifikasiInstructor(instructorVerif)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail verif user",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail verif user",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(200, echo.Map{
+		"message": "success verif user",
+	})
+}
+
+func (u *InstructorController) ResendCode(c echo.Context) error {
+	var input dto.ResendCode
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.ResendVerificationCode(input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail resend code",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail resend code",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success resend code",
+	})
+}
+
 func (u *InstructorController) Login(c echo.Context) error {
 	var instructorLogin dto.InstructorLogin
 	err := c.Bind(&instructorLogin)
@@ -64,7 +142,7 @@ func (u *InstructorController) Login(c echo.Context) error {
 		})
 	}
 
-	token, errToken := middlewares.GenerateTokenInstructor(user.ID)
+	tokens, errToken := auth.GenerateTokenPair(user.ID, constantRole.RoleInstructor)
 
 	if errToken != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{
@@ -74,9 +152,10 @@ func (u *InstructorController) Login(c echo.Context) error {
 	}
 
 	instructorResponse := dto.InstructorResponse{
-		Name:  user.Name,
-		Email: user.Email,
-		Token: token,
+		Name:         user.Name,
+		Email:        user.Email,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 	}
 
 	return c.JSON(200, echo.Map{
@@ -85,20 +164,447 @@ func (u *InstructorController) Login(c echo.Context) error {
 	})
 }
 
-func (u *InstructorController) Logout(c echo.Context) error {
-	user := c.Get("user").(*jwt.Token)
+func (u *InstructorController) Refresh(c echo.Context) error {
+	var input dto.RefreshTokenRequest
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	tokens, err := auth.RefreshToken(input.RefreshToken, constantRole.RoleInstructor)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail refresh token",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail refresh token",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success refresh token",
+		"token":   tokens,
+	})
+}
+
+func (u *InstructorController) ForgotPassword(c echo.Context) error {
+	var input dto.ForgotPassword
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.ForgotPassword(input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail forgot password",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail forgot password",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success forgot password",
+	})
+}
+
+func (u *InstructorController) ResetPassword(c echo.Context) error {
+	var input dto.ResetPassword
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.ResetPassword(input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail reset password",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail reset password",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success reset password",
+	})
+}
+
+func (u *InstructorController) ChangePassword(c echo.Context) error {
+	var input dto.ChangePassword
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.ChangePassword(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail change password",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail change password",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success change password",
+	})
+}
+
+func (u *InstructorController) GetProfile(c echo.Context) error {
+	profile, err := u.InstructorService.GetProfile(helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get profile",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get profile",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get profile",
+		"profile": profile,
+	})
+}
+
+// GetPublicProfile is a function for customer to see the profile of an instructor
+func (u *InstructorController) GetPublicProfile(c echo.Context) error {
+	id := c.Param("id")
+
+	profile, err := u.InstructorService.GetPublicProfile(id)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get instructor profile",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get instructor profile",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get instructor profile",
+		"profile": profile,
+	})
+}
+
+func (u *InstructorController) UpdateProfile(c echo.Context) error {
+	var input dto.InstructorProfileUpdate
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.UpdateProfile(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update profile",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update profile",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update profile",
+	})
+}
+
+func (u *InstructorController) ChangeEmail(c echo.Context) error {
+	var input dto.ChangeEmail
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.ChangeEmail(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail change email",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail change email",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success change email, check the new email for the verification code",
+	})
+}
+
+func (u *InstructorController) VerifyEmailChange(c echo.Context) error {
+	var input dto.VerifyEmailChange
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.VerifyEmailChange(helper.GetUser(c).ID, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail verify email",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail verify email",
+			"error":   err.Error(),
+		})
+	}
 
-	isListed := middlewares.CheckTokenInstructor(user.Raw)
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success verify email",
+	})
+}
+
+func (u *InstructorController) UpdateProfileImage(c echo.Context) error {
+	file, err := c.FormFile("image")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+	image, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail open image",
+			"error":   err.Error(),
+		})
+	}
+	defer image.Close()
 
-	if !isListed {
+	url, err := u.InstructorService.UpdateProfileImage(helper.GetUser(c).ID, image)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update profile image",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update profile image",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":       "success update profile image",
+		"profile_image": url,
+	})
+}
+
+func (u *InstructorController) Logout(c echo.Context) error {
+	claims := auth.GetUser(c)
+
+	if claims == nil || !auth.CheckToken(claims.Id, constantRole.RoleInstructor) {
 		return c.JSON(http.StatusUnauthorized, map[string]string{
 			"message": "invalid token",
 		})
 	}
 
-	middlewares.LogoutInstructor(user.Raw)
+	auth.Logout(claims.Id)
 
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "logout success",
 	})
 }
+
+// GetAllInstructor is a function for admin to get all instructor account
+func (u *InstructorController) GetAllInstructor(c echo.Context) error {
+	accounts, err := u.InstructorService.GetAllInstructor()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get all instructor",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":     "success get all instructor",
+		"instructors": accounts,
+	})
+}
+
+// UpdateInstructorStatus is a function for admin to approve or suspend instructor account
+func (u *InstructorController) UpdateInstructorStatus(c echo.Context) error {
+	id := c.Param("id")
+
+	var input dto.InstructorStatus
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// validate request body
+	err = c.Validate(input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	err = u.InstructorService.UpdateInstructorStatus(id, input)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update instructor status",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update instructor status",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update instructor status",
+	})
+}
+
+// DeleteInstructor is a function for admin to delete instructor account
+func (u *InstructorController) DeleteInstructor(c echo.Context) error {
+	id := c.Param("id")
+
+	err := u.InstructorService.DeleteInstructor(id)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete instructor",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete instructor",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete instructor",
+	})
+}
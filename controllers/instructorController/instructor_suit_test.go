@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	instructormockservice "golang/service/instructorService/instructorMockService"
@@ -12,8 +15,10 @@ import (
 	"testing"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 )
 
 type suiteInstructor struct {
@@ -127,6 +132,362 @@ func (s *suiteInstructor) TestCreateInstructor() {
 	}
 }
 
+func (s *suiteInstructor) TestChangePassword() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.ChangePassword
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success change password",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "oldpassword",
+				NewPassword: "newpassword",
+			},
+			nil,
+			http.StatusOK,
+			"success change password",
+		},
+		{
+			"fail bind data",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "oldpassword",
+				NewPassword: "newpassword",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"PUT",
+			dto.ChangePassword{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail change password old password not match",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "wrongpassword",
+				NewPassword: "newpassword",
+			},
+			errors.New(constantError.ErrorOldPasswordNotMatch),
+			http.StatusBadRequest,
+			"fail change password",
+		},
+		{
+			"fail change password",
+			"PUT",
+			dto.ChangePassword{
+				OldPassword: "oldpassword",
+				NewPassword: "newpassword",
+			},
+			errors.New("fail change password"),
+			http.StatusInternalServerError,
+			"fail change password",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("ChangePassword", "abcde", v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/instructor/change-password", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/instructor/change-password")
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: constantRole.RoleInstructor}})
+
+			err := s.instructorController.ChangePassword(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteInstructor) TestGetPublicProfile() {
+	testCase := []struct {
+		Name               string
+		ParamID            string
+		MockReturnBody     dto.InstructorPublicProfile
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get instructor profile",
+			"abcde",
+			dto.InstructorPublicProfile{ID: "abcde", Name: "tes", Rating: 4.5},
+			nil,
+			http.StatusOK,
+			"success get instructor profile",
+		},
+		{
+			"fail get instructor profile because not found",
+			"abcde",
+			dto.InstructorPublicProfile{},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail get instructor profile",
+		},
+		{
+			"fail get instructor profile",
+			"abcde",
+			dto.InstructorPublicProfile{},
+			errors.New("fail get instructor profile"),
+			http.StatusInternalServerError,
+			"fail get instructor profile",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetPublicProfile", v.ParamID).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("GET", "/customer/instructor/"+v.ParamID+"/profile", nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/customer/instructor/:id/profile")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			err := s.instructorController.GetPublicProfile(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteInstructor) TestGetAllInstructor() {
+	testCase := []struct {
+		Name               string
+		MockReturnBody     []dto.InstructorAccount
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get all instructor",
+			[]dto.InstructorAccount{
+				{
+					ID:    "abcde",
+					Name:  "tes",
+					Email: "tes@gmail.com",
+				},
+			},
+			nil,
+			http.StatusOK,
+			"success get all instructor",
+		},
+		{
+			"fail get all instructor",
+			[]dto.InstructorAccount{},
+			errors.New("fail get all instructor"),
+			http.StatusInternalServerError,
+			"fail get all instructor",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetAllInstructor").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("GET", "/admin/instructor/get_all", nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/admin/instructor/get_all")
+
+			err := s.instructorController.GetAllInstructor(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteInstructor) TestUpdateInstructorStatus() {
+	testCase := []struct {
+		Name               string
+		ParamID            string
+		Body               dto.InstructorStatus
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success update instructor status",
+			"abcde",
+			dto.InstructorStatus{Status: dto.InstructorStatusApproved},
+			nil,
+			http.StatusOK,
+			"success update instructor status",
+		},
+		{
+			"fail unknown status",
+			"abcde",
+			dto.InstructorStatus{Status: "deleted"},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail update instructor status because not found",
+			"abcde",
+			dto.InstructorStatus{Status: dto.InstructorStatusSuspended},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail update instructor status",
+		},
+		{
+			"fail update instructor status",
+			"abcde",
+			dto.InstructorStatus{Status: dto.InstructorStatusSuspended},
+			errors.New("fail update instructor status"),
+			http.StatusInternalServerError,
+			"fail update instructor status",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("UpdateInstructorStatus", v.ParamID, v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest("PUT", "/admin/instructor/status/"+v.ParamID, bytes.NewBuffer(res))
+			r.Header.Set("Content-Type", "application/json")
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/admin/instructor/status/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			err := s.instructorController.UpdateInstructorStatus(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteInstructor) TestDeleteInstructor() {
+	testCase := []struct {
+		Name               string
+		ParamID            string
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success delete instructor",
+			"abcde",
+			nil,
+			http.StatusOK,
+			"success delete instructor",
+		},
+		{
+			"fail delete instructor because not found",
+			"abcde",
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail delete instructor",
+		},
+		{
+			"fail delete instructor",
+			"abcde",
+			errors.New("fail delete instructor"),
+			http.StatusInternalServerError,
+			"fail delete instructor",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteInstructor", v.ParamID).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest("DELETE", "/admin/instructor/delete/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/admin/instructor/delete/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			err := s.instructorController.DeleteInstructor(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
 func TestSuiteInstructor(t *testing.T) {
 	suite.Run(t, new(suiteInstructor))
 }
@@ -0,0 +1,178 @@
+package learningPathController
+
+import (
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/learningPathService"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LearningPathController struct {
+	LearningPathService learningPathService.LearningPathService
+}
+
+// CreateLearningPath is a function to create a learning path of courses in order
+func (lpc *LearningPathController) CreateLearningPath(c echo.Context) error {
+	var learningPath dto.LearningPathTransaction
+	// Binding request body to struct
+	err := c.Bind(&learningPath)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(learningPath); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to create learning path
+	err = lpc.LearningPathService.CreateLearningPath(learningPath, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create learning path",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail create learning path",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success create learning path",
+	})
+}
+
+// DeleteLearningPath is a function to delete learning path
+func (lpc *LearningPathController) DeleteLearningPath(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to delete learning path
+	err := lpc.LearningPathService.DeleteLearningPath(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete learning path",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete learning path",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete learning path",
+	})
+}
+
+// GetLearningPathByID is a function to get learning path by id, the customers get their progress
+func (lpc *LearningPathController) GetLearningPathByID(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to get learning path
+	learningPath, err := lpc.LearningPathService.GetLearningPathByID(id, user)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get learning path by id",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get learning path by id",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get learning path by id",
+		"data":    learningPath,
+	})
+}
+
+// GetLearningPaths is a function to get all learning paths
+func (lpc *LearningPathController) GetLearningPaths(c echo.Context) error {
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to get learning paths
+	learningPaths, err := lpc.LearningPathService.GetLearningPaths(user)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get all learning path",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get all learning path",
+		"data":    learningPaths,
+	})
+}
+
+// UpdateLearningPath is a function to update learning path
+func (lpc *LearningPathController) UpdateLearningPath(c echo.Context) error {
+	var learningPath dto.LearningPathTransaction
+	// Binding request body to struct
+	err := c.Bind(&learningPath)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	learningPath.ID = c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to update learning path
+	err = lpc.LearningPathService.UpdateLearningPath(learningPath, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update learning path",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update learning path",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update learning path",
+	})
+}
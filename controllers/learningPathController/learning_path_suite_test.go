@@ -0,0 +1,447 @@
+package learningPathController
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/learningPathService/learningPathMockService"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteLearningPath struct {
+	suite.Suite
+	learningPathController *LearningPathController
+	mock                   *learningPathMockService.LearningPathMock
+}
+
+func (s *suiteLearningPath) SetupTest() {
+	mock := &learningPathMockService.LearningPathMock{}
+	s.mock = mock
+	s.learningPathController = &LearningPathController{
+		LearningPathService: s.mock,
+	}
+}
+
+func (s *suiteLearningPath) TestCreateLearningPath() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.LearningPathTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success create learning path",
+			"POST",
+			dto.LearningPathTransaction{
+				Title:     "go",
+				CourseIDs: []string{"basic", "concurrency"},
+			},
+			nil,
+			http.StatusOK,
+			"success create learning path",
+		},
+		{
+			"fail bind data",
+			"POST",
+			dto.LearningPathTransaction{
+				Title:     "go",
+				CourseIDs: []string{"basic"},
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.LearningPathTransaction{
+				Title: "go",
+			},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail create learning path with duplicate courses",
+			"POST",
+			dto.LearningPathTransaction{
+				Title:     "go",
+				CourseIDs: []string{"basic", "basic"},
+			},
+			errors.New(constantError.ErrorLearningPathCourses),
+			http.StatusBadRequest,
+			"fail create learning path",
+		},
+		{
+			"fail create learning path",
+			"POST",
+			dto.LearningPathTransaction{
+				Title:     "go",
+				CourseIDs: []string{"basic"},
+			},
+			errors.New("fail create learning path"),
+			http.StatusInternalServerError,
+			"fail create learning path",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("CreateLearningPath", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/learning_path/create", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/learning_path/create")
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.learningPathController.CreateLearningPath(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestDeleteLearningPath() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success delete learning path",
+			"DELETE",
+			"abcde",
+			nil,
+			http.StatusOK,
+			"success delete learning path",
+		},
+		{
+			"fail delete learning path not found",
+			"DELETE",
+			"abcde",
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail delete learning path",
+		},
+		{
+			"fail delete learning path not owner",
+			"DELETE",
+			"abcde",
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail delete learning path",
+		},
+		{
+			"fail delete learning path",
+			"DELETE",
+			"abcde",
+			errors.New("fail delete learning path"),
+			http.StatusInternalServerError,
+			"fail delete learning path",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteLearningPath", v.ParamID, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/learning_path/delete/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/learning_path/delete/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.learningPathController.DeleteLearningPath(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestGetLearningPathByID() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnBody     dto.LearningPath
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get learning path by id",
+			"GET",
+			"abcde",
+			dto.LearningPath{
+				ID:                 "abcde",
+				Title:              "go",
+				Courses:            []dto.LearningPathCourse{{CourseID: "basic", NoCourse: 1, IsFinish: true}},
+				ProgressPercentage: 100,
+			},
+			nil,
+			http.StatusOK,
+			"success get learning path by id",
+		},
+		{
+			"fail get learning path by id not found",
+			"GET",
+			"abcde",
+			dto.LearningPath{},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail get learning path by id",
+		},
+		{
+			"fail get learning path by id",
+			"GET",
+			"abcde",
+			dto.LearningPath{},
+			errors.New("fail get learning path by id"),
+			http.StatusInternalServerError,
+			"fail get learning path by id",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "customer"}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetLearningPathByID", v.ParamID, user).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/learning_path/get_by_id/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/learning_path/get_by_id/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.learningPathController.GetLearningPathByID(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+			if v.ExpectedStatusCode == http.StatusOK {
+				data := resp["data"].(map[string]interface{})
+				s.Equal(v.MockReturnBody.ProgressPercentage, data["progress_percentage"])
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestGetLearningPaths() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		MockReturnBody     []dto.LearningPath
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get all learning path",
+			"GET",
+			[]dto.LearningPath{{ID: "abcde", Title: "go"}},
+			nil,
+			http.StatusOK,
+			"success get all learning path",
+		},
+		{
+			"fail get all learning path",
+			"GET",
+			[]dto.LearningPath{},
+			errors.New("fail get all learning path"),
+			http.StatusInternalServerError,
+			"fail get all learning path",
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetLearningPaths", user).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/learning_path/get_all", nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/learning_path/get_all")
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: user.ID, Role: user.Role}})
+
+			err := s.learningPathController.GetLearningPaths(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestUpdateLearningPath() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		Body               dto.LearningPathTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success update learning path",
+			"PUT",
+			"abcde",
+			dto.LearningPathTransaction{
+				ID:        "abcde",
+				Title:     "go",
+				CourseIDs: []string{"concurrency", "basic"},
+			},
+			nil,
+			http.StatusOK,
+			"success update learning path",
+		},
+		{
+			"fail bind data",
+			"PUT",
+			"abcde",
+			dto.LearningPathTransaction{
+				ID:    "abcde",
+				Title: "go",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"fail update learning path not owner",
+			"PUT",
+			"abcde",
+			dto.LearningPathTransaction{
+				ID:    "abcde",
+				Title: "go",
+			},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail update learning path",
+		},
+		{
+			"fail update learning path",
+			"PUT",
+			"abcde",
+			dto.LearningPathTransaction{
+				ID:    "abcde",
+				Title: "go",
+			},
+			errors.New("fail update learning path"),
+			http.StatusInternalServerError,
+			"fail update learning path",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("UpdateLearningPath", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/learning_path/update/"+v.ParamID, bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/learning_path/update/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.learningPathController.UpdateLearningPath(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteLearningPath(t *testing.T) {
+	suite.Run(t, new(suiteLearningPath))
+}
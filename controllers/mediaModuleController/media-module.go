@@ -2,6 +2,7 @@ package mediamodulecontroller
 
 import (
 	"golang/constant/constantError"
+	"golang/helper"
 	"golang/models/dto"
 	mediamoduleservice "golang/service/mediaModuleService"
 	"net/http"
@@ -33,9 +34,18 @@ func (mmc *MediaModuleController) CreateMediaModule(c echo.Context) error {
 		})
 	}
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to create module
-	err = mmc.MediaModuleService.CreateMediaModule(mediaModule)
+	err = mmc.MediaModuleService.CreateMediaModule(mediaModule, instructorID)
 	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create media module",
+				"error":   err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail create media module",
 			"error":   err.Error(),
@@ -53,11 +63,14 @@ func (mmc *MediaModuleController) DeleteMediaModule(c echo.Context) error {
 	// Get id from url
 	id := c.Param("id")
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to delete media module
-	err := mmc.MediaModuleService.DeleteMediaModule(id)
+	err := mmc.MediaModuleService.DeleteMediaModule(id, instructorID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
 				"message": "fail delete media module",
 				"error":   err.Error(),
 			})
@@ -135,11 +148,14 @@ func (mmc *MediaModuleController) UpdateMediaModule(c echo.Context) error {
 	id := c.Param("id")
 	mediaModule.ID = id
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to update module
-	err = mmc.MediaModuleService.UpdateMediaModule(mediaModule)
+	err = mmc.MediaModuleService.UpdateMediaModule(mediaModule, instructorID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
 				"message": "fail update media module",
 				"error":   err.Error(),
 			})
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
 	mediamodulemockservice "golang/service/mediaModuleService/mediaModuleMockService"
@@ -13,6 +15,7 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -87,7 +90,7 @@ func (s *suiteMediaModule) TestCreateMediaModule() {
 		},
 	}
 	for i, v := range testCase {
-		mockCall := s.mock.On("CreateMediaModule", v.Body).Return(v.MockReturnError)
+		mockCall := s.mock.On("CreateMediaModule", v.Body, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			res, _ := json.Marshal(v.Body)
 			// Create request
@@ -106,6 +109,8 @@ func (s *suiteMediaModule) TestCreateMediaModule() {
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/media_module/create")
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.mediaModuleController.CreateMediaModule(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
@@ -143,7 +148,7 @@ func (s *suiteMediaModule) TestDeleteMediaModule() {
 			"DELETE",
 			"abcde",
 			gorm.ErrRecordNotFound,
-			http.StatusInternalServerError,
+			http.StatusNotFound,
 			"fail delete media module",
 		},
 		{
@@ -154,9 +159,17 @@ func (s *suiteMediaModule) TestDeleteMediaModule() {
 			http.StatusInternalServerError,
 			"fail delete media module",
 		},
+		{
+			"fail delete media module not owner",
+			"DELETE",
+			"abcde",
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail delete media module",
+		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("DeleteMediaModule", v.ParamID).Return(v.MockReturnError)
+		mockCall := s.mock.On("DeleteMediaModule", v.ParamID, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			// Create request
 			r := httptest.NewRequest(v.Method, "/media_module/delete/"+v.ParamID, nil)
@@ -173,6 +186,8 @@ func (s *suiteMediaModule) TestDeleteMediaModule() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.mediaModuleController.DeleteMediaModule(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
@@ -426,7 +441,7 @@ func (s *suiteMediaModule) TestUpdateMediaModule() {
 			},
 			"abcde",
 			gorm.ErrRecordNotFound,
-			http.StatusInternalServerError,
+			http.StatusNotFound,
 			"fail update media module",
 		},
 		{
@@ -444,7 +459,7 @@ func (s *suiteMediaModule) TestUpdateMediaModule() {
 		},
 	}
 	for i, v := range testCase {
-		mockCall := s.mock.On("UpdateMediaModule", v.Body).Return(v.MockReturnError)
+		mockCall := s.mock.On("UpdateMediaModule", v.Body, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			res, _ := json.Marshal(v.Body)
 			// Create request
@@ -465,6 +480,8 @@ func (s *suiteMediaModule) TestUpdateMediaModule() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.mediaModuleController.UpdateMediaModule(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
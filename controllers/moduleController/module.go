@@ -2,6 +2,7 @@ package moduleController
 
 import (
 	"golang/constant/constantError"
+	"golang/helper"
 	"golang/models/dto"
 	"golang/models/model"
 	moduleservice "golang/service/moduleService"
@@ -34,9 +35,18 @@ func (mc *ModuleController) CreateModule(c echo.Context) error {
 		})
 	}
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to create module
-	err = mc.ModuleService.CreateModule(module)
+	err = mc.ModuleService.CreateModule(module, instructorID)
 	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create module",
+				"error":   err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail create module",
 			"error":   err.Error(),
@@ -54,11 +64,14 @@ func (mc *ModuleController) DeleteModule(c echo.Context) error {
 	// Get id from url
 	id := c.Param("id")
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to delete module
-	err := mc.ModuleService.DeleteModule(id)
+	err := mc.ModuleService.DeleteModule(id, instructorID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
 				"message": "fail delete module",
 				"error":   err.Error(),
 			})
@@ -169,7 +182,7 @@ func (mc *ModuleController) GetModuleByCourseID(c echo.Context) error {
 	// Call service to get module by id
 	modules, err := mc.ModuleService.GetModuleByCourseID(input.CourseID, input.CustomerID)
 
-	if len(modules) == 0 {
+	if len(modules.Modules) == 0 && len(modules.Sections) == 0 {
 		return c.JSON(http.StatusNotFound, echo.Map{
 			"message": "fail get module by course id",
 		})
@@ -200,7 +213,7 @@ func (mc *ModuleController) GetModuleByCourseIDifInstructror(c echo.Context) err
 	// Call service to get module by id
 	modules, err := mc.ModuleService.GetModuleByCourseIDifInstructror(id)
 
-	if len(modules) == 0 {
+	if len(modules.Modules) == 0 && len(modules.Sections) == 0 {
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail get module by course id",
 		})
@@ -242,11 +255,14 @@ func (mc *ModuleController) UpdateModule(c echo.Context) error {
 	id := c.Param("id")
 	module.ID = id
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to update module
-	err = mc.ModuleService.UpdateModule(module)
+	err = mc.ModuleService.UpdateModule(module, instructorID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
 				"message": "fail update module",
 				"error":   err.Error(),
 			})
@@ -262,3 +278,50 @@ func (mc *ModuleController) UpdateModule(c echo.Context) error {
 		"message": "success update module",
 	})
 }
+
+// ReorderModules is a function to change the order of the modules of a course
+func (mc *ModuleController) ReorderModules(c echo.Context) error {
+	var order dto.ModuleOrder
+	// Binding request body to struct
+	err := c.Bind(&order)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(order); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get course id from url
+	courseID := c.Param("courseId")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to reorder modules
+	err = mc.ModuleService.ReorderModules(courseID, order, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail reorder modules",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail reorder modules",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success reorder modules",
+	})
+}
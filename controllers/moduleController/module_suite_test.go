@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/models/model"
@@ -14,6 +16,7 @@ import (
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
@@ -98,7 +101,7 @@ func (s *suiteModule) TestCreateModule() {
 		},
 	}
 	for i, v := range testCase {
-		mockCall := s.mock.On("CreateModule", v.Body).Return(v.MockReturnError)
+		mockCall := s.mock.On("CreateModule", v.Body, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			res, _ := json.Marshal(v.Body)
 			// Create request
@@ -117,6 +120,8 @@ func (s *suiteModule) TestCreateModule() {
 			ctx := e.NewContext(r, w)
 			ctx.SetPath("/module/create")
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.moduleController.CreateModule(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
@@ -154,7 +159,7 @@ func (s *suiteModule) TestDeleteModule() {
 			"DELETE",
 			"abcde",
 			gorm.ErrRecordNotFound,
-			http.StatusInternalServerError,
+			http.StatusNotFound,
 			"fail delete module",
 		},
 		{
@@ -166,9 +171,17 @@ func (s *suiteModule) TestDeleteModule() {
 			http.StatusInternalServerError,
 			"fail delete module",
 		},
+		{
+			"fail delete module not owner",
+			"DELETE",
+			"abcde",
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail delete module",
+		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("DeleteModule", v.ParamID).Return(v.MockReturnError)
+		mockCall := s.mock.On("DeleteModule", v.ParamID, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			// Create request
 			r := httptest.NewRequest(v.Method, "/module/"+v.ParamID, nil)
@@ -185,6 +198,8 @@ func (s *suiteModule) TestDeleteModule() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.moduleController.DeleteModule(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
@@ -502,10 +517,10 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 		Name               string
 		Method             string
 		ParamID            string
-		MockReturnBody     []dto.ModuleCourse
+		MockReturnBody     dto.CourseModules
 		MockReturnError    error
 		HasReturnBody      bool
-		ExpectedBody       []dto.ModuleCourse
+		ExpectedBody       dto.CourseModules
 		ExpectedStatusCode int
 		ExpectedMesaage    string
 	}{
@@ -513,7 +528,7 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 			"success get module by course id",
 			"GET",
 			"abcde",
-			[]dto.ModuleCourse{{
+			dto.CourseModules{Modules: []dto.ModuleCourse{{
 				ID:        "abcde",
 				Name:      "tes",
 				Content:   "tes",
@@ -535,11 +550,11 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 					ModuleID:            "abcde",
 					CustomerAssignments: []dto.CustomerAssignment{},
 				},
-			},
+			}},
 			},
 			nil,
 			true,
-			[]dto.ModuleCourse{{
+			dto.CourseModules{Modules: []dto.ModuleCourse{{
 				ID:        "abcde",
 				Name:      "tes",
 				Content:   "tes",
@@ -561,7 +576,7 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 					ModuleID:            "abcde",
 					CustomerAssignments: []dto.CustomerAssignment{},
 				},
-			},
+			}},
 			},
 			http.StatusOK,
 			"success get module by course id",
@@ -570,10 +585,10 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 			"fail get module by course id",
 			"GET",
 			"abcde",
-			[]dto.ModuleCourse{},
+			dto.CourseModules{},
 			gorm.ErrRecordNotFound,
 			false,
-			[]dto.ModuleCourse{},
+			dto.CourseModules{},
 			http.StatusInternalServerError,
 			"fail get module by course id",
 		},
@@ -581,10 +596,10 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 			"fail get module by course id",
 			"GET",
 			"abcde",
-			[]dto.ModuleCourse{},
+			dto.CourseModules{},
 			gorm.ErrRecordNotFound,
 			false,
-			[]dto.ModuleCourse{},
+			dto.CourseModules{},
 			http.StatusInternalServerError,
 			"fail get module by course id",
 		},
@@ -775,7 +790,7 @@ func (s *suiteModule) TestUpdateModule() {
 			},
 			"abcde",
 			gorm.ErrRecordNotFound,
-			http.StatusInternalServerError,
+			http.StatusNotFound,
 			"fail update module",
 		},
 		{
@@ -795,7 +810,7 @@ func (s *suiteModule) TestUpdateModule() {
 		},
 	}
 	for i, v := range testCase {
-		mockCall := s.mock.On("UpdateModule", v.Body).Return(v.MockReturnError)
+		mockCall := s.mock.On("UpdateModule", v.Body, "abcde").Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
 			res, _ := json.Marshal(v.Body)
 			// Create request
@@ -816,6 +831,8 @@ func (s *suiteModule) TestUpdateModule() {
 			ctx.SetParamNames("id")
 			ctx.SetParamValues(v.ParamID)
 
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
 			err := s.moduleController.UpdateModule(ctx)
 			s.NoError(err)
 			s.Equal(v.ExpectedStatusCode, w.Code)
@@ -831,6 +848,103 @@ func (s *suiteModule) TestUpdateModule() {
 	}
 }
 
+func (s *suiteModule) TestReorderModules() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.ModuleOrder
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success reorder modules",
+			"PUT",
+			dto.ModuleOrder{
+				ModuleIDs: []string{"module2", "module1"},
+			},
+			nil,
+			http.StatusOK,
+			"success reorder modules",
+		},
+		{
+			"fail bind data",
+			"PUT",
+			dto.ModuleOrder{
+				ModuleIDs: []string{"module2", "module1"},
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"PUT",
+			dto.ModuleOrder{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail reorder modules not in the course",
+			"PUT",
+			dto.ModuleOrder{
+				ModuleIDs: []string{"module2"},
+			},
+			errors.New(constantError.ErrorModuleOrder),
+			http.StatusBadRequest,
+			"fail reorder modules",
+		},
+		{
+			"fail reorder modules",
+			"PUT",
+			dto.ModuleOrder{
+				ModuleIDs: []string{"module2", "module1"},
+			},
+			errors.New("fail reorder modules"),
+			http.StatusInternalServerError,
+			"fail reorder modules",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("ReorderModules", "course1", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/module/reorder/course1", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/module/reorder/:courseId")
+			ctx.SetParamNames("courseId")
+			ctx.SetParamValues("course1")
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.moduleController.ReorderModules(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
 func TestSuiteModule(t *testing.T) {
 	suite.Run(t, new(suiteModule))
 }
@@ -0,0 +1,203 @@
+package progressionController
+
+import (
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/progressionService"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProgressionController struct {
+	ProgressionService progressionService.ProgressionService
+}
+
+// GetModuleRules is a function to get the completion rules of a module, a module without rule returns its default rules
+func (pc *ProgressionController) GetModuleRules(c echo.Context) error {
+	// Get module id from url
+	id := c.Param("id")
+
+	// Call service to get the rules of the module
+	rules, err := pc.ProgressionService.GetModuleRules(id, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get module rules",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get module rules",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get module rules",
+		"rules":   rules,
+	})
+}
+
+// UpdateModuleRules is a function to replace the completion rules of a module
+func (pc *ProgressionController) UpdateModuleRules(c echo.Context) error {
+	var input dto.ModuleRulesTransaction
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get module id from url
+	id := c.Param("id")
+
+	// Call service to replace the rules of the module
+	err = pc.ProgressionService.UpdateModuleRules(id, input, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update module rules",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update module rules",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update module rules",
+	})
+}
+
+// ViewModule is a function to record the customer opened the module
+func (pc *ProgressionController) ViewModule(c echo.Context) error {
+	// Get module id from url
+	id := c.Param("id")
+
+	// Call service to record the view
+	progress, err := pc.ProgressionService.ViewModule(id, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail view module",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail view module",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":  "success view module",
+		"progress": progress,
+	})
+}
+
+// WatchMedia is a function to record the part of the media the customer watched
+func (pc *ProgressionController) WatchMedia(c echo.Context) error {
+	var input dto.MediaProgressTransaction
+	// Binding request body to struct
+	err := c.Bind(&input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(input); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get media module id from url
+	input.MediaModuleID = c.Param("id")
+
+	// Call service to record the watched part
+	progress, err := pc.ProgressionService.WatchMedia(input, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail watch media",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail watch media",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":  "success watch media",
+		"progress": progress,
+	})
+}
+
+// GetModuleProgress is a function to get the rules of a module the customer met
+func (pc *ProgressionController) GetModuleProgress(c echo.Context) error {
+	// Get module id from url
+	id := c.Param("id")
+
+	// Call service to get the progress of the module
+	progress, err := pc.ProgressionService.GetModuleProgress(id, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get module progress",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get module progress",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message":  "success get module progress",
+		"progress": progress,
+	})
+}
+
+// GetProgressEvents is a function to get the modules and the courses the customer finished
+func (pc *ProgressionController) GetProgressEvents(c echo.Context) error {
+	// Call service to get the progress events
+	events, err := pc.ProgressionService.GetProgressEvents(helper.GetUser(c).ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get progress events",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get progress events",
+		"events":  events,
+	})
+}
@@ -0,0 +1,313 @@
+package progressionController
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/progressionService/progressionMockService"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteProgression struct {
+	suite.Suite
+	progressionController *ProgressionController
+	mock                  *progressionMockService.ProgressionMock
+}
+
+func (s *suiteProgression) SetupTest() {
+	mock := &progressionMockService.ProgressionMock{}
+	s.mock = mock
+	s.progressionController = &ProgressionController{
+		ProgressionService: s.mock,
+	}
+}
+
+func (s *suiteProgression) TestUpdateModuleRules() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		Body               dto.ModuleRulesTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success update module rules",
+			"PUT",
+			"abcde",
+			dto.ModuleRulesTransaction{
+				Rules: []dto.ModuleRuleTransaction{{Type: dto.ModuleRuleAssignmentPassed, MinValue: 70}},
+			},
+			nil,
+			http.StatusOK,
+			"success update module rules",
+		},
+		{
+			"fail bind data",
+			"PUT",
+			"abcde",
+			dto.ModuleRulesTransaction{
+				Rules: []dto.ModuleRuleTransaction{{Type: dto.ModuleRuleAssignmentPassed, MinValue: 70}},
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"PUT",
+			"abcde",
+			dto.ModuleRulesTransaction{
+				Rules: []dto.ModuleRuleTransaction{{Type: "finished"}},
+			},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail update module rules with target of other module",
+			"PUT",
+			"abcde",
+			dto.ModuleRulesTransaction{
+				Rules: []dto.ModuleRuleTransaction{{Type: dto.ModuleRuleQuizPassed, TargetID: "quiz"}},
+			},
+			errors.New(constantError.ErrorModuleRuleTarget),
+			http.StatusBadRequest,
+			"fail update module rules",
+		},
+		{
+			"fail update module rules",
+			"PUT",
+			"abcde",
+			dto.ModuleRulesTransaction{
+				Rules: []dto.ModuleRuleTransaction{{Type: dto.ModuleRuleViewed}},
+			},
+			errors.New("fail update module rules"),
+			http.StatusInternalServerError,
+			"fail update module rules",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("UpdateModuleRules", v.ParamID, mock.Anything, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/module/rules/"+v.ParamID, bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/module/rules/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+			// set user claims
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.progressionController.UpdateModuleRules(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteProgression) TestWatchMedia() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		Body               dto.MediaProgressTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success watch media",
+			"POST",
+			"media",
+			dto.MediaProgressTransaction{ModuleID: "abcde", Watched: 80},
+			nil,
+			http.StatusOK,
+			"success watch media",
+		},
+		{
+			"fail bind data",
+			"POST",
+			"media",
+			dto.MediaProgressTransaction{ModuleID: "abcde", Watched: 80},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			"media",
+			dto.MediaProgressTransaction{ModuleID: "abcde", Watched: 150},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail watch media of other module",
+			"POST",
+			"media",
+			dto.MediaProgressTransaction{ModuleID: "abcde", Watched: 80},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail watch media",
+		},
+	}
+	for i, v := range testCase {
+		input := v.Body
+		input.MediaModuleID = v.ParamID
+		mockCall := s.mock.On("WatchMedia", input, "abcde").Return(dto.ModuleProgress{ModuleID: "abcde"}, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/progress/media/"+v.ParamID, bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/progress/media/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+			// set user claims
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "customer"}})
+
+			err := s.progressionController.WatchMedia(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteProgression) TestViewModule() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnBody     dto.ModuleProgress
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success view module",
+			"POST",
+			"abcde",
+			dto.ModuleProgress{
+				ModuleID: "abcde",
+				IsFinish: true,
+				Rules:    []dto.ModuleRuleProgress{{Type: dto.ModuleRuleViewed, IsMet: true}},
+			},
+			nil,
+			http.StatusOK,
+			"success view module",
+		},
+		{
+			"fail view module the customer cannot learn yet",
+			"POST",
+			"abcde",
+			dto.ModuleProgress{},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail view module",
+		},
+		{
+			"fail view module",
+			"POST",
+			"abcde",
+			dto.ModuleProgress{},
+			errors.New("fail view module"),
+			http.StatusInternalServerError,
+			"fail view module",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ViewModule", v.ParamID, "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/progress/view/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/progress/view/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+			// set user claims
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "customer"}})
+
+			err := s.progressionController.ViewModule(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+			if v.MockReturnError == nil {
+				var progress dto.ModuleProgress
+				data, _ := json.Marshal(resp["progress"])
+				s.NoError(json.Unmarshal(data, &progress))
+				s.Equal(v.MockReturnBody, progress)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteProgression(t *testing.T) {
+	suite.Run(t, new(suiteProgression))
+}
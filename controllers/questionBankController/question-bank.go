@@ -0,0 +1,407 @@
+package questionBankController
+
+import (
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/questionBankService"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxQuizFileSize is the biggest GIFT or QTI file accepted by the import
+const maxQuizFileSize = 5 << 20
+
+type QuestionBankController struct {
+	QuestionBankService questionBankService.QuestionBankService
+}
+
+// CreateQuestionBank is a function to create a question bank of a course
+func (qbc *QuestionBankController) CreateQuestionBank(c echo.Context) error {
+	var questionBank dto.QuestionBankTransaction
+	// Binding request body to struct
+	err := c.Bind(&questionBank)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(questionBank); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to create question bank
+	err = qbc.QuestionBankService.CreateQuestionBank(questionBank, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create question bank",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail create question bank",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success create question bank",
+	})
+}
+
+// DeleteQuestionBank is a function to delete a question bank with its questions
+func (qbc *QuestionBankController) DeleteQuestionBank(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to delete question bank
+	err := qbc.QuestionBankService.DeleteQuestionBank(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete question bank",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete question bank",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete question bank",
+	})
+}
+
+// GetQuestionBankByID is a function to get a question bank with its questions
+func (qbc *QuestionBankController) GetQuestionBankByID(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to get question bank by id
+	questionBank, err := qbc.QuestionBankService.GetQuestionBankByID(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get question bank by id",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get question bank by id",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get question bank by id",
+		"data":    questionBank,
+	})
+}
+
+// GetQuestionBanksByCourseID is a function to get the question banks of a course
+func (qbc *QuestionBankController) GetQuestionBanksByCourseID(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to get question banks by course id
+	questionBanks, err := qbc.QuestionBankService.GetQuestionBanksByCourseID(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get question banks by course id",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get question banks by course id",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get question banks by course id",
+		"data":    questionBanks,
+	})
+}
+
+// UpdateQuestionBank is a function to update a question bank
+func (qbc *QuestionBankController) UpdateQuestionBank(c echo.Context) error {
+	var questionBank dto.QuestionBankTransaction
+	// Binding request body to struct
+	err := c.Bind(&questionBank)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	questionBank.ID = c.Param("id")
+
+	// Validate request body
+	if err = c.Validate(questionBank); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to update question bank
+	err = qbc.QuestionBankService.UpdateQuestionBank(questionBank, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update question bank",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update question bank",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update question bank",
+	})
+}
+
+// CreateBankQuestion is a function to add a question to a question bank
+func (qbc *QuestionBankController) CreateBankQuestion(c echo.Context) error {
+	var question dto.QuizQuestionTransaction
+	// Binding request body to struct
+	err := c.Bind(&question)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(question); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to create bank question
+	err = qbc.QuestionBankService.CreateBankQuestion(question, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create bank question",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail create bank question",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success create bank question",
+	})
+}
+
+// DeleteBankQuestion is a function to delete a question of a question bank
+func (qbc *QuestionBankController) DeleteBankQuestion(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to delete bank question
+	err := qbc.QuestionBankService.DeleteBankQuestion(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete bank question",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete bank question",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete bank question",
+	})
+}
+
+// UpdateBankQuestion is a function to update a question of a question bank
+func (qbc *QuestionBankController) UpdateBankQuestion(c echo.Context) error {
+	var question dto.QuizQuestionTransaction
+	// Binding request body to struct
+	err := c.Bind(&question)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	question.ID = c.Param("id")
+
+	// Validate request body
+	if err = c.Validate(question); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to update bank question
+	err = qbc.QuestionBankService.UpdateBankQuestion(question, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update bank question",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update bank question",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update bank question",
+	})
+}
+
+// ImportBankQuestions is a function to add the questions of a GIFT or QTI file to a question bank, the file is sent as the file of a form or as the body
+// and the questions that cannot be read are returned with their line
+func (qbc *QuestionBankController) ImportBankQuestions(c echo.Context) error {
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxQuizFileSize)
+	c.Request().Body = body
+	var reader io.Reader = body
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"message": "fail bind data",
+				"error":   err.Error(),
+			})
+		}
+		defer src.Close()
+		reader = src
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get question bank id from url
+	id := c.Param("id")
+
+	// Call service to import the questions
+	result, err := qbc.QuestionBankService.ImportBankQuestions(id, helper.GetQuizFormat(c.QueryParam("format"), data), data, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			response := echo.Map{
+				"message": "fail import question bank questions",
+				"error":   err.Error(),
+			}
+			if len(result.Errors) > 0 {
+				response["data"] = result
+			}
+			return c.JSON(val, response)
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail import question bank questions",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success import question bank questions",
+		"data":    result,
+	})
+}
+
+// ExportBankQuestions is a function to download the questions of a question bank as a GIFT file or a QTI zip
+func (qbc *QuestionBankController) ExportBankQuestions(c echo.Context) error {
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = dto.QuizGIFT
+	}
+
+	// Get question bank id from url
+	id := c.Param("id")
+
+	// Call service to export the questions
+	data, err := qbc.QuestionBankService.ExportBankQuestions(id, format, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail export question bank questions",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail export question bank questions",
+			"error":   err.Error(),
+		})
+	}
+
+	contentType, extension := echo.MIMETextPlainCharsetUTF8, "gift"
+	if format == dto.QuizQTI {
+		contentType, extension = "application/zip", "zip"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="question-bank-`+id+`.`+extension+`"`)
+	return c.Blob(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,360 @@
+package questionBankController
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/questionBankService/questionBankMockService"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteQuestionBank struct {
+	suite.Suite
+	questionBankController *QuestionBankController
+	mock                   *questionBankMockService.QuestionBankMock
+}
+
+func (s *suiteQuestionBank) SetupTest() {
+	mock := &questionBankMockService.QuestionBankMock{}
+	s.mock = mock
+	s.questionBankController = &QuestionBankController{
+		QuestionBankService: s.mock,
+	}
+}
+
+func (s *suiteQuestionBank) TestCreateQuestionBank() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.QuestionBankTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success create question bank",
+			"POST",
+			dto.QuestionBankTransaction{CourseID: "abcde", Title: "basic"},
+			nil,
+			http.StatusOK,
+			"success create question bank",
+		},
+		{
+			"fail bind data",
+			"POST",
+			dto.QuestionBankTransaction{CourseID: "abcde", Title: "basic"},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.QuestionBankTransaction{CourseID: "abcde"},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail create question bank of other instructor",
+			"POST",
+			dto.QuestionBankTransaction{CourseID: "abcde", Title: "basic"},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail create question bank",
+		},
+		{
+			"fail create question bank",
+			"POST",
+			dto.QuestionBankTransaction{CourseID: "abcde", Title: "basic"},
+			errors.New("fail create question bank"),
+			http.StatusInternalServerError,
+			"fail create question bank",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("CreateQuestionBank", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/question_bank/create", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/question_bank/create")
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.questionBankController.CreateQuestionBank(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuestionBank) TestGetQuestionBankByID() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnBody     dto.QuestionBank
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get question bank by id",
+			"GET",
+			"abcde",
+			dto.QuestionBank{ID: "abcde", CourseID: "abcde", Title: "basic"},
+			nil,
+			http.StatusOK,
+			"success get question bank by id",
+		},
+		{
+			"fail get question bank by id not found",
+			"GET",
+			"abcde",
+			dto.QuestionBank{},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail get question bank by id",
+		},
+		{
+			"fail get question bank by id",
+			"GET",
+			"abcde",
+			dto.QuestionBank{},
+			errors.New("fail get question bank by id"),
+			http.StatusInternalServerError,
+			"fail get question bank by id",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetQuestionBankByID", v.ParamID, "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/question_bank/get_by_id/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/question_bank/get_by_id/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.questionBankController.GetQuestionBankByID(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuestionBank) TestCreateBankQuestion() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.QuizQuestionTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success create bank question",
+			"POST",
+			dto.QuizQuestionTransaction{BankID: "abcde", Topic: "loop", Difficulty: dto.QuizEasy, Type: dto.QuizTrueFalse, Text: "tes", Answer: "true"},
+			nil,
+			http.StatusOK,
+			"success create bank question",
+		},
+		{
+			"fail bind data",
+			"POST",
+			dto.QuizQuestionTransaction{BankID: "abcde", Type: dto.QuizTrueFalse, Text: "tes", Answer: "true"},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.QuizQuestionTransaction{BankID: "abcde", Difficulty: "expert", Type: dto.QuizTrueFalse, Text: "tes", Answer: "true"},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail create bank question invalid question",
+			"POST",
+			dto.QuizQuestionTransaction{BankID: "abcde", Type: dto.QuizTrueFalse, Text: "tes", Answer: "maybe"},
+			errors.New(constantError.ErrorQuizQuestion),
+			http.StatusBadRequest,
+			"fail create bank question",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("CreateBankQuestion", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/question_bank/question/create", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/question_bank/question/create")
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.questionBankController.CreateBankQuestion(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuestionBank) TestImportBankQuestions() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		File               string
+		MockReturnBody     dto.QuizImport
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success import bank questions",
+			"POST",
+			"abcde",
+			"$CATEGORY: loop\n\nA for loop can run forever. {T}\n",
+			dto.QuizImport{Imported: 1},
+			nil,
+			http.StatusOK,
+			"success import question bank questions",
+		},
+		{
+			"fail import bank questions without question to import",
+			"POST",
+			"abcde",
+			"Write an essay. {}",
+			dto.QuizImport{Errors: []dto.QuizImportError{{Line: 1, Question: 1, Message: "essay questions are not supported"}}},
+			errors.New(constantError.ErrorQuizImport),
+			http.StatusBadRequest,
+			"fail import question bank questions",
+		},
+		{
+			"fail import bank questions",
+			"POST",
+			"abcde",
+			"A for loop can run forever. {T}",
+			dto.QuizImport{},
+			errors.New("fail import bank questions"),
+			http.StatusInternalServerError,
+			"fail import question bank questions",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ImportBankQuestions", v.ParamID, dto.QuizGIFT, []byte(v.File), "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// the file is sent as the file of a form
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, err := writer.CreateFormFile("file", "questions.gift")
+			s.NoError(err)
+			_, err = part.Write([]byte(v.File))
+			s.NoError(err)
+			s.NoError(writer.Close())
+
+			// Create request
+			r := httptest.NewRequest(v.Method, "/question_bank/import/"+v.ParamID, &body)
+			r.Header.Set("Content-Type", writer.FormDataContentType())
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/question_bank/import/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err = s.questionBankController.ImportBankQuestions(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteQuestionBank(t *testing.T) {
+	suite.Run(t, new(suiteQuestionBank))
+}
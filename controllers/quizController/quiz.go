@@ -2,13 +2,19 @@ package quizcontroller
 
 import (
 	"golang/constant/constantError"
+	"golang/helper"
 	"golang/models/dto"
 	quizservice "golang/service/quizService"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
+// maxQuizFileSize is the biggest GIFT or QTI file accepted by the import
+const maxQuizFileSize = 5 << 20
+
 type QuizController struct {
 	QuizService quizservice.QuizService
 }
@@ -32,9 +38,18 @@ func (qc *QuizController) CreateQuiz(c echo.Context) error {
 		})
 	}
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to create quiz
-	err = qc.QuizService.CreateQuiz(quiz)
+	err = qc.QuizService.CreateQuiz(quiz, instructorID)
 	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create quiz",
+				"error":   err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{
 			"message": "fail create quiz",
 			"error":   err.Error(),
@@ -67,11 +82,14 @@ func (qc *QuizController) DeleteQuiz(c echo.Context) error {
 	// Get id from url
 	id := c.Param("id")
 
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
 	// Call service to delete quiz
-	err := qc.QuizService.DeleteQuiz(id)
+	err := qc.QuizService.DeleteQuiz(id, instructorID)
 	if err != nil {
-		if _, ok := constantError.ErrorCode[err.Error()]; ok {
-			return c.JSON(http.StatusInternalServerError, echo.Map{
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
 				"message": "fail delete quiz",
 				"error":   err.Error(),
 			})
@@ -121,3 +139,509 @@ func (qc *QuizController) TakeQuiz(c echo.Context) error {
 		"quizs":   quiz,
 	})
 }
+
+// GetQuizByID is a function to get quiz by id with its questions and answers
+func (qc *QuizController) GetQuizByID(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to get quiz by id
+	quiz, err := qc.QuizService.GetQuizByID(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get quiz by id",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get quiz by id",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get quiz by id",
+		"data":    quiz,
+	})
+}
+
+// GetQuizzesByCourseID is a function to get the quizzes of a course
+func (qc *QuizController) GetQuizzesByCourseID(c echo.Context) error {
+	// Get course id from url
+	courseID := c.Param("id")
+
+	// Get user from jwt
+	user := helper.GetUser(c)
+
+	// Call service to get quizzes by course id
+	quizzes, err := qc.QuizService.GetQuizzesByCourseID(courseID, user)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get quiz by course id",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get quiz by course id",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get quiz by course id",
+		"data":    quizzes,
+	})
+}
+
+// UpdateQuiz is a function to update the settings of a quiz
+func (qc *QuizController) UpdateQuiz(c echo.Context) error {
+	var quiz dto.QuizTransaction
+	// Binding request body to struct
+	err := c.Bind(&quiz)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	quiz.ID = c.Param("id")
+
+	// Validate request body
+	if err = c.Validate(quiz); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to update quiz
+	err = qc.QuizService.UpdateQuiz(quiz, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update quiz",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update quiz",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update quiz",
+	})
+}
+
+// GetQuizResults is a function to get the attempts of all customers of a quiz
+func (qc *QuizController) GetQuizResults(c echo.Context) error {
+	// Get quiz id from url
+	quizID := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to get quiz results
+	attempts, err := qc.QuizService.GetQuizResults(quizID, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get quiz results",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get quiz results",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get quiz results",
+		"data":    attempts,
+	})
+}
+
+// RegradeQuizAttempt is a function to grade a submitted attempt again with the current answers of its questions
+func (qc *QuizController) RegradeQuizAttempt(c echo.Context) error {
+	// Get attempt id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to regrade quiz attempt
+	attempt, err := qc.QuizService.RegradeQuizAttempt(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail regrade quiz attempt",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail regrade quiz attempt",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success regrade quiz attempt",
+		"data":    attempt,
+	})
+}
+
+// CreateQuizQuestion is a function to add a question to a quiz
+func (qc *QuizController) CreateQuizQuestion(c echo.Context) error {
+	var question dto.QuizQuestionTransaction
+	// Binding request body to struct
+	err := c.Bind(&question)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(question); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to create quiz question
+	err = qc.QuizService.CreateQuizQuestion(question, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create quiz question",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail create quiz question",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success create quiz question",
+	})
+}
+
+// UpdateQuizQuestion is a function to update a question, the options replace the options of the question
+func (qc *QuizController) UpdateQuizQuestion(c echo.Context) error {
+	var question dto.QuizQuestionTransaction
+	// Binding request body to struct
+	err := c.Bind(&question)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	question.ID = c.Param("id")
+
+	// Validate request body
+	if err = c.Validate(question); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to update quiz question
+	err = qc.QuizService.UpdateQuizQuestion(question, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update quiz question",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update quiz question",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update quiz question",
+	})
+}
+
+// DeleteQuizQuestion is a function to delete a question of a quiz
+func (qc *QuizController) DeleteQuizQuestion(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to delete quiz question
+	err := qc.QuizService.DeleteQuizQuestion(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete quiz question",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete quiz question",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete quiz question",
+	})
+}
+
+// StartQuiz is a function to start an attempt of a quiz, the attempt in progress is returned when there is one
+func (qc *QuizController) StartQuiz(c echo.Context) error {
+	// Get quiz id from url
+	quizID := c.Param("id")
+
+	// Get customer id from jwt
+	customerID := helper.GetUser(c).ID
+
+	// Call service to start quiz
+	attempt, err := qc.QuizService.StartQuiz(quizID, customerID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail start quiz",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail start quiz",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success start quiz",
+		"data":    attempt,
+	})
+}
+
+// SubmitQuiz is a function to submit the answers of an attempt and get its grade
+func (qc *QuizController) SubmitQuiz(c echo.Context) error {
+	var submission dto.QuizSubmission
+	// Binding request body to struct
+	err := c.Bind(&submission)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(submission); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get attempt id from url
+	attemptID := c.Param("id")
+
+	// Get customer id from jwt
+	customerID := helper.GetUser(c).ID
+
+	// Call service to submit quiz
+	attempt, err := qc.QuizService.SubmitQuiz(attemptID, customerID, submission)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail submit quiz",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail submit quiz",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success submit quiz",
+		"data":    attempt,
+	})
+}
+
+// GetQuizAttempts is a function to get the attempts of the customer of a quiz
+func (qc *QuizController) GetQuizAttempts(c echo.Context) error {
+	// Get quiz id from url
+	quizID := c.Param("id")
+
+	// Get customer id from jwt
+	customerID := helper.GetUser(c).ID
+
+	// Call service to get quiz attempts
+	attempts, err := qc.QuizService.GetQuizAttempts(quizID, customerID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get all quiz attempt",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get all quiz attempt",
+		"data":    attempts,
+	})
+}
+
+// GetQuizAttemptByID is a function to get an attempt of the customer with its questions
+func (qc *QuizController) GetQuizAttemptByID(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get customer id from jwt
+	customerID := helper.GetUser(c).ID
+
+	// Call service to get quiz attempt by id
+	attempt, err := qc.QuizService.GetQuizAttemptByID(id, customerID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get quiz attempt by id",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get quiz attempt by id",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get quiz attempt by id",
+		"data":    attempt,
+	})
+}
+
+// ImportQuizQuestions is a function to add the questions of a GIFT or QTI file to a quiz, the file is sent as the file of a form or as the body
+// and the questions that cannot be read are returned with their line
+func (qc *QuizController) ImportQuizQuestions(c echo.Context) error {
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxQuizFileSize)
+	c.Request().Body = body
+	var reader io.Reader = body
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{
+				"message": "fail bind data",
+				"error":   err.Error(),
+			})
+		}
+		defer src.Close()
+		reader = src
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get quiz id from url
+	id := c.Param("id")
+
+	// Call service to import the questions
+	result, err := qc.QuizService.ImportQuizQuestions(id, helper.GetQuizFormat(c.QueryParam("format"), data), data, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			response := echo.Map{
+				"message": "fail import quiz questions",
+				"error":   err.Error(),
+			}
+			if len(result.Errors) > 0 {
+				response["data"] = result
+			}
+			return c.JSON(val, response)
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail import quiz questions",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success import quiz questions",
+		"data":    result,
+	})
+}
+
+// ExportQuizQuestions is a function to download the questions of a quiz as a GIFT file or a QTI zip
+func (qc *QuizController) ExportQuizQuestions(c echo.Context) error {
+	format := strings.ToLower(c.QueryParam("format"))
+	if format == "" {
+		format = dto.QuizGIFT
+	}
+
+	// Get quiz id from url
+	id := c.Param("id")
+
+	// Call service to export the questions
+	data, err := qc.QuizService.ExportQuizQuestions(id, format, helper.GetUser(c).ID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail export quiz questions",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail export quiz questions",
+			"error":   err.Error(),
+		})
+	}
+
+	contentType, extension := echo.MIMETextPlainCharsetUTF8, "gift"
+	if format == dto.QuizQTI {
+		contentType, extension = "application/zip", "zip"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="quiz-`+id+`.`+extension+`"`)
+	return c.Blob(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,591 @@
+package quizcontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/quizService/quizMockService"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteQuiz struct {
+	suite.Suite
+	quizController *QuizController
+	mock           *quizMockService.QuizMock
+}
+
+func (s *suiteQuiz) SetupTest() {
+	mock := &quizMockService.QuizMock{}
+	s.mock = mock
+	s.quizController = &QuizController{
+		QuizService: s.mock,
+	}
+}
+
+func (s *suiteQuiz) TestCreateQuizQuestion() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.QuizQuestionTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success create quiz question",
+			"POST",
+			dto.QuizQuestionTransaction{
+				QuizID: "tes",
+				Type:   dto.QuizTrueFalse,
+				Text:   "tes",
+				Answer: "true",
+			},
+			nil,
+			http.StatusOK,
+			"success create quiz question",
+		},
+		{
+			"fail bind data",
+			"POST",
+			dto.QuizQuestionTransaction{
+				QuizID: "tes",
+				Type:   dto.QuizTrueFalse,
+				Text:   "tes",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.QuizQuestionTransaction{
+				QuizID: "tes",
+				Type:   "essay",
+				Text:   "tes",
+			},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail create quiz question invalid question",
+			"POST",
+			dto.QuizQuestionTransaction{
+				QuizID: "tes",
+				Type:   dto.QuizTrueFalse,
+				Text:   "tes",
+				Answer: "maybe",
+			},
+			errors.New(constantError.ErrorQuizQuestion),
+			http.StatusBadRequest,
+			"fail create quiz question",
+		},
+		{
+			"fail create quiz question",
+			"POST",
+			dto.QuizQuestionTransaction{
+				QuizID: "tes",
+				Type:   dto.QuizTrueFalse,
+				Text:   "tes",
+				Answer: "true",
+			},
+			errors.New("fail create quiz question"),
+			http.StatusInternalServerError,
+			"fail create quiz question",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("CreateQuizQuestion", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/quiz/question/create", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/quiz/question/create")
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.quizController.CreateQuizQuestion(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestStartQuiz() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnBody     dto.QuizAttempt
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success start quiz",
+			"POST",
+			"abcde",
+			dto.QuizAttempt{ID: "abcde", QuizID: "abcde", Status: dto.QuizAttemptInProgress},
+			nil,
+			http.StatusOK,
+			"success start quiz",
+		},
+		{
+			"fail start quiz not found",
+			"POST",
+			"abcde",
+			dto.QuizAttempt{},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail start quiz",
+		},
+		{
+			"fail start quiz attempt limit",
+			"POST",
+			"abcde",
+			dto.QuizAttempt{},
+			errors.New(constantError.ErrorQuizAttemptLimit),
+			http.StatusBadRequest,
+			"fail start quiz",
+		},
+		{
+			"fail start quiz",
+			"POST",
+			"abcde",
+			dto.QuizAttempt{},
+			errors.New("fail start quiz"),
+			http.StatusInternalServerError,
+			"fail start quiz",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("StartQuiz", v.ParamID, "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/quiz/start/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/quiz/start/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "customer"}})
+
+			err := s.quizController.StartQuiz(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestSubmitQuiz() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		Body               dto.QuizSubmission
+		MockReturnBody     dto.QuizAttempt
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success submit quiz",
+			"POST",
+			"abcde",
+			dto.QuizSubmission{Answers: []dto.QuizAnswer{{QuestionID: "q1", Answer: "true"}}},
+			dto.QuizAttempt{ID: "abcde", Status: dto.QuizAttemptSubmitted, Score: 1, MaxScore: 1, Percentage: 100, IsPassed: true},
+			nil,
+			http.StatusOK,
+			"success submit quiz",
+		},
+		{
+			"fail bind data",
+			"POST",
+			"abcde",
+			dto.QuizSubmission{Answers: []dto.QuizAnswer{{QuestionID: "q1", Answer: "true"}}},
+			dto.QuizAttempt{},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			"abcde",
+			dto.QuizSubmission{Answers: []dto.QuizAnswer{{Answer: "true"}}},
+			dto.QuizAttempt{},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail submit quiz already submitted",
+			"POST",
+			"abcde",
+			dto.QuizSubmission{Answers: []dto.QuizAnswer{{QuestionID: "q1", Answer: "true"}}},
+			dto.QuizAttempt{},
+			errors.New(constantError.ErrorQuizAttemptSubmitted),
+			http.StatusBadRequest,
+			"fail submit quiz",
+		},
+		{
+			"fail submit quiz of other customer",
+			"POST",
+			"abcde",
+			dto.QuizSubmission{Answers: []dto.QuizAnswer{{QuestionID: "q1", Answer: "true"}}},
+			dto.QuizAttempt{},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail submit quiz",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("SubmitQuiz", v.ParamID, "abcde", v.Body).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/quiz/submit/"+v.ParamID, bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/quiz/submit/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "customer"}})
+
+			err := s.quizController.SubmitQuiz(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestRegradeQuizAttempt() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnBody     dto.QuizAttempt
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success regrade quiz attempt",
+			"POST",
+			"abcde",
+			dto.QuizAttempt{ID: "abcde", Status: dto.QuizAttemptSubmitted, Score: 1, MaxScore: 1, Percentage: 100, IsPassed: true},
+			nil,
+			http.StatusOK,
+			"success regrade quiz attempt",
+		},
+		{
+			"fail regrade quiz attempt not submitted",
+			"POST",
+			"abcde",
+			dto.QuizAttempt{},
+			errors.New(constantError.ErrorQuizAttemptNotSubmitted),
+			http.StatusBadRequest,
+			"fail regrade quiz attempt",
+		},
+		{
+			"fail regrade quiz attempt of other instructor",
+			"POST",
+			"abcde",
+			dto.QuizAttempt{},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail regrade quiz attempt",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("RegradeQuizAttempt", v.ParamID, "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/quiz/attempt/regrade/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/quiz/attempt/regrade/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.quizController.RegradeQuizAttempt(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestImportQuizQuestions() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		Query              string
+		Body               string
+		Format             string
+		MockReturnBody     dto.QuizImport
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+		ExpectedData       bool
+	}{
+		{
+			"success import gift questions",
+			"POST",
+			"abcde",
+			"",
+			"The earth is flat. {F}",
+			dto.QuizGIFT,
+			dto.QuizImport{Imported: 1},
+			nil,
+			http.StatusOK,
+			"success import quiz questions",
+			true,
+		},
+		{
+			"success import qti questions",
+			"POST",
+			"abcde",
+			"",
+			`<?xml version="1.0" encoding="UTF-8"?><assessmentItem></assessmentItem>`,
+			dto.QuizQTI,
+			dto.QuizImport{Imported: 1},
+			nil,
+			http.StatusOK,
+			"success import quiz questions",
+			true,
+		},
+		{
+			"fail import quiz questions without question to import",
+			"POST",
+			"abcde",
+			"?format=GIFT",
+			"Write an essay. {}",
+			dto.QuizGIFT,
+			dto.QuizImport{Errors: []dto.QuizImportError{{Line: 1, Question: 1, Message: "essay questions are not supported"}}},
+			errors.New(constantError.ErrorQuizImport),
+			http.StatusBadRequest,
+			"fail import quiz questions",
+			true,
+		},
+		{
+			"fail import quiz questions of other instructor",
+			"POST",
+			"abcde",
+			"",
+			"The earth is flat. {F}",
+			dto.QuizGIFT,
+			dto.QuizImport{},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail import quiz questions",
+			false,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ImportQuizQuestions", v.ParamID, v.Format, []byte(v.Body), "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/quiz/import/"+v.ParamID+v.Query, bytes.NewBufferString(v.Body))
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/quiz/import/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.quizController.ImportQuizQuestions(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+			s.Equal(v.ExpectedData, resp["data"] != nil)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestExportQuizQuestions() {
+	testCase := []struct {
+		Name                string
+		Method              string
+		ParamID             string
+		Format              string
+		MockReturnBody      []byte
+		MockReturnError     error
+		ExpectedStatusCode  int
+		ExpectedContentType string
+		ExpectedFileName    string
+	}{
+		{
+			"success export gift questions",
+			"GET",
+			"abcde",
+			"",
+			[]byte("The earth is flat. {FALSE}\n\n"),
+			nil,
+			http.StatusOK,
+			echo.MIMETextPlainCharsetUTF8,
+			`attachment; filename="quiz-abcde.gift"`,
+		},
+		{
+			"success export qti questions",
+			"GET",
+			"abcde",
+			dto.QuizQTI,
+			[]byte("PK"),
+			nil,
+			http.StatusOK,
+			"application/zip",
+			`attachment; filename="quiz-abcde.zip"`,
+		},
+		{
+			"fail export quiz questions of unsupported format",
+			"GET",
+			"abcde",
+			"csv",
+			[]byte(nil),
+			errors.New(constantError.ErrorQuizFormat),
+			http.StatusBadRequest,
+			echo.MIMEApplicationJSONCharsetUTF8,
+			"",
+		},
+		{
+			"fail export quiz questions not found",
+			"GET",
+			"abcde",
+			dto.QuizGIFT,
+			[]byte(nil),
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			echo.MIMEApplicationJSONCharsetUTF8,
+			"",
+		},
+	}
+	for _, v := range testCase {
+		format := v.Format
+		if format == "" {
+			format = dto.QuizGIFT
+		}
+		mockCall := s.mock.On("ExportQuizQuestions", v.ParamID, format, "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/quiz/export/"+v.ParamID+"?format="+v.Format, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/quiz/export/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.quizController.ExportQuizQuestions(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+			s.Equal(v.ExpectedContentType, w.Header().Get(echo.HeaderContentType))
+			s.Equal(v.ExpectedFileName, w.Header().Get(echo.HeaderContentDisposition))
+			if v.MockReturnError == nil {
+				s.Equal(v.MockReturnBody, w.Body.Bytes())
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteQuiz(t *testing.T) {
+	suite.Run(t, new(suiteQuiz))
+}
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	middlewareCustomer "golang/app/middlewares/costumer"
+	"golang/app/middlewares/auth"
 	"golang/constant/constantError"
 	"golang/helper"
 	"golang/models/dto"
@@ -161,7 +161,7 @@ func (s *suiteRating) TestAddRating() {
 			ctx.SetPath("/rating/:courseId")
 			ctx.SetParamNames("courseId")
 			ctx.SetParamValues(v.ParamCourseID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.ratingController.AddRating(ctx)
 			s.NoError(err)
@@ -242,7 +242,7 @@ func (s *suiteRating) TestDeleteRating() {
 			ctx.SetPath("/rating/:courseId")
 			ctx.SetParamNames("courseId")
 			ctx.SetParamValues(v.CourseID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.ratingController.DeleteRating(ctx)
 			s.NoError(err)
@@ -346,7 +346,7 @@ func (s *suiteRating) TestGetCourseByCustomerID() {
 			ctx.SetPath("/rating/:courseId")
 			ctx.SetParamNames("courseId")
 			ctx.SetParamValues(v.CourseID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.ratingController.GetRatingByCourseIDCustomerID(ctx)
 			s.NoError(err)
@@ -475,7 +475,7 @@ func (s *suiteRating) TestGetRatingByCourseID() {
 			ctx.SetPath("/rating/:courseId")
 			ctx.SetParamNames("courseId")
 			ctx.SetParamValues(v.CourseID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.ratingController.GetRatingByCourseID(ctx)
 			s.NoError(err)
@@ -604,7 +604,7 @@ func (s *suiteRating) TestUpdateRating() {
 			ctx.SetPath("/rating/:ratingId")
 			ctx.SetParamNames("ratingId")
 			ctx.SetParamValues(v.ParamID)
-			ctx.Set("user", &jwt.Token{Claims: &middlewareCustomer.JwtCostumerClaims{ID: v.User.ID, Role: v.User.Role}})
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: v.User.ID, Role: v.User.Role}})
 
 			err := s.ratingController.UpdateRating(ctx)
 			s.NoError(err)
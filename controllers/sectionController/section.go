@@ -0,0 +1,157 @@
+package sectionController
+
+import (
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/sectionService"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SectionController struct {
+	SectionService sectionService.SectionService
+}
+
+// CreateSection is a function to create section
+func (sc *SectionController) CreateSection(c echo.Context) error {
+	var section dto.SectionTransaction
+	// Binding request body to struct
+	err := c.Bind(&section)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate request body
+	if err = c.Validate(section); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{
+			"message": "There is an empty field",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to create section
+	err = sc.SectionService.CreateSection(section, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail create section",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail create section",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success create section",
+	})
+}
+
+// DeleteSection is a function to delete section, the modules of the section stay in the course
+func (sc *SectionController) DeleteSection(c echo.Context) error {
+	// Get id from url
+	id := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to delete section
+	err := sc.SectionService.DeleteSection(id, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail delete section",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail delete section",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success delete section",
+	})
+}
+
+// GetSectionsByCourseID is a function to get the sections of a course
+func (sc *SectionController) GetSectionsByCourseID(c echo.Context) error {
+	// Get course id from url
+	courseID := c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to get sections
+	sections, err := sc.SectionService.GetSectionsByCourseID(courseID, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail get sections by course id",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail get sections by course id",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success get sections by course id",
+		"data":    sections,
+	})
+}
+
+// UpdateSection is a function to update section
+func (sc *SectionController) UpdateSection(c echo.Context) error {
+	var section dto.SectionTransaction
+	// Binding request body to struct
+	err := c.Bind(&section)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail bind data",
+			"error":   err.Error(),
+		})
+	}
+
+	// Get id from url
+	section.ID = c.Param("id")
+
+	// Get instructor id from jwt
+	instructorID := helper.GetUser(c).ID
+
+	// Call service to update section
+	err = sc.SectionService.UpdateSection(section, instructorID)
+	if err != nil {
+		if val, ok := constantError.ErrorCode[err.Error()]; ok {
+			return c.JSON(val, echo.Map{
+				"message": "fail update section",
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{
+			"message": "fail update section",
+			"error":   err.Error(),
+		})
+	}
+
+	// Return response if success
+	return c.JSON(http.StatusOK, echo.Map{
+		"message": "success update section",
+	})
+}
@@ -0,0 +1,391 @@
+package sectionController
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"golang/app/middlewares/auth"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/service/sectionService/sectionMockService"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteSection struct {
+	suite.Suite
+	sectionController *SectionController
+	mock              *sectionMockService.SectionMock
+}
+
+func (s *suiteSection) SetupTest() {
+	mock := &sectionMockService.SectionMock{}
+	s.mock = mock
+	s.sectionController = &SectionController{
+		SectionService: s.mock,
+	}
+}
+
+func (s *suiteSection) TestCreateSection() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		Body               dto.SectionTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success create section",
+			"POST",
+			dto.SectionTransaction{
+				Title:     "tes",
+				CourseID:  "tes",
+				NoSection: 1,
+			},
+			nil,
+			http.StatusOK,
+			"success create section",
+		},
+		{
+			"fail bind data",
+			"POST",
+			dto.SectionTransaction{
+				Title:    "tes",
+				CourseID: "tes",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"There is an empty field",
+			"POST",
+			dto.SectionTransaction{
+				Title: "tes",
+			},
+			nil,
+			http.StatusBadRequest,
+			"There is an empty field",
+		},
+		{
+			"fail create section not owner",
+			"POST",
+			dto.SectionTransaction{
+				Title:    "tes",
+				CourseID: "tes",
+			},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail create section",
+		},
+		{
+			"fail create section",
+			"POST",
+			dto.SectionTransaction{
+				Title:    "tes",
+				CourseID: "tes",
+			},
+			errors.New("fail create section"),
+			http.StatusInternalServerError,
+			"fail create section",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("CreateSection", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/section/create", bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			e.Validator = &helper.CustomValidator{
+				Validator: validator.New(),
+			}
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/section/create")
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.sectionController.CreateSection(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteSection) TestDeleteSection() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success delete section",
+			"DELETE",
+			"abcde",
+			nil,
+			http.StatusOK,
+			"success delete section",
+		},
+		{
+			"fail delete section not found",
+			"DELETE",
+			"abcde",
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail delete section",
+		},
+		{
+			"fail delete section not owner",
+			"DELETE",
+			"abcde",
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail delete section",
+		},
+		{
+			"fail delete section",
+			"DELETE",
+			"abcde",
+			errors.New("fail delete section"),
+			http.StatusInternalServerError,
+			"fail delete section",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteSection", v.ParamID, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/section/delete/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/section/delete/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.sectionController.DeleteSection(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteSection) TestGetSectionsByCourseID() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		MockReturnBody     []dto.Section
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success get sections by course id",
+			"GET",
+			"abcde",
+			[]dto.Section{
+				{
+					ID:        "abcde",
+					Title:     "tes",
+					CourseID:  "abcde",
+					NoSection: 1,
+				},
+			},
+			nil,
+			http.StatusOK,
+			"success get sections by course id",
+		},
+		{
+			"fail get sections by course id not owner",
+			"GET",
+			"abcde",
+			[]dto.Section{},
+			errors.New(constantError.ErrorNotAuthorized),
+			http.StatusUnauthorized,
+			"fail get sections by course id",
+		},
+		{
+			"fail get sections by course id",
+			"GET",
+			"abcde",
+			[]dto.Section{},
+			errors.New("fail get sections by course id"),
+			http.StatusInternalServerError,
+			"fail get sections by course id",
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetSectionsByCourseID", v.ParamID, "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			// Create request
+			r := httptest.NewRequest(v.Method, "/section/get_by_course_id/"+v.ParamID, nil)
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/section/get_by_course_id/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.sectionController.GetSectionsByCourseID(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+			if v.ExpectedStatusCode == http.StatusOK {
+				s.Len(resp["data"], len(v.MockReturnBody))
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteSection) TestUpdateSection() {
+	testCase := []struct {
+		Name               string
+		Method             string
+		ParamID            string
+		Body               dto.SectionTransaction
+		MockReturnError    error
+		ExpectedStatusCode int
+		ExpectedMesaage    string
+	}{
+		{
+			"success update section",
+			"PUT",
+			"abcde",
+			dto.SectionTransaction{
+				ID:        "abcde",
+				Title:     "tes",
+				NoSection: 2,
+			},
+			nil,
+			http.StatusOK,
+			"success update section",
+		},
+		{
+			"fail bind data",
+			"PUT",
+			"abcde",
+			dto.SectionTransaction{
+				ID:    "abcde",
+				Title: "tes",
+			},
+			nil,
+			http.StatusInternalServerError,
+			"fail bind data",
+		},
+		{
+			"fail update section not found",
+			"PUT",
+			"abcde",
+			dto.SectionTransaction{
+				ID:    "abcde",
+				Title: "tes",
+			},
+			gorm.ErrRecordNotFound,
+			http.StatusNotFound,
+			"fail update section",
+		},
+		{
+			"fail update section",
+			"PUT",
+			"abcde",
+			dto.SectionTransaction{
+				ID:    "abcde",
+				Title: "tes",
+			},
+			errors.New("fail update section"),
+			http.StatusInternalServerError,
+			"fail update section",
+		},
+	}
+	for i, v := range testCase {
+		mockCall := s.mock.On("UpdateSection", v.Body, "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			res, _ := json.Marshal(v.Body)
+			// Create request
+			r := httptest.NewRequest(v.Method, "/section/update/"+v.ParamID, bytes.NewBuffer(res))
+			if i != 1 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			// Create response recorder
+			w := httptest.NewRecorder()
+
+			// handler echo
+			e := echo.New()
+			ctx := e.NewContext(r, w)
+			ctx.SetPath("/section/update/:id")
+			ctx.SetParamNames("id")
+			ctx.SetParamValues(v.ParamID)
+
+			ctx.Set("user", &jwt.Token{Claims: &auth.JwtClaims{ID: "abcde", Role: "instructor"}})
+
+			err := s.sectionController.UpdateSection(ctx)
+			s.NoError(err)
+			s.Equal(v.ExpectedStatusCode, w.Code)
+
+			var resp map[string]interface{}
+			err = json.NewDecoder(w.Result().Body).Decode(&resp)
+			s.NoError(err)
+
+			s.Equal(v.ExpectedMesaage, resp["message"])
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteSection(t *testing.T) {
+	suite.Run(t, new(suiteSection))
+}
@@ -0,0 +1,30 @@
+package mailer
+
+import "sync"
+
+// FakeMailer records every message instead of delivering it, Err is returned by Send when set
+type FakeMailer struct {
+	mu       sync.Mutex
+	messages []Message
+	Err      error
+}
+
+// Send implements Mailer
+func (fm *FakeMailer) Send(message Message) error {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	if fm.Err != nil {
+		return fm.Err
+	}
+	fm.messages = append(fm.messages, message)
+	return nil
+}
+
+// Sent returns the messages recorded so far
+func (fm *FakeMailer) Sent() []Message {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+	messages := make([]Message, len(fm.messages))
+	copy(messages, fm.messages)
+	return messages
+}
@@ -0,0 +1,63 @@
+package mailer
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// DriverSMTP delivers mails through an SMTP server
+	DriverSMTP = "smtp"
+	// DriverFile writes mails to a directory, used for local development
+	DriverFile = "file"
+	// DriverLog writes mails to the application log, used for local development
+	DriverLog = "log"
+
+	defaultSMTPPort = 587
+)
+
+// Message is a mail ready to be delivered
+type Message struct {
+	To      []string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Mailer delivers a message to its recipients
+type Mailer interface {
+	Send(message Message) error
+}
+
+// Config holds the settings used by New to build a mailer
+type Config struct {
+	Driver   string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	Dir      string
+}
+
+// New builds the mailer of the configured driver, smtp is used when the driver is empty
+func New(config Config) Mailer {
+	switch strings.ToLower(config.Driver) {
+	case DriverFile:
+		return NewFileMailer(config.Dir)
+	case DriverLog:
+		return NewLogMailer(nil)
+	}
+
+	port, err := strconv.Atoi(config.Port)
+	if err != nil || port <= 0 {
+		port = defaultSMTPPort
+	}
+	return NewSMTPMailer(SMTPConfig{
+		Host:     config.Host,
+		Port:     port,
+		Username: config.Username,
+		Password: config.Password,
+		From:     config.From,
+	})
+}
@@ -0,0 +1,95 @@
+package mailer
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type fileMailer struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// Send implements Mailer
+func (fm *fileMailer) Send(message Message) error {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	err := os.MkdirAll(fm.dir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000000"), slug(message.Subject))
+	return os.WriteFile(filepath.Join(fm.dir, name), []byte(format(message)), 0o644)
+}
+
+// NewFileMailer creates a mailer that writes every mail as a file in the directory
+func NewFileMailer(dir string) Mailer {
+	if dir == "" {
+		dir = "mails"
+	}
+	return &fileMailer{
+		dir: dir,
+	}
+}
+
+type logMailer struct {
+	logger *log.Logger
+}
+
+// Send implements Mailer
+func (lm *logMailer) Send(message Message) error {
+	if lm.logger == nil {
+		log.Print(format(message))
+		return nil
+	}
+	lm.logger.Print(format(message))
+	return nil
+}
+
+// NewLogMailer creates a mailer that writes every mail to the logger, the standard logger is used when it is nil
+func NewLogMailer(logger *log.Logger) Mailer {
+	return &logMailer{
+		logger: logger,
+	}
+}
+
+// format renders the message in a readable form for the sinks
+func format(message Message) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "To: %s\n", strings.Join(message.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\n\n", message.Subject)
+	if message.Text != "" {
+		b.WriteString(message.Text)
+		b.WriteString("\n")
+	}
+	if message.HTML != "" {
+		b.WriteString("\n--- html ---\n")
+		b.WriteString(message.HTML)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// slug keeps the letters and digits of the subject so it can be used in a file name
+func slug(subject string) string {
+	s := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, subject)
+	if s == "" {
+		return "mail"
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+package mailer
+
+import (
+	"errors"
+
+	"gopkg.in/gomail.v2"
+)
+
+// SMTPConfig holds the connection settings of the SMTP server
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	config SMTPConfig
+	dialer *gomail.Dialer
+}
+
+// Send implements Mailer
+func (sm *smtpMailer) Send(message Message) error {
+	if len(message.To) == 0 {
+		return errors.New("mail has no recipient")
+	}
+
+	mail := gomail.NewMessage()
+	mail.SetHeader("From", sm.config.From)
+	mail.SetHeader("To", message.To...)
+	mail.SetHeader("Subject", message.Subject)
+	if message.Text != "" {
+		mail.SetBody("text/plain", message.Text)
+		if message.HTML != "" {
+			mail.AddAlternative("text/html", message.HTML)
+		}
+	} else {
+		mail.SetBody("text/html", message.HTML)
+	}
+
+	return sm.dialer.DialAndSend(mail)
+}
+
+// NewSMTPMailer creates a mailer that delivers through the SMTP server
+func NewSMTPMailer(config SMTPConfig) Mailer {
+	if config.From == "" {
+		config.From = config.Username
+	}
+	return &smtpMailer{
+		config: config,
+		dialer: gomail.NewDialer(config.Host, config.Port, config.Username, config.Password),
+	}
+}
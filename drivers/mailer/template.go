@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+)
+
+const (
+	// TemplateVerification is the mail with the email verification code
+	TemplateVerification = "verification"
+	// TemplatePasswordReset is the mail with the password reset token
+	TemplatePasswordReset = "password-reset"
+	// TemplateEnrollment is the mail sent after a customer enrolls in a course
+	TemplateEnrollment = "enrollment"
+)
+
+var subjects = map[string]string{
+	TemplateVerification:  "Verify your email",
+	TemplatePasswordReset: "Reset your password",
+	TemplateEnrollment:    "Course enrollment",
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	htmlTemplates = htmlTemplate.Must(htmlTemplate.ParseFS(templateFS, "templates/*.html"))
+	textTemplates = textTemplate.Must(textTemplate.ParseFS(templateFS, "templates/*.txt"))
+)
+
+// TemplateData is the data available in the templates, fields that are not used by a template can be left empty
+type TemplateData struct {
+	Name       string
+	Code       string
+	Token      string
+	Link       string
+	ExpiresIn  string
+	CourseName string
+}
+
+// Render builds the message of the template for the recipient
+func Render(name, to string, data TemplateData) (Message, error) {
+	subject, ok := subjects[name]
+	if !ok {
+		return Message{}, fmt.Errorf("mail template %s not found", name)
+	}
+
+	var html bytes.Buffer
+	err := htmlTemplates.ExecuteTemplate(&html, name+".html", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	var text bytes.Buffer
+	err = textTemplates.ExecuteTemplate(&text, name+".txt", data)
+	if err != nil {
+		return Message{}, err
+	}
+
+	return Message{
+		To:      []string{to},
+		Subject: subject,
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
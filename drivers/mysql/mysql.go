@@ -2,7 +2,9 @@ package mysql_driver
 
 import (
 	"fmt"
+	"golang/models/dto"
 	"golang/models/model"
+	modulerepository "golang/repository/moduleRepository"
 
 	"log"
 
@@ -39,8 +41,14 @@ func (config *ConfigDB) InitDB() *gorm.DB {
 }
 
 func DBMigrate(db *gorm.DB) error {
+	// the courses made before the course lifecycle were already visible to the customers
+	publishExistingCourses := db.Migrator().HasTable(&model.Course{}) && !db.Migrator().HasColumn(&model.Course{}, "Status")
+	// the progress of the enrollments made before the module progress was only the number of the module
+	backfillModuleProgress := db.Migrator().HasTable(&model.CustomerCourse{}) && !db.Migrator().HasTable(&model.ModuleProgress{})
+
 	err := db.AutoMigrate(
 		model.Instructor{},
+		model.InstructorCode{},
 		model.Customer{},
 		model.CustomerCode{},
 		model.Category{},
@@ -52,12 +60,55 @@ func DBMigrate(db *gorm.DB) error {
 		model.MediaModule{},
 		model.Assignment{},
 		model.CustomerAssignment{},
+		model.CustomerAssignmentVersion{},
 		model.Quiz{},
+		model.Session{},
+		model.RefreshToken{},
+		model.Admin{},
+		model.MailOutbox{},
+		model.PasswordReset{},
+		model.EmailChange{},
+		model.CourseSearch{},
+		model.SearchTerm{},
+		model.CourseStats{},
+		model.CourseVersion{},
+		model.CourseVersionModule{},
+		model.CourseVersionMedia{},
+		model.ModuleProgress{},
+		model.Section{},
+		model.CoursePrerequisite{},
+		model.LearningPath{},
+		model.LearningPathCourse{},
+		model.LearningPathCompletion{},
+		model.QuizQuestion{},
+		model.QuizOption{},
+		model.QuizAttempt{},
+		model.QuizAttemptAnswer{},
+		model.QuestionBank{},
+		model.QuizPool{},
+		model.ModuleRule{},
+		model.ModuleView{},
+		model.MediaProgress{},
+		model.ProgressEvent{},
 	)
 
 	if err != nil {
 		return err
 	}
 
+	if publishExistingCourses {
+		err = db.Model(&model.Course{}).Unscoped().Where("1 = 1").Update("status", dto.CourseStatusPublished).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if backfillModuleProgress {
+		err = db.Transaction(modulerepository.BackfillModuleProgress)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,132 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// typoFactor lowers the score of a word matched with typos, for every typo
+const typoFactor = 0.5
+
+// MemoryIndex is an inverted index kept in the memory of the process
+type MemoryIndex struct {
+	mu   sync.RWMutex
+	docs map[string]Document
+	// postings maps a word to the weighted frequency of the word in every document
+	postings map[string]map[string]float64
+}
+
+// NewMemoryIndex returns an empty index
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		docs:     map[string]Document{},
+		postings: map[string]map[string]float64{},
+	}
+}
+
+// Index implements SearchIndex
+func (m *MemoryIndex) Index(doc Document) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(doc.ID)
+	m.docs[doc.ID] = doc
+	for _, f := range doc.fields() {
+		for _, word := range tokenize(f.text) {
+			if m.postings[word] == nil {
+				m.postings[word] = map[string]float64{}
+			}
+			m.postings[word][doc.ID] += f.weight
+		}
+	}
+	return nil
+}
+
+// Remove implements SearchIndex
+func (m *MemoryIndex) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(id)
+	return nil
+}
+
+func (m *MemoryIndex) remove(id string) {
+	doc, ok := m.docs[id]
+	if !ok {
+		return
+	}
+	for _, f := range doc.fields() {
+		for _, word := range tokenize(f.text) {
+			delete(m.postings[word], id)
+			if len(m.postings[word]) == 0 {
+				delete(m.postings, word)
+			}
+		}
+	}
+	delete(m.docs, id)
+}
+
+// Search implements SearchIndex, the score is a tf-idf over the weighted fields
+// multiplied by the share of query words found in the document
+func (m *MemoryIndex) Search(query string, limit int) ([]Result, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	terms := queryTerms(query)
+	scores := map[string]float64{}
+	found := map[string]int{}
+	matched := map[string]bool{}
+	for _, term := range terms {
+		// an exact word wins over the words with typos
+		words := map[string]float64{}
+		if _, ok := m.postings[term]; ok {
+			words[term] = 1
+		} else {
+			for word := range m.postings {
+				if d, ok := typos(term, word); ok {
+					words[word] = math.Pow(typoFactor, float64(d))
+				}
+			}
+		}
+
+		termScores := map[string]float64{}
+		for word, factor := range words {
+			matched[word] = true
+			idf := math.Log(1 + float64(len(m.docs))/float64(len(m.postings[word])))
+			for id, frequency := range m.postings[word] {
+				// the frequency is saturated so a repeated word doesn't outweigh the other words
+				score := factor * idf * frequency * 2.2 / (frequency + 1.2)
+				if score > termScores[id] {
+					termScores[id] = score
+				}
+			}
+		}
+		for id, score := range termScores {
+			scores[id] += score
+			found[id]++
+		}
+	}
+
+	results := []Result{}
+	for id, score := range scores {
+		results = append(results, Result{
+			ID:    id,
+			Score: score * float64(found[id]) / float64(len(terms)),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	for i := range results {
+		results[i].Highlights = m.docs[results[i].ID].highlights(matched)
+	}
+	return results, nil
+}
@@ -0,0 +1,132 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteMemoryIndex struct {
+	suite.Suite
+	index SearchIndex
+}
+
+func (s *suiteMemoryIndex) SetupTest() {
+	s.index = NewMemoryIndex()
+	docs := []Document{
+		{
+			ID:          "golang",
+			Name:        "Golang for Beginners",
+			Description: "Learn the basics of programming with Go, from variables to concurrency.",
+			Category:    "Programming",
+			Modules:     []string{"Installing Go", "Goroutines and channels"},
+			Instructor:  "Budi Santoso",
+		},
+		{
+			ID:          "python",
+			Name:        "Python Data Science",
+			Description: "Analyze data with pandas. No golang knowledge needed.",
+			Category:    "Programming",
+			Modules:     []string{"Dataframes"},
+			Instructor:  "Siti Rahma",
+		},
+		{
+			ID:          "design",
+			Name:        "UI Design",
+			Description: "Design <b>beautiful</b> interfaces.",
+			Category:    "Design",
+			Instructor:  "Budi Santoso",
+		},
+	}
+	for _, doc := range docs {
+		s.NoError(s.index.Index(doc))
+	}
+}
+
+func (s *suiteMemoryIndex) TestSearchRanking() {
+	// a word in the name counts more than a word in the description
+	results, err := s.index.Search("golang", 10)
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal("golang", results[0].ID)
+	s.Equal("python", results[1].ID)
+	s.Greater(results[0].Score, results[1].Score)
+
+	// documents with every word of the query come first
+	results, err = s.index.Search("budi design", 10)
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal("design", results[0].ID)
+	s.Equal("golang", results[1].ID)
+
+	results, err = s.index.Search("programming", 1)
+	s.NoError(err)
+	s.Len(results, 1)
+}
+
+func (s *suiteMemoryIndex) TestSearchTypo() {
+	results, err := s.index.Search("goroutnes", 10)
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Equal("golang", results[0].ID)
+	s.Equal("Installing Go, <mark>Goroutines</mark> and channels", results[0].Highlights["modules"])
+
+	// short words have to be exact
+	results, err = s.index.Search("ui", 10)
+	s.NoError(err)
+	s.Len(results, 1)
+	results, err = s.index.Search("uo", 10)
+	s.NoError(err)
+	s.Len(results, 0)
+}
+
+func (s *suiteMemoryIndex) TestSearchHighlights() {
+	results, err := s.index.Search("beautiful", 10)
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Equal(map[string]string{"description": "Design <mark>&lt;b&gt;beautiful&lt;/b&gt;</mark> interfaces."}, results[0].Highlights)
+
+	results, err = s.index.Search("concurrency", 10)
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Equal("Learn the basics of programming with Go, from variables to <mark>concurrency.</mark>", results[0].Highlights["description"])
+
+	results, err = s.index.Search("santoso", 10)
+	s.NoError(err)
+	s.Len(results, 2)
+	for _, result := range results {
+		s.Equal(map[string]string{"instructor": "Budi <mark>Santoso</mark>"}, result.Highlights)
+	}
+}
+
+func (s *suiteMemoryIndex) TestIndexAndRemove() {
+	// indexing the same id replaces the document
+	s.NoError(s.index.Index(Document{ID: "golang", Name: "Advanced Golang"}))
+	results, err := s.index.Search("beginners", 10)
+	s.NoError(err)
+	s.Len(results, 0)
+	results, err = s.index.Search("advanced", 10)
+	s.NoError(err)
+	s.Len(results, 1)
+
+	s.NoError(s.index.Remove("golang"))
+	s.NoError(s.index.Remove("unknown"))
+	results, err = s.index.Search("golang", 10)
+	s.NoError(err)
+	s.Len(results, 1)
+	s.Equal("python", results[0].ID)
+}
+
+func (s *suiteMemoryIndex) TestSnippet() {
+	text := "one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen nineteen twenty"
+	result, ok := snippet(text, map[string]bool{"seven": true})
+	s.True(ok)
+	s.Equal("… three four five six <mark>seven</mark> eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen eighteen …", result)
+
+	_, ok = snippet(text, map[string]bool{"zero": true})
+	s.False(ok)
+}
+
+func TestSuiteMemoryIndex(t *testing.T) {
+	suite.Run(t, new(suiteMemoryIndex))
+}
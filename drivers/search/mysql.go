@@ -0,0 +1,156 @@
+package search
+
+import (
+	"golang/models/model"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// matchName and matchText must use the columns of the FULLTEXT indexes of model.CourseSearch
+	matchName = "MATCH(name) AGAINST (?)"
+	matchText = "MATCH(name, description, objective, category, modules, instructor) AGAINST (?)"
+
+	// moduleSeparator joins the module names in a single column
+	moduleSeparator = "\n"
+	// maxTermLength is the longest word saved in the vocabulary
+	maxTermLength = 100
+)
+
+// MySQLIndex is an index on a mysql table with FULLTEXT indexes, the typos are corrected with a vocabulary table
+type MySQLIndex struct {
+	db *gorm.DB
+}
+
+// NewMySQLIndex returns the index saved in the course_searches and search_terms tables
+func NewMySQLIndex(db *gorm.DB) *MySQLIndex {
+	return &MySQLIndex{db: db}
+}
+
+// Index implements SearchIndex
+func (m *MySQLIndex) Index(doc Document) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&model.CourseSearch{
+			CourseID:    doc.ID,
+			Name:        doc.Name,
+			Description: doc.Description,
+			Objective:   doc.Objective,
+			Category:    doc.Category,
+			Modules:     strings.Join(doc.Modules, moduleSeparator),
+			Instructor:  doc.Instructor,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		var terms []model.SearchTerm
+		seen := map[string]bool{}
+		for _, f := range doc.fields() {
+			for _, word := range tokenize(f.text) {
+				if !seen[word] && utf8.RuneCountInString(word) <= maxTermLength {
+					seen[word] = true
+					terms = append(terms, model.SearchTerm{Term: word})
+				}
+			}
+		}
+		if len(terms) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&terms, 100).Error
+	})
+}
+
+// Remove implements SearchIndex, the words stay in the vocabulary
+func (m *MySQLIndex) Remove(id string) error {
+	return m.db.Where("course_id = ?", id).Delete(&model.CourseSearch{}).Error
+}
+
+// Search implements SearchIndex, the score is the relevance of the name counted three times plus the relevance of all the fields
+func (m *MySQLIndex) Search(query string, limit int) ([]Result, error) {
+	terms, err := m.correct(queryTerms(query))
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return []Result{}, nil
+	}
+	text := strings.Join(terms, " ")
+
+	var rows []struct {
+		model.CourseSearch
+		Score float64
+	}
+	search := m.db.Model(&model.CourseSearch{}).
+		Select("*, "+matchName+" * 3 + "+matchText+" AS score", text, text).
+		Where(matchText, text).
+		Order("score DESC").
+		Order("course_id")
+	if limit > 0 {
+		search = search.Limit(limit)
+	}
+	err = search.Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	matched := map[string]bool{}
+	for _, term := range terms {
+		matched[term] = true
+	}
+	results := []Result{}
+	for _, row := range rows {
+		doc := Document{
+			ID:          row.CourseID,
+			Name:        row.Name,
+			Description: row.Description,
+			Objective:   row.Objective,
+			Category:    row.Category,
+			Instructor:  row.Instructor,
+		}
+		if row.Modules != "" {
+			doc.Modules = strings.Split(row.Modules, moduleSeparator)
+		}
+		results = append(results, Result{
+			ID:         row.CourseID,
+			Score:      row.Score,
+			Highlights: doc.highlights(matched),
+		})
+	}
+	return results, nil
+}
+
+// correct replaces the terms missing from the vocabulary by the closest known word starting with the same letter
+func (m *MySQLIndex) correct(terms []string) ([]string, error) {
+	var corrected []string
+	for _, term := range terms {
+		var count int64
+		err := m.db.Model(&model.SearchTerm{}).Where("term = ?", term).Count(&count).Error
+		if err != nil {
+			return nil, err
+		}
+		limit := maxTypos(term)
+		if count > 0 || limit == 0 {
+			corrected = append(corrected, term)
+			continue
+		}
+
+		first, _ := utf8.DecodeRuneInString(term)
+		length := utf8.RuneCountInString(term)
+		var vocabulary []string
+		err = m.db.Model(&model.SearchTerm{}).
+			Where("term LIKE ? AND CHAR_LENGTH(term) BETWEEN ? AND ?", string(first)+"%", length-limit, length+limit).
+			Pluck("term", &vocabulary).Error
+		if err != nil {
+			return nil, err
+		}
+		if word, ok := closest(term, vocabulary); ok {
+			corrected = append(corrected, word)
+		} else {
+			corrected = append(corrected, term)
+		}
+	}
+	return corrected, nil
+}
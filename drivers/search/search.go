@@ -0,0 +1,79 @@
+package search
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// DriverMySQL keeps the index in a mysql table with a FULLTEXT index
+	DriverMySQL = "mysql"
+	// DriverMemory keeps the index in the memory of the process, it is empty after every restart
+	DriverMemory = "memory"
+)
+
+// Document is the searchable text of a course
+type Document struct {
+	ID          string
+	Name        string
+	Description string
+	Objective   string
+	Category    string
+	Modules     []string
+	Instructor  string
+}
+
+// Result is a course matching the query, the highlights hold a snippet of every matched field
+type Result struct {
+	ID         string
+	Score      float64
+	Highlights map[string]string
+}
+
+// SearchIndex finds courses by the words of their name, description, objective, category, modules and instructor
+type SearchIndex interface {
+	// Index adds the document or replaces the document with the same id
+	Index(doc Document) error
+	// Remove deletes the document of the course, unknown ids are ignored
+	Remove(id string) error
+	// Search returns the best matching documents first, words with a typo still match the closest indexed word
+	Search(query string, limit int) ([]Result, error)
+}
+
+// New builds the index of the driver, mysql is used when the driver is empty
+func New(db *gorm.DB, driver string) SearchIndex {
+	if strings.ToLower(driver) == DriverMemory {
+		return NewMemoryIndex()
+	}
+	return NewMySQLIndex(db)
+}
+
+type field struct {
+	name   string
+	text   string
+	weight float64
+}
+
+// fields returns the searchable fields of the document, a word in the name counts more than a word in the description
+func (doc Document) fields() []field {
+	return []field{
+		{"name", doc.Name, 3},
+		{"category", doc.Category, 2},
+		{"modules", strings.Join(doc.Modules, ", "), 1.5},
+		{"instructor", doc.Instructor, 1.5},
+		{"objective", doc.Objective, 1},
+		{"description", doc.Description, 1},
+	}
+}
+
+// highlights returns a snippet of every field of the document containing one of the terms
+func (doc Document) highlights(terms map[string]bool) map[string]string {
+	highlights := map[string]string{}
+	for _, f := range doc.fields() {
+		if text, ok := snippet(f.text, terms); ok {
+			highlights[f.name] = text
+		}
+	}
+	return highlights
+}
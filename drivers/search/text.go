@@ -0,0 +1,158 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// snippetWords is the number of words in a snippet
+	snippetWords = 16
+	// snippetLead is the number of words shown before the first match of a snippet
+	snippetLead = 4
+)
+
+// tokenize splits the text into lower case words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// queryTerms returns the words of the query without duplicates
+func queryTerms(query string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	for _, term := range tokenize(query) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// maxTypos is the number of typos tolerated in a word, short words have to be exact
+func maxTypos(term string) int {
+	length := utf8.RuneCountInString(term)
+	switch {
+	case length >= 8:
+		return 2
+	case length >= 4:
+		return 1
+	}
+	return 0
+}
+
+// distance is the Levenshtein distance between two words
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
+
+// typos returns the distance between the term and the word, ok is false when the word is too far to be a typo of the term
+func typos(term, word string) (int, bool) {
+	limit := maxTypos(term)
+	if limit == 0 {
+		return 0, false
+	}
+	diff := utf8.RuneCountInString(term) - utf8.RuneCountInString(word)
+	if diff > limit || -diff > limit {
+		return 0, false
+	}
+	d := distance(term, word)
+	return d, d <= limit
+}
+
+// closest returns the word of the vocabulary with the fewest typos from the term
+func closest(term string, vocabulary []string) (string, bool) {
+	best, bestTypos := "", -1
+	for _, word := range vocabulary {
+		d, ok := typos(term, word)
+		if ok && (bestTypos < 0 || d < bestTypos || (d == bestTypos && word < best)) {
+			best, bestTypos = word, d
+		}
+	}
+	return best, bestTypos >= 0
+}
+
+// snippet returns the words of the text around the first word matching a term,
+// the text is html escaped and the matching words are wrapped in <mark>
+func snippet(text string, terms map[string]bool) (string, bool) {
+	words := strings.Fields(text)
+	matches := make([]bool, len(words))
+	first := -1
+	for i, word := range words {
+		for _, token := range tokenize(word) {
+			if terms[token] {
+				matches[i] = true
+			}
+		}
+		if matches[i] && first < 0 {
+			first = i
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	start := first - snippetLead
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWords
+	if end > len(words) {
+		// a match near the end shows more words before it
+		end = len(words)
+		start = end - snippetWords
+		if start < 0 {
+			start = 0
+		}
+	}
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("… ")
+	}
+	for i := start; i < end; i++ {
+		if i > start {
+			builder.WriteString(" ")
+		}
+		if matches[i] {
+			builder.WriteString("<mark>" + html.EscapeString(words[i]) + "</mark>")
+		} else {
+			builder.WriteString(html.EscapeString(words[i]))
+		}
+	}
+	if end < len(words) {
+		builder.WriteString(" …")
+	}
+	return builder.String(), true
+}
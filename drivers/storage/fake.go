@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"io"
+	"sync"
+)
+
+// FakeStorage keeps the files in memory, Err is returned by Put and Delete when set
+type FakeStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+	Err   error
+}
+
+// Put implements Storage
+func (fs *FakeStorage) Put(key string, content io.Reader) (string, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.Err != nil {
+		return "", fs.Err
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+	if fs.files == nil {
+		fs.files = map[string][]byte{}
+	}
+	fs.files["/"+key] = data
+	return "/" + key, nil
+}
+
+// Delete implements Storage
+func (fs *FakeStorage) Delete(url string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if fs.Err != nil {
+		return fs.Err
+	}
+	delete(fs.files, url)
+	return nil
+}
+
+// Files returns the urls of the files saved so far
+func (fs *FakeStorage) Files() []string {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	urls := make([]string, 0, len(fs.files))
+	for url := range fs.files {
+		urls = append(urls, url)
+	}
+	return urls
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// LocalPath is the path the application serves the files of the local storage under
+	LocalPath = "/uploads"
+	// DefaultLocalDir is the directory of the local storage when none is configured
+	DefaultLocalDir = "uploads"
+)
+
+// ErrInvalidKey is returned when a key would be saved outside of the storage directory
+var ErrInvalidKey = errors.New("invalid storage key")
+
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// Put implements Storage
+func (ls *localStorage) Put(key string, content io.Reader) (string, error) {
+	name, err := ls.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		os.Remove(name)
+		return "", err
+	}
+	return ls.baseURL + LocalPath + path.Clean("/"+key), nil
+}
+
+// Delete implements Storage
+func (ls *localStorage) Delete(url string) error {
+	key := strings.TrimPrefix(url, ls.baseURL+LocalPath+"/")
+	if key == url {
+		return nil
+	}
+	name, err := ls.path(key)
+	if err != nil {
+		return nil
+	}
+	err = os.Remove(name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path returns the file name of the key, keys can not leave the storage directory
+func (ls *localStorage) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(ls.dir, filepath.FromSlash(clean)), nil
+}
+
+// NewLocalStorage creates a storage that saves files in the directory, baseURL is the public address of the application and can be empty
+func NewLocalStorage(dir, baseURL string) Storage {
+	if dir == "" {
+		dir = DefaultLocalDir
+	}
+	return &localStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteLocalStorage struct {
+	suite.Suite
+	dir     string
+	storage Storage
+}
+
+func (s *suiteLocalStorage) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.storage = NewLocalStorage(s.dir, "http://localhost:8080")
+}
+
+func (s *suiteLocalStorage) TestPutAndDelete() {
+	url, err := s.storage.Put("profiles/abcde/image.png", strings.NewReader("image"))
+	s.NoError(err)
+	s.Equal("http://localhost:8080/uploads/profiles/abcde/image.png", url)
+
+	name := filepath.Join(s.dir, "profiles", "abcde", "image.png")
+	data, err := os.ReadFile(name)
+	s.NoError(err)
+	s.Equal("image", string(data))
+
+	// urls that do not belong to the storage are left alone
+	s.NoError(s.storage.Delete("https://example.com/image.png"))
+	s.FileExists(name)
+
+	s.NoError(s.storage.Delete(url))
+	s.NoFileExists(name)
+}
+
+func (s *suiteLocalStorage) TestPutInvalidKey() {
+	_, err := s.storage.Put("../image.png", strings.NewReader("image"))
+	s.Equal(ErrInvalidKey, err)
+}
+
+func TestSuiteLocalStorage(t *testing.T) {
+	suite.Run(t, new(suiteLocalStorage))
+}
@@ -0,0 +1,30 @@
+package storage
+
+import "io"
+
+// Storage saves uploaded files and tells the url they are served from
+type Storage interface {
+	// Put saves the content under the key and returns its public url
+	Put(key string, content io.Reader) (string, error)
+	// Delete removes the file served from the url, urls that do not belong to the storage are ignored
+	Delete(url string) error
+}
+
+// Config holds the settings used by New to build a storage
+type Config struct {
+	Dir     string
+	BaseURL string
+}
+
+// LocalDir returns the directory used by the local storage
+func (config Config) LocalDir() string {
+	if config.Dir == "" {
+		return DefaultLocalDir
+	}
+	return config.Dir
+}
+
+// New builds the storage from the config, files are kept on the local disk
+func New(config Config) Storage {
+	return NewLocalStorage(config.LocalDir(), config.BaseURL)
+}
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"golang/models/dto"
+	"golang/util"
+	"io"
+	"strconv"
+)
+
+// GetRetentionPolicy read the retention policy of deleted accounts from the config, learning records are kept when nothing is configured
+func GetRetentionPolicy() dto.RetentionPolicy {
+	policy := dto.RetentionPolicy{
+		KeepRatings: true,
+		KeepGrades:  true,
+	}
+	if keep, err := strconv.ParseBool(util.GetConfig("ACCOUNT_KEEP_RATINGS")); err == nil {
+		policy.KeepRatings = keep
+	}
+	if keep, err := strconv.ParseBool(util.GetConfig("ACCOUNT_KEEP_GRADES")); err == nil {
+		policy.KeepGrades = keep
+	}
+	return policy
+}
+
+// WriteExportArchive writes the export as a zip archive with a json file for every part of the account
+func WriteExportArchive(w io.Writer, export dto.CustomerExport) error {
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"enrollments.json", export.Enrollments},
+		{"submissions.json", export.Submissions},
+		{"reviews.json", export.Reviews},
+		{"favorites.json", export.Favorites},
+		{"quiz_attempts.json", export.QuizAttempts},
+		{"module_progress.json", export.ModuleProgress},
+		{"module_views.json", export.ModuleViews},
+		{"media_progress.json", export.MediaProgress},
+		{"progress_events.json", export.ProgressEvents},
+		{"learning_path_completions.json", export.LearningPathCompletions},
+	}
+	for _, file := range files {
+		f, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(file.data)
+		if err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"math"
+	"time"
+)
+
+// assignmentDefaultPoints is the max points of an assignment created without max points
+const assignmentDefaultPoints = 100
+
+// CheckAssignment checks the policy of the assignment and that it opens before it is due and is due before it closes,
+// an assignment without max points is worth the default points
+func CheckAssignment(assignment *dto.AssignmentTransaction) error {
+	if assignment.MaxPoints < 0 || assignment.MaxResubmissions < 0 || assignment.LatePenalty < 0 || assignment.LatePenalty > 100 {
+		return errors.New(constantError.ErrorAssignmentPolicy)
+	}
+	if assignment.OpenAt != nil && assignment.DueAt != nil && assignment.DueAt.Before(*assignment.OpenAt) {
+		return errors.New(constantError.ErrorAssignmentDates)
+	}
+	if assignment.DueAt != nil && assignment.CloseAt != nil && assignment.CloseAt.Before(*assignment.DueAt) {
+		return errors.New(constantError.ErrorAssignmentDates)
+	}
+	if assignment.OpenAt != nil && assignment.CloseAt != nil && assignment.CloseAt.Before(*assignment.OpenAt) {
+		return errors.New(constantError.ErrorAssignmentDates)
+	}
+	if assignment.MaxPoints == 0 {
+		assignment.MaxPoints = assignmentDefaultPoints
+	}
+	return nil
+}
+
+// CheckAssignmentSubmission checks the customer can submit the submission at the time, a graded submission is final
+// and a submitted submission can be submitted again while the customer has resubmissions left.
+// A returned submission can always be submitted again because the instructor asked for it
+func CheckAssignmentSubmission(assignment dto.Assignment, submission dto.CustomerAssignment, now time.Time) error {
+	if assignment.OpenAt != nil && now.Before(*assignment.OpenAt) {
+		return errors.New(constantError.ErrorAssignmentNotOpen)
+	}
+	if assignment.CloseAt != nil && now.After(*assignment.CloseAt) {
+		return errors.New(constantError.ErrorAssignmentClosed)
+	}
+	switch submission.Status {
+	case dto.AssignmentGraded:
+		return errors.New(constantError.ErrorAssignmentGraded)
+	case dto.AssignmentSubmitted, dto.AssignmentLate:
+		if submission.Attempts > assignment.MaxResubmissions {
+			return errors.New(constantError.ErrorAssignmentResubmission)
+		}
+	}
+	return nil
+}
+
+// GetAssignmentStatus is the status of a version submitted at the time and its late penalty,
+// every day started after the due time takes the late penalty of the assignment until the whole grade is taken
+func GetAssignmentStatus(assignment dto.Assignment, submittedAt time.Time) (string, float64) {
+	if assignment.DueAt == nil || !submittedAt.After(*assignment.DueAt) {
+		return dto.AssignmentSubmitted, 0
+	}
+	days := math.Ceil(submittedAt.Sub(*assignment.DueAt).Hours() / 24)
+	return dto.AssignmentLate, math.Min(100, days*assignment.LatePenalty)
+}
+
+// GetAssignmentScore is the grade after the late penalty
+func GetAssignmentScore(grade, latePenalty float64) float64 {
+	return math.Round(grade*(100-latePenalty)) / 100
+}
@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"golang/models/dto"
+)
+
+// EncodeCatalogCursor encode the cursor of the catalog as an opaque string
+func EncodeCatalogCursor(cursor dto.CatalogCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCatalogCursor decode the cursor made by EncodeCatalogCursor
+func DecodeCatalogCursor(cursor string) (dto.CatalogCursor, error) {
+	var result dto.CatalogCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return dto.CatalogCursor{}, err
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return dto.CatalogCursor{}, err
+	}
+	return result, nil
+}
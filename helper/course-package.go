@@ -0,0 +1,407 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// coursePackageFile is the json of the course inside the zip package
+	coursePackageFile = "course.json"
+	// commonCartridgeManifest is the IMS Common Cartridge manifest inside the zip package
+	commonCartridgeManifest = "imsmanifest.xml"
+	// maxCoursePackageFile is the biggest file read from a zip package
+	maxCoursePackageFile = 10 << 20
+)
+
+// the subset of the IMS Common Cartridge 1.1 manifest made by the export and read by the import,
+// the modules are the items of the organization, the web content is the content of a module and the web links are its media
+type ccManifest struct {
+	XMLName       xml.Name         `xml:"manifest"`
+	Xmlns         string           `xml:"xmlns,attr,omitempty"`
+	Identifier    string           `xml:"identifier,attr"`
+	Metadata      ccMetadata       `xml:"metadata"`
+	Organizations []ccOrganization `xml:"organizations>organization"`
+	Resources     []ccResource     `xml:"resources>resource"`
+}
+
+type ccMetadata struct {
+	Schema        string `xml:"schema"`
+	SchemaVersion string `xml:"schemaversion"`
+	Lom           ccLom  `xml:"lom"`
+}
+
+type ccLom struct {
+	Xmlns       string `xml:"xmlns,attr,omitempty"`
+	Title       string `xml:"general>title>string"`
+	Description string `xml:"general>description>string"`
+}
+
+type ccOrganization struct {
+	Identifier string   `xml:"identifier,attr"`
+	Structure  string   `xml:"structure,attr"`
+	Items      []ccItem `xml:"item"`
+}
+
+type ccItem struct {
+	Identifier    string   `xml:"identifier,attr"`
+	IdentifierRef string   `xml:"identifierref,attr,omitempty"`
+	Title         string   `xml:"title,omitempty"`
+	Items         []ccItem `xml:"item"`
+}
+
+type ccResource struct {
+	Identifier string   `xml:"identifier,attr"`
+	Type       string   `xml:"type,attr"`
+	Href       string   `xml:"href,attr,omitempty"`
+	Files      []ccFile `xml:"file"`
+}
+
+type ccFile struct {
+	Href string `xml:"href,attr"`
+}
+
+type ccWebLink struct {
+	XMLName xml.Name `xml:"webLink"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Title   string   `xml:"title"`
+	Url     ccFile   `xml:"url"`
+}
+
+// EncodeCoursePackage encode the package as json
+func EncodeCoursePackage(coursePackage dto.CoursePackage) ([]byte, error) {
+	return json.MarshalIndent(coursePackage, "", "  ")
+}
+
+// EncodeCoursePackageZip encode the package as a zip with the json of the course
+// and an IMS Common Cartridge manifest so other LMS can read the modules
+func EncodeCoursePackageZip(coursePackage dto.CoursePackage) ([]byte, error) {
+	data, err := EncodeCoursePackage(coursePackage)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := ccManifest{
+		Xmlns:      "http://www.imsglobal.org/xsd/imsccv1p1/imscp_v1p1",
+		Identifier: "course",
+		Metadata: ccMetadata{
+			Schema:        "IMS Common Cartridge",
+			SchemaVersion: "1.1.0",
+			Lom: ccLom{
+				Xmlns:       "http://ltsc.ieee.org/xsd/imsccv1p1/LOM/manifest",
+				Title:       coursePackage.Course.Name,
+				Description: coursePackage.Course.Description,
+			},
+		},
+	}
+	files := map[string][]byte{}
+	var fileNames []string
+	addFile := func(name string, content []byte) {
+		files[name] = content
+		fileNames = append(fileNames, name)
+	}
+
+	root := ccItem{Identifier: "root"}
+	for i, module := range coursePackage.Modules {
+		id := fmt.Sprintf("module-%d", i+1)
+		item := ccItem{Identifier: id, Title: module.Name}
+
+		contentFile := fmt.Sprintf("modules/%d/content.html", i+1)
+		addFile(contentFile, []byte(module.Content))
+		manifest.Resources = append(manifest.Resources, ccResource{
+			Identifier: "resource-" + id,
+			Type:       "webcontent",
+			Href:       contentFile,
+			Files:      []ccFile{{Href: contentFile}},
+		})
+		item.Items = append(item.Items, ccItem{Identifier: id + "-content", IdentifierRef: "resource-" + id, Title: module.Name})
+
+		for j, url := range module.Media {
+			mediaID := fmt.Sprintf("%s-media-%d", id, j+1)
+			mediaFile := fmt.Sprintf("modules/%d/media-%d.xml", i+1, j+1)
+			webLink, err := xml.MarshalIndent(ccWebLink{
+				Xmlns: "http://www.imsglobal.org/xsd/imsccv1p1/imswl_v1p1",
+				Title: module.Name,
+				Url:   ccFile{Href: url},
+			}, "", "  ")
+			if err != nil {
+				return nil, err
+			}
+			addFile(mediaFile, append([]byte(xml.Header), webLink...))
+			manifest.Resources = append(manifest.Resources, ccResource{
+				Identifier: "resource-" + mediaID,
+				Type:       "imswl_xmlv1p1",
+				Files:      []ccFile{{Href: mediaFile}},
+			})
+			item.Items = append(item.Items, ccItem{Identifier: mediaID, IdentifierRef: "resource-" + mediaID, Title: module.Name})
+		}
+		root.Items = append(root.Items, item)
+	}
+	manifest.Organizations = []ccOrganization{{Identifier: "organization", Structure: "rooted-hierarchy", Items: []ccItem{root}}}
+
+	manifestData, err := xml.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	entries := append([]string{coursePackageFile, commonCartridgeManifest}, fileNames...)
+	files[coursePackageFile] = data
+	files[commonCartridgeManifest] = append([]byte(xml.Header), manifestData...)
+	for _, name := range entries {
+		file, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = file.Write(files[name])
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// DecodeCoursePackage decode a json package or a zip package, a zip without the json of the course
+// is read as an IMS Common Cartridge
+func DecodeCoursePackage(data []byte) (dto.CoursePackage, error) {
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return decodeCoursePackageJSON(data)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return dto.CoursePackage{}, errors.New(constantError.ErrorCoursePackageInvalid)
+	}
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		files[path.Clean(file.Name)] = file
+	}
+
+	if file, ok := files[coursePackageFile]; ok {
+		content, err := readZipFile(file)
+		if err != nil {
+			return dto.CoursePackage{}, err
+		}
+		// the json of the course is never read as a package again, so a zip inside the zip is rejected
+		return decodeCoursePackageJSON(content)
+	}
+	if file, ok := files[commonCartridgeManifest]; ok {
+		return decodeCommonCartridge(file, files)
+	}
+	return dto.CoursePackage{}, errors.New(constantError.ErrorCoursePackageInvalid)
+}
+
+// decodeCoursePackageJSON decode the json of the course
+func decodeCoursePackageJSON(data []byte) (dto.CoursePackage, error) {
+	var coursePackage dto.CoursePackage
+	err := json.Unmarshal(data, &coursePackage)
+	if err != nil {
+		return dto.CoursePackage{}, errors.New(constantError.ErrorCoursePackageInvalid)
+	}
+	return coursePackage, nil
+}
+
+// ValidateCoursePackage returns the problems of the package that stop the import
+func ValidateCoursePackage(coursePackage dto.CoursePackage) []string {
+	var conflicts []string
+	if coursePackage.FormatVersion > dto.CoursePackageVersion {
+		conflicts = append(conflicts, fmt.Sprintf("package format version %d is not supported, the latest version is %d", coursePackage.FormatVersion, dto.CoursePackageVersion))
+	}
+	if strings.TrimSpace(coursePackage.Course.Name) == "" {
+		conflicts = append(conflicts, "course has no name")
+	}
+	if strings.TrimSpace(coursePackage.Course.Description) == "" {
+		conflicts = append(conflicts, "course has no description")
+	}
+	if coursePackage.Course.Capacity < 0 {
+		conflicts = append(conflicts, "course capacity is lower than zero")
+	}
+	for i, section := range coursePackage.Sections {
+		if strings.TrimSpace(section.Title) == "" {
+			conflicts = append(conflicts, fmt.Sprintf("section %d has no title", i+1))
+		}
+	}
+	for i, module := range coursePackage.Modules {
+		if strings.TrimSpace(module.Name) == "" {
+			conflicts = append(conflicts, fmt.Sprintf("module %d has no name", i+1))
+		}
+		if module.Section < 0 || module.Section > len(coursePackage.Sections) {
+			conflicts = append(conflicts, fmt.Sprintf("module %d is in section %d that is not in the package", i+1, module.Section))
+		}
+		if module.Assignment != nil && strings.TrimSpace(module.Assignment.Title) == "" {
+			conflicts = append(conflicts, fmt.Sprintf("assignment of module %d has no title", i+1))
+		}
+		if module.Assignment != nil && (module.Assignment.MaxPoints < 0 || module.Assignment.MaxResubmissions < 0 ||
+			module.Assignment.LatePenalty < 0 || module.Assignment.LatePenalty > 100) {
+			conflicts = append(conflicts, fmt.Sprintf("assignment of module %d has an invalid policy", i+1))
+		}
+	}
+	for i, bank := range coursePackage.QuestionBanks {
+		if strings.TrimSpace(bank.Title) == "" {
+			conflicts = append(conflicts, fmt.Sprintf("question bank %d has no title", i+1))
+		}
+		for j, question := range bank.Questions {
+			if !isValidPackageQuestion(question) {
+				conflicts = append(conflicts, fmt.Sprintf("question %d of question bank %d is invalid", j+1, i+1))
+			}
+		}
+	}
+	for i, quiz := range coursePackage.Quizzes {
+		if strings.TrimSpace(quiz.Title) == "" && strings.TrimSpace(quiz.Link) == "" {
+			conflicts = append(conflicts, fmt.Sprintf("quiz %d has no title or link", i+1))
+		}
+		if quiz.Module < 0 || quiz.Module > len(coursePackage.Modules) {
+			conflicts = append(conflicts, fmt.Sprintf("quiz %d is in module %d that is not in the package", i+1, quiz.Module))
+		}
+		if quiz.TimeLimit < 0 || quiz.MaxAttempts < 0 || quiz.PassingScore < 0 || quiz.PassingScore > 100 {
+			conflicts = append(conflicts, fmt.Sprintf("quiz %d has invalid settings", i+1))
+		}
+		for j, question := range quiz.Questions {
+			if !isValidPackageQuestion(question) {
+				conflicts = append(conflicts, fmt.Sprintf("question %d of quiz %d is invalid", j+1, i+1))
+			}
+		}
+		for j, pool := range quiz.Pools {
+			if pool.Bank < 1 || pool.Bank > len(coursePackage.QuestionBanks) {
+				conflicts = append(conflicts, fmt.Sprintf("pool %d of quiz %d draws from question bank %d that is not in the package", j+1, i+1, pool.Bank))
+			}
+			if pool.Count < 1 || !isQuizDifficulty(pool.Difficulty) {
+				conflicts = append(conflicts, fmt.Sprintf("pool %d of quiz %d is invalid", j+1, i+1))
+			}
+		}
+	}
+	return conflicts
+}
+
+// isValidPackageQuestion checks a question of the package like a question that is created
+func isValidPackageQuestion(question dto.CoursePackageQuizQuestion) bool {
+	quizQuestion := GetQuizQuestionTransaction(question)
+	return strings.TrimSpace(question.Text) != "" && question.Points >= 0 && question.Tolerance >= 0 &&
+		utf8.RuneCountInString(question.Topic) <= 100 && isQuizDifficulty(question.Difficulty) && CheckQuizQuestion(&quizQuestion) == nil
+}
+
+// isQuizDifficulty checks the difficulty is empty or one of the difficulties of the questions
+func isQuizDifficulty(difficulty string) bool {
+	return difficulty == "" || difficulty == dto.QuizEasy || difficulty == dto.QuizMedium || difficulty == dto.QuizHard
+}
+
+// decodeCommonCartridge reads the modules of the first organization of the manifest
+func decodeCommonCartridge(manifestFile *zip.File, files map[string]*zip.File) (dto.CoursePackage, error) {
+	data, err := readZipFile(manifestFile)
+	if err != nil {
+		return dto.CoursePackage{}, err
+	}
+	var manifest ccManifest
+	err = xml.Unmarshal(data, &manifest)
+	if err != nil {
+		return dto.CoursePackage{}, errors.New(constantError.ErrorCoursePackageInvalid)
+	}
+
+	coursePackage := dto.CoursePackage{
+		FormatVersion: dto.CoursePackageVersion,
+		Course: dto.CoursePackageCourse{
+			Name:        strings.TrimSpace(manifest.Metadata.Lom.Title),
+			Description: strings.TrimSpace(manifest.Metadata.Lom.Description),
+		},
+	}
+	if len(manifest.Organizations) == 0 {
+		return coursePackage, nil
+	}
+
+	resources := map[string]ccResource{}
+	for _, resource := range manifest.Resources {
+		resources[resource.Identifier] = resource
+	}
+
+	// a rooted hierarchy has one item without resource holding the modules
+	items := manifest.Organizations[0].Items
+	if len(items) == 1 && items[0].IdentifierRef == "" && len(items[0].Items) > 0 {
+		items = items[0].Items
+	}
+	for _, item := range items {
+		module := dto.CoursePackageModule{
+			Name:  strings.TrimSpace(item.Title),
+			Media: []string{},
+		}
+		var contents []string
+		for _, ref := range ccItemRefs(item) {
+			resource, ok := resources[ref]
+			if !ok {
+				continue
+			}
+			href := resource.Href
+			if href == "" && len(resource.Files) > 0 {
+				href = resource.Files[0].Href
+			}
+			file, ok := files[path.Clean(href)]
+			if !ok {
+				continue
+			}
+			content, err := readZipFile(file)
+			if err != nil {
+				return dto.CoursePackage{}, err
+			}
+
+			switch {
+			case resource.Type == "webcontent":
+				contents = append(contents, string(content))
+			case strings.HasPrefix(resource.Type, "imswl_"):
+				var webLink ccWebLink
+				err = xml.Unmarshal(content, &webLink)
+				if err != nil {
+					return dto.CoursePackage{}, errors.New(constantError.ErrorCoursePackageInvalid)
+				}
+				if webLink.Url.Href != "" {
+					module.Media = append(module.Media, webLink.Url.Href)
+				}
+			}
+		}
+		module.Content = strings.Join(contents, "\n")
+		module.NoModule = len(coursePackage.Modules) + 1
+		coursePackage.Modules = append(coursePackage.Modules, module)
+	}
+	return coursePackage, nil
+}
+
+// ccItemRefs returns the resources of the item and of the items inside it in order
+func ccItemRefs(item ccItem) []string {
+	var refs []string
+	if item.IdentifierRef != "" {
+		refs = append(refs, item.IdentifierRef)
+	}
+	for _, child := range item.Items {
+		refs = append(refs, ccItemRefs(child)...)
+	}
+	return refs
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxCoursePackageFile {
+		return nil, errors.New(constantError.ErrorCoursePackageInvalid)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.New(constantError.ErrorCoursePackageInvalid)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, maxCoursePackageFile+1))
+	if err != nil || len(data) > maxCoursePackageFile {
+		return nil, errors.New(constantError.ErrorCoursePackageInvalid)
+	}
+	return data, nil
+}
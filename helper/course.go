@@ -1,21 +1,16 @@
 package helper
 
 import (
+	"errors"
+	"golang/constant/constantError"
 	"golang/models/dto"
 )
 
-// get rating from course
-func GetRatingCourse(course dto.Course) float64 {
-	if len(course.Ratings) == 0 {
-		return 0
-	}
-	// get rating of course
-	for _, rating := range course.Ratings {
-		course.Rating += float64(rating.Rating)
-	}
-	// average rating
-	average := course.Rating / float64(len(course.Ratings))
-	return average
+// get rating, number of module and amount of customer from the stats of course
+func GetCourseStats(course *dto.Course) {
+	course.Rating = course.Stats.RatingAverage
+	course.NumberOfModules = course.Stats.ModuleCount
+	course.AmountCustomer = course.Stats.EnrolledCount
 }
 
 func GetFavoriteCourse(course dto.Course, customerID string) bool {
@@ -31,6 +26,62 @@ func GetFavoriteCourse(course dto.Course, customerID string) bool {
 	return false
 }
 
+// check if the instructor can make courses, only verified instructors approved by an admin can publish courses
+func CheckCourseInstructor(instructor dto.InstructorResponseGet) error {
+	if !instructor.IsActive {
+		return errors.New(constantError.ErrorNoActive)
+	}
+	if instructor.Status == dto.InstructorStatusSuspended {
+		return errors.New(constantError.ErrorInstructorSuspended)
+	}
+	if instructor.Status != dto.InstructorStatusApproved {
+		return errors.New(constantError.ErrorInstructorNotApproved)
+	}
+	return nil
+}
+
+// check if the course has the modules and media needed to be reviewed and published
+func CheckCourseContent(content dto.CourseContent) error {
+	if content.Modules == 0 {
+		return errors.New(constantError.ErrorCourseNoModule)
+	}
+	if content.MediaModules == 0 {
+		return errors.New(constantError.ErrorCourseNoMedia)
+	}
+	return nil
+}
+
+// check if the customer can see the course, archived courses stay visible to the customers enrolled in it
+func IsCourseVisible(course dto.Course, customerID string) bool {
+	if course.Status == dto.CourseStatusPublished {
+		return true
+	}
+	if course.Status != dto.CourseStatusArchived {
+		return false
+	}
+	for _, customerCourse := range course.CustomerCourses {
+		if customerCourse.CustomerID == customerID {
+			return true
+		}
+	}
+	return false
+}
+
+// get the modules of a course version, the id of a module is the id of the module it is copied from
+func GetVersionModules(version dto.CourseVersion) []dto.Module {
+	modules := []dto.Module{}
+	for _, module := range version.Modules {
+		modules = append(modules, dto.Module{
+			ID:       module.ModuleID,
+			Name:     module.Name,
+			Content:  module.Content,
+			CourseID: version.CourseID,
+			NoModule: module.NoModule,
+		})
+	}
+	return modules
+}
+
 // function to get enrolled course
 func GetEnrolledCourse(course *dto.Course, customerID string) {
 	course.StatusEnroll = false
@@ -0,0 +1,20 @@
+package helper
+
+import "golang/models/dto"
+
+// GetLearningPathProgress computes the percentage of the courses of the path the customer finished,
+// the path is finished once its completion is issued
+func GetLearningPathProgress(learningPath *dto.LearningPath) {
+	learningPath.IsFinish = learningPath.CompletedAt != nil
+	if len(learningPath.Courses) == 0 {
+		learningPath.ProgressPercentage = 0
+		return
+	}
+	var finished int
+	for _, course := range learningPath.Courses {
+		if course.IsFinish {
+			finished++
+		}
+	}
+	learningPath.ProgressPercentage = float64(finished) * 100 / float64(len(learningPath.Courses))
+}
@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"io"
+	"net/http"
+)
+
+// MaxProfileImageSize is the largest profile image that can be uploaded
+const MaxProfileImageSize = 2 << 20
+
+var profileImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ReadProfileImage reads the uploaded image and returns it with its file extension, only jpeg, png and webp images are accepted
+func ReadProfileImage(image io.Reader) ([]byte, string, error) {
+	data, err := io.ReadAll(io.LimitReader(image, MaxProfileImageSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > MaxProfileImageSize {
+		return nil, "", errors.New(constantError.ErrorImageTooLarge)
+	}
+
+	// the type is detected from the content, the name and header of the upload can not be trusted
+	extension, ok := profileImageExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, "", errors.New(constantError.ErrorInvalidImage)
+	}
+	return data, extension, nil
+}
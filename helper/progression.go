@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+)
+
+// mediaDefaultWatched is the percentage of the media to watch of a media rule without minimum
+const mediaDefaultWatched = 100
+
+// GetDefaultModuleRules returns the rules of a module without rule, a module with an assignment is finished
+// when the assignment is submitted and the other modules are finished when they are opened
+func GetDefaultModuleRules(moduleID string, hasAssignment bool) []dto.ModuleRule {
+	if hasAssignment {
+		return []dto.ModuleRule{{ModuleID: moduleID, Type: dto.ModuleRuleAssignmentSubmitted}}
+	}
+	return []dto.ModuleRule{{ModuleID: moduleID, Type: dto.ModuleRuleViewed}}
+}
+
+// CheckModuleRules checks every rule has the target and the minimum of its type and that no rule is given twice,
+// a media rule without minimum needs the whole media to be watched
+func CheckModuleRules(rules []dto.ModuleRuleTransaction) error {
+	seen := map[string]bool{}
+	for i := range rules {
+		rule := &rules[i]
+		switch rule.Type {
+		case dto.ModuleRuleViewed, dto.ModuleRuleAssignmentSubmitted:
+			if rule.TargetID != "" || rule.MinValue != 0 {
+				return errors.New(constantError.ErrorModuleRule)
+			}
+		case dto.ModuleRuleAssignmentPassed:
+			if rule.TargetID != "" {
+				return errors.New(constantError.ErrorModuleRule)
+			}
+		case dto.ModuleRuleMediaWatched:
+			if rule.TargetID == "" || rule.MinValue > 100 {
+				return errors.New(constantError.ErrorModuleRule)
+			}
+			if rule.MinValue == 0 {
+				rule.MinValue = mediaDefaultWatched
+			}
+		case dto.ModuleRuleQuizPassed:
+			if rule.TargetID == "" || rule.MinValue != 0 {
+				return errors.New(constantError.ErrorModuleRule)
+			}
+		default:
+			return errors.New(constantError.ErrorModuleRule)
+		}
+		if seen[rule.Type+rule.TargetID] {
+			return errors.New(constantError.ErrorModuleRule)
+		}
+		seen[rule.Type+rule.TargetID] = true
+	}
+	return nil
+}
+
+// IsModuleFinished returns if all the rules of the module are met
+func IsModuleFinished(rules []dto.ModuleRuleProgress) bool {
+	for _, rule := range rules {
+		if !rule.IsMet {
+			return false
+		}
+	}
+	return true
+}
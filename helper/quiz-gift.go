@@ -0,0 +1,363 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang/models/dto"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// giftSpecial are the characters escaped with a backslash in a GIFT file
+const giftSpecial = "~=#{}:\\"
+
+// giftAnswer is an answer of the answer block of a GIFT question, the mark is = or ~
+// and the weight is the percent of the answer when it is given
+type giftAnswer struct {
+	mark   rune
+	weight *float64
+	text   string
+}
+
+// EncodeGIFT encode the questions as a Moodle GIFT file, the topic of a question is its category
+// and the points are not written because GIFT has no points
+func EncodeGIFT(questions []dto.QuizQuestion) []byte {
+	var buffer bytes.Buffer
+	var topic string
+	for _, question := range questions {
+		if question.Topic != topic {
+			topic = question.Topic
+			fmt.Fprintf(&buffer, "$CATEGORY: %s\n\n", topic)
+		}
+		buffer.WriteString(escapeGIFT(question.Text))
+		switch question.Type {
+		case dto.QuizTrueFalse:
+			if answer, _ := strconv.ParseBool(question.Answer); answer {
+				buffer.WriteString(" {TRUE}")
+			} else {
+				buffer.WriteString(" {FALSE}")
+			}
+		case dto.QuizNumeric:
+			buffer.WriteString(" {#" + question.Answer)
+			if question.Tolerance > 0 {
+				buffer.WriteString(":" + strconv.FormatFloat(question.Tolerance, 'f', -1, 64))
+			}
+			buffer.WriteString("}")
+		default:
+			var correct int
+			for _, option := range question.Options {
+				if option.IsCorrect {
+					correct++
+				}
+			}
+			buffer.WriteString(" {\n")
+			for _, option := range question.Options {
+				mark := "~"
+				switch {
+				case question.Type == dto.QuizMultipleChoice && option.IsCorrect:
+					mark = "~%" + strconv.FormatFloat(math.Round(100/float64(correct)*1e5)/1e5, 'f', -1, 64) + "%"
+				case question.Type == dto.QuizMultipleChoice:
+					mark = "~%-100%"
+				case option.IsCorrect || question.Type == dto.QuizShortAnswer:
+					mark = "="
+				}
+				buffer.WriteString("\t" + mark + escapeGIFT(option.Text) + "\n")
+			}
+			buffer.WriteString("}")
+		}
+		buffer.WriteString("\n\n")
+	}
+	return buffer.Bytes()
+}
+
+// DecodeGIFT decode the questions of a Moodle GIFT file, the questions are separated by blank lines
+// and a question that cannot be read is an error with the line it starts on while the other questions are still read
+func DecodeGIFT(data []byte) ([]dto.QuizQuestionTransaction, []dto.QuizImportError) {
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))), "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	var questions []dto.QuizQuestionTransaction
+	var importErrors []dto.QuizImportError
+	var topic string
+	var block []string
+	var blockLine, number int
+	readBlock := func() {
+		if len(block) == 0 {
+			return
+		}
+		// a category is the topic of the questions after it
+		if strings.HasPrefix(block[0], "$CATEGORY:") {
+			category := strings.TrimSpace(strings.TrimPrefix(block[0], "$CATEGORY:"))
+			topic = strings.TrimSpace(category[strings.LastIndex(category, "/")+1:])
+			block = block[1:]
+			blockLine++
+		}
+		if len(block) > 0 {
+			number++
+			question, err := decodeGIFTQuestion(strings.Join(block, "\n"))
+			if err == nil {
+				question.Topic = topic
+				err = checkImportedQuestion(&question)
+			}
+			if err != nil {
+				importErrors = append(importErrors, dto.QuizImportError{Line: blockLine, Question: number, Message: err.Error()})
+			} else {
+				questions = append(questions, question)
+			}
+		}
+		block = nil
+	}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		if trimmed == "" {
+			readBlock()
+			continue
+		}
+		if len(block) == 0 {
+			blockLine = i + 1
+		}
+		block = append(block, trimmed)
+	}
+	readBlock()
+	return questions, importErrors
+}
+
+// decodeGIFTQuestion decode the title, the text and the answer block of a question
+func decodeGIFTQuestion(text string) (dto.QuizQuestionTransaction, error) {
+	if strings.HasPrefix(text, "::") {
+		end := indexGIFT(text[2:], "::")
+		if end < 0 {
+			return dto.QuizQuestionTransaction{}, errors.New("the title of the question is not closed with ::")
+		}
+		text = strings.TrimSpace(text[end+4:])
+	}
+	if strings.HasPrefix(text, "[") {
+		end := strings.Index(text, "]")
+		switch strings.ToLower(text[:end+1]) {
+		case "[html]", "[moodle]", "[plain]", "[markdown]":
+			text = strings.TrimSpace(text[end+1:])
+		}
+	}
+
+	open := indexGIFT(text, "{")
+	if open < 0 {
+		return dto.QuizQuestionTransaction{}, errors.New("the question has no answer block")
+	}
+	end := indexGIFT(text[open:], "}")
+	if end < 0 {
+		return dto.QuizQuestionTransaction{}, errors.New("the answer block is not closed with }")
+	}
+	end += open
+	question := dto.QuizQuestionTransaction{Text: unescapeGIFT(strings.TrimSpace(text[:open]))}
+	// the answer block in the middle of the text is the missing word of the question
+	if after := strings.TrimSpace(text[end+1:]); after != "" {
+		question.Text = strings.TrimSpace(question.Text + " _____ " + unescapeGIFT(after))
+	}
+
+	answers := strings.TrimSpace(text[open+1 : end])
+	if strings.HasPrefix(answers, "#") {
+		return decodeGIFTNumeric(question, answers[1:])
+	}
+	switch strings.ToUpper(strings.TrimSpace(cutGIFTFeedback(answers))) {
+	case "":
+		return dto.QuizQuestionTransaction{}, errors.New("essay questions are not supported")
+	case "T", "TRUE":
+		question.Type = dto.QuizTrueFalse
+		question.Answer = "true"
+		return question, nil
+	case "F", "FALSE":
+		question.Type = dto.QuizTrueFalse
+		question.Answer = "false"
+		return question, nil
+	}
+	if indexGIFT(answers, "->") >= 0 {
+		return dto.QuizQuestionTransaction{}, errors.New("matching questions are not supported")
+	}
+
+	giftAnswers, err := splitGIFTAnswers(answers)
+	if err != nil {
+		return dto.QuizQuestionTransaction{}, err
+	}
+	var wrong, correct, weighted int
+	for _, answer := range giftAnswers {
+		isCorrect := answer.mark == '=' || (answer.weight != nil && *answer.weight > 0)
+		if answer.mark == '~' && isCorrect {
+			weighted++
+		}
+		if isCorrect {
+			correct++
+		} else {
+			wrong++
+		}
+		question.Options = append(question.Options, dto.QuizOptionTransaction{Text: answer.text, IsCorrect: isCorrect})
+	}
+	switch {
+	case wrong == 0 && weighted == 0:
+		question.Type = dto.QuizShortAnswer
+	case correct == 1 && weighted == 0:
+		question.Type = dto.QuizSingleChoice
+	default:
+		question.Type = dto.QuizMultipleChoice
+	}
+	return question, nil
+}
+
+// decodeGIFTNumeric decode the answer of a numeric question as a number with its tolerance or as a range,
+// the first answer with full credit is the answer when the question has more answers
+func decodeGIFTNumeric(question dto.QuizQuestionTransaction, answers string) (dto.QuizQuestionTransaction, error) {
+	question.Type = dto.QuizNumeric
+	answer := answers
+	if strings.HasPrefix(strings.TrimSpace(answers), "=") {
+		giftAnswers, err := splitGIFTAnswers(answers)
+		if err != nil {
+			return dto.QuizQuestionTransaction{}, err
+		}
+		answer = ""
+		for _, giftAnswer := range giftAnswers {
+			if giftAnswer.weight == nil || *giftAnswer.weight == 100 {
+				answer = giftAnswer.text
+				break
+			}
+		}
+	}
+	answer = strings.TrimSpace(cutGIFTFeedback(answer))
+
+	var err, errTolerance error
+	var value, tolerance float64
+	if low, high, ok := strings.Cut(answer, ".."); ok {
+		var lowValue, highValue float64
+		lowValue, err = strconv.ParseFloat(strings.TrimSpace(low), 64)
+		highValue, errTolerance = strconv.ParseFloat(strings.TrimSpace(high), 64)
+		value, tolerance = (lowValue+highValue)/2, math.Abs(highValue-lowValue)/2
+	} else if number, margin, ok := strings.Cut(answer, ":"); ok {
+		value, err = strconv.ParseFloat(strings.TrimSpace(number), 64)
+		tolerance, errTolerance = strconv.ParseFloat(strings.TrimSpace(margin), 64)
+	} else {
+		value, err = strconv.ParseFloat(answer, 64)
+	}
+	if err != nil || errTolerance != nil {
+		return dto.QuizQuestionTransaction{}, fmt.Errorf("%q is not a number answer", answer)
+	}
+	question.Answer = strconv.FormatFloat(value, 'f', -1, 64)
+	question.Tolerance = math.Abs(tolerance)
+	return question, nil
+}
+
+// splitGIFTAnswers split the answer block at the = and ~ that start the answers,
+// the feedback of an answer is removed and its weight is read
+func splitGIFTAnswers(answers string) ([]giftAnswer, error) {
+	var giftAnswers []giftAnswer
+	var current *giftAnswer
+	var text strings.Builder
+	flush := func() {
+		if current != nil {
+			current.text = text.String()
+			giftAnswers = append(giftAnswers, *current)
+		}
+		text.Reset()
+	}
+	escaped := false
+	for _, char := range answers {
+		switch {
+		case escaped:
+			text.WriteRune('\\')
+			text.WriteRune(char)
+			escaped = false
+		case char == '\\':
+			escaped = true
+		case char == '=' || char == '~':
+			if current == nil && strings.TrimSpace(text.String()) != "" {
+				return nil, errors.New("an answer does not start with = or ~")
+			}
+			flush()
+			current = &giftAnswer{mark: char}
+		default:
+			text.WriteRune(char)
+		}
+	}
+	flush()
+	if len(giftAnswers) == 0 {
+		return nil, errors.New("an answer does not start with = or ~")
+	}
+
+	for i, answer := range giftAnswers {
+		text := strings.TrimSpace(cutGIFTFeedback(answer.text))
+		if strings.HasPrefix(text, "%") {
+			end := strings.Index(text[1:], "%")
+			if end < 0 {
+				return nil, fmt.Errorf("the weight of answer %d is not closed with %%", i+1)
+			}
+			weight, err := strconv.ParseFloat(text[1:end+1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("the weight of answer %d is not a number", i+1)
+			}
+			giftAnswers[i].weight = &weight
+			text = strings.TrimSpace(text[end+2:])
+		}
+		giftAnswers[i].text = unescapeGIFT(text)
+	}
+	return giftAnswers, nil
+}
+
+// cutGIFTFeedback removes the feedback after the first # that is not escaped
+func cutGIFTFeedback(text string) string {
+	if i := indexGIFT(text, "#"); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
+
+// indexGIFT is the index of the first substr that is not escaped
+func indexGIFT(text, substr string) int {
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(text[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func escapeGIFT(text string) string {
+	var builder strings.Builder
+	for _, char := range text {
+		if strings.ContainsRune(giftSpecial, char) {
+			builder.WriteRune('\\')
+		}
+		if char == '\n' {
+			builder.WriteString("\\n")
+			continue
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+func unescapeGIFT(text string) string {
+	var builder strings.Builder
+	escaped := false
+	for _, char := range text {
+		switch {
+		case escaped && char == 'n':
+			builder.WriteRune('\n')
+		case escaped && !strings.ContainsRune(giftSpecial, char):
+			builder.WriteRune('\\')
+			builder.WriteRune(char)
+		case !escaped && char == '\\':
+			escaped = true
+			continue
+		default:
+			builder.WriteRune(char)
+		}
+		escaped = false
+	}
+	return builder.String()
+}
@@ -0,0 +1,555 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	qtiNamespace        = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	qtiItemResourceType = "imsqti_item_xmlv2p1"
+	qtiMatchCorrect     = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/match_correct"
+	qtiMapResponse      = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/map_response"
+)
+
+// the subset of QTI 2.1 made by the export and read by the import, every question is an assessment item
+// with one choice or text entry interaction and its points are the default value of MAXSCORE
+type qtiManifest struct {
+	XMLName    xml.Name     `xml:"manifest"`
+	Xmlns      string       `xml:"xmlns,attr,omitempty"`
+	Identifier string       `xml:"identifier,attr"`
+	Resources  []ccResource `xml:"resources>resource"`
+}
+
+type qtiAssessmentItem struct {
+	XMLName             xml.Name                `xml:"assessmentItem"`
+	Xmlns               string                  `xml:"xmlns,attr"`
+	Identifier          string                  `xml:"identifier,attr"`
+	Title               string                  `xml:"title,attr"`
+	Adaptive            bool                    `xml:"adaptive,attr"`
+	TimeDependent       bool                    `xml:"timeDependent,attr"`
+	ResponseDeclaration qtiResponseDeclaration  `xml:"responseDeclaration"`
+	OutcomeDeclarations []qtiOutcomeDeclaration `xml:"outcomeDeclaration"`
+	ItemBody            qtiItemBody             `xml:"itemBody"`
+	ResponseProcessing  qtiResponseProcessing   `xml:"responseProcessing"`
+}
+
+type qtiResponseDeclaration struct {
+	Identifier      string      `xml:"identifier,attr"`
+	Cardinality     string      `xml:"cardinality,attr"`
+	BaseType        string      `xml:"baseType,attr"`
+	CorrectResponse []string    `xml:"correctResponse>value"`
+	Mapping         *qtiMapping `xml:"mapping,omitempty"`
+}
+
+type qtiMapping struct {
+	DefaultValue float64       `xml:"defaultValue,attr"`
+	MapEntries   []qtiMapEntry `xml:"mapEntry"`
+}
+
+type qtiMapEntry struct {
+	MapKey        string  `xml:"mapKey,attr"`
+	MappedValue   float64 `xml:"mappedValue,attr"`
+	CaseSensitive bool    `xml:"caseSensitive,attr"`
+}
+
+type qtiOutcomeDeclaration struct {
+	Identifier   string   `xml:"identifier,attr"`
+	Cardinality  string   `xml:"cardinality,attr"`
+	BaseType     string   `xml:"baseType,attr"`
+	DefaultValue []string `xml:"defaultValue>value,omitempty"`
+}
+
+type qtiItemBody struct {
+	ChoiceInteraction *qtiChoiceInteraction `xml:"choiceInteraction,omitempty"`
+	Paragraphs        []qtiParagraph        `xml:"p"`
+}
+
+type qtiChoiceInteraction struct {
+	ResponseIdentifier string            `xml:"responseIdentifier,attr"`
+	Shuffle            bool              `xml:"shuffle,attr"`
+	MaxChoices         int               `xml:"maxChoices,attr"`
+	Prompt             string            `xml:"prompt"`
+	SimpleChoices      []qtiSimpleChoice `xml:"simpleChoice"`
+}
+
+type qtiSimpleChoice struct {
+	Identifier string `xml:"identifier,attr"`
+	Text       string `xml:",chardata"`
+}
+
+type qtiParagraph struct {
+	Text                 string                   `xml:",chardata"`
+	TextEntryInteraction *qtiTextEntryInteraction `xml:"textEntryInteraction,omitempty"`
+}
+
+type qtiTextEntryInteraction struct {
+	ResponseIdentifier string `xml:"responseIdentifier,attr"`
+}
+
+type qtiResponseProcessing struct {
+	Template          string                `xml:"template,attr,omitempty"`
+	ResponseCondition *qtiResponseCondition `xml:"responseCondition,omitempty"`
+}
+
+type qtiResponseCondition struct {
+	ResponseIf qtiResponseIf `xml:"responseIf"`
+}
+
+type qtiResponseIf struct {
+	Equal           qtiEqual           `xml:"equal"`
+	SetOutcomeValue qtiSetOutcomeValue `xml:"setOutcomeValue"`
+}
+
+type qtiEqual struct {
+	ToleranceMode string      `xml:"toleranceMode,attr"`
+	Tolerance     string      `xml:"tolerance,attr"`
+	Variable      qtiVariable `xml:"variable"`
+	Correct       qtiVariable `xml:"correct"`
+}
+
+type qtiSetOutcomeValue struct {
+	Identifier string      `xml:"identifier,attr"`
+	Variable   qtiVariable `xml:"variable"`
+}
+
+type qtiVariable struct {
+	Identifier string `xml:"identifier,attr"`
+}
+
+// qtiItem is an assessment item while it is read, only the first response declaration is read
+type qtiItem struct {
+	line         int
+	title        string
+	cardinality  string
+	baseType     string
+	correct      []string
+	mapKeys      []string
+	maxScore     string
+	tolerance    string
+	interactions []string
+	maxChoices   int
+	choices      []qtiSimpleChoice
+	body         strings.Builder
+	declarations int
+	inMaxScore   bool
+}
+
+// EncodeQTI encode the questions as a zip package of QTI 2.1 items with an IMS content packaging manifest
+func EncodeQTI(questions []dto.QuizQuestion) ([]byte, error) {
+	manifest := qtiManifest{
+		Xmlns:      "http://www.imsglobal.org/xsd/imscp_v1p1",
+		Identifier: "questions",
+	}
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	var itemFiles [][]byte
+	for i, question := range questions {
+		item, err := xml.MarshalIndent(getQTIItem(fmt.Sprintf("item-%d", i+1), question), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		itemFiles = append(itemFiles, append([]byte(xml.Header), item...))
+		itemFile := fmt.Sprintf("items/item-%d.xml", i+1)
+		manifest.Resources = append(manifest.Resources, ccResource{
+			Identifier: fmt.Sprintf("resource-%d", i+1),
+			Type:       qtiItemResourceType,
+			Href:       itemFile,
+			Files:      []ccFile{{Href: itemFile}},
+		})
+	}
+	manifestData, err := xml.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	files := append([][]byte{append([]byte(xml.Header), manifestData...)}, itemFiles...)
+	names := []string{commonCartridgeManifest}
+	for _, resource := range manifest.Resources {
+		names = append(names, resource.Href)
+	}
+	for i, name := range names {
+		file, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = file.Write(files[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// DecodeQTI decode the items of a QTI 2.1 zip package or of a QTI xml file, the items of a package are the item
+// resources of its manifest or every xml file when it has none. An item that cannot be read is an error with the line
+// it starts on while the other items are still read
+func DecodeQTI(data []byte) ([]dto.QuizQuestionTransaction, []dto.QuizImportError, error) {
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		questions, importErrors := decodeQTIItems("", data, 0)
+		return questions, importErrors, nil
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, errors.New(constantError.ErrorQuizFileInvalid)
+	}
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		files[path.Clean(file.Name)] = file
+	}
+
+	var names []string
+	if file, ok := files[commonCartridgeManifest]; ok {
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, nil, errors.New(constantError.ErrorQuizFileInvalid)
+		}
+		var manifest qtiManifest
+		err = xml.Unmarshal(content, &manifest)
+		if err != nil {
+			return nil, nil, errors.New(constantError.ErrorQuizFileInvalid)
+		}
+		for _, resource := range manifest.Resources {
+			if !strings.HasPrefix(resource.Type, "imsqti_item") {
+				continue
+			}
+			href := resource.Href
+			if href == "" && len(resource.Files) > 0 {
+				href = resource.Files[0].Href
+			}
+			names = append(names, path.Clean(href))
+		}
+	}
+	if len(names) == 0 {
+		for name := range files {
+			if strings.HasSuffix(name, ".xml") && name != commonCartridgeManifest {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+
+	var questions []dto.QuizQuestionTransaction
+	var importErrors []dto.QuizImportError
+	var number int
+	for _, name := range names {
+		number++
+		file, ok := files[name]
+		if !ok {
+			importErrors = append(importErrors, dto.QuizImportError{File: name, Question: number, Message: "the file of the item is not in the package"})
+			continue
+		}
+		content, err := readZipFile(file)
+		if err != nil {
+			importErrors = append(importErrors, dto.QuizImportError{File: name, Question: number, Message: "the file of the item cannot be read"})
+			continue
+		}
+		fileQuestions, fileErrors := decodeQTIItems(name, content, number-1)
+		questions = append(questions, fileQuestions...)
+		importErrors = append(importErrors, fileErrors...)
+		number += len(fileQuestions) + len(fileErrors) - 1
+	}
+	return questions, importErrors, nil
+}
+
+// decodeQTIItems decode every assessment item of the xml, the items are numbered after the number of items before the file
+func decodeQTIItems(file string, data []byte, number int) ([]dto.QuizQuestionTransaction, []dto.QuizImportError) {
+	var questions []dto.QuizQuestionTransaction
+	var importErrors []dto.QuizImportError
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var item *qtiItem
+	var stack []string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			line, _ := decoder.InputPos()
+			var syntaxError *xml.SyntaxError
+			if errors.As(err, &syntaxError) {
+				line = syntaxError.Line
+			}
+			importErrors = append(importErrors, dto.QuizImportError{File: file, Line: line, Question: number + 1, Message: "the xml cannot be read: " + err.Error()})
+			break
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, element.Name.Local)
+			if element.Name.Local == "assessmentItem" {
+				line, _ := decoder.InputPos()
+				item = &qtiItem{line: line, title: getXMLAttr(element, "title")}
+			} else if item != nil {
+				item.start(element, stack)
+			}
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+			if element.Name.Local == "assessmentItem" && item != nil {
+				number++
+				question, err := item.getQuestion()
+				if err == nil {
+					err = checkImportedQuestion(&question)
+				}
+				if err != nil {
+					importErrors = append(importErrors, dto.QuizImportError{File: file, Line: item.line, Question: number, Message: err.Error()})
+				} else {
+					questions = append(questions, question)
+				}
+				item = nil
+			} else if item != nil {
+				item.end(element)
+			}
+		case xml.CharData:
+			if item != nil {
+				item.text(string(element), stack)
+			}
+		}
+	}
+	if len(questions) == 0 && len(importErrors) == 0 {
+		importErrors = append(importErrors, dto.QuizImportError{File: file, Line: 1, Question: number + 1, Message: "the xml has no assessment item"})
+	}
+	return questions, importErrors
+}
+
+func (item *qtiItem) start(element xml.StartElement, stack []string) {
+	switch name := element.Name.Local; {
+	case name == "responseDeclaration":
+		item.declarations++
+		if item.declarations == 1 {
+			item.cardinality = getXMLAttr(element, "cardinality")
+			item.baseType = getXMLAttr(element, "baseType")
+		}
+	case name == "mapEntry" && item.declarations == 1 && inXML(stack, "responseDeclaration"):
+		item.mapKeys = append(item.mapKeys, getXMLAttr(element, "mapKey"))
+	case name == "outcomeDeclaration":
+		item.inMaxScore = getXMLAttr(element, "identifier") == "MAXSCORE"
+	case name == "equal" && inXML(stack, "responseProcessing"):
+		item.tolerance = strings.Fields(getXMLAttr(element, "tolerance") + " 0")[0]
+	case name == "simpleChoice":
+		item.choices = append(item.choices, qtiSimpleChoice{Identifier: getXMLAttr(element, "identifier")})
+	case strings.HasSuffix(name, "Interaction"):
+		item.interactions = append(item.interactions, name)
+		item.maxChoices, _ = strconv.Atoi(getXMLAttr(element, "maxChoices"))
+	case inXML(stack, "itemBody"):
+		// the elements of the body are words apart
+		item.body.WriteString(" ")
+	}
+}
+
+func (item *qtiItem) end(element xml.EndElement) {
+	switch element.Name.Local {
+	case "outcomeDeclaration":
+		item.inMaxScore = false
+	default:
+		item.body.WriteString(" ")
+	}
+}
+
+func (item *qtiItem) text(text string, stack []string) {
+	switch {
+	case stack[len(stack)-1] == "value" && inXML(stack, "correctResponse") && item.declarations == 1:
+		item.correct = append(item.correct, strings.TrimSpace(text))
+	case stack[len(stack)-1] == "value" && item.inMaxScore:
+		item.maxScore = strings.TrimSpace(text)
+	case inXML(stack, "simpleChoice"):
+		item.choices[len(item.choices)-1].Text += text
+	case inXML(stack, "itemBody") && (!inInteraction(stack) || inXML(stack, "prompt")):
+		item.body.WriteString(text)
+	}
+}
+
+// getQuestion get the item as a question, a choice between true and false is a true/false question
+func (item *qtiItem) getQuestion() (dto.QuizQuestionTransaction, error) {
+	question := dto.QuizQuestionTransaction{Text: strings.Join(strings.Fields(item.body.String()), " ")}
+	if question.Text == "" {
+		question.Text = strings.TrimSpace(item.title)
+	}
+	if item.maxScore != "" {
+		points, err := strconv.ParseFloat(item.maxScore, 64)
+		if err != nil {
+			return dto.QuizQuestionTransaction{}, fmt.Errorf("the MAXSCORE %q is not a number", item.maxScore)
+		}
+		question.Points = points
+	}
+	if len(item.interactions) == 0 {
+		return dto.QuizQuestionTransaction{}, errors.New("the item has no interaction")
+	}
+	if len(item.interactions) > 1 {
+		return dto.QuizQuestionTransaction{}, errors.New("items with more than one interaction are not supported")
+	}
+
+	correct := map[string]bool{}
+	for _, value := range item.correct {
+		correct[value] = true
+	}
+	switch item.interactions[0] {
+	case "choiceInteraction":
+		for _, choice := range item.choices {
+			question.Options = append(question.Options, dto.QuizOptionTransaction{
+				Text:      strings.Join(strings.Fields(choice.Text), " "),
+				IsCorrect: correct[choice.Identifier],
+			})
+		}
+		question.Type = dto.QuizMultipleChoice
+		if item.cardinality == "single" || item.maxChoices == 1 {
+			question.Type = dto.QuizSingleChoice
+			if len(question.Options) == 2 && question.Options[0].IsCorrect != question.Options[1].IsCorrect {
+				first, errFirst := strconv.ParseBool(strings.ToLower(question.Options[0].Text))
+				second, errSecond := strconv.ParseBool(strings.ToLower(question.Options[1].Text))
+				if errFirst == nil && errSecond == nil && first != second {
+					question.Type = dto.QuizTrueFalse
+					question.Answer = strconv.FormatBool(first == question.Options[0].IsCorrect)
+					question.Options = nil
+				}
+			}
+		}
+	case "textEntryInteraction":
+		if item.baseType == "float" || item.baseType == "integer" {
+			if len(item.correct) == 0 {
+				return dto.QuizQuestionTransaction{}, errors.New("the item has no correct response")
+			}
+			question.Type = dto.QuizNumeric
+			question.Answer = item.correct[0]
+			if item.tolerance != "" {
+				tolerance, err := strconv.ParseFloat(item.tolerance, 64)
+				if err != nil {
+					return dto.QuizQuestionTransaction{}, fmt.Errorf("the tolerance %q is not a number", item.tolerance)
+				}
+				question.Tolerance = tolerance
+			}
+			break
+		}
+		question.Type = dto.QuizShortAnswer
+		accepted := map[string]bool{}
+		for _, answer := range append(item.correct, item.mapKeys...) {
+			if answer == "" || accepted[answer] {
+				continue
+			}
+			accepted[answer] = true
+			question.Options = append(question.Options, dto.QuizOptionTransaction{Text: answer, IsCorrect: true})
+		}
+	default:
+		return dto.QuizQuestionTransaction{}, fmt.Errorf("%s is not supported", item.interactions[0])
+	}
+	return question, nil
+}
+
+// getQTIItem get the question as an assessment item, a true/false question is a choice between true and false
+func getQTIItem(identifier string, question dto.QuizQuestion) qtiAssessmentItem {
+	item := qtiAssessmentItem{
+		Xmlns:      qtiNamespace,
+		Identifier: identifier,
+		Title:      question.Text,
+		ResponseDeclaration: qtiResponseDeclaration{
+			Identifier:  "RESPONSE",
+			Cardinality: "single",
+			BaseType:    "identifier",
+		},
+		OutcomeDeclarations: []qtiOutcomeDeclaration{
+			{Identifier: "SCORE", Cardinality: "single", BaseType: "float"},
+			{Identifier: "MAXSCORE", Cardinality: "single", BaseType: "float", DefaultValue: []string{strconv.FormatFloat(question.Points, 'f', -1, 64)}},
+		},
+		ResponseProcessing: qtiResponseProcessing{Template: qtiMatchCorrect},
+	}
+
+	options := question.Options
+	if question.Type == dto.QuizTrueFalse {
+		answer, _ := strconv.ParseBool(question.Answer)
+		options = []dto.QuizOption{{Text: "true", IsCorrect: answer}, {Text: "false", IsCorrect: !answer}}
+	}
+	switch question.Type {
+	case dto.QuizSingleChoice, dto.QuizMultipleChoice, dto.QuizTrueFalse:
+		interaction := &qtiChoiceInteraction{ResponseIdentifier: "RESPONSE", MaxChoices: 1, Prompt: question.Text}
+		if question.Type == dto.QuizMultipleChoice {
+			item.ResponseDeclaration.Cardinality = "multiple"
+			interaction.MaxChoices = 0
+		}
+		for i, option := range options {
+			choiceID := fmt.Sprintf("choice-%d", i+1)
+			interaction.SimpleChoices = append(interaction.SimpleChoices, qtiSimpleChoice{Identifier: choiceID, Text: option.Text})
+			if option.IsCorrect {
+				item.ResponseDeclaration.CorrectResponse = append(item.ResponseDeclaration.CorrectResponse, choiceID)
+			}
+		}
+		item.ItemBody.ChoiceInteraction = interaction
+	case dto.QuizShortAnswer:
+		item.ResponseDeclaration.BaseType = "string"
+		item.ResponseDeclaration.Mapping = &qtiMapping{}
+		for _, option := range options {
+			if len(item.ResponseDeclaration.CorrectResponse) == 0 {
+				item.ResponseDeclaration.CorrectResponse = []string{option.Text}
+			}
+			item.ResponseDeclaration.Mapping.MapEntries = append(item.ResponseDeclaration.Mapping.MapEntries, qtiMapEntry{MapKey: option.Text, MappedValue: question.Points})
+		}
+		item.ItemBody.Paragraphs = getQTIParagraphs(question.Text)
+		item.ResponseProcessing.Template = qtiMapResponse
+	case dto.QuizNumeric:
+		item.ResponseDeclaration.BaseType = "float"
+		item.ResponseDeclaration.CorrectResponse = []string{question.Answer}
+		item.ItemBody.Paragraphs = getQTIParagraphs(question.Text)
+		if question.Tolerance > 0 {
+			tolerance := strconv.FormatFloat(question.Tolerance, 'f', -1, 64)
+			item.ResponseProcessing = qtiResponseProcessing{ResponseCondition: &qtiResponseCondition{ResponseIf: qtiResponseIf{
+				Equal: qtiEqual{
+					ToleranceMode: "absolute",
+					Tolerance:     tolerance + " " + tolerance,
+					Variable:      qtiVariable{Identifier: "RESPONSE"},
+					Correct:       qtiVariable{Identifier: "RESPONSE"},
+				},
+				SetOutcomeValue: qtiSetOutcomeValue{Identifier: "SCORE", Variable: qtiVariable{Identifier: "MAXSCORE"}},
+			}}}
+		}
+	}
+	return item
+}
+
+// getQTIParagraphs get the text of the question with the text entry after it
+func getQTIParagraphs(text string) []qtiParagraph {
+	return []qtiParagraph{
+		{Text: text},
+		{TextEntryInteraction: &qtiTextEntryInteraction{ResponseIdentifier: "RESPONSE"}},
+	}
+}
+
+func getXMLAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func inXML(stack []string, name string) bool {
+	for _, element := range stack {
+		if element == name {
+			return true
+		}
+	}
+	return false
+}
+
+func inInteraction(stack []string) bool {
+	for _, element := range stack {
+		if strings.HasSuffix(element, "Interaction") {
+			return true
+		}
+	}
+	return false
+}
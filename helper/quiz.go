@@ -0,0 +1,317 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// quizNumericEpsilon absorbs the rounding of the float answers so 0.1+0.2 is still 0.3
+const quizNumericEpsilon = 1e-9
+
+// CheckQuizQuestion checks the options and the answer fit the type of the question,
+// the answer of a true/false question is normalized to true or false
+func CheckQuizQuestion(question *dto.QuizQuestionTransaction) error {
+	var correct int
+	for _, option := range question.Options {
+		if option.IsCorrect {
+			correct++
+		}
+	}
+	valid := false
+	switch question.Type {
+	case dto.QuizSingleChoice:
+		valid = len(question.Options) >= 2 && correct == 1
+	case dto.QuizMultipleChoice:
+		valid = len(question.Options) >= 2 && correct >= 1
+	case dto.QuizTrueFalse:
+		answer, err := strconv.ParseBool(strings.TrimSpace(question.Answer))
+		if err == nil && len(question.Options) == 0 {
+			question.Answer = strconv.FormatBool(answer)
+			valid = true
+		}
+	case dto.QuizShortAnswer:
+		valid = len(question.Options) >= 1
+	case dto.QuizNumeric:
+		_, err := strconv.ParseFloat(strings.TrimSpace(question.Answer), 64)
+		valid = err == nil && len(question.Options) == 0
+	}
+	if !valid {
+		return errors.New(constantError.ErrorQuizQuestion)
+	}
+	return nil
+}
+
+// DecodeQuizQuestions decode the questions of a GIFT or QTI file, the questions that cannot be read
+// are the errors of the import
+func DecodeQuizQuestions(format string, data []byte) ([]dto.QuizQuestionTransaction, []dto.QuizImportError, error) {
+	switch format {
+	case dto.QuizGIFT:
+		questions, importErrors := DecodeGIFT(data)
+		return questions, importErrors, nil
+	case dto.QuizQTI:
+		return DecodeQTI(data)
+	}
+	return nil, nil, errors.New(constantError.ErrorQuizFormat)
+}
+
+// GetQuizFormat is the format of a quiz file, a file without format is QTI when it is a zip or an xml file and GIFT otherwise
+func GetQuizFormat(format string, data []byte) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if bytes.HasPrefix(trimmed, []byte("PK")) || bytes.HasPrefix(trimmed, []byte("<")) {
+		return dto.QuizQTI
+	}
+	return dto.QuizGIFT
+}
+
+// EncodeQuizQuestions encode the questions as a GIFT or QTI file
+func EncodeQuizQuestions(format string, questions []dto.QuizQuestion) ([]byte, error) {
+	switch format {
+	case dto.QuizGIFT:
+		return EncodeGIFT(questions), nil
+	case dto.QuizQTI:
+		return EncodeQTI(questions)
+	}
+	return nil, errors.New(constantError.ErrorQuizFormat)
+}
+
+// checkImportedQuestion checks a question of a file like a question that is created and tells why it cannot be imported
+func checkImportedQuestion(question *dto.QuizQuestionTransaction) error {
+	if strings.TrimSpace(question.Text) == "" {
+		return errors.New("the question has no text")
+	}
+	if question.Points < 0 {
+		return errors.New("the points of the question are lower than zero")
+	}
+	if utf8.RuneCountInString(question.Topic) > 100 {
+		return errors.New("the category of the question is longer than 100 characters")
+	}
+	if CheckQuizQuestion(question) != nil {
+		return fmt.Errorf("the answers do not fit a %s question", strings.ReplaceAll(question.Type, "_", " "))
+	}
+	return nil
+}
+
+// GetQuizQuestionTransaction get the question of a course package as a question to create
+func GetQuizQuestionTransaction(question dto.CoursePackageQuizQuestion) dto.QuizQuestionTransaction {
+	quizQuestion := dto.QuizQuestionTransaction{
+		Topic:      question.Topic,
+		Difficulty: question.Difficulty,
+		Type:       question.Type,
+		Text:       question.Text,
+		Points:     question.Points,
+		Answer:     question.Answer,
+		Tolerance:  question.Tolerance,
+	}
+	for _, option := range question.Options {
+		quizQuestion.Options = append(quizQuestion.Options, dto.QuizOptionTransaction{
+			Text:      option.Text,
+			IsCorrect: option.IsCorrect,
+		})
+	}
+	return quizQuestion
+}
+
+// DrawQuizQuestions draws the questions of an attempt, the questions of the quiz come first and then every pool
+// draws its count of questions from its candidates that are not drawn yet. The same seed and candidates
+// always draw the same questions in the same order so the attempt can be drawn again to review it
+func DrawQuizQuestions(seed int64, quiz dto.Quiz, candidates [][]dto.QuizQuestion) ([]dto.QuizQuestion, error) {
+	random := rand.New(rand.NewSource(seed))
+	drawn := map[string]bool{}
+	var questions []dto.QuizQuestion
+	for _, question := range quiz.Questions {
+		drawn[question.ID] = true
+		questions = append(questions, question)
+	}
+	for i, pool := range quiz.Pools {
+		var undrawn []dto.QuizQuestion
+		if i < len(candidates) {
+			for _, question := range candidates[i] {
+				if !drawn[question.ID] {
+					undrawn = append(undrawn, question)
+				}
+			}
+		}
+		if len(undrawn) < pool.Count {
+			return nil, errors.New(constantError.ErrorQuizPoolQuestions)
+		}
+		random.Shuffle(len(undrawn), func(a, b int) {
+			undrawn[a], undrawn[b] = undrawn[b], undrawn[a]
+		})
+		for _, question := range undrawn[:pool.Count] {
+			drawn[question.ID] = true
+			questions = append(questions, question)
+		}
+	}
+	if quiz.ShuffleQuestions {
+		random.Shuffle(len(questions), func(a, b int) {
+			questions[a], questions[b] = questions[b], questions[a]
+		})
+	}
+	return questions, nil
+}
+
+// GradeQuizAttempt grades the answers of the questions of the attempt, a question is worth its points
+// only when the answer is completely correct and a question without answer is wrong
+func GradeQuizAttempt(attempt *dto.QuizAttempt, questions []dto.QuizQuestion, answers []dto.QuizAnswer, passingScore float64) {
+	questionByID := map[string]dto.QuizQuestion{}
+	for _, question := range questions {
+		questionByID[question.ID] = question
+	}
+	answerByID := map[string]dto.QuizAnswer{}
+	for _, answer := range answers {
+		answerByID[answer.QuestionID] = answer
+	}
+
+	attempt.Score = 0
+	attempt.MaxScore = 0
+	for i, attemptAnswer := range attempt.Answers {
+		question := questionByID[attemptAnswer.QuestionID]
+		answer, correct := gradeQuizQuestion(question, answerByID[attemptAnswer.QuestionID])
+		attempt.Answers[i].Answer = answer
+		attempt.Answers[i].IsCorrect = correct
+		attempt.Answers[i].Score = 0
+		if correct {
+			attempt.Answers[i].Score = question.Points
+			attempt.Score += question.Points
+		}
+		attempt.MaxScore += question.Points
+	}
+	attempt.Percentage = 0
+	if attempt.MaxScore > 0 {
+		attempt.Percentage = attempt.Score * 100 / attempt.MaxScore
+	}
+	attempt.IsPassed = attempt.Percentage >= passingScore
+}
+
+// GetQuizAnswers get the stored answers of the attempt as they were submitted
+func GetQuizAnswers(attempt dto.QuizAttempt) []dto.QuizAnswer {
+	var answers []dto.QuizAnswer
+	for _, attemptAnswer := range attempt.Answers {
+		answer := dto.QuizAnswer{
+			QuestionID: attemptAnswer.QuestionID,
+			Answer:     attemptAnswer.Answer,
+		}
+		if attemptAnswer.Answer != "" {
+			answer.OptionIDs = strings.Split(attemptAnswer.Answer, ",")
+		}
+		answers = append(answers, answer)
+	}
+	return answers
+}
+
+// GetQuizAttemptQuestions get the questions of the attempt with the answers of the customer, the correct answers are hidden
+// and the options are in the order of the seed of the attempt when the options are shuffled
+func GetQuizAttemptQuestions(attempt dto.QuizAttempt, questions []dto.QuizQuestion) []dto.QuizAttemptQuestion {
+	questionByID := map[string]dto.QuizQuestion{}
+	for _, question := range questions {
+		questionByID[question.ID] = question
+	}
+
+	attemptQuestions := []dto.QuizAttemptQuestion{}
+	for _, answer := range attempt.Answers {
+		question, ok := questionByID[answer.QuestionID]
+		if !ok {
+			continue
+		}
+		attemptQuestion := dto.QuizAttemptQuestion{
+			ID:         question.ID,
+			Type:       question.Type,
+			Text:       question.Text,
+			Points:     question.Points,
+			NoQuestion: answer.NoQuestion,
+			IsCorrect:  answer.IsCorrect,
+			Score:      answer.Score,
+		}
+		if isChoiceQuestion(question.Type) {
+			for _, option := range question.Options {
+				attemptQuestion.Options = append(attemptQuestion.Options, dto.QuizAttemptOption{
+					ID:   option.ID,
+					Text: option.Text,
+				})
+			}
+			if attempt.ShuffleOptions {
+				shuffleQuizOptions(attempt.Seed, question.ID, attemptQuestion.Options)
+			}
+			if answer.Answer != "" {
+				attemptQuestion.OptionIDs = strings.Split(answer.Answer, ",")
+			}
+		} else {
+			attemptQuestion.Answer = answer.Answer
+		}
+		attemptQuestions = append(attemptQuestions, attemptQuestion)
+	}
+	return attemptQuestions
+}
+
+// gradeQuizQuestion returns the answer as it is stored and if it is correct,
+// the answer of a choice question is the sorted ids of the chosen options of the question
+func gradeQuizQuestion(question dto.QuizQuestion, answer dto.QuizAnswer) (string, bool) {
+	if isChoiceQuestion(question.Type) {
+		chosen := map[string]bool{}
+		for _, optionID := range answer.OptionIDs {
+			chosen[optionID] = true
+		}
+		var optionIDs []string
+		correct := true
+		for _, option := range question.Options {
+			if chosen[option.ID] {
+				optionIDs = append(optionIDs, option.ID)
+			}
+			if chosen[option.ID] != option.IsCorrect {
+				correct = false
+			}
+		}
+		sort.Strings(optionIDs)
+		return strings.Join(optionIDs, ","), correct && len(optionIDs) > 0
+	}
+
+	text := strings.TrimSpace(answer.Answer)
+	if text == "" {
+		return "", false
+	}
+	switch question.Type {
+	case dto.QuizTrueFalse:
+		value, err := strconv.ParseBool(text)
+		expected, errExpected := strconv.ParseBool(question.Answer)
+		return text, err == nil && errExpected == nil && value == expected
+	case dto.QuizShortAnswer:
+		for _, option := range question.Options {
+			if strings.EqualFold(strings.Join(strings.Fields(text), " "), strings.Join(strings.Fields(option.Text), " ")) {
+				return text, true
+			}
+		}
+	case dto.QuizNumeric:
+		value, err := strconv.ParseFloat(text, 64)
+		expected, errExpected := strconv.ParseFloat(question.Answer, 64)
+		return text, err == nil && errExpected == nil && math.Abs(value-expected) <= question.Tolerance+quizNumericEpsilon
+	}
+	return text, false
+}
+
+// shuffleQuizOptions shuffles the options of a question with the seed of the attempt,
+// every question gets its own order and the order does not change when the attempt is read again
+func shuffleQuizOptions(seed int64, questionID string, options []dto.QuizAttemptOption) {
+	hash := fnv.New64a()
+	hash.Write([]byte(questionID))
+	random := rand.New(rand.NewSource(seed ^ int64(hash.Sum64())))
+	random.Shuffle(len(options), func(a, b int) {
+		options[a], options[b] = options[b], options[a]
+	})
+}
+
+func isChoiceQuestion(questionType string) bool {
+	return questionType == dto.QuizSingleChoice || questionType == dto.QuizMultipleChoice
+}
@@ -0,0 +1,86 @@
+package helper
+
+import "golang/models/dto"
+
+// get the sections of the course with their modules and the progress of the customer in every section,
+// the finished modules are empty when the user is not a customer
+func GetCourseSections(sections []dto.Section, modules []dto.Module, finishedModules []string) []dto.CourseSection {
+	finished := getFinishedModules(finishedModules)
+	var courseSections []dto.CourseSection
+	for _, section := range sections {
+		courseSection := dto.CourseSection{
+			ID:          section.ID,
+			Title:       section.Title,
+			Description: section.Description,
+			NoSection:   section.NoSection,
+			Modules:     []dto.Module{},
+		}
+		var moduleIDs []string
+		for _, module := range modules {
+			if module.SectionID == section.ID {
+				courseSection.Modules = append(courseSection.Modules, module)
+				moduleIDs = append(moduleIDs, module.ID)
+			}
+		}
+		courseSection.ProgressPercentage = getSectionProgress(moduleIDs, finished)
+		courseSections = append(courseSections, courseSection)
+	}
+	return courseSections
+}
+
+// group the modules of the course by section, the modules without section stay out of the sections
+func GetModuleSections(sections []dto.Section, modules []dto.ModuleCourse, finishedModules []string) dto.CourseModules {
+	finished := getFinishedModules(finishedModules)
+	courseModules := dto.CourseModules{
+		Sections: []dto.ModuleCourseSection{},
+		Modules:  []dto.ModuleCourse{},
+	}
+	inSection := map[string]bool{}
+	for _, section := range sections {
+		moduleSection := dto.ModuleCourseSection{
+			ID:          section.ID,
+			Title:       section.Title,
+			Description: section.Description,
+			NoSection:   section.NoSection,
+			Modules:     []dto.ModuleCourse{},
+		}
+		var moduleIDs []string
+		for _, module := range modules {
+			if module.SectionID == section.ID {
+				moduleSection.Modules = append(moduleSection.Modules, module)
+				moduleIDs = append(moduleIDs, module.ID)
+				inSection[module.ID] = true
+			}
+		}
+		moduleSection.ProgressPercentage = getSectionProgress(moduleIDs, finished)
+		courseModules.Sections = append(courseModules.Sections, moduleSection)
+	}
+	for _, module := range modules {
+		if !inSection[module.ID] {
+			courseModules.Modules = append(courseModules.Modules, module)
+		}
+	}
+	return courseModules
+}
+
+func getFinishedModules(moduleIDs []string) map[string]bool {
+	finished := map[string]bool{}
+	for _, moduleID := range moduleIDs {
+		finished[moduleID] = true
+	}
+	return finished
+}
+
+// get the percentage of the modules of the section the customer finished
+func getSectionProgress(moduleIDs []string, finished map[string]bool) float64 {
+	if len(moduleIDs) == 0 {
+		return 0
+	}
+	var count int
+	for _, moduleID := range moduleIDs {
+		if finished[moduleID] {
+			count++
+		}
+	}
+	return float64(count) * 100 / float64(len(moduleIDs))
+}
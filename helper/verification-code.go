@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"golang/models/dto"
+	"golang/util"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultCodeLength     = 6
+	defaultCodeExpiry     = 15 * time.Minute
+	defaultMaxAttempts    = 5
+	defaultResendCooldown = time.Minute
+)
+
+// GenerateVerificationCode generate a random numeric code with the given length
+func GenerateVerificationCode(length int) (string, error) {
+	if length <= 0 {
+		length = defaultCodeLength
+	}
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
+
+// CompareVerificationCode compare two codes in constant time
+func CompareVerificationCode(code, input string) bool {
+	return subtle.ConstantTimeCompare([]byte(code), []byte(input)) == 1
+}
+
+// GetVerificationPolicy read the verification policy from the config, missing values use the defaults
+func GetVerificationPolicy() dto.VerificationPolicy {
+	policy := DefaultVerificationPolicy()
+	if length, err := strconv.Atoi(util.GetConfig("VERIFICATION_CODE_LENGTH")); err == nil && length > 0 {
+		policy.CodeLength = length
+	}
+	if expiry, err := time.ParseDuration(util.GetConfig("VERIFICATION_CODE_EXPIRY")); err == nil && expiry > 0 {
+		policy.CodeExpiry = expiry
+	}
+	if attempts, err := strconv.Atoi(util.GetConfig("VERIFICATION_MAX_ATTEMPTS")); err == nil && attempts > 0 {
+		policy.MaxAttempts = attempts
+	}
+	if cooldown, err := time.ParseDuration(util.GetConfig("VERIFICATION_RESEND_COOLDOWN")); err == nil && cooldown >= 0 {
+		policy.ResendCooldown = cooldown
+	}
+	return policy
+}
+
+// DefaultVerificationPolicy is the verification policy used when nothing is configured
+func DefaultVerificationPolicy() dto.VerificationPolicy {
+	return dto.VerificationPolicy{
+		CodeLength:     defaultCodeLength,
+		CodeExpiry:     defaultCodeExpiry,
+		MaxAttempts:    defaultMaxAttempts,
+		ResendCooldown: defaultResendCooldown,
+	}
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"golang/app/routes"
+	"golang/repository/courseStatsRepository"
 	"golang/util"
 	"log"
+	"os"
 
 	_dbDriver "golang/drivers/mysql"
 )
@@ -22,8 +24,28 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+
+	// go run main.go rebuild-course-stats computes the stats of every course from scratch
+	if len(os.Args) > 1 && os.Args[1] == "rebuild-course-stats" {
+		err := rebuildCourseStats(courseStatsRepository.NewCourseStatsRepository(db))
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	app := routes.New(db)
 
 	log.Fatal(app.Start(util.GetConfig("APP_PORT")))
 	// app.Logger.Fatal(app.StartAutoTLS(":443"))
 }
+
+// rebuildCourseStats runs the rebuild-course-stats command
+func rebuildCourseStats(courseStatsRepo courseStatsRepository.CourseStatsRepository) error {
+	count, err := courseStatsRepo.RebuildCourseStats()
+	if err != nil {
+		return err
+	}
+	log.Printf("rebuild stats of %d courses", count)
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"golang/repository/courseStatsRepository/courseStatsMockRepository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildCourseStats(t *testing.T) {
+	testCase := []struct {
+		Name            string
+		MockReturnCount int
+		MockReturnError error
+		ExpectedError   error
+	}{
+		{"success rebuild course stats", 3, nil, nil},
+		{"fail rebuild course stats", 0, errors.New("error"), errors.New("error")},
+	}
+	for _, v := range testCase {
+		t.Run(v.Name, func(t *testing.T) {
+			mockRepo := &courseStatsMockRepository.CourseStatsMock{}
+			mockRepo.On("RebuildCourseStats").Return(v.MockReturnCount, v.MockReturnError)
+
+			err := rebuildCourseStats(mockRepo)
+			assert.Equal(t, v.ExpectedError, err)
+			mockRepo.AssertCalled(t, "RebuildCourseStats")
+		})
+	}
+}
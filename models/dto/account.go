@@ -0,0 +1,125 @@
+package dto
+
+import "time"
+
+// RetentionPolicy tells what is kept of the learning records when a customer deletes the account, the personal data is always removed
+type RetentionPolicy struct {
+	// KeepRatings keeps the score of the reviews so the course rating does not change, the testimonial is removed
+	KeepRatings bool
+	// KeepGrades keeps the grade of the submissions for the instructor records, the submitted file is removed
+	KeepGrades bool
+}
+
+type DeleteAccount struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type CustomerExport struct {
+	ExportedAt              time.Time                      `json:"exported_at"`
+	Profile                 CustomerProfile                `json:"profile"`
+	Enrollments             []ExportEnrollment             `json:"enrollments"`
+	Submissions             []ExportSubmission             `json:"submissions"`
+	Reviews                 []ExportReview                 `json:"reviews"`
+	Favorites               []ExportFavorite               `json:"favorites"`
+	QuizAttempts            []ExportQuizAttempt            `json:"quiz_attempts"`
+	ModuleProgress          []ExportModuleProgress         `json:"module_progress"`
+	ModuleViews             []ExportModuleView             `json:"module_views"`
+	MediaProgress           []ExportMediaProgress          `json:"media_progress"`
+	ProgressEvents          []ExportProgressEvent          `json:"progress_events"`
+	LearningPathCompletions []ExportLearningPathCompletion `json:"learning_path_completions"`
+}
+
+type ExportEnrollment struct {
+	CourseID   string    `json:"course_id"`
+	CourseName string    `json:"course_name"`
+	Status     bool      `json:"status"`
+	NoModule   int       `json:"no_module"`
+	IsFinish   bool      `json:"is_finish"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+type ExportSubmission struct {
+	AssignmentID    string     `json:"assignment_id"`
+	AssignmentTitle string     `json:"assignment_title"`
+	File            string     `json:"file"`
+	Status          string     `json:"status"`
+	Grade           float64    `json:"grade"`
+	Score           float64    `json:"score"`
+	Feedback        string     `json:"feedback"`
+	SubmittedAt     *time.Time `json:"submitted_at"`
+}
+
+type ExportReview struct {
+	CourseID    string    `json:"course_id"`
+	CourseName  string    `json:"course_name"`
+	Rating      int       `json:"rating"`
+	Testimonial string    `json:"testimonial"`
+	IsPublish   bool      `json:"is_publish"`
+	ReviewedAt  time.Time `json:"reviewed_at"`
+}
+
+type ExportFavorite struct {
+	CourseID    string    `json:"course_id"`
+	CourseName  string    `json:"course_name"`
+	FavoritedAt time.Time `json:"favorited_at"`
+}
+
+type ExportQuizAttempt struct {
+	AttemptID   string             `json:"attempt_id"`
+	QuizID      string             `json:"quiz_id"`
+	QuizTitle   string             `json:"quiz_title"`
+	Status      string             `json:"status"`
+	Score       float64            `json:"score"`
+	MaxScore    float64            `json:"max_score"`
+	Percentage  float64            `json:"percentage"`
+	IsPassed    bool               `json:"is_passed"`
+	StartedAt   time.Time          `json:"started_at"`
+	SubmittedAt *time.Time         `json:"submitted_at"`
+	Answers     []ExportQuizAnswer `json:"answers" gorm:"-"`
+}
+
+type ExportQuizAnswer struct {
+	AttemptID    string  `json:"-"`
+	QuestionID   string  `json:"question_id"`
+	QuestionText string  `json:"question_text"`
+	NoQuestion   int     `json:"no_question"`
+	Answer       string  `json:"answer"`
+	IsCorrect    bool    `json:"is_correct"`
+	Score        float64 `json:"score"`
+}
+
+type ExportModuleProgress struct {
+	CourseID    string    `json:"course_id"`
+	CourseName  string    `json:"course_name"`
+	ModuleID    string    `json:"module_id"`
+	ModuleName  string    `json:"module_name"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type ExportModuleView struct {
+	ModuleID   string    `json:"module_id"`
+	ModuleName string    `json:"module_name"`
+	ViewedAt   time.Time `json:"viewed_at"`
+}
+
+type ExportMediaProgress struct {
+	ModuleID      string    `json:"module_id"`
+	ModuleName    string    `json:"module_name"`
+	MediaModuleID string    `json:"media_module_id"`
+	Watched       float64   `json:"watched"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type ExportProgressEvent struct {
+	CourseID   string    `json:"course_id"`
+	CourseName string    `json:"course_name"`
+	ModuleID   string    `json:"module_id"`
+	Type       string    `json:"type"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ExportLearningPathCompletion struct {
+	LearningPathID    string    `json:"learning_path_id"`
+	LearningPathTitle string    `json:"learning_path_title"`
+	CompletedAt       time.Time `json:"completed_at"`
+}
@@ -14,16 +14,29 @@ type Assignment struct {
 	Title               string               `json:"title"`
 	Description         string               `json:"description"`
 	ModuleID            string               `json:"module_id"`
+	CourseID            string               `json:"course_id,omitempty"`
+	OpenAt              *time.Time           `json:"open_at"`
+	DueAt               *time.Time           `json:"due_at"`
+	CloseAt             *time.Time           `json:"close_at"`
+	MaxPoints           float64              `json:"max_points"`
+	MaxResubmissions    int                  `json:"max_resubmissions"`
+	LatePenalty         float64              `json:"late_penalty"`
 	CustomerAssignments []CustomerAssignment `json:"customer_assignments"`
 }
 type AssignmentCourse struct {
-	ID          string         `json:"id"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	ModuleID    string         `json:"module_id"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"deleted_at"`
+	ID               string         `json:"id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	ModuleID         string         `json:"module_id"`
+	OpenAt           *time.Time     `json:"open_at"`
+	DueAt            *time.Time     `json:"due_at"`
+	CloseAt          *time.Time     `json:"close_at"`
+	MaxPoints        float64        `json:"max_points"`
+	MaxResubmissions int            `json:"max_resubmissions"`
+	LatePenalty      float64        `json:"late_penalty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at"`
 }
 type GetAssignment struct {
 	ID                  string               `json:"id"`
@@ -37,8 +50,15 @@ type GetAssignment struct {
 }
 
 type AssignmentTransaction struct {
-	ID          string `json:"id" `
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	ModuleID    string `json:"module_id"  validate:"required"`
+	ID          string     `json:"id" `
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description" validate:"required"`
+	ModuleID    string     `json:"module_id"  validate:"required"`
+	OpenAt      *time.Time `json:"open_at"`
+	DueAt       *time.Time `json:"due_at"`
+	CloseAt     *time.Time `json:"close_at"`
+	// MaxPoints of 0 is the default of 100 points
+	MaxPoints        float64 `json:"max_points" validate:"min=0"`
+	MaxResubmissions int     `json:"max_resubmissions" validate:"min=0"`
+	LatePenalty      float64 `json:"late_penalty" validate:"min=0,max=100"`
 }
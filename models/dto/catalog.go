@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+const (
+	// CatalogSortNewest sorts the catalog by the newest course
+	CatalogSortNewest = "newest"
+	// CatalogSortPopular sorts the catalog by the amount of enrolled customers
+	CatalogSortPopular = "popular"
+	// CatalogSortTopRated sorts the catalog by the average rating
+	CatalogSortTopRated = "top_rated"
+
+	// DefaultCatalogLimit is the page size when the request has no limit
+	DefaultCatalogLimit = 20
+)
+
+// CourseCatalogQuery is the query string of the course catalog, the prices are the price after discount
+type CourseCatalogQuery struct {
+	Page       int     `query:"page" validate:"omitempty,min=1"`
+	Limit      int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor     string  `query:"cursor"`
+	Search     string  `query:"search"`
+	CategoryID string  `query:"category_id"`
+	MinPrice   float64 `query:"min_price" validate:"omitempty,min=0"`
+	MaxPrice   float64 `query:"max_price" validate:"omitempty,min=0"`
+	Free       bool    `query:"free"`
+	Discounted bool    `query:"discounted"`
+	MinRating  float64 `query:"min_rating" validate:"omitempty,min=0,max=5"`
+	Sort       string  `query:"sort" validate:"omitempty,oneof=newest popular top_rated"`
+}
+
+// CatalogCursor points to the last course of a catalog page
+type CatalogCursor struct {
+	Sort      string    `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	Value     float64   `json:"v"`
+	ID        string    `json:"i"`
+}
+
+type CatalogPagination struct {
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor"`
+}
+
+type CourseCatalog struct {
+	Courses    []GetCourse       `json:"courses"`
+	Pagination CatalogPagination `json:"pagination"`
+}
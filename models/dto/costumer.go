@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type Costumer struct {
 	ID           string `json:"id"`
 	Name         string `json:"name"`
@@ -34,15 +36,16 @@ type CostumerLogin struct {
 }
 
 type CustomerVerif struct {
-	Email string `json:"email"`
-	Code  string `json:"code"`
+	Email string `json:"email" validate:"required,email"`
+	Code  string `json:"code" validate:"required"`
 }
 
 type CostumerResponse struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Token string `json:"token"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 type CustomerEnroll struct {
@@ -61,3 +64,12 @@ type CustomerCourseEnroll struct {
 	ProfileImage string `json:"profile_image"`
 	StatusEnroll bool   `json:"status_enroll"`
 }
+
+type CustomerAccount struct {
+	ID           string    `json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	ProfileImage string    `json:"profile_image"`
+	IsActive     bool      `json:"is_active"`
+}
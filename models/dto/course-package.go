@@ -0,0 +1,115 @@
+package dto
+
+import "time"
+
+const (
+	// CoursePackageVersion is the version of the package format made by the export, the import refuses newer versions
+	CoursePackageVersion = 1
+	// CoursePackageJSON is the package as one json file
+	CoursePackageJSON = "json"
+	// CoursePackageZIP is the package as a zip with the json file and an IMS Common Cartridge manifest
+	CoursePackageZIP = "zip"
+)
+
+// CoursePackage is a course with all its content that can be moved to another environment,
+// the category is referenced by name because the ids are different in every environment
+type CoursePackage struct {
+	FormatVersion int                    `json:"format_version"`
+	ExportedAt    time.Time              `json:"exported_at"`
+	Course        CoursePackageCourse    `json:"course"`
+	Sections      []CoursePackageSection `json:"sections,omitempty"`
+	Modules       []CoursePackageModule  `json:"modules"`
+	QuestionBanks []CoursePackageBank    `json:"question_banks,omitempty"`
+	Quizzes       []CoursePackageQuiz    `json:"quizzes"`
+}
+
+type CoursePackageCourse struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Objective   string  `json:"objective"`
+	Price       float64 `json:"price"`
+	Discount    float64 `json:"discount"`
+	Thumbnail   string  `json:"thumbnail"`
+	Capacity    int     `json:"capacity"`
+	Category    string  `json:"category"`
+}
+
+// CoursePackageSection is a section of the package, the modules refer to the number of their section
+type CoursePackageSection struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type CoursePackageModule struct {
+	Name       string                   `json:"name"`
+	Content    string                   `json:"content"`
+	NoModule   int                      `json:"no_module"`
+	Section    int                      `json:"section,omitempty"`
+	Media      []string                 `json:"media"`
+	Assignment *CoursePackageAssignment `json:"assignment,omitempty"`
+}
+
+// CoursePackageAssignment is an assignment of a module with its policy, the dates are not in the package
+// because they belong to a run of the course
+type CoursePackageAssignment struct {
+	Title            string  `json:"title"`
+	Description      string  `json:"description"`
+	MaxPoints        float64 `json:"max_points,omitempty"`
+	MaxResubmissions int     `json:"max_resubmissions,omitempty"`
+	LatePenalty      float64 `json:"late_penalty,omitempty"`
+}
+
+// CoursePackageBank is a question bank of the package, the pools of the quizzes refer to the number of their bank
+type CoursePackageBank struct {
+	Title       string                      `json:"title"`
+	Description string                      `json:"description,omitempty"`
+	Questions   []CoursePackageQuizQuestion `json:"questions,omitempty"`
+}
+
+// CoursePackageQuiz is a quiz of the package, the module is the number of the module of the quiz
+// and a quiz made before the native quizzes only has a link
+type CoursePackageQuiz struct {
+	Title            string                      `json:"title,omitempty"`
+	Description      string                      `json:"description,omitempty"`
+	Link             string                      `json:"link"`
+	Module           int                         `json:"module,omitempty"`
+	TimeLimit        int                         `json:"time_limit,omitempty"`
+	PassingScore     float64                     `json:"passing_score,omitempty"`
+	MaxAttempts      int                         `json:"max_attempts,omitempty"`
+	ShuffleQuestions bool                        `json:"shuffle_questions,omitempty"`
+	ShuffleOptions   bool                        `json:"shuffle_options,omitempty"`
+	Questions        []CoursePackageQuizQuestion `json:"questions,omitempty"`
+	Pools            []CoursePackageQuizPool     `json:"pools,omitempty"`
+}
+
+type CoursePackageQuizQuestion struct {
+	Type       string                    `json:"type"`
+	Text       string                    `json:"text"`
+	Points     float64                   `json:"points"`
+	Topic      string                    `json:"topic,omitempty"`
+	Difficulty string                    `json:"difficulty,omitempty"`
+	Answer     string                    `json:"answer,omitempty"`
+	Tolerance  float64                   `json:"tolerance,omitempty"`
+	Options    []CoursePackageQuizOption `json:"options,omitempty"`
+}
+
+type CoursePackageQuizPool struct {
+	Bank       int    `json:"bank"`
+	Topic      string `json:"topic,omitempty"`
+	Difficulty string `json:"difficulty,omitempty"`
+	Count      int    `json:"count"`
+}
+
+type CoursePackageQuizOption struct {
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// CourseImport is the course made by an import, the name and category of the package are replaced when they are given
+// and the conflicts are the reasons the package cannot be imported
+type CourseImport struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	CategoryID string   `json:"category_id"`
+	Conflicts  []string `json:"conflicts,omitempty"`
+}
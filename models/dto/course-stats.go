@@ -0,0 +1,16 @@
+package dto
+
+// CourseStats holds the aggregates of a course, Rating1 to Rating5 is the number of ratings for every star
+type CourseStats struct {
+	CourseID       string  `json:"-"`
+	RatingAverage  float64 `json:"rating_average"`
+	RatingCount    int     `json:"rating_count"`
+	Rating1        int     `json:"rating_1"`
+	Rating2        int     `json:"rating_2"`
+	Rating3        int     `json:"rating_3"`
+	Rating4        int     `json:"rating_4"`
+	Rating5        int     `json:"rating_5"`
+	EnrolledCount  int     `json:"enrolled_count"`
+	CompletedCount int     `json:"completed_count"`
+	ModuleCount    int     `json:"module_count"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type CourseVersion struct {
+	ID        string                `json:"id"`
+	CreatedAt time.Time             `json:"created_at"`
+	CourseID  string                `json:"course_id"`
+	Version   int                   `json:"version"`
+	Modules   []CourseVersionModule `json:"modules,omitempty" gorm:"foreignKey:CourseVersionID"`
+}
+
+type CourseVersionModule struct {
+	ID              string               `json:"-"`
+	CourseVersionID string               `json:"-"`
+	ModuleID        string               `json:"module_id"`
+	NoModule        int                  `json:"no_module"`
+	Name            string               `json:"name"`
+	Content         string               `json:"content"`
+	Media           []CourseVersionMedia `json:"media" gorm:"foreignKey:CourseVersionModuleID"`
+}
+
+type CourseVersionMedia struct {
+	ID                    string `json:"-"`
+	CourseVersionModuleID string `json:"-"`
+	MediaModuleID         string `json:"media_module_id"`
+	Url                   string `json:"url"`
+}
+
+// EnrollmentVersion is the version of the course the customer learns, the customer can migrate when a newer version is published
+type EnrollmentVersion struct {
+	CourseID        string `json:"course_id"`
+	PinnedVersion   int    `json:"pinned_version"`
+	LatestVersion   int    `json:"latest_version"`
+	UpdateAvailable bool   `json:"update_available"`
+	NoModule        int    `json:"no_module"`
+	IsFinish        bool   `json:"is_finish"`
+}
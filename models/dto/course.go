@@ -6,6 +6,17 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// CourseStatusDraft is a course the instructor is still working on, only the instructor can see it
+	CourseStatusDraft = "draft"
+	// CourseStatusInReview is a course waiting for an admin to publish it
+	CourseStatusInReview = "in_review"
+	// CourseStatusPublished is a course the customers can find and enroll
+	CourseStatusPublished = "published"
+	// CourseStatusArchived is a course the enrolled customers can still learn, but no new customer can enroll
+	CourseStatusArchived = "archived"
+)
+
 type Course struct {
 	ID                 string           `json:"id"`
 	CreatedAt          time.Time        `json:"created_at"`
@@ -21,6 +32,9 @@ type Course struct {
 	InstructorID       string           `json:"instructor_id"`
 	CategoryID         string           `json:"category_id"`
 	Category           Category         `json:"category"`
+	Status             string           `json:"status"`
+	Version            int              `json:"version"`
+	IsTemplate         bool             `json:"is_template"`
 	Rating             float64          `json:"rating"`
 	Favorite           bool             `json:"favorite"`
 	NumberOfModules    int              `json:"number_of_modules"`
@@ -33,6 +47,8 @@ type Course struct {
 	Favorites          []Favorite       `json:"favorites"`
 	Ratings            []Rating         `json:"ratings"`
 	Modules            []Module         `json:"modules"`
+	Sections           []Section        `json:"sections"`
+	Stats              CourseStats      `json:"stats" gorm:"foreignKey:CourseID"`
 }
 
 type GetCourseCategory struct {
@@ -50,10 +66,15 @@ type GetCourseCategory struct {
 	InstructorID    string           `json:"instructor_id"`
 	CategoryID      string           `json:"category_id"`
 	Category        Category         `json:"category"`
+	Status          string           `json:"status"`
+	Version         int              `json:"version"`
+	IsTemplate      bool             `json:"is_template"`
 	CustomerCourses []CustomerCourse `json:"customer_courses" gorm:"foreignKey:CourseID"` // foreignKey:CourseID is not needed
 	Favorites       []Favorite       `json:"favorites" gorm:"foreignKey:CourseID"`        // foreignKey:CourseID is not needed
 	Ratings         []Rating         `json:"ratings" gorm:"foreignKey:CourseID"`          // foreignKey:CourseID is not needed
 	Modules         []Module         `json:"modules" gorm:"foreignKey:CourseID"`          // foreignKey:CourseID is not needed
+	Sections        []Section        `json:"sections" gorm:"foreignKey:CourseID"`
+	Stats           CourseStats      `json:"stats" gorm:"foreignKey:CourseID"`
 }
 
 type CourseTransaction struct {
@@ -80,6 +101,8 @@ type GetCourse struct {
 	Capacity           int      `json:"capacity"`
 	InstructorID       string   `json:"instructor_id"`
 	Category           Category `json:"category"`
+	Status             string   `json:"status"`
+	IsTemplate         bool     `json:"is_template"`
 	Rating             float64  `json:"rating"`
 	Favorite           bool     `json:"favorite"`
 	StatusEnroll       bool     `json:"status_enroll"`
@@ -120,6 +143,9 @@ type GetCourseByID struct {
 	Capacity           int                 `json:"capacity"`
 	InstructorID       string              `json:"instructor_id"`
 	Category           Category            `json:"category"`
+	Status             string              `json:"status"`
+	Version            int                 `json:"version"`
+	IsTemplate         bool                `json:"is_template"`
 	Rating             float64             `json:"rating"`
 	Favorite           bool                `json:"favorite"`
 	NumberOfModules    int                 `json:"number_of_modules"`
@@ -127,8 +153,10 @@ type GetCourseByID struct {
 	ProgressModule     int                 `json:"progress_module"`
 	ProgressPercentage float64             `json:"progress_percentage"`
 	IsFinish           bool             `json:"is_finish"`
+	Stats              CourseStats         `json:"stats"`
 	Ratings            []Rating            `json:"ratings" gorm:"foreignKey:CourseID"` // foreignKey:CourseID is not needed
 	Modules            []Module `json:"modules" gorm:"foreignKey:CourseID"` // foreignKey:CourseID is not needed
+	Sections           []CourseSection `json:"sections"`
 }
 
 type GetCourseInstructor struct {
@@ -142,6 +170,7 @@ type GetCourseInstructor struct {
 	Capacity        int      `json:"capacity"`
 	InstructorID    string   `json:"instructor_id"`
 	Category        Category `json:"category" gorm:"references:CategoryID"`
+	Status          string   `json:"status"`
 	Rating          float64  `json:"rating"`
 	NumberOfModules int      `json:"number_of_modules"`
 	AmountCustomer int `json:"amount_customer"`
@@ -172,6 +201,8 @@ type GetCourseInstructorByID struct {
 	Capacity        int                 `json:"capacity"`
 	InstructorID    string              `json:"instructor_id"`
 	Category        Category            `json:"category" gorm:"references:CategoryID"`
+	Status          string              `json:"status"`
+	Version         int                 `json:"version"`
 	Rating          float64             `json:"rating"`
 	NumberOfModules int                 `json:"number_of_modules"`
 	Modules         []Module `json:"modules" gorm:"foreignKey:CourseID"` // foreignKey:CourseID is not needed
@@ -192,9 +223,42 @@ type CourseCustomerEnroll struct {
 	InstructorID    string           `json:"instructor_id"`
 	CategoryID      string           `json:"category_id"`
 	Category        Category         `json:"category"`
+	Status          string           `json:"status"`
 	StatusEnroll    bool             `json:"status_enroll"`
 	CustomerCourses []CustomerCourse `json:"customer_courses" gorm:"foreignKey:CourseID"` // foreignKey:CourseID is not needed
 	Favorites       []Favorite       `json:"favorites" gorm:"foreignKey:CourseID"`        // foreignKey:CourseID is not needed
 	Ratings         []Rating         `json:"ratings" gorm:"foreignKey:CourseID"`          // foreignKey:CourseID is not needed
 	Modules         []Module         `json:"modules" gorm:"foreignKey:CourseID"`          // foreignKey:CourseID is not needed
+	Stats           CourseStats      `json:"stats" gorm:"foreignKey:CourseID"`
+}
+
+type CourseStatus struct {
+	Status string `json:"status" validate:"required,oneof=draft in_review published archived"`
+}
+
+// CourseDuplicate is the copy of a course, the name must be new because the names of courses are unique
+// and the category of the copied course is used when no category is given
+type CourseDuplicate struct {
+	ID           string `json:"id"`
+	Name         string `json:"name" validate:"required"`
+	CategoryID   string `json:"category_id" validate:"omitempty,alphanum"`
+	InstructorID string `json:"instructor_id"`
+	IsTemplate   bool   `json:"is_template"`
+}
+
+// CourseContent is the number of modules and media of a course, a course needs both to be published
+type CourseContent struct {
+	Modules      int64
+	MediaModules int64
+}
+
+// CoursePrerequisite is a course the customer must finish before they take the course
+type CoursePrerequisite struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	IsFinish bool   `json:"is_finish"`
+}
+
+type CoursePrerequisiteTransaction struct {
+	PrerequisiteID string `json:"prerequisite_id" validate:"required"`
 }
@@ -6,34 +6,64 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// AssignmentDraft is a submission the customer has not submitted yet
+	AssignmentDraft = "draft"
+	// AssignmentSubmitted is a submission submitted before the due time
+	AssignmentSubmitted = "submitted"
+	// AssignmentLate is a submission submitted after the due time, its grade gets the late penalty
+	AssignmentLate = "late"
+	// AssignmentGraded is a submission graded by the instructor, it cannot be submitted again
+	AssignmentGraded = "graded"
+	// AssignmentReturned is a submission the instructor sent back to the customer to submit again
+	AssignmentReturned = "returned"
+)
+
 type CustomerAssignment struct {
-	ID           string         `json:"id"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"deleted_at"`
-	File         string         `json:"file"`
-	Grade        int            `json:"grade" gorm:"notNull"`
-	AssignmentID string         `json:"assignment_id"`
-	CustomerID   string         `json:"customer_id"`
+	ID           string                      `json:"id"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt              `json:"deleted_at"`
+	File         string                      `json:"file"`
+	AssignmentID string                      `json:"assignment_id"`
+	CustomerID   string                      `json:"customer_id"`
+	CustomerName string                      `json:"customer_name,omitempty"`
+	Status       string                      `json:"status"`
+	Attempts     int                         `json:"attempts"`
+	SubmittedAt  *time.Time                  `json:"submitted_at"`
+	LatePenalty  float64                     `json:"late_penalty"`
+	Grade        float64                     `json:"grade"`
+	Score        float64                     `json:"score"`
+	Feedback     string                      `json:"feedback"`
+	GradedAt     *time.Time                  `json:"graded_at"`
+	Versions     []CustomerAssignmentVersion `json:"versions,omitempty"`
 }
-type CustomerAssignmentAcc struct {
-	ID           string         `json:"id"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"deleted_at"`
-	File         string         `json:"file"`
-	Grade        int            `json:"grade" gorm:"notNull"`
-	AssignmentID string         `json:"assignment_id"`
-	CustomerID   string         `json:"customer_id"`
-	Customer     struct {
-		Name string `json:"name" gorm:"notNull;size:255"`
-	} `json:"customer"`
+
+type CustomerAssignmentVersion struct {
+	ID                   string    `json:"id"`
+	CustomerAssignmentID string    `json:"customer_assignment_id"`
+	NoVersion            int       `json:"no_version"`
+	File                 string    `json:"file"`
+	Status               string    `json:"status"`
+	LatePenalty          float64   `json:"late_penalty"`
+	SubmittedAt          time.Time `json:"submitted_at"`
 }
 
+// CustomerAssignmentTransaction is the file of a draft or of a submission, a submission without file submits the draft
 type CustomerAssignmentTransaction struct {
-	ID           string `json:"id" `
-	File         string `json:"file" validate:"required"`
-	Grade        int    `json:"grade" gorm:"notNull" validate:"required"`
 	AssignmentID string `json:"assignment_id" validate:"required"`
-	CustomerID   string `json:"customer_id" validate:"required"`
+	File         string `json:"file"`
+}
+
+// AssignmentGrade is the grade of a submission, only the instructor of the assignment can grade it
+type AssignmentGrade struct {
+	ID       string  `json:"id"`
+	Grade    float64 `json:"grade" validate:"min=0"`
+	Feedback string  `json:"feedback"`
+}
+
+// AssignmentReturn sends a submission back to the customer with the feedback of the instructor
+type AssignmentReturn struct {
+	ID       string `json:"id"`
+	Feedback string `json:"feedback" validate:"required"`
 }
@@ -16,6 +16,7 @@ type CustomerCourse struct {
 	Status     bool           `json:"status"`
 	NoModule   int            `json:"no_module"`
 	IsFinish   bool           `json:"is_finish"`
+	CourseVersionID string `json:"course_version_id"`
 }
 
 type CustomerCourseTransaction struct {
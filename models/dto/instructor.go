@@ -1,11 +1,24 @@
 package dto
 
+import "time"
+
+const (
+	// InstructorStatusPending is an instructor waiting for an admin to approve the account
+	InstructorStatusPending = "pending"
+	// InstructorStatusApproved is an instructor that can publish courses
+	InstructorStatusApproved = "approved"
+	// InstructorStatusSuspended is an instructor that can no longer publish courses
+	InstructorStatusSuspended = "suspended"
+)
+
 type Instructor struct {
 	ID           string `json:"id"`
 	Name         string `json:"name"`
 	Email        string `json:"email"`
 	Password     string `json:"password"`
 	ProfileImage string `json:"profile_image"`
+	IsActive     bool   `json:"is_active"`
+	Status       string `json:"status"`
 }
 
 type InstructorResponseGet struct {
@@ -15,14 +28,29 @@ type InstructorResponseGet struct {
 	Password     string `json:"password"`
 	ProfileImage string `json:"profile_image"`
 	Role         string `json:"role"`
+	Headline     string `json:"headline"`
+	Bio          string `json:"bio"`
+	IsActive     bool   `json:"is_active"`
+	Status       string `json:"status"`
 }
 
 type InstructorRegister struct {
-	ID           string `json:"id"`
-	Name         string `json:"name" validate:"required"`
-	Email        string `json:"email" validate:"required,email"`
-	Password     string `json:"password" validate:"required"`
-	ProfileImage string `json:"profile_image"`
+	ID               string `json:"id"`
+	Name             string `json:"name" validate:"required"`
+	Email            string `json:"email" validate:"required,email"`
+	Password         string `json:"password" validate:"required"`
+	ProfileImage     string `json:"profile_image"`
+	Status           string `json:"-"`
+	InstructorCodeID string `json:"-"`
+}
+
+type InstructorVerif struct {
+	Email string `json:"email" validate:"required,email"`
+	Code  string `json:"code" validate:"required"`
+}
+
+type InstructorStatus struct {
+	Status string `json:"status" validate:"required,oneof=approved suspended"`
 }
 
 type InstructorLogin struct {
@@ -32,7 +60,18 @@ type InstructorLogin struct {
 }
 
 type InstructorResponse struct {
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Token string `json:"token"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type InstructorAccount struct {
+	ID           string    `json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	ProfileImage string    `json:"profile_image"`
+	IsActive     bool      `json:"is_active"`
+	Status       string    `json:"status"`
 }
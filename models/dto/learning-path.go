@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// LearningPath is an ordered list of courses, the progress is the percentage of the courses the customer finished
+type LearningPath struct {
+	ID                 string               `json:"id"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+	Title              string               `json:"title"`
+	Description        string               `json:"description"`
+	InstructorID       string               `json:"instructor_id"`
+	Courses            []LearningPathCourse `json:"courses"`
+	ProgressPercentage float64              `json:"progress_percentage"`
+	IsFinish           bool                 `json:"is_finish"`
+	CompletedAt        *time.Time           `json:"completed_at"`
+}
+
+type LearningPathCourse struct {
+	CourseID     string `json:"course_id"`
+	Name         string `json:"name"`
+	NoCourse     int    `json:"no_course"`
+	StatusEnroll bool   `json:"status_enroll"`
+	IsFinish     bool   `json:"is_finish"`
+}
+
+type LearningPathTransaction struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title" validate:"required"`
+	Description  string   `json:"description"`
+	InstructorID string   `json:"instructor_id"`
+	CourseIDs    []string `json:"course_ids" validate:"required,min=1"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+const (
+	// MailStatusPending is a mail waiting in the outbox to be delivered
+	MailStatusPending = "pending"
+	// MailStatusSending is a mail claimed by a worker that is delivering it
+	MailStatusSending = "sending"
+	// MailStatusSent is a mail that has been delivered
+	MailStatusSent = "sent"
+	// MailStatusFailed is a mail that could not be delivered after every attempt
+	MailStatusFailed = "failed"
+)
+
+type Mail struct {
+	ID            string     `json:"id"`
+	Recipient     string     `json:"recipient"`
+	Subject       string     `json:"subject"`
+	HTML          string     `json:"html"`
+	Text          string     `json:"text"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error"`
+	LockedUntil   *time.Time `json:"locked_until"`
+	SentAt        *time.Time `json:"sent_at"`
+}
@@ -14,6 +14,7 @@ type Module struct {
 	Name      string `json:"name"`
 	Content   string `json:"content"`
 	CourseID  string `json:"course_id"`
+	SectionID string `json:"section_id"`
 	NoModule  int    `json:"no_module"`
 }
 
@@ -30,6 +31,7 @@ type ModuleCourse struct {
 		Description string `json:"description"`
 		Objective   string `json:"objective"`
 	} `json:"course"`
+	SectionID    string `json:"section_id"`
 	MediaModules []MediaModule
 	Assignment   Assignment
 	NoModule     int `json:"no_module"`
@@ -42,6 +44,7 @@ type ModuleAcc struct {
 	Name         string `json:"name"`
 	Content      string `json:"content"`
 	CourseID     string `json:"course_id"`
+	SectionID    string `json:"section_id"`
 	MediaModules []MediaModule
 	Assignment   Assignment
 	NoModule     int `json:"no_module"`
@@ -59,17 +62,24 @@ type ModuleCourseAcc struct {
 		Description string `json:"description"`
 		Objective   string `json:"objective"`
 	} `json:"course"`
+	SectionID    string `json:"section_id"`
 	MediaModules []MediaModule
 	Assignment   Assignment
 	NoModule     int `json:"no_module"`
 }
 
 type ModuleTransaction struct {
-	ID            string `json:"id"`
-	Name          string `json:"name" validate:"required"`
-	Content       string `json:"content" validate:"required"`
-	CourseID      string `json:"course_id" validate:"required"`
-	NoModule      int    `json:"no_module" validate:"required"`
-	MediaModuleID string `json:"media_module_id"`
-	Url           string `json:"url" validate:"required"`
+	ID            string  `json:"id"`
+	Name          string  `json:"name" validate:"required"`
+	Content       string  `json:"content" validate:"required"`
+	CourseID      string  `json:"course_id" validate:"required"`
+	NoModule      int     `json:"no_module" validate:"omitempty,min=1"`
+	SectionID     *string `json:"section_id"`
+	MediaModuleID string  `json:"media_module_id"`
+	Url           string  `json:"url" validate:"required"`
+}
+
+// ModuleOrder is the new order of the modules of a course, every module of the course must be in it once
+type ModuleOrder struct {
+	ModuleIDs []string `json:"module_ids" validate:"required,min=1"`
 }
@@ -0,0 +1,71 @@
+package dto
+
+import "time"
+
+type EmailChange struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	NewEmail  string    `json:"new_email"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Attempts  int       `json:"attempts"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+type ChangeEmail struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type VerifyEmailChange struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type CustomerProfile struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	ProfileImage string `json:"profile_image"`
+}
+
+type CustomerProfileUpdate struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type InstructorProfile struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	ProfileImage string `json:"profile_image"`
+	Headline     string `json:"headline"`
+	Bio          string `json:"bio"`
+	Status       string `json:"status"`
+}
+
+type InstructorProfileUpdate struct {
+	Name     string `json:"name" validate:"required"`
+	Headline string `json:"headline" validate:"max=255"`
+	Bio      string `json:"bio"`
+}
+
+type InstructorPublicProfile struct {
+	ID           string                   `json:"id"`
+	Name         string                   `json:"name"`
+	ProfileImage string                   `json:"profile_image"`
+	Headline     string                   `json:"headline"`
+	Bio          string                   `json:"bio"`
+	Rating       float64                  `json:"rating"`
+	TotalRating  int                      `json:"total_rating"`
+	Courses      []InstructorPublicCourse `json:"courses"`
+}
+
+type InstructorPublicCourse struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Thumbnail       string  `json:"thumbnail"`
+	Price           float64 `json:"price"`
+	Discount        float64 `json:"discount"`
+	Rating          float64 `json:"rating"`
+	NumberOfModules int     `json:"number_of_modules"`
+}
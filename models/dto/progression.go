@@ -0,0 +1,72 @@
+package dto
+
+import "time"
+
+const (
+	// ModuleRuleViewed is met when the customer opened the module
+	ModuleRuleViewed = "viewed"
+	// ModuleRuleMediaWatched is met when the customer watched the minimum percentage of the media
+	ModuleRuleMediaWatched = "media_watched"
+	// ModuleRuleAssignmentSubmitted is met when the customer submitted the assignment of the module
+	ModuleRuleAssignmentSubmitted = "assignment_submitted"
+	// ModuleRuleAssignmentPassed is met when the assignment of the module is graded with the minimum score
+	ModuleRuleAssignmentPassed = "assignment_passed"
+	// ModuleRuleQuizPassed is met when the customer passed an attempt of the quiz
+	ModuleRuleQuizPassed = "quiz_passed"
+)
+
+// the types of the progress events
+const (
+	ProgressModuleCompleted = "module_completed"
+	ProgressCourseCompleted = "course_completed"
+)
+
+// ModuleRule is a completion rule of a module, the default rules of a module without rule have no id
+type ModuleRule struct {
+	ID       string  `json:"id,omitempty"`
+	ModuleID string  `json:"module_id"`
+	Type     string  `json:"type"`
+	TargetID string  `json:"target_id,omitempty"`
+	MinValue float64 `json:"min_value"`
+}
+
+type ModuleRuleTransaction struct {
+	Type     string  `json:"type" validate:"required,oneof=viewed media_watched assignment_submitted assignment_passed quiz_passed"`
+	TargetID string  `json:"target_id"`
+	MinValue float64 `json:"min_value" validate:"min=0"`
+}
+
+// ModuleRulesTransaction replaces the rules of the module, the module uses the default rules again without rule
+type ModuleRulesTransaction struct {
+	Rules []ModuleRuleTransaction `json:"rules" validate:"dive"`
+}
+
+type MediaProgressTransaction struct {
+	MediaModuleID string  `json:"media_module_id"`
+	ModuleID      string  `json:"module_id" validate:"required"`
+	Watched       float64 `json:"watched" validate:"min=0,max=100"`
+}
+
+// ModuleRuleProgress is a rule of the module for the customer, the value is the watched percentage of a media rule,
+// the score of an assignment rule and the best percentage of a quiz rule
+type ModuleRuleProgress struct {
+	Type     string  `json:"type"`
+	TargetID string  `json:"target_id,omitempty"`
+	MinValue float64 `json:"min_value"`
+	Value    float64 `json:"value"`
+	IsMet    bool    `json:"is_met"`
+}
+
+type ModuleProgress struct {
+	ModuleID string               `json:"module_id"`
+	IsFinish bool                 `json:"is_finish"`
+	Rules    []ModuleRuleProgress `json:"rules"`
+}
+
+type ProgressEvent struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	CourseID  string    `json:"course_id"`
+	ModuleID  string    `json:"module_id,omitempty"`
+	Type      string    `json:"type"`
+}
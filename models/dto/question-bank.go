@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// QuestionBank is a pool of questions of a course, only the instructor of the course can see it
+type QuestionBank struct {
+	ID          string         `json:"id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CourseID    string         `json:"course_id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Questions   []QuizQuestion `json:"questions,omitempty"`
+}
+
+type QuestionBankTransaction struct {
+	ID          string `json:"id"`
+	CourseID    string `json:"course_id" validate:"required_without=ID"`
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description"`
+}
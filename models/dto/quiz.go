@@ -6,20 +6,210 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// QuizSingleChoice is a question with one correct option
+	QuizSingleChoice = "single_choice"
+	// QuizMultipleChoice is a question where the customer has to choose all the correct options
+	QuizMultipleChoice = "multiple_choice"
+	// QuizTrueFalse is a question with the answer true or false
+	QuizTrueFalse = "true_false"
+	// QuizShortAnswer is a question where the options are the accepted answers, the answer is not case sensitive
+	QuizShortAnswer = "short_answer"
+	// QuizNumeric is a question with a number answer, the answer is correct within the tolerance
+	QuizNumeric = "numeric"
+
+	// QuizAttemptInProgress is an attempt the customer can still submit
+	QuizAttemptInProgress = "in_progress"
+	// QuizAttemptSubmitted is an attempt that is graded
+	QuizAttemptSubmitted = "submitted"
+	// QuizAttemptExpired is an attempt that was not submitted before the time limit, its score is zero
+	QuizAttemptExpired = "expired"
+
+	// QuizGIFT is the Moodle GIFT text format of the questions
+	QuizGIFT = "gift"
+	// QuizQTI is a zip package of QTI 2.1 items, one item for every question
+	QuizQTI = "qti"
+
+	// QuizEasy, QuizMedium and QuizHard are the difficulties of the questions of a question bank
+	QuizEasy   = "easy"
+	QuizMedium = "medium"
+	QuizHard   = "hard"
+)
+
 type Quiz struct {
-	ID        string `json:"id" gorm:"primaryKey;notNull;size:255"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
-	CourseID  string         `json:"course_id"`
-	Link      string         `json:"link"`
+	ID               string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	CourseID         string         `json:"course_id"`
+	ModuleID         string         `json:"module_id"`
+	Title            string         `json:"title"`
+	Description      string         `json:"description"`
+	Link             string         `json:"link"`
+	TimeLimit        int            `json:"time_limit"`
+	PassingScore     float64        `json:"passing_score"`
+	MaxAttempts      int            `json:"max_attempts"`
+	ShuffleQuestions bool           `json:"shuffle_questions"`
+	ShuffleOptions   bool           `json:"shuffle_options"`
+	Questions        []QuizQuestion `json:"questions,omitempty"`
+	Pools            []QuizPool     `json:"pools,omitempty"`
 }
 type QuizTransaction struct {
-	ID       string `json:"id"`
-	CourseID string `json:"course_id" validate:"required"`
-	Link     string `json:"link" validate:"required"`
+	ID               string  `json:"id"`
+	CourseID         string  `json:"course_id" validate:"required_without=ID"`
+	ModuleID         string  `json:"module_id"`
+	Title            string  `json:"title" validate:"required_without=Link"`
+	Description      string  `json:"description"`
+	Link             string  `json:"link"`
+	TimeLimit        int     `json:"time_limit" validate:"min=0"`
+	PassingScore     float64 `json:"passing_score" validate:"min=0,max=100"`
+	MaxAttempts      int     `json:"max_attempts" validate:"min=0"`
+	ShuffleQuestions bool    `json:"shuffle_questions"`
+	ShuffleOptions   bool    `json:"shuffle_options"`
+	// Pools replace the draws of the quiz
+	Pools []QuizPoolTransaction `json:"pools" validate:"dive"`
+}
+
+// QuizPool is a draw of the quiz, every attempt gets count questions of the bank with the topic and difficulty
+type QuizPool struct {
+	ID         string `json:"id"`
+	BankID     string `json:"bank_id"`
+	Topic      string `json:"topic"`
+	Difficulty string `json:"difficulty"`
+	Count      int    `json:"count"`
+	NoPool     int    `json:"no_pool"`
+}
+
+type QuizPoolTransaction struct {
+	BankID     string `json:"bank_id" validate:"required"`
+	Topic      string `json:"topic"`
+	Difficulty string `json:"difficulty" validate:"omitempty,oneof=easy medium hard"`
+	Count      int    `json:"count" validate:"required,min=1"`
 }
 type TakeQuizTransaction struct {
 	CourseID   string `json:"course_id" validate:"required"`
 	CustomerID string `json:"customer_id" validate:"required"`
 }
+
+// QuizQuestion is a question with its answer, only the instructor of the quiz can see it
+type QuizQuestion struct {
+	ID         string       `json:"id"`
+	QuizID     string       `json:"quiz_id"`
+	BankID     string       `json:"bank_id,omitempty"`
+	Topic      string       `json:"topic,omitempty"`
+	Difficulty string       `json:"difficulty,omitempty"`
+	Type       string       `json:"type"`
+	Text       string       `json:"text"`
+	Points     float64      `json:"points"`
+	NoQuestion int          `json:"no_question"`
+	Answer     string       `json:"answer"`
+	Tolerance  float64      `json:"tolerance"`
+	Options    []QuizOption `json:"options"`
+}
+
+type QuizOption struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+	NoOption  int    `json:"no_option"`
+}
+
+// QuizQuestionTransaction is a question of a quiz or of a question bank to create or update, the options replace
+// the options of the question and without number the question is the last question of the quiz
+type QuizQuestionTransaction struct {
+	ID         string                  `json:"id"`
+	QuizID     string                  `json:"quiz_id" validate:"required_without_all=ID BankID"`
+	BankID     string                  `json:"bank_id"`
+	Topic      string                  `json:"topic" validate:"max=100"`
+	Difficulty string                  `json:"difficulty" validate:"omitempty,oneof=easy medium hard"`
+	Type       string                  `json:"type" validate:"required,oneof=single_choice multiple_choice true_false short_answer numeric"`
+	Text       string                  `json:"text" validate:"required"`
+	Points     float64                 `json:"points" validate:"min=0"`
+	NoQuestion int                     `json:"no_question" validate:"omitempty,min=1"`
+	Answer     string                  `json:"answer"`
+	Tolerance  float64                 `json:"tolerance" validate:"min=0"`
+	Options    []QuizOptionTransaction `json:"options" validate:"dive"`
+}
+
+// QuizOptionTransaction is an option of a question, an option with the id of an option of the question
+// keeps its id so the answers of the attempts are still graded against it
+type QuizOptionTransaction struct {
+	ID        string `json:"id"`
+	Text      string `json:"text" validate:"required"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// QuizAttempt is an attempt of a customer, the questions are the questions of the attempt
+// with the answers of the customer and they are graded when the attempt is not in progress
+type QuizAttempt struct {
+	ID             string                `json:"id"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	QuizID         string                `json:"quiz_id"`
+	CustomerID     string                `json:"customer_id"`
+	Status         string                `json:"status"`
+	ExpiresAt      *time.Time            `json:"expires_at"`
+	SubmittedAt    *time.Time            `json:"submitted_at"`
+	Score          float64               `json:"score"`
+	MaxScore       float64               `json:"max_score"`
+	Percentage     float64               `json:"percentage"`
+	IsPassed       bool                  `json:"is_passed"`
+	Seed           int64                 `json:"seed"`
+	ShuffleOptions bool                  `json:"-"`
+	Answers        []QuizAttemptAnswer   `json:"-"`
+	Questions      []QuizAttemptQuestion `json:"questions,omitempty"`
+}
+
+type QuizAttemptAnswer struct {
+	QuestionID string
+	NoQuestion int
+	Answer     string
+	IsCorrect  bool
+	Score      float64
+}
+
+// QuizAttemptQuestion is a question as the customer sees it, without the correct answer
+type QuizAttemptQuestion struct {
+	ID         string              `json:"id"`
+	Type       string              `json:"type"`
+	Text       string              `json:"text"`
+	Points     float64             `json:"points"`
+	NoQuestion int                 `json:"no_question"`
+	Options    []QuizAttemptOption `json:"options,omitempty"`
+	OptionIDs  []string            `json:"option_ids,omitempty"`
+	Answer     string              `json:"answer,omitempty"`
+	IsCorrect  bool                `json:"is_correct"`
+	Score      float64             `json:"score"`
+}
+
+type QuizAttemptOption struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// QuizSubmission is the answers of an attempt, the choice questions are answered with option ids
+// and the other questions with the answer
+type QuizSubmission struct {
+	Answers []QuizAnswer `json:"answers" validate:"dive"`
+}
+
+type QuizAnswer struct {
+	QuestionID string   `json:"question_id" validate:"required"`
+	OptionIDs  []string `json:"option_ids"`
+	Answer     string   `json:"answer"`
+}
+
+// QuizImport is the result of an import of questions, the questions with an error are not imported
+type QuizImport struct {
+	Imported int               `json:"imported"`
+	Errors   []QuizImportError `json:"errors,omitempty"`
+}
+
+// QuizImportError is the error of a question of the file, the question is the number of the question in the file
+// and the line is the line the question starts on
+type QuizImportError struct {
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line"`
+	Question int    `json:"question"`
+	Message  string `json:"message"`
+}
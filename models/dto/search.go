@@ -0,0 +1,26 @@
+package dto
+
+// DefaultSearchLimit is the number of results when the request has no limit
+const DefaultSearchLimit = 10
+
+type CourseSearchQuery struct {
+	Query string `query:"q" validate:"required"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// CourseSearchData is the text of a course that is put in the search index
+type CourseSearchData struct {
+	ID             string
+	Name           string
+	Description    string
+	Objective      string
+	CategoryName   string
+	InstructorName string
+	ModuleNames    []string `gorm:"-"`
+}
+
+type CourseSearchResult struct {
+	Course     GetCourse         `json:"course"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
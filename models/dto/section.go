@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+type Section struct {
+	ID          string    `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	CourseID    string    `json:"course_id"`
+	NoSection   int       `json:"no_section"`
+}
+
+type SectionTransaction struct {
+	ID          string `json:"id"`
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description"`
+	CourseID    string `json:"course_id" validate:"required"`
+	NoSection   int    `json:"no_section" validate:"omitempty,min=1"`
+}
+
+// CourseSection is a section of the course with its modules, the progress is the percentage of the modules the customer finished
+type CourseSection struct {
+	ID                 string   `json:"id"`
+	Title              string   `json:"title"`
+	Description        string   `json:"description"`
+	NoSection          int      `json:"no_section"`
+	ProgressPercentage float64  `json:"progress_percentage"`
+	Modules            []Module `json:"modules"`
+}
+
+// ModuleCourseSection is a section of the course with the modules of the module list
+type ModuleCourseSection struct {
+	ID                 string         `json:"id"`
+	Title              string         `json:"title"`
+	Description        string         `json:"description"`
+	NoSection          int            `json:"no_section"`
+	ProgressPercentage float64        `json:"progress_percentage"`
+	Modules            []ModuleCourse `json:"modules"`
+}
+
+// CourseModules is the module list of a course, the modules of a section are in the section
+// and the modules without section are in the modules
+type CourseModules struct {
+	Sections []ModuleCourseSection `json:"sections"`
+	Modules  []ModuleCourse        `json:"modules"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+type Session struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	Role      string     `json:"role"`
+	FamilyID  string     `json:"family_id"`
+	IssuedAt  time.Time  `json:"issued_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// IsActive reports whether the session is neither revoked nor expired at the given time
+func (s Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+type RefreshToken struct {
+	ID             string     `json:"id"`
+	TokenHash      string     `json:"token_hash"`
+	FamilyID       string     `json:"family_id"`
+	FamilyIssuedAt time.Time  `json:"family_issued_at"`
+	UserID         string     `json:"user_id"`
+	Role           string     `json:"role"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RotatedAt      *time.Time `json:"rotated_at"`
+	RevokedAt      *time.Time `json:"revoked_at"`
+}
+
+type TokenPair struct {
+	AccessToken      string    `json:"token"`
+	AccessExpiresAt  time.Time `json:"token_expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// VerificationPolicy controls how email verification codes are generated and checked
+type VerificationPolicy struct {
+	CodeLength     int
+	CodeExpiry     time.Duration
+	MaxAttempts    int
+	ResendCooldown time.Duration
+}
+
+type CustomerCode struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Attempts  int       `json:"attempts"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+type InstructorCode struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Attempts  int       `json:"attempts"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+type ResendCode struct {
+	Email string `json:"email" validate:"required,email"`
+}
@@ -7,12 +7,22 @@ import (
 )
 
 type Assignment struct {
-	ID                  string `json:"id" gorm:"primaryKey;notNull;size:255"`
-	CreatedAt           time.Time
-	UpdatedAt           time.Time
-	DeletedAt           gorm.DeletedAt `gorm:"index"`
-	Title               string         `json:"title" gorm:"notNull;size:255"`
-	Description         string         `json:"description" gorm:"notNull;size:255"`
-	ModuleID            string         `json:"module_id" gorm:"notNull;size:255"`
+	ID          string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Title       string         `json:"title" gorm:"notNull;size:255"`
+	Description string         `json:"description" gorm:"notNull;size:255"`
+	ModuleID    string         `json:"module_id" gorm:"notNull;size:255"`
+	// OpenAt is the time the customers can submit, CloseAt is the last time they can submit even late
+	OpenAt  *time.Time `json:"open_at"`
+	DueAt   *time.Time `json:"due_at"`
+	CloseAt *time.Time `json:"close_at"`
+	// MaxPoints is the highest grade of a submission
+	MaxPoints float64 `json:"max_points" gorm:"notNull;default:100"`
+	// MaxResubmissions is the number of times a customer can submit again before the submission is graded
+	MaxResubmissions int `json:"max_resubmissions" gorm:"notNull;default:0"`
+	// LatePenalty is the percentage of the grade taken for every day started after the due time
+	LatePenalty         float64 `json:"late_penalty" gorm:"notNull;default:0"`
 	CustomerAssignments []CustomerAssignment
 }
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// CoursePrerequisite is a course the customer must finish before they can take the course
+type CoursePrerequisite struct {
+	CourseID       string `gorm:"primaryKey;notNull;size:255"`
+	PrerequisiteID string `gorm:"primaryKey;notNull;size:255;index"`
+	CreatedAt      time.Time
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// CourseSearch is the searchable text of a course used by the mysql search index
+type CourseSearch struct {
+	CourseID    string `gorm:"primaryKey;notNull;size:255"`
+	UpdatedAt   time.Time
+	Name        string `gorm:"size:255;index:idx_course_searches_name,class:FULLTEXT;index:idx_course_searches_text,class:FULLTEXT"`
+	Description string `gorm:"type:text;index:idx_course_searches_text,class:FULLTEXT"`
+	Objective   string `gorm:"type:text;index:idx_course_searches_text,class:FULLTEXT"`
+	Category    string `gorm:"size:255;index:idx_course_searches_text,class:FULLTEXT"`
+	Modules     string `gorm:"type:text;index:idx_course_searches_text,class:FULLTEXT"`
+	Instructor  string `gorm:"size:255;index:idx_course_searches_text,class:FULLTEXT"`
+}
+
+// SearchTerm is a word of the indexed courses, it is used to correct typos in the search query
+type SearchTerm struct {
+	Term string `gorm:"primaryKey;notNull;size:100"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// CourseStats is the projection of the ratings, enrollments and modules of a course
+type CourseStats struct {
+	CourseID       string `gorm:"primaryKey;notNull;size:255"`
+	UpdatedAt      time.Time
+	RatingAverage  float64 `gorm:"notNull;default:0"`
+	RatingCount    int     `gorm:"notNull;default:0"`
+	Rating1        int     `gorm:"notNull;default:0"`
+	Rating2        int     `gorm:"notNull;default:0"`
+	Rating3        int     `gorm:"notNull;default:0"`
+	Rating4        int     `gorm:"notNull;default:0"`
+	Rating5        int     `gorm:"notNull;default:0"`
+	EnrolledCount  int     `gorm:"notNull;default:0"`
+	CompletedCount int     `gorm:"notNull;default:0"`
+	ModuleCount    int     `gorm:"notNull;default:0"`
+}
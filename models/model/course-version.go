@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+// CourseVersion is a published snapshot of the modules of a course, the enrollments are pinned to a version
+// so the changes of the instructor don't move the content under the customers
+type CourseVersion struct {
+	ID        string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt time.Time
+	CourseID  string                `json:"course_id" gorm:"notNull;size:255;uniqueIndex:idx_course_versions_course_version"`
+	Version   int                   `json:"version" gorm:"notNull;uniqueIndex:idx_course_versions_course_version"`
+	Modules   []CourseVersionModule `json:"modules"`
+}
+
+// CourseVersionModule is a module as it was when the version was published, ModuleID is the module it is copied from
+type CourseVersionModule struct {
+	ID              string               `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CourseVersionID string               `json:"course_version_id" gorm:"notNull;size:255;index"`
+	ModuleID        string               `json:"module_id" gorm:"notNull;size:255;index"`
+	NoModule        int                  `json:"no_module" gorm:"notNull"`
+	Name            string               `json:"name" gorm:"notNull;size:255"`
+	Content         string               `json:"content"`
+	Media           []CourseVersionMedia `json:"media"`
+}
+
+// CourseVersionMedia is a media of a module as it was when the version was published
+type CourseVersionMedia struct {
+	ID                    string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CourseVersionModuleID string `json:"course_version_module_id" gorm:"notNull;size:255;index"`
+	MediaModuleID         string `json:"media_module_id" gorm:"notNull;size:255"`
+	Url                   string `json:"url" gorm:"notNull;size:255"`
+}
@@ -20,8 +20,13 @@ type Course struct {
 	Capacity        int            `json:"capacity" gorm:"notNull;default:0"`
 	InstructorID    string         `json:"instructor_id" gorm:"notNull;size:255"`
 	CategoryID      string         `json:"category_id" gorm:"notNull;size:255"`
+	Status          string         `json:"status" gorm:"notNull;size:20;default:draft;index"`
+	Version         int            `json:"version" gorm:"notNull;default:0"`
+	IsTemplate      bool           `json:"is_template" gorm:"notNull;default:false;index"`
 	CustomerCourses []CustomerCourse
 	Favorites       []Favorite
 	Ratings         []Rating
 	Modules         []Module
+	Sections        []Section
+	Stats           CourseStats `gorm:"foreignKey:CourseID"`
 }
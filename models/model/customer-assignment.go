@@ -12,7 +12,31 @@ type CustomerAssignment struct {
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
 	File         string         `json:"file" gorm:"notNull;size:255"`
-	Grade        int            `json:"grade" gorm:"notNull"`
-	AssignmentID string         `json:"assignment_id" gorm:"notNull;size:255"`
-	CustomerID   string         `json:"customer_id" gorm:"notNull;size:255"`
+	AssignmentID string         `json:"assignment_id" gorm:"notNull;size:255;index"`
+	CustomerID   string         `json:"customer_id" gorm:"notNull;size:255;index"`
+	Status       string         `json:"status" gorm:"notNull;size:20;default:draft"`
+	// Attempts is the number of versions the customer submitted
+	Attempts    int        `json:"attempts" gorm:"notNull;default:0"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	// LatePenalty is the percentage taken from the grade of the last version because it was late
+	LatePenalty float64 `json:"late_penalty" gorm:"notNull;default:0"`
+	// Grade is the grade given by the instructor and Score is the grade after the late penalty
+	Grade    float64    `json:"grade" gorm:"notNull;default:0"`
+	Score    float64    `json:"score" gorm:"notNull;default:0"`
+	Feedback string     `json:"feedback"`
+	GradedAt *time.Time `json:"graded_at"`
+	Versions []CustomerAssignmentVersion
+}
+
+// CustomerAssignmentVersion is a submitted version of a submission, a resubmission adds a version
+// so the files the customer submitted before are kept
+type CustomerAssignmentVersion struct {
+	ID                   string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt            time.Time
+	CustomerAssignmentID string    `json:"customer_assignment_id" gorm:"notNull;size:255;index"`
+	NoVersion            int       `json:"no_version" gorm:"notNull"`
+	File                 string    `json:"file" gorm:"notNull;size:255"`
+	Status               string    `json:"status" gorm:"notNull;size:20"`
+	LatePenalty          float64   `json:"late_penalty" gorm:"notNull;default:0"`
+	SubmittedAt          time.Time `json:"submitted_at"`
 }
@@ -13,4 +13,7 @@ type CustomerCode struct {
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 	Email     string         `json:"email"`
 	Code      string         `json:"code" gorm:"notNull;size:255"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	Attempts  int            `json:"attempts" gorm:"notNull;default:0"`
+	SentAt    time.Time      `json:"sent_at"`
 }
@@ -16,4 +16,6 @@ type CustomerCourse struct {
 	Status     bool           `json:"status" gorm:"notNull;default:true"`
 	NoModule   int            `json:"no_module" gorm:"notNull;default:1"`
 	IsFinish   bool           `json:"is_finish" gorm:"notNull;default:false"`
+	// CourseVersionID is the version the customer learns, it is empty for the enrollments of a course without version
+	CourseVersionID string `json:"course_version_id" gorm:"size:255"`
 }
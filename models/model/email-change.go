@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmailChange struct {
+	ID        string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	UserID    string         `json:"user_id" gorm:"notNull;size:255;index"`
+	Role      string         `json:"role" gorm:"notNull;size:50"`
+	NewEmail  string         `json:"new_email" gorm:"notNull;size:255"`
+	Code      string         `json:"code" gorm:"notNull;size:255"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	Attempts  int            `json:"attempts" gorm:"notNull;default:0"`
+	SentAt    time.Time      `json:"sent_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type InstructorCode struct {
+	ID        string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Email     string         `json:"email"`
+	Code      string         `json:"code" gorm:"notNull;size:255"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	Attempts  int            `json:"attempts" gorm:"notNull;default:0"`
+	SentAt    time.Time      `json:"sent_at"`
+}
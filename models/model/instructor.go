@@ -15,5 +15,9 @@ type Instructor struct {
 	Email        string         `json:"email" gorm:"notNull;unique;size:255"`
 	Password     string         `json:"password" gorm:"notNull"`
 	ProfileImage string         `json:"profile_image" gorm:"size:255;default:null"`
+	Headline     string         `json:"headline" gorm:"size:255"`
+	Bio          string         `json:"bio" gorm:"type:text"`
+	IsActive     bool           `json:"is_active"`
+	Status       string         `json:"status" gorm:"notNull;size:20;default:pending"`
 	Courses      []Course
 }
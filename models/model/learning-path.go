@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LearningPath struct {
+	ID           string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt       `gorm:"index"`
+	Title        string               `json:"title" gorm:"notNull;size:255"`
+	Description  string               `json:"description"`
+	InstructorID string               `json:"instructor_id" gorm:"notNull;size:255;index"`
+	Courses      []LearningPathCourse `gorm:"foreignKey:LearningPathID"`
+}
+
+// LearningPathCourse is a course of the learning path at its place in the path
+type LearningPathCourse struct {
+	LearningPathID string `gorm:"primaryKey;notNull;size:255"`
+	CourseID       string `gorm:"primaryKey;notNull;size:255;index"`
+	NoCourse       int    `gorm:"notNull"`
+}
+
+// LearningPathCompletion is issued to the customer when they finished every course of the learning path
+type LearningPathCompletion struct {
+	LearningPathID string `gorm:"primaryKey;notNull;size:255"`
+	CustomerID     string `gorm:"primaryKey;notNull;size:255;index"`
+	CreatedAt      time.Time
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MailOutbox struct {
+	ID            string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	Recipient     string         `json:"recipient" gorm:"notNull;size:255"`
+	Subject       string         `json:"subject" gorm:"notNull;size:255"`
+	HTML          string         `json:"html" gorm:"type:text"`
+	Text          string         `json:"text" gorm:"type:text"`
+	Status        string         `json:"status" gorm:"notNull;size:50;index:idx_mail_outbox_pending,priority:1"`
+	Attempts      int            `json:"attempts" gorm:"notNull;default:0"`
+	NextAttemptAt time.Time      `json:"next_attempt_at" gorm:"notNull;index:idx_mail_outbox_pending,priority:2"`
+	LastError     string         `json:"last_error" gorm:"type:text"`
+	LockedUntil   *time.Time     `json:"locked_until" gorm:"default:null"`
+	SentAt        *time.Time     `json:"sent_at" gorm:"default:null"`
+}
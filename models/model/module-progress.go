@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// ModuleProgress is a module the customer finished, the progress refers to the module and not to its number
+// so it stays right when the modules are reordered
+type ModuleProgress struct {
+	CustomerID string `gorm:"primaryKey;notNull;size:255"`
+	ModuleID   string `gorm:"primaryKey;notNull;size:255"`
+	CourseID   string `gorm:"notNull;size:255;index"`
+	CreatedAt  time.Time
+}
+
+// ModuleView is a module the customer opened
+type ModuleView struct {
+	CustomerID string `gorm:"primaryKey;notNull;size:255"`
+	ModuleID   string `gorm:"primaryKey;notNull;size:255"`
+	CreatedAt  time.Time
+}
+
+// MediaProgress is the part of the media the customer watched, it keeps the highest percentage
+type MediaProgress struct {
+	CustomerID    string  `gorm:"primaryKey;notNull;size:255"`
+	MediaModuleID string  `gorm:"primaryKey;notNull;size:255"`
+	ModuleID      string  `gorm:"notNull;size:255"`
+	Watched       float64 `gorm:"notNull;default:0"`
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// ModuleRule is a rule the customer meets to finish the module, the module is finished when all its rules are met
+// and a module without rule uses the default rules
+type ModuleRule struct {
+	ID        string `gorm:"primaryKey;notNull;size:255"`
+	CreatedAt time.Time
+	ModuleID  string `gorm:"notNull;size:255;index"`
+	Type      string `gorm:"notNull;size:30"`
+	// TargetID is the media of a media rule and the quiz of a quiz rule
+	TargetID string `gorm:"size:255"`
+	// MinValue is the percentage of the media to watch and the score to pass the assignment
+	MinValue float64 `gorm:"notNull;default:0"`
+	NoRule   int     `gorm:"notNull"`
+}
+
+// ProgressEvent is a module or a course the customer finished, the event is saved with the progress
+// so there is an event only for a progress that is saved
+type ProgressEvent struct {
+	ID         string `gorm:"primaryKey;notNull;size:255"`
+	CreatedAt  time.Time
+	CustomerID string `gorm:"notNull;size:255;index"`
+	CourseID   string `gorm:"notNull;size:255"`
+	ModuleID   string `gorm:"size:255"`
+	Type       string `gorm:"notNull;size:30"`
+}
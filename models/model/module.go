@@ -15,6 +15,7 @@ type Module struct {
 	Content      string         `json:"content"`
 	CourseID     string         `json:"course_id" gorm:"notNull;size:255"`
 	Course       Course         `json:"course"`
+	SectionID    string         `json:"section_id" gorm:"size:255;index"`
 	MediaModules []MediaModule
 	Assignment   Assignment
 	NoModule     int `json:"no_module"`
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordReset struct {
+	ID        string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	TokenHash string         `json:"token_hash" gorm:"notNull;size:64;uniqueIndex"`
+	UserID    string         `json:"user_id" gorm:"notNull;size:255;index"`
+	Role      string         `json:"role" gorm:"notNull;size:50"`
+	ExpiresAt time.Time      `json:"expires_at" gorm:"notNull"`
+	UsedAt    *time.Time     `json:"used_at" gorm:"default:null"`
+}
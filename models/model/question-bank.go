@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QuestionBank is a pool of questions of a course the quizzes draw their questions from
+type QuestionBank struct {
+	ID          string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	CourseID    string         `json:"course_id" gorm:"notNull;size:255;index"`
+	Title       string         `json:"title" gorm:"notNull;size:255"`
+	Description string         `json:"description"`
+	Questions   []QuizQuestion `gorm:"foreignKey:BankID"`
+}
+
+// QuizPool is a draw of the quiz, every attempt gets count questions of the bank with the topic and difficulty,
+// an empty topic or difficulty matches all questions
+type QuizPool struct {
+	ID         string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	QuizID     string `json:"quiz_id" gorm:"notNull;size:255;index"`
+	BankID     string `json:"bank_id" gorm:"notNull;size:255;index"`
+	Topic      string `json:"topic" gorm:"size:100"`
+	Difficulty string `json:"difficulty" gorm:"size:20"`
+	Count      int    `json:"count" gorm:"notNull"`
+	NoPool     int    `json:"no_pool"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+type QuizAttempt struct {
+	ID          string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	QuizID      string     `json:"quiz_id" gorm:"notNull;size:255;index"`
+	CustomerID  string     `json:"customer_id" gorm:"notNull;size:255;index"`
+	Status      string     `json:"status" gorm:"notNull;size:20"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	Score       float64    `json:"score" gorm:"notNull;default:0"`
+	MaxScore    float64    `json:"max_score" gorm:"notNull;default:0"`
+	Percentage  float64    `json:"percentage" gorm:"notNull;default:0"`
+	IsPassed    bool       `json:"is_passed" gorm:"notNull;default:false"`
+	// Seed makes the questions drawn from the banks and the order of the attempt again for the review
+	Seed           int64 `json:"seed" gorm:"notNull;default:0"`
+	ShuffleOptions bool  `json:"shuffle_options" gorm:"notNull;default:false"`
+	Answers        []QuizAttemptAnswer
+}
+
+// QuizAttemptAnswer is a question of the attempt, the questions are chosen when the attempt starts
+// so the attempt is graded on the questions the customer saw
+type QuizAttemptAnswer struct {
+	QuizAttemptID string  `gorm:"primaryKey;notNull;size:255"`
+	QuestionID    string  `gorm:"primaryKey;notNull;size:255"`
+	NoQuestion    int     `gorm:"notNull"`
+	Answer        string  `json:"answer"`
+	IsCorrect     bool    `json:"is_correct" gorm:"notNull;default:false"`
+	Score         float64 `json:"score" gorm:"notNull;default:0"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type QuizQuestion struct {
+	ID        string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	QuizID    string         `json:"quiz_id" gorm:"notNull;size:255;index"`
+	// BankID is the question bank of the question, a question is either in a quiz or in a bank
+	BankID     string  `json:"bank_id" gorm:"notNull;size:255;index"`
+	Topic      string  `json:"topic" gorm:"size:100"`
+	Difficulty string  `json:"difficulty" gorm:"size:20"`
+	Type       string  `json:"type" gorm:"notNull;size:20"`
+	Text       string  `json:"text" gorm:"notNull"`
+	Points     float64 `json:"points" gorm:"notNull;default:1"`
+	NoQuestion int     `json:"no_question"`
+	// Answer is the answer of a true/false or numeric question, the choices and the accepted short answers are options
+	Answer    string       `json:"answer"`
+	Tolerance float64      `json:"tolerance" gorm:"notNull;default:0"`
+	Options   []QuizOption `gorm:"foreignKey:QuestionID"`
+}
+
+type QuizOption struct {
+	ID         string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	QuestionID string `json:"question_id" gorm:"notNull;size:255;index"`
+	Text       string `json:"text" gorm:"notNull"`
+	IsCorrect  bool   `json:"is_correct" gorm:"notNull;default:false"`
+	NoOption   int    `json:"no_option"`
+}
@@ -12,5 +12,21 @@ type Quiz struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 	CourseID  string         `json:"course_id"`
-	Link      string         `json:"link"`
+	// ModuleID is the module the quiz is attached to, it is empty for a quiz of the whole course
+	ModuleID    string `json:"module_id" gorm:"size:255;index"`
+	Title       string `json:"title" gorm:"size:255"`
+	Description string `json:"description"`
+	// Link is the external quiz of the quizzes made before the native quizzes
+	Link string `json:"link"`
+	// TimeLimit is the minutes the customer has to submit an attempt, 0 is no limit
+	TimeLimit int `json:"time_limit" gorm:"notNull;default:0"`
+	// PassingScore is the percentage of the points the customer needs to pass
+	PassingScore float64 `json:"passing_score" gorm:"notNull;default:0"`
+	// MaxAttempts is the number of attempts of a customer, 0 is no limit
+	MaxAttempts int `json:"max_attempts" gorm:"notNull;default:0"`
+	// ShuffleQuestions and ShuffleOptions change the order for every attempt, the order comes from the seed of the attempt
+	ShuffleQuestions bool `json:"shuffle_questions" gorm:"notNull;default:false"`
+	ShuffleOptions   bool `json:"shuffle_options" gorm:"notNull;default:false"`
+	Questions        []QuizQuestion
+	Pools            []QuizPool
 }
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshToken struct {
+	ID             string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+	TokenHash      string         `json:"token_hash" gorm:"notNull;size:255;uniqueIndex"`
+	FamilyID       string         `json:"family_id" gorm:"notNull;size:255;index"`
+	FamilyIssuedAt time.Time      `json:"family_issued_at" gorm:"notNull"`
+	UserID         string         `json:"user_id" gorm:"notNull;size:255;index"`
+	Role           string         `json:"role" gorm:"notNull;size:50"`
+	ExpiresAt      time.Time      `json:"expires_at" gorm:"notNull;index"`
+	RotatedAt      *time.Time     `json:"rotated_at" gorm:"default:null"`
+	RevokedAt      *time.Time     `json:"revoked_at" gorm:"default:null"`
+}
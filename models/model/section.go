@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Section struct {
+	ID          string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Title       string         `json:"title" gorm:"notNull;size:255"`
+	Description string         `json:"description"`
+	CourseID    string         `json:"course_id" gorm:"notNull;size:255;index"`
+	NoSection   int            `json:"no_section"`
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Session struct {
+	ID        string `json:"id" gorm:"primaryKey;notNull;size:255"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	UserID    string         `json:"user_id" gorm:"notNull;size:255;index"`
+	Role      string         `json:"role" gorm:"notNull;size:50"`
+	FamilyID  string         `json:"family_id" gorm:"size:255;index"`
+	IssuedAt  time.Time      `json:"issued_at" gorm:"notNull"`
+	ExpiresAt time.Time      `json:"expires_at" gorm:"notNull;index"`
+	RevokedAt *time.Time     `json:"revoked_at" gorm:"default:null"`
+}
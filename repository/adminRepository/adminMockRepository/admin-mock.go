@@ -0,0 +1,23 @@
+package adminMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AdminMock struct {
+	mock.Mock
+}
+
+func (a *AdminMock) CreateAdmin(admin dto.AdminRegister) error {
+	args := a.Called(admin)
+
+	return args.Error(0)
+}
+
+func (a *AdminMock) GetAdminByEmail(email string) (dto.Admin, error) {
+	args := a.Called(email)
+
+	return args.Get(0).(dto.Admin), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package adminRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+
+	"gorm.io/gorm"
+)
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+// CreateAdmin implements AdminRepository
+func (ar *adminRepository) CreateAdmin(admin dto.AdminRegister) error {
+	err := ar.db.Model(&model.Admin{}).Create(&model.Admin{
+		ID:       admin.ID,
+		Name:     admin.Name,
+		Email:    admin.Email,
+		Password: admin.Password,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetAdminByEmail implements AdminRepository
+func (ar *adminRepository) GetAdminByEmail(email string) (dto.Admin, error) {
+	var admin dto.Admin
+	err := ar.db.Model(&model.Admin{}).Where("email = ?", email).Find(&admin)
+	if err.Error != nil {
+		return dto.Admin{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.Admin{}, gorm.ErrRecordNotFound
+	}
+	return admin, nil
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{
+		db: db,
+	}
+}
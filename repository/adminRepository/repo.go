@@ -0,0 +1,8 @@
+package adminRepository
+
+import "golang/models/dto"
+
+type AdminRepository interface {
+	CreateAdmin(admin dto.AdminRegister) error
+	GetAdminByEmail(email string) (dto.Admin, error)
+}
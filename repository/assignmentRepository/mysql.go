@@ -79,15 +79,26 @@ func (ar *assignmentRepository) GetAssignmentByID(id string) (dto.Assignment, er
 	return assignment, nil
 }
 
-// UpdateAssignment implements AssignmentRepository
+// UpdateAssignment implements AssignmentRepository, the dates and the policy are always updated
+// so a date or a policy can be removed while the title, the description and the module are only updated when they are sent
 func (ar *assignmentRepository) UpdateAssignment(assignment dto.AssignmentTransaction) error {
 	var assignmentModel model.Assignment
 	errCopy := copier.Copy(&assignmentModel, &assignment)
 	if errCopy != nil {
 		return errCopy
 	}
+	columns := []interface{}{"due_at", "close_at", "max_points", "max_resubmissions", "late_penalty"}
+	if assignment.Title != "" {
+		columns = append(columns, "title")
+	}
+	if assignment.Description != "" {
+		columns = append(columns, "description")
+	}
+	if assignment.ModuleID != "" {
+		columns = append(columns, "module_id")
+	}
 	// update account with new data
-	err := ar.db.Model(&model.Assignment{}).Where("id = ?", assignment.ID).Updates(&assignmentModel)
+	err := ar.db.Model(&model.Assignment{}).Where("id = ?", assignment.ID).Select("open_at", columns...).Updates(&assignmentModel)
 	if err.Error != nil {
 		return err.Error
 	}
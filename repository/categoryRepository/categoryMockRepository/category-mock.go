@@ -27,6 +27,12 @@ func (c *CategoryMock) GetCategoryByID(id string, user dto.User) (dto.Category,
 	return args.Get(0).(dto.Category), args.Error(1)
 }
 
+func (c *CategoryMock) GetCategoryByName(name string) (dto.CategoryTransaction, error) {
+	args := c.Called(name)
+
+	return args.Get(0).(dto.CategoryTransaction), args.Error(1)
+}
+
 func (c *CategoryMock) GetAllCategory() ([]dto.CategoryTransaction, error) {
 	args := c.Called()
 
@@ -54,9 +54,11 @@ func (cr *categoryRepository) GetCategoryByID(id string, user dto.User) (dto.Cat
 	var category dto.Category
 	var err *gorm.DB
 	if user.Role == "instructor" {
-		err = cr.db.Model(&model.Category{}).Preload("Courses", "instructor_id = ?", user.ID).Preload("Courses.CustomerCourses").Preload("Courses.Favorites").Preload("Courses.Ratings").Preload("Courses.Modules").Where("id = ?", id).Find(&category)
+		err = cr.db.Model(&model.Category{}).Preload("Courses", "instructor_id = ?", user.ID).Preload("Courses.Stats").Where("id = ?", id).Find(&category)
 	} else if user.Role == "customer" {
-		err = cr.db.Model(&model.Category{}).Preload("Courses.CustomerCourses", "customer_id = ?", user.ID).Preload("Courses.Favorites").Preload("Courses.Ratings").Preload("Courses.Modules").Where("id = ?", id).Find(&category)
+		err = cr.db.Model(&model.Category{}).Preload("Courses", "status = ?", dto.CourseStatusPublished).Preload("Courses.CustomerCourses", "customer_id = ?", user.ID).Preload("Courses.Favorites", "customer_id = ?", user.ID).Preload("Courses.Stats").Where("id = ?", id).Find(&category)
+	} else {
+		err = cr.db.Model(&model.Category{}).Preload("Courses.Stats").Where("id = ?", id).Find(&category)
 	}
 	if err.Error != nil {
 		return dto.Category{}, err.Error
@@ -67,6 +69,19 @@ func (cr *categoryRepository) GetCategoryByID(id string, user dto.User) (dto.Cat
 	return category, nil
 }
 
+// GetCategoryByName implements CategoryRepository
+func (cr *categoryRepository) GetCategoryByName(name string) (dto.CategoryTransaction, error) {
+	var category dto.CategoryTransaction
+	err := cr.db.Model(&model.Category{}).Where("name = ?", name).Limit(1).Find(&category)
+	if err.Error != nil {
+		return dto.CategoryTransaction{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CategoryTransaction{}, gorm.ErrRecordNotFound
+	}
+	return category, nil
+}
+
 // UpdateCategory implements CategoryRepository
 func (cr *categoryRepository) UpdateCategory(category dto.CategoryTransaction) error {
 	// update account with new data
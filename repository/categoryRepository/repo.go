@@ -6,6 +6,7 @@ type CategoryRepository interface {
 	CreateCategory(dto.CategoryTransaction) error
 	DeleteCategory(id string) error
 	GetCategoryByID(id string, user dto.User) (dto.Category, error)
+	GetCategoryByName(name string) (dto.CategoryTransaction, error)
 	GetAllCategory() ([]dto.CategoryTransaction, error)
 	UpdateCategory(dto.CategoryTransaction) error
 }
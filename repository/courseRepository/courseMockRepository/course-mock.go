@@ -35,8 +35,78 @@ func (c *CourseMock) GetAllCourse(user dto.User) ([]dto.Course, error) {
 
 	return args.Get(0).([]dto.Course), args.Error(1)
 }
+func (c *CourseMock) GetCourseTemplates(instructorID string) ([]dto.Course, error) {
+	args := c.Called(instructorID)
+
+	return args.Get(0).([]dto.Course), args.Error(1)
+}
+func (c *CourseMock) DuplicateCourse(id string, course dto.CourseDuplicate) error {
+	args := c.Called(id, course)
+
+	return args.Error(0)
+}
+func (c *CourseMock) GetCoursePackage(id string) (dto.CoursePackage, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.CoursePackage), args.Error(1)
+}
+func (c *CourseMock) ImportCoursePackage(course dto.CourseTransaction, coursePackage dto.CoursePackage) error {
+	args := c.Called(course, coursePackage)
+
+	return args.Error(0)
+}
+func (c *CourseMock) IsCourseNameUsed(name string) (bool, error) {
+	args := c.Called(name)
+
+	return args.Bool(0), args.Error(1)
+}
+func (c *CourseMock) GetFinishedModules(courseID, customerID string) ([]string, error) {
+	args := c.Called(courseID, customerID)
+
+	return args.Get(0).([]string), args.Error(1)
+}
 func (c *CourseMock) UpdateCourse(course dto.CourseTransaction) error {
 	args := c.Called(course)
 
 	return args.Error(0)
-}
\ No newline at end of file
+}
+func (c *CourseMock) GetCourseCatalog(user dto.User, query dto.CourseCatalogQuery, after *dto.CatalogCursor) ([]dto.Course, int64, error) {
+	args := c.Called(user, query, after)
+
+	return args.Get(0).([]dto.Course), args.Get(1).(int64), args.Error(2)
+}
+func (c *CourseMock) GetCourseCatalogByIDs(user dto.User, ids []string) ([]dto.Course, error) {
+	args := c.Called(user, ids)
+
+	return args.Get(0).([]dto.Course), args.Error(1)
+}
+func (c *CourseMock) GetCourseSearchData(ids ...string) ([]dto.CourseSearchData, error) {
+	args := c.Called(ids)
+
+	return args.Get(0).([]dto.CourseSearchData), args.Error(1)
+}
+func (c *CourseMock) GetCourseContent(id string) (dto.CourseContent, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.CourseContent), args.Error(1)
+}
+func (c *CourseMock) UpdateCourseStatus(id, status string) error {
+	args := c.Called(id, status)
+
+	return args.Error(0)
+}
+func (c *CourseMock) AddCoursePrerequisite(courseID, prerequisiteID string) error {
+	args := c.Called(courseID, prerequisiteID)
+
+	return args.Error(0)
+}
+func (c *CourseMock) DeleteCoursePrerequisite(courseID, prerequisiteID string) error {
+	args := c.Called(courseID, prerequisiteID)
+
+	return args.Error(0)
+}
+func (c *CourseMock) GetCoursePrerequisites(courseID, customerID string) ([]dto.CoursePrerequisite, error) {
+	args := c.Called(courseID, customerID)
+
+	return args.Get(0).([]dto.CoursePrerequisite), args.Error(1)
+}
@@ -1,12 +1,19 @@
 package courseRepository
 
 import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/helper"
 	"golang/models/dto"
 	"golang/models/model"
+	"golang/repository/courseStatsRepository"
+	modulerepository "golang/repository/moduleRepository"
 
 	"github.com/jinzhu/copier"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type courseRepository struct {
@@ -23,6 +30,9 @@ func (cr *courseRepository) CreateCourse(course dto.CourseTransaction) error {
 		return err
 	}
 
+	// a new course starts as a draft with empty stats
+	courseModel.Status = dto.CourseStatusDraft
+	courseModel.Stats = model.CourseStats{CourseID: courseModel.ID}
 	err = cr.db.Model(&model.Course{}).Create(&courseModel).Error
 	if err != nil {
 		return err
@@ -33,7 +43,7 @@ func (cr *courseRepository) CreateCourse(course dto.CourseTransaction) error {
 // DeleteCourse implements CourseRepository
 func (cr *courseRepository) DeleteCourse(id string) error {
 	// delete data course from database by id
-	err := cr.db.Select("modules", "Favorites", "Ratings").Where("id = ?", id).Delete(&model.Course{})
+	err := cr.db.Select("modules", "Sections", "Favorites", "Ratings", "Stats").Where("id = ?", id).Delete(&model.Course{})
 	if err.Error != nil {
 		return err.Error
 	}
@@ -49,10 +59,12 @@ func (cr *courseRepository) GetAllCourse(user dto.User) ([]dto.Course, error) {
 	var courseModels []dto.GetCourseCategory
 	// get data sub category from database by user
 	var err error
-	if user.Role == "instructor" {
-		err = cr.db.Model(&model.Course{}).Preload("Category").Preload("CustomerCourses").Preload("Favorites").Preload("Ratings").Preload("Modules").Where("instructor_id = ?", user.ID).Find(&courseModels).Error
-	} else if user.Role == "customer" {
-		err = cr.db.Model(&model.Course{}).Preload("Category").Preload("CustomerCourses", "customer_id = ?", user.ID).Preload("Favorites", "customer_id = ?", user.ID).Preload("Ratings").Preload("Modules").Find(&courseModels).Error
+	if user.Role == constantRole.RoleInstructor {
+		err = cr.db.Model(&model.Course{}).Preload("Category").Preload("Stats").Where("instructor_id = ? AND is_template = ?", user.ID, false).Find(&courseModels).Error
+	} else if user.Role == constantRole.RoleCustomer {
+		err = cr.db.Model(&model.Course{}).Preload("Category").Preload("CustomerCourses", "customer_id = ?", user.ID).Preload("Favorites", "customer_id = ?", user.ID).Preload("Stats").Where("status = ?", dto.CourseStatusPublished).Find(&courseModels).Error
+	} else if user.Role == constantRole.RoleAdmin {
+		err = cr.db.Model(&model.Course{}).Preload("Category").Preload("Stats").Find(&courseModels).Error
 	}
 	if err != nil {
 		return nil, err
@@ -66,10 +78,550 @@ func (cr *courseRepository) GetAllCourse(user dto.User) ([]dto.Course, error) {
 	return courses, nil
 }
 
+// GetCourseTemplates implements CourseRepository
+func (cr *courseRepository) GetCourseTemplates(instructorID string) ([]dto.Course, error) {
+	var courseModels []dto.GetCourseCategory
+	err := cr.db.Model(&model.Course{}).Preload("Category").Preload("Stats").Where("instructor_id = ? AND is_template = ?", instructorID, true).Find(&courseModels).Error
+	if err != nil {
+		return nil, err
+	}
+	// copy data from model to dto
+	var courses []dto.Course
+	err = copier.Copy(&courses, &courseModels)
+	if err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// DuplicateCourse implements CourseRepository, the modules, media, assignments, question banks and quizzes are copied with new ids
+// and the new course starts as a draft without customers, favorites and ratings
+func (cr *courseRepository) DuplicateCourse(id string, course dto.CourseDuplicate) error {
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		coursePackage, err := getCoursePackage(tx, id)
+		if err != nil {
+			return err
+		}
+
+		return createCoursePackage(tx, model.Course{
+			ID:           course.ID,
+			Name:         course.Name,
+			Description:  coursePackage.Course.Description,
+			Objective:    coursePackage.Course.Objective,
+			Price:        coursePackage.Course.Price,
+			Discount:     coursePackage.Course.Discount,
+			Thumbnail:    coursePackage.Course.Thumbnail,
+			Capacity:     coursePackage.Course.Capacity,
+			InstructorID: course.InstructorID,
+			CategoryID:   course.CategoryID,
+			IsTemplate:   course.IsTemplate,
+		}, coursePackage)
+	})
+}
+
+// GetCoursePackage implements CourseRepository
+func (cr *courseRepository) GetCoursePackage(id string) (dto.CoursePackage, error) {
+	return getCoursePackage(cr.db, id)
+}
+
+// ImportCoursePackage implements CourseRepository
+func (cr *courseRepository) ImportCoursePackage(course dto.CourseTransaction, coursePackage dto.CoursePackage) error {
+	var courseModel model.Course
+	err := copier.Copy(&courseModel, &course)
+	if err != nil {
+		return err
+	}
+
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		return createCoursePackage(tx, courseModel, coursePackage)
+	})
+}
+
+// GetFinishedModules implements CourseRepository
+func (cr *courseRepository) GetFinishedModules(courseID, customerID string) ([]string, error) {
+	return modulerepository.GetFinishedModules(cr.db, courseID, customerID)
+}
+
+// IsCourseNameUsed implements CourseRepository, the deleted courses still hold their name in the unique index
+func (cr *courseRepository) IsCourseNameUsed(name string) (bool, error) {
+	var used int64
+	err := cr.db.Unscoped().Model(&model.Course{}).Where("name = ?", name).Count(&used).Error
+	if err != nil {
+		return false, err
+	}
+	return used > 0, nil
+}
+
+// getCoursePackage reads the course with its ordered sections, modules, media, assignments, question banks and quizzes
+func getCoursePackage(tx *gorm.DB, id string) (dto.CoursePackage, error) {
+	var course model.Course
+	err := tx.Preload("Sections", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_section")
+	}).Preload("Modules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_module")
+	}).Preload("Modules.MediaModules").Preload("Modules.Assignment").Where("id = ?", id).Find(&course)
+	if err.Error != nil {
+		return dto.CoursePackage{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CoursePackage{}, gorm.ErrRecordNotFound
+	}
+
+	var category model.Category
+	errCategory := tx.Unscoped().Where("id = ?", course.CategoryID).Limit(1).Find(&category).Error
+	if errCategory != nil {
+		return dto.CoursePackage{}, errCategory
+	}
+
+	coursePackage := dto.CoursePackage{
+		FormatVersion: dto.CoursePackageVersion,
+		Course: dto.CoursePackageCourse{
+			Name:        course.Name,
+			Description: course.Description,
+			Objective:   course.Objective,
+			Price:       course.Price,
+			Discount:    course.Discount,
+			Thumbnail:   course.Thumbnail,
+			Capacity:    course.Capacity,
+			Category:    category.Name,
+		},
+		Modules: []dto.CoursePackageModule{},
+		Quizzes: []dto.CoursePackageQuiz{},
+	}
+	noSections := map[string]int{}
+	for i, section := range course.Sections {
+		noSections[section.ID] = i + 1
+		coursePackage.Sections = append(coursePackage.Sections, dto.CoursePackageSection{
+			Title:       section.Title,
+			Description: section.Description,
+		})
+	}
+	noModules := map[string]int{}
+	for i, module := range course.Modules {
+		noModules[module.ID] = i + 1
+		packageModule := dto.CoursePackageModule{
+			Name:     module.Name,
+			Content:  module.Content,
+			NoModule: module.NoModule,
+			Section:  noSections[module.SectionID],
+			Media:    []string{},
+		}
+		for _, media := range module.MediaModules {
+			packageModule.Media = append(packageModule.Media, media.Url)
+		}
+		if module.Assignment.ID != "" {
+			packageModule.Assignment = &dto.CoursePackageAssignment{
+				Title:            module.Assignment.Title,
+				Description:      module.Assignment.Description,
+				MaxPoints:        module.Assignment.MaxPoints,
+				MaxResubmissions: module.Assignment.MaxResubmissions,
+				LatePenalty:      module.Assignment.LatePenalty,
+			}
+		}
+		coursePackage.Modules = append(coursePackage.Modules, packageModule)
+	}
+
+	var banks []model.QuestionBank
+	errBank := tx.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at")
+	}).Preload("Questions.Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_option")
+	}).Where("course_id = ?", id).Order("created_at").Find(&banks).Error
+	if errBank != nil {
+		return dto.CoursePackage{}, errBank
+	}
+	noBanks := map[string]int{}
+	for i, bank := range banks {
+		noBanks[bank.ID] = i + 1
+		packageBank := dto.CoursePackageBank{
+			Title:       bank.Title,
+			Description: bank.Description,
+		}
+		for _, question := range bank.Questions {
+			packageBank.Questions = append(packageBank.Questions, getCoursePackageQuestion(question))
+		}
+		coursePackage.QuestionBanks = append(coursePackage.QuestionBanks, packageBank)
+	}
+
+	var quizzes []model.Quiz
+	errQuiz := tx.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_question")
+	}).Preload("Questions.Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_option")
+	}).Preload("Pools", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_pool")
+	}).Where("course_id = ?", id).Order("created_at").Find(&quizzes).Error
+	if errQuiz != nil {
+		return dto.CoursePackage{}, errQuiz
+	}
+	for _, quiz := range quizzes {
+		packageQuiz := dto.CoursePackageQuiz{
+			Title:            quiz.Title,
+			Description:      quiz.Description,
+			Link:             quiz.Link,
+			Module:           noModules[quiz.ModuleID],
+			TimeLimit:        quiz.TimeLimit,
+			PassingScore:     quiz.PassingScore,
+			MaxAttempts:      quiz.MaxAttempts,
+			ShuffleQuestions: quiz.ShuffleQuestions,
+			ShuffleOptions:   quiz.ShuffleOptions,
+		}
+		for _, question := range quiz.Questions {
+			packageQuiz.Questions = append(packageQuiz.Questions, getCoursePackageQuestion(question))
+		}
+		for _, pool := range quiz.Pools {
+			if noBanks[pool.BankID] == 0 {
+				continue
+			}
+			packageQuiz.Pools = append(packageQuiz.Pools, dto.CoursePackageQuizPool{
+				Bank:       noBanks[pool.BankID],
+				Topic:      pool.Topic,
+				Difficulty: pool.Difficulty,
+				Count:      pool.Count,
+			})
+		}
+		coursePackage.Quizzes = append(coursePackage.Quizzes, packageQuiz)
+	}
+	return coursePackage, nil
+}
+
+// getCoursePackageQuestion get the question of a quiz or a question bank as a question of the package
+func getCoursePackageQuestion(question model.QuizQuestion) dto.CoursePackageQuizQuestion {
+	packageQuestion := dto.CoursePackageQuizQuestion{
+		Type:       question.Type,
+		Text:       question.Text,
+		Points:     question.Points,
+		Topic:      question.Topic,
+		Difficulty: question.Difficulty,
+		Answer:     question.Answer,
+		Tolerance:  question.Tolerance,
+	}
+	for _, option := range question.Options {
+		packageQuestion.Options = append(packageQuestion.Options, dto.CoursePackageQuizOption{
+			Text:      option.Text,
+			IsCorrect: option.IsCorrect,
+		})
+	}
+	return packageQuestion
+}
+
+// getQuizQuestionModel get the question of the package as a new question with the number
+func getQuizQuestionModel(question dto.CoursePackageQuizQuestion, noQuestion int) model.QuizQuestion {
+	questionModel := model.QuizQuestion{
+		ID:         helper.GenerateUUID(),
+		Topic:      question.Topic,
+		Difficulty: question.Difficulty,
+		Type:       question.Type,
+		Text:       question.Text,
+		Points:     question.Points,
+		NoQuestion: noQuestion,
+		Answer:     question.Answer,
+		Tolerance:  question.Tolerance,
+	}
+	if questionModel.Points == 0 {
+		questionModel.Points = 1
+	}
+	for i, option := range question.Options {
+		questionModel.Options = append(questionModel.Options, model.QuizOption{
+			ID:        helper.GenerateUUID(),
+			Text:      option.Text,
+			IsCorrect: option.IsCorrect,
+			NoOption:  i + 1,
+		})
+	}
+	return questionModel
+}
+
+// createCoursePackage creates the course as a draft with the content of the package, every row gets a new id
+// and the sections and modules are numbered in the order of the package
+func createCoursePackage(tx *gorm.DB, course model.Course, coursePackage dto.CoursePackage) error {
+	var used int64
+	err := tx.Unscoped().Model(&model.Course{}).Where("name = ?", course.Name).Count(&used).Error
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return errors.New(constantError.ErrorCourseNameUsed)
+	}
+
+	course.Status = dto.CourseStatusDraft
+	course.Version = 0
+	course.Sections = nil
+	for i, section := range coursePackage.Sections {
+		course.Sections = append(course.Sections, model.Section{
+			ID:          helper.GenerateUUID(),
+			Title:       section.Title,
+			Description: section.Description,
+			NoSection:   i + 1,
+		})
+	}
+	course.Modules = nil
+	for i, module := range coursePackage.Modules {
+		moduleModel := model.Module{
+			ID:       helper.GenerateUUID(),
+			Name:     module.Name,
+			Content:  module.Content,
+			NoModule: i + 1,
+		}
+		if module.Section > 0 && module.Section <= len(course.Sections) {
+			moduleModel.SectionID = course.Sections[module.Section-1].ID
+		}
+		for _, url := range module.Media {
+			moduleModel.MediaModules = append(moduleModel.MediaModules, model.MediaModule{
+				ID:  helper.GenerateUUID(),
+				Url: url,
+			})
+		}
+		if module.Assignment != nil {
+			moduleModel.Assignment = model.Assignment{
+				ID:               helper.GenerateUUID(),
+				Title:            module.Assignment.Title,
+				Description:      module.Assignment.Description,
+				MaxPoints:        module.Assignment.MaxPoints,
+				MaxResubmissions: module.Assignment.MaxResubmissions,
+				LatePenalty:      module.Assignment.LatePenalty,
+			}
+		}
+		course.Modules = append(course.Modules, moduleModel)
+	}
+	err = tx.Create(&course).Error
+	if err != nil {
+		return err
+	}
+
+	var banks []model.QuestionBank
+	for _, bank := range coursePackage.QuestionBanks {
+		bankModel := model.QuestionBank{
+			ID:          helper.GenerateUUID(),
+			CourseID:    course.ID,
+			Title:       bank.Title,
+			Description: bank.Description,
+		}
+		for _, question := range bank.Questions {
+			bankModel.Questions = append(bankModel.Questions, getQuizQuestionModel(question, 0))
+		}
+		banks = append(banks, bankModel)
+	}
+	if len(banks) > 0 {
+		err = tx.Create(&banks).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	var quizzes []model.Quiz
+	for _, quiz := range coursePackage.Quizzes {
+		quizModel := model.Quiz{
+			ID:               helper.GenerateUUID(),
+			CourseID:         course.ID,
+			Title:            quiz.Title,
+			Description:      quiz.Description,
+			Link:             quiz.Link,
+			TimeLimit:        quiz.TimeLimit,
+			PassingScore:     quiz.PassingScore,
+			MaxAttempts:      quiz.MaxAttempts,
+			ShuffleQuestions: quiz.ShuffleQuestions,
+			ShuffleOptions:   quiz.ShuffleOptions,
+		}
+		if quiz.Module > 0 && quiz.Module <= len(course.Modules) {
+			quizModel.ModuleID = course.Modules[quiz.Module-1].ID
+		}
+		for j, question := range quiz.Questions {
+			quizModel.Questions = append(quizModel.Questions, getQuizQuestionModel(question, j+1))
+		}
+		for j, pool := range quiz.Pools {
+			if pool.Bank < 1 || pool.Bank > len(banks) {
+				continue
+			}
+			quizModel.Pools = append(quizModel.Pools, model.QuizPool{
+				ID:         helper.GenerateUUID(),
+				BankID:     banks[pool.Bank-1].ID,
+				Topic:      pool.Topic,
+				Difficulty: pool.Difficulty,
+				Count:      pool.Count,
+				NoPool:     j + 1,
+			})
+		}
+		quizzes = append(quizzes, quizModel)
+	}
+	if len(quizzes) > 0 {
+		err = tx.Create(&quizzes).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return courseStatsRepository.RefreshCourseStats(tx, course.ID)
+}
+
+// GetCourseCatalog implements CourseRepository, it returns one course more than the limit so the caller knows if there is a next page
+func (cr *courseRepository) GetCourseCatalog(user dto.User, query dto.CourseCatalogQuery, after *dto.CatalogCursor) ([]dto.Course, int64, error) {
+	var total int64
+	err := cr.catalogQuery(user, query).Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// the id is the tie breaker so the order is stable between pages
+	var column string
+	var value interface{}
+	switch query.Sort {
+	case dto.CatalogSortPopular:
+		column = "catalog.amount_customer"
+	case dto.CatalogSortTopRated:
+		column = "catalog.rating"
+	default:
+		column = "catalog.created_at"
+	}
+	catalog := cr.catalogQuery(user, query).Order(column + " DESC").Order("catalog.id DESC")
+	if after != nil {
+		value = after.Value
+		if column == "catalog.created_at" {
+			value = after.CreatedAt
+		}
+		catalog = catalog.Where("("+column+" < ? OR ("+column+" = ? AND catalog.id < ?))", value, value, after.ID)
+	} else {
+		catalog = catalog.Offset((query.Page - 1) * query.Limit)
+	}
+
+	var courses []dto.Course
+	err = catalog.Limit(query.Limit + 1).Scan(&courses).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	err = cr.getCatalogCategories(courses)
+	if err != nil {
+		return nil, 0, err
+	}
+	return courses, total, nil
+}
+
+// GetCourseCatalogByIDs implements CourseRepository
+func (cr *courseRepository) GetCourseCatalogByIDs(user dto.User, ids []string) ([]dto.Course, error) {
+	var courses []dto.Course
+	if len(ids) == 0 {
+		return courses, nil
+	}
+	err := cr.catalogQuery(user, dto.CourseCatalogQuery{}).Where("catalog.id IN ?", ids).Scan(&courses).Error
+	if err != nil {
+		return nil, err
+	}
+	err = cr.getCatalogCategories(courses)
+	if err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// getCatalogCategories get the categories of the courses with one query
+func (cr *courseRepository) getCatalogCategories(courses []dto.Course) error {
+	if len(courses) == 0 {
+		return nil
+	}
+	var categoryIDs []string
+	for _, course := range courses {
+		categoryIDs = append(categoryIDs, course.CategoryID)
+	}
+	var categories []dto.Category
+	err := cr.db.Model(&model.Category{}).Where("id IN ?", categoryIDs).Find(&categories).Error
+	if err != nil {
+		return err
+	}
+	for i := range courses {
+		for _, category := range categories {
+			if category.ID == courses[i].CategoryID {
+				courses[i].Category = category
+			}
+		}
+	}
+	return nil
+}
+
+// GetCourseSearchData implements CourseRepository, all published courses are returned when no id is given
+func (cr *courseRepository) GetCourseSearchData(ids ...string) ([]dto.CourseSearchData, error) {
+	var courses []dto.CourseSearchData
+	query := cr.db.Model(&model.Course{}).
+		Select("courses.id, courses.name, courses.description, courses.objective, categories.name AS category_name, instructors.name AS instructor_name").
+		Joins("LEFT JOIN categories ON categories.id = courses.category_id").
+		Joins("LEFT JOIN instructors ON instructors.id = courses.instructor_id").
+		Where("courses.status = ?", dto.CourseStatusPublished)
+	if len(ids) > 0 {
+		query = query.Where("courses.id IN ?", ids)
+	}
+	err := query.Scan(&courses).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(courses) == 0 {
+		return courses, nil
+	}
+
+	// get the module names of the courses
+	courseIDs := make([]string, len(courses))
+	for i, course := range courses {
+		courseIDs[i] = course.ID
+	}
+	var modules []model.Module
+	err = cr.db.Select("course_id", "name").Where("course_id IN ?", courseIDs).Order("no_module").Find(&modules).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range courses {
+		for _, module := range modules {
+			if module.CourseID == courses[i].ID {
+				courses[i].ModuleNames = append(courses[i].ModuleNames, module.Name)
+			}
+		}
+	}
+	return courses, nil
+}
+
+// catalogQuery select the courses with the rating, enrollment and module stats and applies the filters of the query
+func (cr *courseRepository) catalogQuery(user dto.User, query dto.CourseCatalogQuery) *gorm.DB {
+	favorites := cr.db.Model(&model.Favorite{}).Select("1").Where("favorites.course_id = courses.id AND favorites.customer_id = ?", user.ID)
+
+	courses := cr.db.Model(&model.Course{}).
+		Select("courses.id, courses.created_at, courses.name, courses.description, courses.objective, courses.price, courses.discount, courses.thumbnail, courses.capacity, courses.instructor_id, courses.category_id, courses.status, "+
+			"COALESCE(course_stats.rating_average, 0) AS rating, COALESCE(course_stats.enrolled_count, 0) AS amount_customer, COALESCE(course_stats.module_count, 0) AS number_of_modules, "+
+			"COALESCE(customer_courses.status, false) AS status_enroll, COALESCE(customer_courses.no_module, 0) AS progress_module, COALESCE(customer_courses.is_finish, false) AS is_finish, "+
+			"EXISTS (?) AS favorite", favorites).
+		Where("courses.status = ?", dto.CourseStatusPublished).
+		Joins("LEFT JOIN course_stats ON course_stats.course_id = courses.id").
+		Joins("LEFT JOIN customer_courses ON customer_courses.course_id = courses.id AND customer_courses.customer_id = ? AND customer_courses.deleted_at IS NULL", user.ID)
+
+	catalog := cr.db.Table("(?) AS catalog", courses)
+	if query.Search != "" {
+		catalog = catalog.Where("(catalog.name LIKE ? OR catalog.description LIKE ?)", "%"+query.Search+"%", "%"+query.Search+"%")
+	}
+	if query.CategoryID != "" {
+		catalog = catalog.Where("catalog.category_id = ?", query.CategoryID)
+	}
+	if query.MinPrice > 0 {
+		catalog = catalog.Where("catalog.price - catalog.discount >= ?", query.MinPrice)
+	}
+	if query.MaxPrice > 0 {
+		catalog = catalog.Where("catalog.price - catalog.discount <= ?", query.MaxPrice)
+	}
+	if query.Free {
+		catalog = catalog.Where("catalog.price - catalog.discount <= 0")
+	}
+	if query.Discounted {
+		catalog = catalog.Where("catalog.discount > 0")
+	}
+	if query.MinRating > 0 {
+		catalog = catalog.Where("catalog.rating >= ?", query.MinRating)
+	}
+	return catalog
+}
+
 // GetCourseByID implements CourseRepository
 func (cr *courseRepository) GetCourseByID(id string) (dto.Course, error) {
 	var courseModel dto.GetCourseCategory
-	err := cr.db.Model(&model.Course{}).Preload("Category").Preload("CustomerCourses").Preload("Favorites").Preload("Ratings").Preload("Modules").Where("id = ? ", id).Find(&courseModel)
+	err := cr.db.Model(&model.Course{}).Preload("Category").Preload("CustomerCourses").Preload("Favorites").Preload("Ratings").Preload("Modules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_module")
+	}).Preload("Sections", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_section")
+	}).Preload("Stats").Where("id = ? ", id).Find(&courseModel)
 	if err.Error != nil {
 		return dto.Course{}, err.Error
 	}
@@ -118,6 +670,103 @@ func (cr *courseRepository) UpdateCourse(course dto.CourseTransaction) error {
 	return nil
 }
 
+// GetCourseContent implements CourseRepository
+func (cr *courseRepository) GetCourseContent(id string) (dto.CourseContent, error) {
+	var content dto.CourseContent
+	err := cr.db.Model(&model.Module{}).Where("course_id = ?", id).Count(&content.Modules).Error
+	if err != nil {
+		return dto.CourseContent{}, err
+	}
+	// a media module is made with every module, only the ones with an url count
+	err = cr.db.Model(&model.MediaModule{}).
+		Joins("JOIN modules ON modules.id = media_modules.module_id AND modules.deleted_at IS NULL").
+		Where("modules.course_id = ? AND media_modules.url <> ''", id).
+		Count(&content.MediaModules).Error
+	if err != nil {
+		return dto.CourseContent{}, err
+	}
+	return content, nil
+}
+
+// UpdateCourseStatus implements CourseRepository
+func (cr *courseRepository) UpdateCourseStatus(id string, status string) error {
+	err := cr.db.Model(&model.Course{}).Where("id = ?", id).Update("status", status)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AddCoursePrerequisite implements CourseRepository, a prerequisite already added is ignored
+func (cr *courseRepository) AddCoursePrerequisite(courseID, prerequisiteID string) error {
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		// the prerequisites are locked so two new prerequisites can't make a cycle together
+		var prerequisites []model.CoursePrerequisite
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&prerequisites).Error
+		if err != nil {
+			return err
+		}
+		coursePrerequisites := map[string][]string{}
+		for _, prerequisite := range prerequisites {
+			coursePrerequisites[prerequisite.CourseID] = append(coursePrerequisites[prerequisite.CourseID], prerequisite.PrerequisiteID)
+		}
+
+		// the course can't be a prerequisite of its own prerequisite
+		visited := map[string]bool{}
+		queue := []string{prerequisiteID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if id == courseID {
+				return errors.New(constantError.ErrorPrerequisiteCycle)
+			}
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			queue = append(queue, coursePrerequisites[id]...)
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.CoursePrerequisite{
+			CourseID:       courseID,
+			PrerequisiteID: prerequisiteID,
+		}).Error
+	})
+}
+
+// DeleteCoursePrerequisite implements CourseRepository
+func (cr *courseRepository) DeleteCoursePrerequisite(courseID, prerequisiteID string) error {
+	err := cr.db.Where("course_id = ? AND prerequisite_id = ?", courseID, prerequisiteID).Delete(&model.CoursePrerequisite{})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetCoursePrerequisites implements CourseRepository, the prerequisites finished by the customer are marked
+// and the deleted courses are not prerequisites anymore
+func (cr *courseRepository) GetCoursePrerequisites(courseID, customerID string) ([]dto.CoursePrerequisite, error) {
+	var prerequisites []dto.CoursePrerequisite
+	err := cr.db.Model(&model.CoursePrerequisite{}).
+		Select("courses.id AS id", "courses.name AS name", "COALESCE(customer_courses.is_finish, false) AS is_finish").
+		Joins("JOIN courses ON courses.id = course_prerequisites.prerequisite_id AND courses.deleted_at IS NULL").
+		Joins("LEFT JOIN customer_courses ON customer_courses.course_id = courses.id AND customer_courses.customer_id = ? AND customer_courses.deleted_at IS NULL", customerID).
+		Where("course_prerequisites.course_id = ?", courseID).
+		Order("courses.name").
+		Scan(&prerequisites).Error
+	if err != nil {
+		return nil, err
+	}
+	return prerequisites, nil
+}
+
 func NewCourseRepository(db *gorm.DB) CourseRepository {
 	return &courseRepository{
 		db: db,
@@ -8,5 +8,19 @@ type CourseRepository interface {
 	GetCourseByID(string) (dto.Course, error)
 	GetCourseEnrollByID(string) ([]dto.CustomerCourseEnroll, error)
 	GetAllCourse(dto.User) ([]dto.Course, error)
+	GetCourseTemplates(instructorID string) ([]dto.Course, error)
+	DuplicateCourse(id string, course dto.CourseDuplicate) error
+	GetCoursePackage(id string) (dto.CoursePackage, error)
+	ImportCoursePackage(course dto.CourseTransaction, coursePackage dto.CoursePackage) error
+	IsCourseNameUsed(name string) (bool, error)
+	GetFinishedModules(courseID, customerID string) ([]string, error)
+	GetCourseCatalog(user dto.User, query dto.CourseCatalogQuery, after *dto.CatalogCursor) ([]dto.Course, int64, error)
+	GetCourseCatalogByIDs(user dto.User, ids []string) ([]dto.Course, error)
+	GetCourseSearchData(ids ...string) ([]dto.CourseSearchData, error)
+	GetCourseContent(id string) (dto.CourseContent, error)
 	UpdateCourse(dto.CourseTransaction) error
+	UpdateCourseStatus(id, status string) error
+	AddCoursePrerequisite(courseID, prerequisiteID string) error
+	DeleteCoursePrerequisite(courseID, prerequisiteID string) error
+	GetCoursePrerequisites(courseID, customerID string) ([]dto.CoursePrerequisite, error)
 }
\ No newline at end of file
@@ -0,0 +1,15 @@
+package courseStatsMockRepository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type CourseStatsMock struct {
+	mock.Mock
+}
+
+func (c *CourseStatsMock) RebuildCourseStats() (int, error) {
+	args := c.Called()
+
+	return args.Int(0), args.Error(1)
+}
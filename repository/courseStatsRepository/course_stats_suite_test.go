@@ -0,0 +1,203 @@
+package courseStatsRepository
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type suiteCourseStats struct {
+	suite.Suite
+	db              *gorm.DB
+	courseStatsRepo CourseStatsRepository
+	mock            sqlmock.Sqlmock
+}
+
+func (s *suiteCourseStats) SetupTest() {
+	sqlDB, mock, err := sqlmock.New()
+	s.NoError(err)
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	s.NoError(err)
+
+	s.db = db
+	s.mock = mock
+	s.courseStatsRepo = NewCourseStatsRepository(db)
+}
+
+// expectStats expects the queries of RefreshCourseStats for one existing course and the upsert of its stats,
+// the stats are course_id, rating_average, rating_count, rating1..rating5, enrolled_count, completed_count and module_count
+func (s *suiteCourseStats) expectStats(ratings, enrollments, modules *sqlmock.Rows, stats ...interface{}) {
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `courses` WHERE id IN (?)")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("abcde"))
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `course_stats` WHERE course_id IN (?) AND course_id NOT IN (?,?)")).
+		WithArgs("abcde", "abcde", "").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `ratings` WHERE course_id IN (?)")).
+		WillReturnRows(ratings)
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `customer_courses` WHERE course_id IN (?)")).
+		WillReturnRows(enrollments)
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `modules` WHERE course_id IN (?)")).
+		WillReturnRows(modules)
+
+	// the stats are saved in batches with a savepoint and the updated_at is set by gorm on insert and on update
+	s.mock.ExpectExec("SAVEPOINT").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	args := []interface{}{stats[0], sqlmock.AnyArg()}
+	for _, stat := range stats[1:] {
+		args = append(args, stat)
+	}
+	args = append(args, sqlmock.AnyArg())
+	s.mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `course_stats`")).
+		WithArgs(toDriverValues(args)...).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *suiteCourseStats) TestRefreshCourseStats() {
+	ratingColumns := []string{"course_id", "rating_average", "rating_count", "rating1", "rating2", "rating3", "rating4", "rating5"}
+	enrollmentColumns := []string{"course_id", "enrolled_count", "completed_count"}
+	moduleColumns := []string{"course_id", "module_count"}
+
+	testCase := []struct {
+		Name        string
+		Ratings     *sqlmock.Rows
+		Enrollments *sqlmock.Rows
+		Modules     *sqlmock.Rows
+		Stats       []interface{}
+	}{
+		{
+			"success course without data",
+			sqlmock.NewRows(ratingColumns),
+			sqlmock.NewRows(enrollmentColumns),
+			sqlmock.NewRows(moduleColumns),
+			[]interface{}{"abcde", 0.0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			"success rating change",
+			sqlmock.NewRows(ratingColumns).AddRow("abcde", 3.5, 4, 1, 0, 1, 0, 2),
+			sqlmock.NewRows(enrollmentColumns),
+			sqlmock.NewRows(moduleColumns),
+			[]interface{}{"abcde", 3.5, 4, 1, 0, 1, 0, 2, 0, 0, 0},
+		},
+		{
+			"success enrollment change",
+			sqlmock.NewRows(ratingColumns).AddRow("abcde", 5.0, 1, 0, 0, 0, 0, 1),
+			sqlmock.NewRows(enrollmentColumns).AddRow("abcde", 3, 1),
+			sqlmock.NewRows(moduleColumns),
+			[]interface{}{"abcde", 5.0, 1, 0, 0, 0, 0, 1, 3, 1, 0},
+		},
+		{
+			"success module change",
+			sqlmock.NewRows(ratingColumns),
+			sqlmock.NewRows(enrollmentColumns).AddRow("abcde", 3, 1),
+			sqlmock.NewRows(moduleColumns).AddRow("abcde", 6),
+			[]interface{}{"abcde", 0.0, 0, 0, 0, 0, 0, 0, 3, 1, 6},
+		},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			s.mock.ExpectBegin()
+			s.expectStats(v.Ratings, v.Enrollments, v.Modules, v.Stats...)
+			s.mock.ExpectCommit()
+
+			// the same course twice is computed once
+			err := s.db.Transaction(func(tx *gorm.DB) error {
+				return RefreshCourseStats(tx, "abcde", "", "abcde")
+			})
+			s.NoError(err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *suiteCourseStats) TestRefreshCourseStatsDeletedCourse() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `courses` WHERE id IN (?)")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `course_stats` WHERE course_id IN (?) AND course_id NOT IN (?)")).
+		WithArgs("abcde", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		return RefreshCourseStats(tx, "abcde")
+	})
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteCourseStats) TestRefreshCourseStatsWithoutCourse() {
+	err := RefreshCourseStats(s.db, "")
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteCourseStats) TestRebuildCourseStats() {
+	testCase := []struct {
+		Name               string
+		MockReturnPluckErr error
+		ExpectedCount      int
+		HasReturnError     bool
+	}{
+		{"success rebuild course stats", nil, 1, false},
+		{"fail get courses", errors.New("error"), 0, true},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			s.mock.ExpectBegin()
+			s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `course_stats` WHERE 1 = 1")).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+			if v.HasReturnError {
+				s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `courses`")).
+					WillReturnError(v.MockReturnPluckErr)
+				s.mock.ExpectRollback()
+			} else {
+				s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `courses`")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("abcde"))
+				s.expectStats(
+					sqlmock.NewRows([]string{"course_id", "rating_average", "rating_count", "rating1", "rating2", "rating3", "rating4", "rating5"}).AddRow("abcde", 4.0, 2, 0, 0, 1, 0, 1),
+					sqlmock.NewRows([]string{"course_id", "enrolled_count", "completed_count"}).AddRow("abcde", 2, 0),
+					sqlmock.NewRows([]string{"course_id", "module_count"}).AddRow("abcde", 3),
+					"abcde", 4.0, 2, 0, 0, 1, 0, 1, 2, 0, 3,
+				)
+				s.mock.ExpectCommit()
+			}
+
+			count, err := s.courseStatsRepo.RebuildCourseStats()
+			if v.HasReturnError {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedCount, count)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// toDriverValues turns the ints of the expected stats into the int64 the driver receives
+func toDriverValues(args []interface{}) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if n, ok := arg.(int); ok {
+			values[i] = int64(n)
+			continue
+		}
+		values[i] = arg
+	}
+	return values
+}
+
+func TestSuiteCourseStats(t *testing.T) {
+	suite.Run(t, new(suiteCourseStats))
+}
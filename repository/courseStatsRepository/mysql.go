@@ -0,0 +1,127 @@
+package courseStatsRepository
+
+import (
+	"golang/models/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type courseStatsRepository struct {
+	db *gorm.DB
+}
+
+// RebuildCourseStats implements CourseStatsRepository, the stats of every course are computed again from scratch
+func (csr *courseStatsRepository) RebuildCourseStats() (int, error) {
+	var courseIDs []string
+	err := csr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("1 = 1").Delete(&model.CourseStats{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&model.Course{}).Pluck("id", &courseIDs).Error
+		if err != nil {
+			return err
+		}
+		return RefreshCourseStats(tx, courseIDs...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(courseIDs), nil
+}
+
+// RefreshCourseStats computes the stats of the courses again, it is called with the transaction
+// of every write to the ratings, enrollments and modules so the stats never drift from the data
+func RefreshCourseStats(tx *gorm.DB, courseIDs ...string) error {
+	var ids []string
+	seen := map[string]bool{}
+	for _, id := range courseIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// the courses that are deleted lose their stats
+	var existingIDs []string
+	err := tx.Model(&model.Course{}).Where("id IN ?", ids).Pluck("id", &existingIDs).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("course_id IN ? AND course_id NOT IN ?", ids, append(existingIDs, "")).Delete(&model.CourseStats{}).Error
+	if err != nil {
+		return err
+	}
+	if len(existingIDs) == 0 {
+		return nil
+	}
+
+	stats := map[string]*model.CourseStats{}
+	for _, id := range existingIDs {
+		stats[id] = &model.CourseStats{CourseID: id}
+	}
+
+	var ratings []model.CourseStats
+	err = tx.Model(&model.Rating{}).
+		Select("course_id, AVG(rating) AS rating_average, COUNT(*) AS rating_count, "+
+			"SUM(rating = 1) AS rating1, SUM(rating = 2) AS rating2, SUM(rating = 3) AS rating3, SUM(rating = 4) AS rating4, SUM(rating = 5) AS rating5").
+		Where("course_id IN ?", existingIDs).
+		Group("course_id").
+		Scan(&ratings).Error
+	if err != nil {
+		return err
+	}
+	for _, rating := range ratings {
+		stat := stats[rating.CourseID]
+		stat.RatingAverage = rating.RatingAverage
+		stat.RatingCount = rating.RatingCount
+		stat.Rating1 = rating.Rating1
+		stat.Rating2 = rating.Rating2
+		stat.Rating3 = rating.Rating3
+		stat.Rating4 = rating.Rating4
+		stat.Rating5 = rating.Rating5
+	}
+
+	var enrollments []model.CourseStats
+	err = tx.Model(&model.CustomerCourse{}).
+		Select("course_id, COUNT(*) AS enrolled_count, SUM(is_finish) AS completed_count").
+		Where("course_id IN ?", existingIDs).
+		Group("course_id").
+		Scan(&enrollments).Error
+	if err != nil {
+		return err
+	}
+	for _, enrollment := range enrollments {
+		stats[enrollment.CourseID].EnrolledCount = enrollment.EnrolledCount
+		stats[enrollment.CourseID].CompletedCount = enrollment.CompletedCount
+	}
+
+	var modules []model.CourseStats
+	err = tx.Model(&model.Module{}).
+		Select("course_id, COUNT(*) AS module_count").
+		Where("course_id IN ?", existingIDs).
+		Group("course_id").
+		Scan(&modules).Error
+	if err != nil {
+		return err
+	}
+	for _, module := range modules {
+		stats[module.CourseID].ModuleCount = module.ModuleCount
+	}
+
+	rows := make([]model.CourseStats, 0, len(stats))
+	for _, id := range existingIDs {
+		rows = append(rows, *stats[id])
+	}
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(&rows, 100).Error
+}
+
+func NewCourseStatsRepository(db *gorm.DB) CourseStatsRepository {
+	return &courseStatsRepository{
+		db: db,
+	}
+}
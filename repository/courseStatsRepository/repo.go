@@ -0,0 +1,5 @@
+package courseStatsRepository
+
+type CourseStatsRepository interface {
+	RebuildCourseStats() (int, error)
+}
@@ -0,0 +1,37 @@
+package courseVersionMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CourseVersionMock struct {
+	mock.Mock
+}
+
+func (c *CourseVersionMock) PublishCourseVersion(courseID string) (dto.CourseVersion, error) {
+	args := c.Called(courseID)
+
+	return args.Get(0).(dto.CourseVersion), args.Error(1)
+}
+func (c *CourseVersionMock) GetCourseVersion(id string) (dto.CourseVersion, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.CourseVersion), args.Error(1)
+}
+func (c *CourseVersionMock) GetLatestCourseVersion(courseID string) (dto.CourseVersion, error) {
+	args := c.Called(courseID)
+
+	return args.Get(0).(dto.CourseVersion), args.Error(1)
+}
+func (c *CourseVersionMock) GetCourseVersions(courseID string) ([]dto.CourseVersion, error) {
+	args := c.Called(courseID)
+
+	return args.Get(0).([]dto.CourseVersion), args.Error(1)
+}
+func (c *CourseVersionMock) MigrateEnrollment(customerCourse dto.CustomerCourse) (dto.CustomerCourse, error) {
+	args := c.Called(customerCourse)
+
+	return args.Get(0).(dto.CustomerCourse), args.Error(1)
+}
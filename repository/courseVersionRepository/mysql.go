@@ -0,0 +1,167 @@
+package courseVersionRepository
+
+import (
+	"golang/helper"
+	"golang/models/dto"
+	"golang/models/model"
+	"golang/repository/courseStatsRepository"
+	modulerepository "golang/repository/moduleRepository"
+
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type courseVersionRepository struct {
+	db *gorm.DB
+}
+
+// PublishCourseVersion implements CourseVersionRepository, the modules of the course are copied to a new version
+func (cvr *courseVersionRepository) PublishCourseVersion(courseID string) (dto.CourseVersion, error) {
+	var version model.CourseVersion
+	err := cvr.db.Transaction(func(tx *gorm.DB) error {
+		// lock the course so two versions can't get the same number
+		var course model.Course
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", courseID).Find(&course)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		var modules []model.Module
+		errFind := tx.Preload("MediaModules").Where("course_id = ?", courseID).Order("no_module").Find(&modules).Error
+		if errFind != nil {
+			return errFind
+		}
+
+		version = model.CourseVersion{
+			ID:       helper.GenerateUUID(),
+			CourseID: courseID,
+			Version:  course.Version + 1,
+		}
+		for _, module := range modules {
+			versionModule := model.CourseVersionModule{
+				ID:              helper.GenerateUUID(),
+				CourseVersionID: version.ID,
+				ModuleID:        module.ID,
+				NoModule:        module.NoModule,
+				Name:            module.Name,
+				Content:         module.Content,
+			}
+			for _, media := range module.MediaModules {
+				if media.Url == "" {
+					continue
+				}
+				versionModule.Media = append(versionModule.Media, model.CourseVersionMedia{
+					ID:                    helper.GenerateUUID(),
+					CourseVersionModuleID: versionModule.ID,
+					MediaModuleID:         media.ID,
+					Url:                   media.Url,
+				})
+			}
+			version.Modules = append(version.Modules, versionModule)
+		}
+		errCreate := tx.Create(&version).Error
+		if errCreate != nil {
+			return errCreate
+		}
+
+		errUpdate := tx.Model(&model.Course{}).Where("id = ?", courseID).Update("version", version.Version).Error
+		if errUpdate != nil {
+			return errUpdate
+		}
+		// the enrollments made before the first version learn the content of the first version
+		return tx.Model(&model.CustomerCourse{}).Where("course_id = ? AND (course_version_id = '' OR course_version_id IS NULL)", courseID).Update("course_version_id", version.ID).Error
+	})
+	if err != nil {
+		return dto.CourseVersion{}, err
+	}
+
+	var courseVersion dto.CourseVersion
+	err = copier.Copy(&courseVersion, &version)
+	if err != nil {
+		return dto.CourseVersion{}, err
+	}
+	return courseVersion, nil
+}
+
+// GetCourseVersion implements CourseVersionRepository
+func (cvr *courseVersionRepository) GetCourseVersion(id string) (dto.CourseVersion, error) {
+	var version dto.CourseVersion
+	err := cvr.db.Model(&model.CourseVersion{}).Preload("Modules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_module")
+	}).Preload("Modules.Media").Where("id = ?", id).Find(&version)
+	if err.Error != nil {
+		return dto.CourseVersion{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CourseVersion{}, gorm.ErrRecordNotFound
+	}
+	return version, nil
+}
+
+// GetLatestCourseVersion implements CourseVersionRepository
+func (cvr *courseVersionRepository) GetLatestCourseVersion(courseID string) (dto.CourseVersion, error) {
+	var version dto.CourseVersion
+	err := cvr.db.Model(&model.CourseVersion{}).Preload("Modules", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_module")
+	}).Preload("Modules.Media").Where("course_id = ?", courseID).Order("version DESC").Limit(1).Find(&version)
+	if err.Error != nil {
+		return dto.CourseVersion{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CourseVersion{}, gorm.ErrRecordNotFound
+	}
+	return version, nil
+}
+
+// GetCourseVersions implements CourseVersionRepository, the modules of the versions are not loaded
+func (cvr *courseVersionRepository) GetCourseVersions(courseID string) ([]dto.CourseVersion, error) {
+	var versions []dto.CourseVersion
+	err := cvr.db.Model(&model.CourseVersion{}).Where("course_id = ?", courseID).Order("version DESC").Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// MigrateEnrollment implements CourseVersionRepository, the progress is computed again from the modules the customer finished
+func (cvr *courseVersionRepository) MigrateEnrollment(customerCourse dto.CustomerCourse) (dto.CustomerCourse, error) {
+	var customerCourseModel model.CustomerCourse
+	err := cvr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.CustomerCourse{}).Where("id = ?", customerCourse.ID).Update("course_version_id", customerCourse.CourseVersionID)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errProgress := modulerepository.RefreshCustomerProgress(tx, customerCourse.CourseID, customerCourse.CustomerID)
+		if errProgress != nil {
+			return errProgress
+		}
+		errFind := tx.Where("id = ?", customerCourse.ID).Find(&customerCourseModel).Error
+		if errFind != nil {
+			return errFind
+		}
+		return courseStatsRepository.RefreshCourseStats(tx, customerCourse.CourseID)
+	})
+	if err != nil {
+		return dto.CustomerCourse{}, err
+	}
+
+	var migrated dto.CustomerCourse
+	err = copier.Copy(&migrated, &customerCourseModel)
+	if err != nil {
+		return dto.CustomerCourse{}, err
+	}
+	return migrated, nil
+}
+
+func NewCourseVersionRepository(db *gorm.DB) CourseVersionRepository {
+	return &courseVersionRepository{
+		db: db,
+	}
+}
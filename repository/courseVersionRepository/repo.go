@@ -0,0 +1,11 @@
+package courseVersionRepository
+
+import "golang/models/dto"
+
+type CourseVersionRepository interface {
+	PublishCourseVersion(courseID string) (dto.CourseVersion, error)
+	GetCourseVersion(id string) (dto.CourseVersion, error)
+	GetLatestCourseVersion(courseID string) (dto.CourseVersion, error)
+	GetCourseVersions(courseID string) ([]dto.CourseVersion, error)
+	MigrateEnrollment(customerCourse dto.CustomerCourse) (dto.CustomerCourse, error)
+}
@@ -10,32 +10,68 @@ type CustomerAssignmentMock struct {
 	mock.Mock
 }
 
-func (c *CustomerAssignmentMock) CreateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
+func (c *CustomerAssignmentMock) GetAssignment(id string) (dto.Assignment, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.Assignment), args.Error(1)
+}
+
+func (c *CustomerAssignmentMock) CreateCustomerAssignment(customerAssignment dto.CustomerAssignment) error {
 	args := c.Called(customerAssignment)
 
 	return args.Error(0)
 }
 
+func (c *CustomerAssignmentMock) UpdateCustomerAssignmentDraft(id, file string) error {
+	args := c.Called(id, file)
+
+	return args.Error(0)
+}
+
+func (c *CustomerAssignmentMock) SubmitCustomerAssignment(submission dto.CustomerAssignment, version dto.CustomerAssignmentVersion) error {
+	args := c.Called(submission, version)
+
+	return args.Error(0)
+}
+
+func (c *CustomerAssignmentMock) GradeCustomerAssignment(submission dto.CustomerAssignment) error {
+	args := c.Called(submission)
+
+	return args.Error(0)
+}
+
+func (c *CustomerAssignmentMock) ReturnCustomerAssignment(id, feedback string) error {
+	args := c.Called(id, feedback)
+
+	return args.Error(0)
+}
+
 func (c *CustomerAssignmentMock) DeleteCustomerAssignment(id string) error {
 	args := c.Called(id)
 
 	return args.Error(0)
 }
 
-func (c *CustomerAssignmentMock) GetAllCustomerAssignment() ([]dto.CustomerAssignmentAcc, error) {
-	args := c.Called()
+func (c *CustomerAssignmentMock) GetCustomerAssignment(assignmentID, customerID string) (dto.CustomerAssignment, error) {
+	args := c.Called(assignmentID, customerID)
 
-	return args.Get(0).([]dto.CustomerAssignmentAcc), args.Error(1)
+	return args.Get(0).(dto.CustomerAssignment), args.Error(1)
 }
 
-func (c *CustomerAssignmentMock) GetCustomerAssignmentByID(id string) (dto.CustomerAssignmentAcc, error) {
+func (c *CustomerAssignmentMock) GetCustomerAssignmentByID(id string) (dto.CustomerAssignment, error) {
 	args := c.Called(id)
 
-	return args.Get(0).(dto.CustomerAssignmentAcc), args.Error(1)
+	return args.Get(0).(dto.CustomerAssignment), args.Error(1)
 }
 
-func (c *CustomerAssignmentMock) UpdateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
-	args := c.Called(customerAssignment)
+func (c *CustomerAssignmentMock) GetCustomerAssignments(customerID string) ([]dto.CustomerAssignment, error) {
+	args := c.Called(customerID)
 
-	return args.Error(0)
+	return args.Get(0).([]dto.CustomerAssignment), args.Error(1)
+}
+
+func (c *CustomerAssignmentMock) GetCustomerAssignmentsByAssignmentID(assignmentID string) ([]dto.CustomerAssignment, error) {
+	args := c.Called(assignmentID)
+
+	return args.Get(0).([]dto.CustomerAssignment), args.Error(1)
 }
@@ -2,164 +2,319 @@ package customerassignmentrepository
 
 import (
 	"errors"
-	"fmt"
 	"golang/constant/constantError"
 	"golang/models/dto"
 	"golang/models/model"
+	"golang/repository/progressionRepository"
 
 	"github.com/jinzhu/copier"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type customerAssignmentRepository struct {
 	db *gorm.DB
 }
 
-// CreatecustomerAssignment implements customerAssignmentRepository
-func (ctr *customerAssignmentRepository) CreateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
-	var customerAssignmentModel model.CustomerAssignment
-	err := copier.Copy(&customerAssignmentModel, &customerAssignment)
-	if err != nil {
-		return err
+// GetAssignment implements CustomerAssignmentRepository, the assignment is read with the course of its module
+func (ctr *customerAssignmentRepository) GetAssignment(id string) (dto.Assignment, error) {
+	var assignmentModel model.Assignment
+	err := ctr.db.Where("id = ?", id).Find(&assignmentModel)
+	if err.Error != nil {
+		return dto.Assignment{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.Assignment{}, gorm.ErrRecordNotFound
 	}
 
-	var getAllCustomerAssignment []model.CustomerAssignment
-
-	ctr.db.Find(&getAllCustomerAssignment)
-
-	for _, gaca := range getAllCustomerAssignment {
-		if customerAssignment.CustomerID == gaca.CustomerID {
-			if customerAssignment.AssignmentID == gaca.AssignmentID {
-				return errors.New(constantError.ErrorDuplicateAssignmentCustomer)
-			}
-		}
+	var assignment dto.Assignment
+	errCopy := copier.Copy(&assignment, &assignmentModel)
+	if errCopy != nil {
+		return dto.Assignment{}, errCopy
+	}
+	var module model.Module
+	err = ctr.db.Select("course_id").Where("id = ?", assignmentModel.ModuleID).Find(&module)
+	if err.Error != nil {
+		return dto.Assignment{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.Assignment{}, gorm.ErrRecordNotFound
 	}
+	assignment.CourseID = module.CourseID
+	return assignment, nil
+}
 
-	result := ctr.db.Model(&model.CustomerAssignment{}).Create(&customerAssignmentModel)
-	var storage dto.CustomerAssignment = dto.CustomerAssignment{}
-	result.Last(&storage)
-	fmt.Println(storage)
+// CreateCustomerAssignment implements CustomerAssignmentRepository, a customer has one submission of an assignment
+func (ctr *customerAssignmentRepository) CreateCustomerAssignment(customerAssignment dto.CustomerAssignment) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := lockAssignment(tx, customerAssignment.AssignmentID)
+		if err != nil {
+			return err
+		}
+		exists, err := hasCustomerAssignment(tx, customerAssignment.AssignmentID, customerAssignment.CustomerID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New(constantError.ErrorAssignmentChanged)
+		}
 
-	var getAssignment model.Assignment
-	ctr.db.Where("id=?", storage.AssignmentID).Find(&getAssignment)
+		return tx.Create(&model.CustomerAssignment{
+			ID:           customerAssignment.ID,
+			File:         customerAssignment.File,
+			AssignmentID: customerAssignment.AssignmentID,
+			CustomerID:   customerAssignment.CustomerID,
+			Status:       dto.AssignmentDraft,
+		}).Error
+	})
+}
 
-	var getModule model.Module
-	ctr.db.Where("id=?", getAssignment.ModuleID).Find(&getModule)
+// UpdateCustomerAssignmentDraft implements CustomerAssignmentRepository, only a draft or a returned submission is changed
+func (ctr *customerAssignmentRepository) UpdateCustomerAssignmentDraft(id, file string) error {
+	err := ctr.db.Model(&model.CustomerAssignment{}).
+		Where("id = ? AND status IN ?", id, []string{dto.AssignmentDraft, dto.AssignmentReturned}).
+		Update("file", file)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return errors.New(constantError.ErrorAssignmentSubmitted)
+	}
+	return nil
+}
 
-	var updateCustomerCourse model.CustomerCourse
+// SubmitCustomerAssignment implements CustomerAssignmentRepository, the submission is saved with its new version
+// only when no other version was submitted and it was not graded since it was read.
+// The completion rules of the module are evaluated again, the module is finished when all its rules are met
+func (ctr *customerAssignmentRepository) SubmitCustomerAssignment(submission dto.CustomerAssignment, version dto.CustomerAssignmentVersion) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := lockAssignment(tx, submission.AssignmentID)
+		if err != nil {
+			return err
+		}
+		exists, err := hasCustomerAssignment(tx, submission.AssignmentID, submission.CustomerID)
+		if err != nil {
+			return err
+		}
 
-	ctr.db.Where("customer_id =?", storage.CustomerID).Where("course_id=?", getModule.CourseID).Find(&updateCustomerCourse)
+		submissionModel := model.CustomerAssignment{
+			ID:           submission.ID,
+			File:         submission.File,
+			AssignmentID: submission.AssignmentID,
+			CustomerID:   submission.CustomerID,
+			Status:       submission.Status,
+			Attempts:     submission.Attempts,
+			SubmittedAt:  submission.SubmittedAt,
+			LatePenalty:  submission.LatePenalty,
+			Feedback:     submission.Feedback,
+		}
+		if exists {
+			result := tx.Model(&model.CustomerAssignment{}).
+				Where("id = ? AND attempts = ? AND status <> ?", submission.ID, submission.Attempts-1, dto.AssignmentGraded).
+				Select("file", "status", "attempts", "submitted_at", "late_penalty", "grade", "score", "graded_at").
+				Updates(&submissionModel)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected <= 0 {
+				return errors.New(constantError.ErrorAssignmentChanged)
+			}
+		} else {
+			err = tx.Create(&submissionModel).Error
+			if err != nil {
+				return err
+			}
+		}
 
-	updateCustomerCourse.NoModule = updateCustomerCourse.NoModule + 1
+		err = tx.Create(&model.CustomerAssignmentVersion{
+			ID:                   version.ID,
+			CustomerAssignmentID: submission.ID,
+			NoVersion:            version.NoVersion,
+			File:                 version.File,
+			Status:               version.Status,
+			LatePenalty:          version.LatePenalty,
+			SubmittedAt:          version.SubmittedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
 
-	var getAllModule []model.Module
-	ctr.db.Where("course_id=?", getModule.CourseID).Find(&getAllModule)
+		// the submission can meet the completion rules of the module
+		return evaluateAssignmentModule(tx, submission.AssignmentID, submission.CustomerID)
+	})
+}
 
-	// check if customer course is finished
-	if updateCustomerCourse.NoModule > len(getAllModule) {
-		updateCustomerCourse.IsFinish = true
-	}
-	for _, gam := range getAllModule {
-		if updateCustomerCourse.NoModule >= gam.NoModule {
-			ctr.db.Save(&updateCustomerCourse)
+// GradeCustomerAssignment implements CustomerAssignmentRepository, a submitted submission is graded
+// and a graded submission is graded again
+func (ctr *customerAssignmentRepository) GradeCustomerAssignment(submission dto.CustomerAssignment) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.CustomerAssignment{}).
+			Where("id = ? AND status IN ?", submission.ID, []string{dto.AssignmentSubmitted, dto.AssignmentLate, dto.AssignmentGraded}).
+			Select("status", "grade", "score", "feedback", "graded_at").
+			Updates(&model.CustomerAssignment{
+				Status:   dto.AssignmentGraded,
+				Grade:    submission.Grade,
+				Score:    submission.Score,
+				Feedback: submission.Feedback,
+				GradedAt: submission.GradedAt,
+			})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return errors.New(constantError.ErrorAssignmentNotSubmitted)
 		}
-	}
-
-	// fmt.Println(updateCustomerCourse)
 
-	return nil
+		// the grade can pass the assignment of the module
+		return evaluateAssignmentModule(tx, submission.AssignmentID, submission.CustomerID)
+	})
 }
 
-// DeletecustomerAssignment implements customerAssignmentRepository
-func (ctr *customerAssignmentRepository) DeleteCustomerAssignment(id string) error {
-	// delete data customerAssignment from database by id
-	err := ctr.db.Where("id = ?", id).Unscoped().Delete(&model.CustomerAssignment{})
+// ReturnCustomerAssignment implements CustomerAssignmentRepository, the grade of a returned submission is removed
+func (ctr *customerAssignmentRepository) ReturnCustomerAssignment(id, feedback string) error {
+	err := ctr.db.Model(&model.CustomerAssignment{}).
+		Where("id = ? AND status IN ?", id, []string{dto.AssignmentSubmitted, dto.AssignmentLate, dto.AssignmentGraded}).
+		Select("status", "grade", "score", "feedback", "graded_at").
+		Updates(&model.CustomerAssignment{
+			Status:   dto.AssignmentReturned,
+			Feedback: feedback,
+		})
 	if err.Error != nil {
 		return err.Error
 	}
 	if err.RowsAffected <= 0 {
-		return gorm.ErrRecordNotFound
+		return errors.New(constantError.ErrorAssignmentNotSubmitted)
 	}
-
 	return nil
 }
 
-// GetAllcustomerAssignment implements customerAssignmentRepository
-func (ctr *customerAssignmentRepository) GetAllCustomerAssignment() ([]dto.CustomerAssignmentAcc, error) {
-	var customerAssignmentModels []model.CustomerAssignment
-	// get data sub category from database by user
-	err := ctr.db.Model(&model.CustomerAssignment{}).Find(&customerAssignmentModels).Error
-	if err != nil {
-		return nil, err
-	}
+// DeleteCustomerAssignment implements CustomerAssignmentRepository, the versions of the submission are deleted with it
+func (ctr *customerAssignmentRepository) DeleteCustomerAssignment(id string) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("customer_assignment_id = ?", id).Delete(&model.CustomerAssignmentVersion{}).Error
+		if err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Unscoped().Delete(&model.CustomerAssignment{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
 
-	// copy data from model to dto
-	var customerAssignments []dto.CustomerAssignmentAcc
-	var customer []model.Customer
-	err = copier.Copy(&customerAssignments, &customerAssignmentModels)
-	if err != nil {
-		return nil, err
+// GetCustomerAssignment implements CustomerAssignmentRepository
+func (ctr *customerAssignmentRepository) GetCustomerAssignment(assignmentID, customerID string) (dto.CustomerAssignment, error) {
+	var customerAssignmentModel model.CustomerAssignment
+	err := ctr.db.Where("assignment_id = ? AND customer_id = ?", assignmentID, customerID).Find(&customerAssignmentModel)
+	if err.Error != nil {
+		return dto.CustomerAssignment{}, err.Error
 	}
-	var result []dto.CustomerAssignmentAcc
-	for _, cam := range customerAssignments {
-		ctr.db.Where("id", cam.CustomerID).Find(&customer)
-
-		for _, cs := range customer {
-			cam.Customer.Name = cs.Name
-			fmt.Println(cam)
-
-		}
-		result = append(result, cam)
+	if err.RowsAffected <= 0 {
+		return dto.CustomerAssignment{}, gorm.ErrRecordNotFound
 	}
 
-	return result, nil
+	var customerAssignment dto.CustomerAssignment
+	errCopy := copier.Copy(&customerAssignment, &customerAssignmentModel)
+	if errCopy != nil {
+		return dto.CustomerAssignment{}, errCopy
+	}
+	return customerAssignment, nil
 }
 
-// GetcustomerAssignmentByID implements customerAssignmentRepository
-func (ctr *customerAssignmentRepository) GetCustomerAssignmentByID(id string) (dto.CustomerAssignmentAcc, error) {
+// GetCustomerAssignmentByID implements CustomerAssignmentRepository, the submission is read with its versions
+func (ctr *customerAssignmentRepository) GetCustomerAssignmentByID(id string) (dto.CustomerAssignment, error) {
 	var customerAssignmentModel model.CustomerAssignment
-	err := ctr.db.Model(&model.CustomerAssignment{}).Where("id = ?", id).Find(&customerAssignmentModel)
+	err := ctr.db.Preload("Versions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_version")
+	}).Where("id = ?", id).Find(&customerAssignmentModel)
 	if err.Error != nil {
-		return dto.CustomerAssignmentAcc{}, err.Error
+		return dto.CustomerAssignment{}, err.Error
 	}
 	if err.RowsAffected <= 0 {
-		return dto.CustomerAssignmentAcc{}, gorm.ErrRecordNotFound
+		return dto.CustomerAssignment{}, gorm.ErrRecordNotFound
 	}
 
 	// copy data from model to dto
-	var customerAssignment dto.CustomerAssignmentAcc
-
-	var customer model.Customer
-	ctr.db.Where("id=?", customerAssignmentModel.CustomerID).Find(&customer)
-	customerAssignment.Customer.Name = customer.Name
+	var customerAssignment dto.CustomerAssignment
 	errCopy := copier.Copy(&customerAssignment, &customerAssignmentModel)
 	if errCopy != nil {
-		return dto.CustomerAssignmentAcc{}, errCopy
+		return dto.CustomerAssignment{}, errCopy
 	}
 
+	var customer model.Customer
+	errFind := ctr.db.Select("name").Where("id = ?", customerAssignmentModel.CustomerID).Find(&customer).Error
+	if errFind != nil {
+		return dto.CustomerAssignment{}, errFind
+	}
+	customerAssignment.CustomerName = customer.Name
 	return customerAssignment, nil
 }
 
-// UpdatecustomerAssignment implements customerAssignmentRepository
-func (ctr *customerAssignmentRepository) UpdateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
-	var customerAssignmentModel model.CustomerAssignment
-	errCopy := copier.Copy(&customerAssignmentModel, &customerAssignment)
-	if errCopy != nil {
-		return errCopy
+// GetCustomerAssignments implements CustomerAssignmentRepository
+func (ctr *customerAssignmentRepository) GetCustomerAssignments(customerID string) ([]dto.CustomerAssignment, error) {
+	var customerAssignmentModels []model.CustomerAssignment
+	err := ctr.db.Where("customer_id = ?", customerID).Order("created_at").Find(&customerAssignmentModels).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var customerAssignments []dto.CustomerAssignment
+	err = copier.Copy(&customerAssignments, &customerAssignmentModels)
+	if err != nil {
+		return nil, err
+	}
+	return customerAssignments, nil
+}
+
+// GetCustomerAssignmentsByAssignmentID implements CustomerAssignmentRepository, the submissions are read with the names of the customers
+func (ctr *customerAssignmentRepository) GetCustomerAssignmentsByAssignmentID(assignmentID string) ([]dto.CustomerAssignment, error) {
+	var customerAssignments []dto.CustomerAssignment
+	err := ctr.db.Model(&model.CustomerAssignment{}).
+		Select("customer_assignments.*, customers.name AS customer_name").
+		Joins("LEFT JOIN customers ON customers.id = customer_assignments.customer_id").
+		Where("customer_assignments.assignment_id = ?", assignmentID).
+		Order("customer_assignments.created_at").
+		Scan(&customerAssignments).Error
+	if err != nil {
+		return nil, err
+	}
+	return customerAssignments, nil
+}
+
+// evaluateAssignmentModule evaluates the completion rules of the module of the assignment for the customer
+func evaluateAssignmentModule(tx *gorm.DB, assignmentID, customerID string) error {
+	var assignment model.Assignment
+	err := tx.Select("module_id").Where("id = ?", assignmentID).Find(&assignment).Error
+	if err != nil {
+		return err
 	}
-	// update account with new data
-	err := ctr.db.Model(&model.CustomerAssignment{}).Where("id = ?", customerAssignment.ID).Updates(&customerAssignmentModel)
+	return progressionRepository.EvaluateModule(tx, assignment.ModuleID, customerID)
+}
+
+// lockAssignment locks the assignment so the submissions of a customer are saved one at a time
+func lockAssignment(tx *gorm.DB, assignmentID string) error {
+	var assignment model.Assignment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", assignmentID).Find(&assignment)
 	if err.Error != nil {
 		return err.Error
 	}
 	if err.RowsAffected <= 0 {
 		return gorm.ErrRecordNotFound
 	}
-
 	return nil
 }
 
+func hasCustomerAssignment(tx *gorm.DB, assignmentID, customerID string) (bool, error) {
+	var count int64
+	err := tx.Model(&model.CustomerAssignment{}).Where("assignment_id = ? AND customer_id = ?", assignmentID, customerID).Count(&count).Error
+	return count > 0, err
+}
+
 func NewcustomerAssignmentRepository(db *gorm.DB) CustomerAssignmentRepository {
 	return &customerAssignmentRepository{
 		db: db,
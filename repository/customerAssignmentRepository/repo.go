@@ -3,9 +3,15 @@ package customerassignmentrepository
 import "golang/models/dto"
 
 type CustomerAssignmentRepository interface {
-	CreateCustomerAssignment(dto.CustomerAssignmentTransaction) error
+	GetAssignment(id string) (dto.Assignment, error)
+	CreateCustomerAssignment(dto.CustomerAssignment) error
+	UpdateCustomerAssignmentDraft(id, file string) error
+	SubmitCustomerAssignment(submission dto.CustomerAssignment, version dto.CustomerAssignmentVersion) error
+	GradeCustomerAssignment(dto.CustomerAssignment) error
+	ReturnCustomerAssignment(id, feedback string) error
 	DeleteCustomerAssignment(id string) error
-	GetAllCustomerAssignment() ([]dto.CustomerAssignmentAcc, error)
-	GetCustomerAssignmentByID(id string) (dto.CustomerAssignmentAcc, error)
-	UpdateCustomerAssignment(dto.CustomerAssignmentTransaction) error
+	GetCustomerAssignment(assignmentID, customerID string) (dto.CustomerAssignment, error)
+	GetCustomerAssignmentByID(id string) (dto.CustomerAssignment, error)
+	GetCustomerAssignments(customerID string) ([]dto.CustomerAssignment, error)
+	GetCustomerAssignmentsByAssignmentID(assignmentID string) ([]dto.CustomerAssignment, error)
 }
@@ -3,6 +3,7 @@ package customerCourseRepository
 import (
 	"golang/models/dto"
 	"golang/models/model"
+	"golang/repository/courseStatsRepository"
 
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
@@ -14,12 +15,26 @@ type customerCourseRepository struct {
 
 // DeleteCustomerCourse implements CustomerCourseRepository
 func (ccr *customerCourseRepository) DeleteCustomerCourse(id string) error {
-	err := ccr.db.Unscoped().Delete(&model.CustomerCourse{}, "id = ?", id)
-	if err.Error != nil {
-		return err.Error
-	}
-
-	return nil
+	return ccr.db.Transaction(func(tx *gorm.DB) error {
+		var customerCourse model.CustomerCourse
+		err := tx.Unscoped().Where("id = ?", id).Find(&customerCourse).Error
+		if err != nil {
+			return err
+		}
+		if customerCourse.ID == "" {
+			return nil
+		}
+		err = tx.Unscoped().Delete(&model.CustomerCourse{}, "id = ?", id).Error
+		if err != nil {
+			return err
+		}
+		// the finished modules belong to the enrollment
+		err = tx.Where("customer_id = ? AND course_id = ?", customerCourse.CustomerID, customerCourse.CourseID).Delete(&model.ModuleProgress{}).Error
+		if err != nil {
+			return err
+		}
+		return courseStatsRepository.RefreshCourseStats(tx, customerCourse.CourseID)
+	})
 }
 
 // GetCustomerCourse implements CustomerCourseRepository
@@ -66,7 +81,7 @@ func (ccr *customerCourseRepository) GetHistoryCourseByCustomerID(customerId str
 	var courseModels []dto.CourseCustomerEnroll
 
 	// get data course from database by customer id
-	err := ccr.db.Model(&model.Course{}).Joins("JOIN customer_courses ON customer_courses.course_id = courses.id").Preload("Category").Preload("CustomerCourses", "customer_id = ?", customerId).Preload("Favorites", "customer_id = ?", customerId).Preload("Ratings").Preload("Modules").Unscoped().Where("customer_courses.customer_id = ?", customerId).Find(&courseModels).Error
+	err := ccr.db.Model(&model.Course{}).Joins("JOIN customer_courses ON customer_courses.course_id = courses.id").Preload("Category").Preload("CustomerCourses", "customer_id = ?", customerId).Preload("Favorites", "customer_id = ?", customerId).Preload("Stats").Unscoped().Where("customer_courses.customer_id = ?", customerId).Find(&courseModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -86,12 +101,24 @@ func (ccr *customerCourseRepository) TakeCourse(customerCourse dto.CustomerCours
 	if err != nil {
 		return err
 	}
-	// save customer course to database
-	err = ccr.db.Model(&model.CustomerCourse{}).Create(&customerCourseModel).Error
-	if err != nil {
-		return err
-	}
-	return nil
+	// save customer course to database and update the stats of the course
+	return ccr.db.Transaction(func(tx *gorm.DB) error {
+		// the customer learns the latest version of the course until they migrate to a newer one
+		var versionIDs []string
+		err := tx.Model(&model.CourseVersion{}).Where("course_id = ?", customerCourseModel.CourseID).Order("version DESC").Limit(1).Pluck("id", &versionIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(versionIDs) > 0 {
+			customerCourseModel.CourseVersionID = versionIDs[0]
+		}
+
+		err = tx.Model(&model.CustomerCourse{}).Create(&customerCourseModel).Error
+		if err != nil {
+			return err
+		}
+		return courseStatsRepository.RefreshCourseStats(tx, customerCourseModel.CourseID)
+	})
 }
 
 // UpdateEnrollmentStatus implements CustomerCourseRepository
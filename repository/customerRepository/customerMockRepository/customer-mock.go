@@ -10,18 +10,98 @@ type CustomerMock struct {
 	mock.Mock
 }
 
-func (c *CustomerMock) CreateCustomer(customer dto.CostumerRegister) error {
-	args := c.Called(customer)
+func (c *CustomerMock) CreateCustomer(customer dto.CostumerRegister, code dto.CustomerCode) error {
+	args := c.Called(customer, code)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) GetCustomerByEmail(email string) (dto.CostumerResponseGet, error) {
+	args := c.Called(email)
+
+	return args.Get(0).(dto.CostumerResponseGet), args.Error(1)
+}
+
+func (c *CustomerMock) GetCustomerByID(id string) (dto.CostumerResponseGet, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.CostumerResponseGet), args.Error(1)
+}
+
+func (c *CustomerMock) UpdateCustomerPassword(id, password string) error {
+	args := c.Called(id, password)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) UpdateCustomerProfile(id string, profile dto.CustomerProfileUpdate) error {
+	args := c.Called(id, profile)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) UpdateCustomerEmail(id, email string) error {
+	args := c.Called(id, email)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) UpdateCustomerProfileImage(id, profileImage string) error {
+	args := c.Called(id, profileImage)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) GetCustomerCode(email string) (dto.CustomerCode, error) {
+	args := c.Called(email)
+
+	return args.Get(0).(dto.CustomerCode), args.Error(1)
+}
+
+func (c *CustomerMock) SaveCustomerCode(code dto.CustomerCode) error {
+	args := c.Called(code)
 
 	return args.Error(0)
 }
-func (c *CustomerMock) VerifikasiCustomer(input dto.CustomerVerif) error {
-	args := c.Called(input)
+
+func (c *CustomerMock) IncrementCustomerCodeAttempts(id string) error {
+	args := c.Called(id)
 
 	return args.Error(0)
 }
+
+func (c *CustomerMock) ActivateCustomer(email string) error {
+	args := c.Called(email)
+
+	return args.Error(0)
+}
+
 func (c *CustomerMock) LoginCustomer(customer dto.CostumerLogin) (dto.CostumerResponseGet, error) {
 	args := c.Called(customer)
 
 	return args.Get(0).(dto.CostumerResponseGet), args.Error(0)
 }
+
+func (c *CustomerMock) GetAllCustomer() ([]dto.CustomerAccount, error) {
+	args := c.Called()
+
+	return args.Get(0).([]dto.CustomerAccount), args.Error(1)
+}
+
+func (c *CustomerMock) DeleteCustomer(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) DeleteCustomerAccount(id string, policy dto.RetentionPolicy) error {
+	args := c.Called(id, policy)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) GetCustomerExport(id string) (dto.CustomerExport, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.CustomerExport), args.Error(1)
+}
@@ -0,0 +1,159 @@
+package customerRepository
+
+import (
+	"golang/models/dto"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type suiteCustomerRepository struct {
+	suite.Suite
+	customerRepo CustomerRepository
+	mock         sqlmock.Sqlmock
+}
+
+func (s *suiteCustomerRepository) SetupTest() {
+	sqlDB, mock, err := sqlmock.New()
+	s.NoError(err)
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	s.NoError(err)
+
+	s.mock = mock
+	s.customerRepo = NewCustomerRepository(db)
+}
+
+func (s *suiteCustomerRepository) TestDeleteCustomerAccount() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `customers` WHERE id = ?")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow("abcde", "customer", "customer@gmail.com"))
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `course_id` FROM `customer_courses`")).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id"}))
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `course_id` FROM `ratings`")).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id"}))
+	s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `customers` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `customers` SET `deleted_at`=")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// the rows keyed to the address are removed with the email the customer had before the anonymization
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `customer_codes` WHERE email = ?")).
+		WithArgs("customer@gmail.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `email_changes` WHERE user_id = ? AND role = ?")).
+		WithArgs("abcde", "customer").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `password_resets` WHERE user_id = ? AND role = ?")).
+		WithArgs("abcde", "customer").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	for _, table := range []string{"favorites", "customer_courses", "module_progresses", "module_views", "media_progresses", "progress_events", "learning_path_completions", "ratings",
+		"customer_assignment_versions", "customer_assignments", "quiz_attempt_answers", "quiz_attempts"} {
+		s.mock.ExpectExec("(DELETE FROM|UPDATE) `" + table + "`").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	s.mock.ExpectCommit()
+
+	err := s.customerRepo.DeleteCustomerAccount("abcde", dto.RetentionPolicy{})
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteCustomerRepository) TestDeleteCustomerAccountNotFound() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `customers` WHERE id = ?")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}))
+	s.mock.ExpectRollback()
+
+	err := s.customerRepo.DeleteCustomerAccount("abcde", dto.RetentionPolicy{})
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteCustomerRepository) TestGetCustomerExport() {
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `customers` WHERE id = ?")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow("abcde", "customer", "customer@gmail.com"))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `customer_courses` LEFT JOIN courses")).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id", "course_name"}).AddRow("course1", "golang"))
+	for _, table := range []string{"customer_assignments", "ratings", "favorites"} {
+		s.mock.ExpectQuery("FROM `" + table + "`").
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}))
+	}
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `quiz_attempts` LEFT JOIN quizzes")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"attempt_id", "quiz_id", "quiz_title", "status", "score"}).
+			AddRow("attempt1", "quiz1", "golang quiz", "submitted", 2.0).
+			AddRow("attempt2", "quiz1", "golang quiz", "in_progress", 0.0))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `quiz_attempt_answers` JOIN quiz_attempts")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"attempt_id", "question_id", "question_text", "no_question", "answer", "is_correct", "score"}).
+			AddRow("attempt1", "question1", "what is go", 1, "a language", true, 1.0).
+			AddRow("attempt2", "question1", "what is go", 1, "", false, 0.0).
+			AddRow("attempt1", "question2", "what is gorm", 2, "an orm", true, 1.0))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `module_progresses`")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"course_id", "course_name", "module_id", "module_name"}).AddRow("course1", "golang", "module1", "intro"))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `module_views`")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"module_id", "module_name"}).AddRow("module1", "intro").AddRow("module2", "basics"))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `media_progresses`")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"module_id", "media_module_id", "watched"}).AddRow("module2", "media1", 40.0))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `progress_events`")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"course_id", "module_id", "type"}).AddRow("course1", "module1", "module_completed"))
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `learning_path_completions`")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"learning_path_id", "learning_path_title"}).AddRow("path1", "backend"))
+
+	export, err := s.customerRepo.GetCustomerExport("abcde")
+	s.NoError(err)
+	s.Equal("abcde", export.Profile.ID)
+	s.Len(export.Enrollments, 1)
+
+	// the answers are exported in the attempt they belong to
+	s.Len(export.QuizAttempts, 2)
+	s.Equal([]dto.ExportQuizAnswer{
+		{AttemptID: "attempt1", QuestionID: "question1", QuestionText: "what is go", NoQuestion: 1, Answer: "a language", IsCorrect: true, Score: 1},
+		{AttemptID: "attempt1", QuestionID: "question2", QuestionText: "what is gorm", NoQuestion: 2, Answer: "an orm", IsCorrect: true, Score: 1},
+	}, export.QuizAttempts[0].Answers)
+	s.Len(export.QuizAttempts[1].Answers, 1)
+
+	s.Equal([]dto.ExportModuleProgress{{CourseID: "course1", CourseName: "golang", ModuleID: "module1", ModuleName: "intro"}}, export.ModuleProgress)
+	s.Len(export.ModuleViews, 2)
+	s.Equal([]dto.ExportMediaProgress{{ModuleID: "module2", MediaModuleID: "media1", Watched: 40}}, export.MediaProgress)
+	s.Equal([]dto.ExportProgressEvent{{CourseID: "course1", ModuleID: "module1", Type: "module_completed"}}, export.ProgressEvents)
+	s.Equal([]dto.ExportLearningPathCompletion{{LearningPathID: "path1", LearningPathTitle: "backend"}}, export.LearningPathCompletions)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteCustomerRepository) TestGetCustomerExportWithoutQuizAttempt() {
+	s.mock.ExpectQuery(regexp.QuoteMeta("FROM `customers` WHERE id = ?")).
+		WithArgs("abcde").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow("abcde", "customer", "customer@gmail.com"))
+	// the answers are not queried when the customer has no attempt
+	for _, table := range []string{"customer_courses", "customer_assignments", "ratings", "favorites", "quiz_attempts",
+		"module_progresses", "module_views", "media_progresses", "progress_events", "learning_path_completions"} {
+		s.mock.ExpectQuery("FROM `" + table + "`").
+			WillReturnRows(sqlmock.NewRows([]string{"course_id"}))
+	}
+
+	export, err := s.customerRepo.GetCustomerExport("abcde")
+	s.NoError(err)
+	s.Empty(export.QuizAttempts)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func TestSuiteCustomerRepository(t *testing.T) {
+	suite.Run(t, new(suiteCustomerRepository))
+}
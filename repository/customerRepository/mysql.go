@@ -4,14 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/models/model"
-	"golang/util"
-	"log"
-	"math/rand"
+	"golang/repository/courseStatsRepository"
 
-	"gopkg.in/gomail.v2"
+	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
 )
 
@@ -20,8 +19,7 @@ type customerRepository struct {
 }
 
 // CreateCustomer implements CustomerRepository
-func (u *customerRepository) CreateCustomer(customer dto.CostumerRegister) error {
-
+func (u *customerRepository) CreateCustomer(customer dto.CostumerRegister, code dto.CustomerCode) error {
 	customerModel := model.Customer{
 		ID:           customer.ID,
 		Name:         customer.Name,
@@ -31,113 +29,155 @@ func (u *customerRepository) CreateCustomer(customer dto.CostumerRegister) error
 		IsActive:     false,
 	}
 
-	var getAllCustomer []model.Customer
-	u.db.Find(&getAllCustomer)
-
-	for _, dataCustomer := range getAllCustomer {
-		if customer.Email == dataCustomer.Email {
-			if !dataCustomer.IsActive {
-				var getCustomerCode model.CustomerCode
-				u.db.First(&getCustomerCode, "email=?", dataCustomer.Email)
-				fmt.Println(getCustomerCode.ID)
-				letter := []rune("1234567890")
-				b := make([]rune, 4)
-				for i := range b {
-					b[i] = letter[rand.Intn(len(letter))]
-				}
-				code := string(b)
-
-				isiEmail := fmt.Sprintf("<p>kode verifikasi yaitu <b>%s</b></p>", code)
-				mailer := gomail.NewMessage()
-				mailer.SetHeader("From", util.GetConfig("SENDER_NAME"))
-				mailer.SetHeader("To", customer.Email, "alimuldev@gmail.com")
-				mailer.SetAddressHeader("Cc", customer.Email, "Tra Lala La")
-				mailer.SetHeader("Subject", "Test mail")
-				mailer.SetBody("text/html", isiEmail)
-				dialer := gomail.NewDialer(
-					util.GetConfig("SMTP_HOST"),
-					587,
-					util.GetConfig("AUTH_EMAIL"),
-					util.GetConfig("AUTH_PASSWORD"),
-				)
-
-				errss := dialer.DialAndSend(mailer)
-				if errss != nil {
-					log.Fatal(errss.Error())
-				}
-				getCustomerCode.Email = dataCustomer.Email
-				getCustomerCode.Code = code
-				u.db.Save(&getCustomerCode)
-
-				return nil
-			}
+	var customerCodeModel model.CustomerCode
+	err := copier.Copy(&customerCodeModel, &code)
+	if err != nil {
+		return err
+	}
 
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&customerModel).Error
+		if err != nil {
+			return err
 		}
+		return tx.Create(&customerCodeModel).Error
+	})
+}
+
+// GetCustomerByEmail implements CustomerRepository
+func (u *customerRepository) GetCustomerByEmail(email string) (dto.CostumerResponseGet, error) {
+	var customer dto.CostumerResponseGet
+	err := u.db.Model(&model.Customer{}).Where("email = ?", email).Find(&customer)
+	if err.Error != nil {
+		return dto.CostumerResponseGet{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CostumerResponseGet{}, gorm.ErrRecordNotFound
 	}
+	return customer, nil
+}
 
-	err := u.db.Create(&customerModel).Error
+// GetCustomerByID implements CustomerRepository
+func (u *customerRepository) GetCustomerByID(id string) (dto.CostumerResponseGet, error) {
+	var customer dto.CostumerResponseGet
+	err := u.db.Model(&model.Customer{}).Where("id = ?", id).Find(&customer)
+	if err.Error != nil {
+		return dto.CostumerResponseGet{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CostumerResponseGet{}, gorm.ErrRecordNotFound
+	}
+	return customer, nil
+}
 
-	if err != nil {
-		return err
+// UpdateCustomerPassword implements CustomerRepository
+func (u *customerRepository) UpdateCustomerPassword(id, password string) error {
+	err := u.db.Model(&model.Customer{}).Where("id = ?", id).Update("password", password)
+	if err.Error != nil {
+		return err.Error
 	}
-	letter := []rune("1234567890")
-	b := make([]rune, 4)
-	for i := range b {
-		b[i] = letter[rand.Intn(len(letter))]
-	}
-	code := string(b)
-
-	isiEmail := fmt.Sprintf("<p>kode verifikasi yaitu <b>%s</b></p>", code)
-	mailer := gomail.NewMessage()
-	mailer.SetHeader("From", util.GetConfig("SENDER_NAME"))
-	mailer.SetHeader("To", customer.Email)
-	mailer.SetAddressHeader("Cc", customer.Email, "GENCER")
-	mailer.SetHeader("Subject", "Test mail")
-	mailer.SetBody("text/html", isiEmail)
-	dialer := gomail.NewDialer(
-		util.GetConfig("SMTP_HOST"),
-		587,
-		util.GetConfig("AUTH_EMAIL"),
-		util.GetConfig("AUTH_PASSWORD"),
-	)
-
-	errss := dialer.DialAndSend(mailer)
-	if errss != nil {
-		log.Fatal(errss.Error())
-	}
-
-	fmt.Println(customer.Email)
-	customerCode := model.CustomerCode{
-		ID:    customer.CustomerCodeID,
-		Email: customer.Email,
-		Code:  code,
-	}
-
-	err2 := u.db.Create(&customerCode).Error
-	if err2 != nil {
-		return err
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
 
-func (u *customerRepository) VerifikasiCustomer(input dto.CustomerVerif) error {
-	var customerCodeModel model.CustomerCode
-	var customerModel model.Customer
-	err := u.db.First(&customerCodeModel, "email=?", input.Email)
+// UpdateCustomerProfile implements CustomerRepository
+func (u *customerRepository) UpdateCustomerProfile(id string, profile dto.CustomerProfileUpdate) error {
+	err := u.db.Model(&model.Customer{}).Where("id = ?", id).Update("name", profile.Name)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateCustomerEmail implements CustomerRepository
+func (u *customerRepository) UpdateCustomerEmail(id, email string) error {
+	err := u.db.Model(&model.Customer{}).Where("id = ?", id).Update("email", email)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
 
+// UpdateCustomerProfileImage implements CustomerRepository
+func (u *customerRepository) UpdateCustomerProfileImage(id, profileImage string) error {
+	err := u.db.Model(&model.Customer{}).Where("id = ?", id).Update("profile_image", profileImage)
 	if err.Error != nil {
 		return err.Error
 	}
-	u.db.First(&customerModel, "email=?", input.Email)
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
 
-	if input.Code == customerCodeModel.Code {
-		customerModel.IsActive = true
-		u.db.Save(&customerModel)
-		u.db.Unscoped().Delete(&customerCodeModel)
+// GetCustomerCode implements CustomerRepository
+func (u *customerRepository) GetCustomerCode(email string) (dto.CustomerCode, error) {
+	var customerCode dto.CustomerCode
+	err := u.db.Model(&model.CustomerCode{}).Where("email = ?", email).Find(&customerCode)
+	if err.Error != nil {
+		return dto.CustomerCode{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CustomerCode{}, gorm.ErrRecordNotFound
+	}
+	return customerCode, nil
+}
+
+// SaveCustomerCode implements CustomerRepository
+func (u *customerRepository) SaveCustomerCode(code dto.CustomerCode) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		// keep a single code per email
+		var customerCode model.CustomerCode
+		err := tx.Where("email = ?", code.Email).Find(&customerCode)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			customerCode.ID = code.ID
+			customerCode.Email = code.Email
+		}
+		customerCode.Code = code.Code
+		customerCode.ExpiresAt = code.ExpiresAt
+		customerCode.Attempts = code.Attempts
+		customerCode.SentAt = code.SentAt
+		return tx.Save(&customerCode).Error
+	})
+}
+
+// IncrementCustomerCodeAttempts implements CustomerRepository
+func (u *customerRepository) IncrementCustomerCodeAttempts(id string) error {
+	err := u.db.Model(&model.CustomerCode{}).Where("id = ?", id).Update("attempts", gorm.Expr("attempts + 1"))
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
 	}
 	return nil
 }
 
+// ActivateCustomer implements CustomerRepository
+func (u *customerRepository) ActivateCustomer(email string) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Customer{}).Where("email = ?", email).Update("is_active", true)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Unscoped().Where("email = ?", email).Delete(&model.CustomerCode{}).Error
+	})
+}
+
 // LoginCustomer implements CustomerRepository
 func (u *customerRepository) LoginCustomer(customer dto.CostumerLogin) (dto.CostumerResponseGet, error) {
 	var customerLogin dto.CostumerResponseGet
@@ -158,42 +198,297 @@ func (u *customerRepository) LoginCustomer(customer dto.CostumerLogin) (dto.Cost
 		IsActive:     customerLogin.IsActive,
 	}
 	if !customerLoginResponse.IsActive {
-		var getCustomerCode model.CustomerCode
-		u.db.First(&getCustomerCode, "email=?", customerLoginResponse.Email)
-		letter := []rune("1234567890")
-		b := make([]rune, 4)
-		for i := range b {
-			b[i] = letter[rand.Intn(len(letter))]
-		}
-		code := string(b)
-
-		isiEmail := fmt.Sprintf("<p>kode verifikasi yaitu <b>%s</b></p>", code)
-		mailer := gomail.NewMessage()
-		mailer.SetHeader("From", util.GetConfig("SENDER_NAME"))
-		mailer.SetHeader("To", customerLogin.Email, "genceralta19@gmail.com")
-		mailer.SetAddressHeader("Cc", customerLogin.Email, "Tra Lala La")
-		mailer.SetHeader("Subject", "Test mail")
-		mailer.SetBody("text/html", isiEmail)
-		dialer := gomail.NewDialer(
-			util.GetConfig("SMTP_HOST"),
-			587,
-			util.GetConfig("AUTH_EMAIL"),
-			util.GetConfig("AUTH_PASSWORD"),
-		)
-
-		errss := dialer.DialAndSend(mailer)
-		if errss != nil {
-			log.Fatal(err.Error())
-		}
-
-		getCustomerCode.Code = code
-		u.db.Save(&getCustomerCode)
-
 		return dto.CostumerResponseGet{}, errors.New(constantError.ErrorNoActive)
 	}
 	return customerLoginResponse, nil
 }
 
+// DeleteCustomerAccount implements CustomerRepository
+func (u *customerRepository) DeleteCustomerAccount(id string, policy dto.RetentionPolicy) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		var customer model.Customer
+		err := tx.Where("id = ?", id).Find(&customer)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// the courses the customer enrolled or rated get new stats
+		var courseIDs, ratedCourseIDs []string
+		errPluck := tx.Model(&model.CustomerCourse{}).Where("customer_id = ?", id).Pluck("course_id", &courseIDs).Error
+		if errPluck != nil {
+			return errPluck
+		}
+		errPluck = tx.Model(&model.Rating{}).Where("customer_id = ?", id).Pluck("course_id", &ratedCourseIDs).Error
+		if errPluck != nil {
+			return errPluck
+		}
+
+		// the email is anonymized below, the rows keyed to the address are removed with the original one
+		email := customer.Email
+
+		// remove the personal data, the row stays for the records that are kept
+		errUpdate := tx.Model(&customer).Updates(map[string]interface{}{
+			"name":          "Deleted User",
+			"email":         fmt.Sprintf("deleted-%s@deleted.invalid", id),
+			"password":      "",
+			"profile_image": nil,
+			"is_active":     false,
+		}).Error
+		if errUpdate != nil {
+			return errUpdate
+		}
+		errDelete := tx.Delete(&customer).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Unscoped().Where("email = ?", email).Delete(&model.CustomerCode{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Unscoped().Where("user_id = ? AND role = ?", id, constantRole.RoleCustomer).Delete(&model.EmailChange{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Unscoped().Where("user_id = ? AND role = ?", id, constantRole.RoleCustomer).Delete(&model.PasswordReset{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+
+		errDelete = tx.Unscoped().Where("customer_id = ?", id).Delete(&model.Favorite{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Where("customer_id = ?", id).Delete(&model.CustomerCourse{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Where("customer_id = ?", id).Delete(&model.ModuleProgress{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Where("customer_id = ?", id).Delete(&model.ModuleView{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Where("customer_id = ?", id).Delete(&model.MediaProgress{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Where("customer_id = ?", id).Delete(&model.ProgressEvent{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Where("customer_id = ?", id).Delete(&model.LearningPathCompletion{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+
+		if policy.KeepRatings {
+			errUpdate = tx.Model(&model.Rating{}).Where("customer_id = ?", id).Update("testimonial", "").Error
+		} else {
+			errUpdate = tx.Where("customer_id = ?", id).Delete(&model.Rating{}).Error
+		}
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		// the versions of the submissions have the files of the customer too
+		submissionIDs := tx.Model(&model.CustomerAssignment{}).Select("id").Where("customer_id = ?", id)
+		if policy.KeepGrades {
+			errUpdate = tx.Model(&model.CustomerAssignmentVersion{}).Where("customer_assignment_id IN (?)", submissionIDs).Update("file", "").Error
+			if errUpdate != nil {
+				return errUpdate
+			}
+			errUpdate = tx.Model(&model.CustomerAssignment{}).Where("customer_id = ?", id).Update("file", "").Error
+		} else {
+			errDelete = tx.Where("customer_assignment_id IN (?)", submissionIDs).Delete(&model.CustomerAssignmentVersion{}).Error
+			if errDelete != nil {
+				return errDelete
+			}
+			errUpdate = tx.Where("customer_id = ?", id).Delete(&model.CustomerAssignment{}).Error
+		}
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		// the quiz attempts are grades too, only the answers of the customer are removed when the grades are kept
+		attemptIDs := tx.Model(&model.QuizAttempt{}).Select("id").Where("customer_id = ?", id)
+		if policy.KeepGrades {
+			errUpdate = tx.Model(&model.QuizAttemptAnswer{}).Where("quiz_attempt_id IN (?)", attemptIDs).Update("answer", "").Error
+			if errUpdate != nil {
+				return errUpdate
+			}
+		} else {
+			errDelete = tx.Where("quiz_attempt_id IN (?)", attemptIDs).Delete(&model.QuizAttemptAnswer{}).Error
+			if errDelete != nil {
+				return errDelete
+			}
+			errDelete = tx.Where("customer_id = ?", id).Delete(&model.QuizAttempt{}).Error
+			if errDelete != nil {
+				return errDelete
+			}
+		}
+		return courseStatsRepository.RefreshCourseStats(tx, append(courseIDs, ratedCourseIDs...)...)
+	})
+}
+
+// GetCustomerExport implements CustomerRepository
+func (u *customerRepository) GetCustomerExport(id string) (dto.CustomerExport, error) {
+	var export dto.CustomerExport
+	err := u.db.Model(&model.Customer{}).Where("id = ?", id).Find(&export.Profile)
+	if err.Error != nil {
+		return dto.CustomerExport{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.CustomerExport{}, gorm.ErrRecordNotFound
+	}
+
+	// the courses are joined with left join so the records of deleted courses are exported too
+	errFind := u.db.Model(&model.CustomerCourse{}).
+		Select("customer_courses.course_id, courses.name AS course_name, customer_courses.status, customer_courses.no_module, customer_courses.is_finish, customer_courses.created_at AS enrolled_at").
+		Joins("LEFT JOIN courses ON courses.id = customer_courses.course_id").
+		Where("customer_courses.customer_id = ?", id).
+		Order("customer_courses.created_at").
+		Scan(&export.Enrollments).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	errFind = u.db.Model(&model.CustomerAssignment{}).
+		Select("customer_assignments.assignment_id, assignments.title AS assignment_title, customer_assignments.file, customer_assignments.status, customer_assignments.grade, customer_assignments.score, customer_assignments.feedback, customer_assignments.submitted_at").
+		Joins("LEFT JOIN assignments ON assignments.id = customer_assignments.assignment_id").
+		Where("customer_assignments.customer_id = ?", id).
+		Order("customer_assignments.created_at").
+		Scan(&export.Submissions).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	errFind = u.db.Model(&model.Rating{}).
+		Select("ratings.course_id, courses.name AS course_name, ratings.rating, ratings.testimonial, ratings.is_publish, ratings.created_at AS reviewed_at").
+		Joins("LEFT JOIN courses ON courses.id = ratings.course_id").
+		Where("ratings.customer_id = ?", id).
+		Order("ratings.created_at").
+		Scan(&export.Reviews).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	errFind = u.db.Model(&model.Favorite{}).
+		Select("favorites.course_id, courses.name AS course_name, favorites.created_at AS favorited_at").
+		Joins("LEFT JOIN courses ON courses.id = favorites.course_id").
+		Where("favorites.customer_id = ?", id).
+		Order("favorites.created_at").
+		Scan(&export.Favorites).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+
+	errFind = u.db.Model(&model.QuizAttempt{}).
+		Select("quiz_attempts.id AS attempt_id, quiz_attempts.quiz_id, quizzes.title AS quiz_title, quiz_attempts.status, quiz_attempts.score, quiz_attempts.max_score, quiz_attempts.percentage, quiz_attempts.is_passed, quiz_attempts.created_at AS started_at, quiz_attempts.submitted_at").
+		Joins("LEFT JOIN quizzes ON quizzes.id = quiz_attempts.quiz_id").
+		Where("quiz_attempts.customer_id = ?", id).
+		Order("quiz_attempts.created_at").
+		Scan(&export.QuizAttempts).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	if len(export.QuizAttempts) > 0 {
+		var answers []dto.ExportQuizAnswer
+		errFind = u.db.Model(&model.QuizAttemptAnswer{}).
+			Select("quiz_attempt_answers.quiz_attempt_id AS attempt_id, quiz_attempt_answers.question_id, quiz_questions.text AS question_text, quiz_attempt_answers.no_question, quiz_attempt_answers.answer, quiz_attempt_answers.is_correct, quiz_attempt_answers.score").
+			Joins("JOIN quiz_attempts ON quiz_attempts.id = quiz_attempt_answers.quiz_attempt_id").
+			Joins("LEFT JOIN quiz_questions ON quiz_questions.id = quiz_attempt_answers.question_id").
+			Where("quiz_attempts.customer_id = ?", id).
+			Order("quiz_attempt_answers.no_question").
+			Scan(&answers).Error
+		if errFind != nil {
+			return dto.CustomerExport{}, errFind
+		}
+		// the answers are put in the attempt they belong to
+		attempts := make(map[string]int, len(export.QuizAttempts))
+		for i, attempt := range export.QuizAttempts {
+			attempts[attempt.AttemptID] = i
+		}
+		for _, answer := range answers {
+			i, ok := attempts[answer.AttemptID]
+			if ok {
+				export.QuizAttempts[i].Answers = append(export.QuizAttempts[i].Answers, answer)
+			}
+		}
+	}
+
+	errFind = u.db.Model(&model.ModuleProgress{}).
+		Select("module_progresses.course_id, courses.name AS course_name, module_progresses.module_id, modules.name AS module_name, module_progresses.created_at AS completed_at").
+		Joins("LEFT JOIN courses ON courses.id = module_progresses.course_id").
+		Joins("LEFT JOIN modules ON modules.id = module_progresses.module_id").
+		Where("module_progresses.customer_id = ?", id).
+		Order("module_progresses.created_at").
+		Scan(&export.ModuleProgress).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	errFind = u.db.Model(&model.ModuleView{}).
+		Select("module_views.module_id, modules.name AS module_name, module_views.created_at AS viewed_at").
+		Joins("LEFT JOIN modules ON modules.id = module_views.module_id").
+		Where("module_views.customer_id = ?", id).
+		Order("module_views.created_at").
+		Scan(&export.ModuleViews).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	errFind = u.db.Model(&model.MediaProgress{}).
+		Select("media_progresses.module_id, modules.name AS module_name, media_progresses.media_module_id, media_progresses.watched, media_progresses.updated_at").
+		Joins("LEFT JOIN modules ON modules.id = media_progresses.module_id").
+		Where("media_progresses.customer_id = ?", id).
+		Order("media_progresses.updated_at").
+		Scan(&export.MediaProgress).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	errFind = u.db.Model(&model.ProgressEvent{}).
+		Select("progress_events.course_id, courses.name AS course_name, progress_events.module_id, progress_events.type, progress_events.created_at").
+		Joins("LEFT JOIN courses ON courses.id = progress_events.course_id").
+		Where("progress_events.customer_id = ?", id).
+		Order("progress_events.created_at").
+		Scan(&export.ProgressEvents).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	errFind = u.db.Model(&model.LearningPathCompletion{}).
+		Select("learning_path_completions.learning_path_id, learning_paths.title AS learning_path_title, learning_path_completions.created_at AS completed_at").
+		Joins("LEFT JOIN learning_paths ON learning_paths.id = learning_path_completions.learning_path_id").
+		Where("learning_path_completions.customer_id = ?", id).
+		Order("learning_path_completions.created_at").
+		Scan(&export.LearningPathCompletions).Error
+	if errFind != nil {
+		return dto.CustomerExport{}, errFind
+	}
+	return export, nil
+}
+
+// GetAllCustomer implements CustomerRepository
+func (u *customerRepository) GetAllCustomer() ([]dto.CustomerAccount, error) {
+	var accounts []dto.CustomerAccount
+	err := u.db.Model(&model.Customer{}).Order("created_at DESC").Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// DeleteCustomer implements CustomerRepository
+func (u *customerRepository) DeleteCustomer(id string) error {
+	err := u.db.Where("id = ?", id).Delete(&model.Customer{})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func NewCustomerRepository(db *gorm.DB) CustomerRepository {
 	return &customerRepository{
 		db: db,
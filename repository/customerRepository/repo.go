@@ -5,7 +5,20 @@ import (
 )
 
 type CustomerRepository interface {
-	CreateCustomer(customer dto.CostumerRegister) error
-	VerifikasiCustomer(input dto.CustomerVerif) error
+	CreateCustomer(customer dto.CostumerRegister, code dto.CustomerCode) error
+	GetCustomerByEmail(email string) (dto.CostumerResponseGet, error)
+	GetCustomerByID(id string) (dto.CostumerResponseGet, error)
+	UpdateCustomerPassword(id, password string) error
+	UpdateCustomerProfile(id string, profile dto.CustomerProfileUpdate) error
+	UpdateCustomerEmail(id, email string) error
+	UpdateCustomerProfileImage(id, profileImage string) error
+	GetCustomerCode(email string) (dto.CustomerCode, error)
+	SaveCustomerCode(code dto.CustomerCode) error
+	IncrementCustomerCodeAttempts(id string) error
+	ActivateCustomer(email string) error
 	LoginCustomer(customer dto.CostumerLogin) (dto.CostumerResponseGet, error)
+	GetAllCustomer() ([]dto.CustomerAccount, error)
+	DeleteCustomer(id string) error
+	DeleteCustomerAccount(id string, policy dto.RetentionPolicy) error
+	GetCustomerExport(id string) (dto.CustomerExport, error)
 }
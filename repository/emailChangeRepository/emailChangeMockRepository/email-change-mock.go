@@ -0,0 +1,35 @@
+package emailChangeMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type EmailChangeMock struct {
+	mock.Mock
+}
+
+func (e *EmailChangeMock) SaveEmailChange(change dto.EmailChange) error {
+	args := e.Called(change)
+
+	return args.Error(0)
+}
+
+func (e *EmailChangeMock) GetEmailChange(userID, role string) (dto.EmailChange, error) {
+	args := e.Called(userID, role)
+
+	return args.Get(0).(dto.EmailChange), args.Error(1)
+}
+
+func (e *EmailChangeMock) IncrementEmailChangeAttempts(id string) error {
+	args := e.Called(id)
+
+	return args.Error(0)
+}
+
+func (e *EmailChangeMock) DeleteEmailChange(id string) error {
+	args := e.Called(id)
+
+	return args.Error(0)
+}
@@ -0,0 +1,78 @@
+package emailChangeRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+
+	"gorm.io/gorm"
+)
+
+type emailChangeRepository struct {
+	db *gorm.DB
+}
+
+// SaveEmailChange implements EmailChangeRepository
+func (er *emailChangeRepository) SaveEmailChange(change dto.EmailChange) error {
+	return er.db.Transaction(func(tx *gorm.DB) error {
+		// keep a single pending change per user
+		var changeModel model.EmailChange
+		err := tx.Where("user_id = ? AND role = ?", change.UserID, change.Role).Find(&changeModel)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			changeModel.ID = change.ID
+			changeModel.UserID = change.UserID
+			changeModel.Role = change.Role
+		}
+		changeModel.NewEmail = change.NewEmail
+		changeModel.Code = change.Code
+		changeModel.ExpiresAt = change.ExpiresAt
+		changeModel.Attempts = change.Attempts
+		changeModel.SentAt = change.SentAt
+		return tx.Save(&changeModel).Error
+	})
+}
+
+// GetEmailChange implements EmailChangeRepository
+func (er *emailChangeRepository) GetEmailChange(userID, role string) (dto.EmailChange, error) {
+	var change dto.EmailChange
+	err := er.db.Model(&model.EmailChange{}).Where("user_id = ? AND role = ?", userID, role).Find(&change)
+	if err.Error != nil {
+		return dto.EmailChange{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.EmailChange{}, gorm.ErrRecordNotFound
+	}
+	return change, nil
+}
+
+// IncrementEmailChangeAttempts implements EmailChangeRepository
+func (er *emailChangeRepository) IncrementEmailChangeAttempts(id string) error {
+	err := er.db.Model(&model.EmailChange{}).Where("id = ?", id).Update("attempts", gorm.Expr("attempts + 1"))
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteEmailChange implements EmailChangeRepository
+func (er *emailChangeRepository) DeleteEmailChange(id string) error {
+	err := er.db.Unscoped().Where("id = ?", id).Delete(&model.EmailChange{})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func NewEmailChangeRepository(db *gorm.DB) EmailChangeRepository {
+	return &emailChangeRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,12 @@
+package emailChangeRepository
+
+import (
+	"golang/models/dto"
+)
+
+type EmailChangeRepository interface {
+	SaveEmailChange(change dto.EmailChange) error
+	GetEmailChange(userID, role string) (dto.EmailChange, error)
+	IncrementEmailChangeAttempts(id string) error
+	DeleteEmailChange(id string) error
+}
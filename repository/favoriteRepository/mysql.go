@@ -43,7 +43,7 @@ func (fr *favoriteRepository) GetFavoriteByCustomerID(customerID string) ([]dto.
 	var courseModels []dto.GetCourseCategory
 
 	// get data course from database by customer id
-	err := fr.db.Model(&model.Course{}).Joins("JOIN favorites ON favorites.course_id = courses.id").Preload("Category").Preload("CustomerCourses", "customer_id = ?", customerID).Preload("Ratings").Preload("Modules").Unscoped().Where("favorites.customer_id = ?", customerID).Find(&courseModels).Error
+	err := fr.db.Model(&model.Course{}).Joins("JOIN favorites ON favorites.course_id = courses.id").Preload("Category").Preload("CustomerCourses", "customer_id = ?", customerID).Preload("Stats").Unscoped().Where("favorites.customer_id = ?", customerID).Find(&courseModels).Error
 	if err != nil {
 		return nil, err
 	}
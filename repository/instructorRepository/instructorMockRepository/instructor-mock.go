@@ -10,8 +10,38 @@ type InstructorMock struct {
 	mock.Mock
 }
 
-func (c *InstructorMock) CreateInstructor(instructor dto.InstructorRegister) error {
-	args := c.Called(instructor)
+func (c *InstructorMock) CreateInstructor(instructor dto.InstructorRegister, code dto.InstructorCode) error {
+	args := c.Called(instructor, code)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) GetInstructorCode(email string) (dto.InstructorCode, error) {
+	args := c.Called(email)
+
+	return args.Get(0).(dto.InstructorCode), args.Error(1)
+}
+
+func (c *InstructorMock) SaveInstructorCode(code dto.InstructorCode) error {
+	args := c.Called(code)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) IncrementInstructorCodeAttempts(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) ActivateInstructor(email string) error {
+	args := c.Called(email)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) UpdateInstructorStatus(id, status string) error {
+	args := c.Called(id, status)
 
 	return args.Error(0)
 }
@@ -21,3 +51,51 @@ func (c *InstructorMock) LoginInstructor(instructor dto.InstructorLogin) (dto.In
 
 	return args.Get(0).(dto.InstructorResponseGet), args.Error(0)
 }
+
+func (c *InstructorMock) GetInstructorByEmail(email string) (dto.InstructorResponseGet, error) {
+	args := c.Called(email)
+
+	return args.Get(0).(dto.InstructorResponseGet), args.Error(1)
+}
+
+func (c *InstructorMock) GetInstructorByID(id string) (dto.InstructorResponseGet, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.InstructorResponseGet), args.Error(1)
+}
+
+func (c *InstructorMock) UpdateInstructorPassword(id, password string) error {
+	args := c.Called(id, password)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) UpdateInstructorProfile(id string, profile dto.InstructorProfileUpdate) error {
+	args := c.Called(id, profile)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) UpdateInstructorEmail(id, email string) error {
+	args := c.Called(id, email)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) UpdateInstructorProfileImage(id, profileImage string) error {
+	args := c.Called(id, profileImage)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) GetAllInstructor() ([]dto.InstructorAccount, error) {
+	args := c.Called()
+
+	return args.Get(0).([]dto.InstructorAccount), args.Error(1)
+}
+
+func (c *InstructorMock) DeleteInstructor(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
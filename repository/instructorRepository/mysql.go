@@ -7,6 +7,7 @@ import (
 	"golang/models/dto"
 	"golang/models/model"
 
+	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
 )
 
@@ -15,18 +16,101 @@ type instructorrepository struct {
 }
 
 // Createinstructor implements instructorrepository
-func (u *instructorrepository) CreateInstructor(instructor dto.InstructorRegister) error {
+func (u *instructorrepository) CreateInstructor(instructor dto.InstructorRegister, code dto.InstructorCode) error {
 	instructorModel := model.Instructor{
 		ID:           instructor.ID,
 		Name:         instructor.Name,
 		Email:        instructor.Email,
 		Password:     instructor.Password,
 		ProfileImage: "https://t3.ftcdn.net/jpg/03/46/83/96/360_F_346839683_6nAPzbhpSkIpb8pmAwufkC7c5eD7wYws.jpg",
+		IsActive:     false,
+		Status:       instructor.Status,
 	}
-	err := u.db.Create(&instructorModel).Error
+
+	var instructorCodeModel model.InstructorCode
+	err := copier.Copy(&instructorCodeModel, &code)
 	if err != nil {
 		return err
 	}
+
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&instructorModel).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&instructorCodeModel).Error
+	})
+}
+
+// GetInstructorCode implements InstructorRepository
+func (u *instructorrepository) GetInstructorCode(email string) (dto.InstructorCode, error) {
+	var instructorCode dto.InstructorCode
+	err := u.db.Model(&model.InstructorCode{}).Where("email = ?", email).Find(&instructorCode)
+	if err.Error != nil {
+		return dto.InstructorCode{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.InstructorCode{}, gorm.ErrRecordNotFound
+	}
+	return instructorCode, nil
+}
+
+// SaveInstructorCode implements InstructorRepository
+func (u *instructorrepository) SaveInstructorCode(code dto.InstructorCode) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		// keep a single code per email
+		var instructorCode model.InstructorCode
+		err := tx.Where("email = ?", code.Email).Find(&instructorCode)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			instructorCode.ID = code.ID
+			instructorCode.Email = code.Email
+		}
+		instructorCode.Code = code.Code
+		instructorCode.ExpiresAt = code.ExpiresAt
+		instructorCode.Attempts = code.Attempts
+		instructorCode.SentAt = code.SentAt
+		return tx.Save(&instructorCode).Error
+	})
+}
+
+// IncrementInstructorCodeAttempts implements InstructorRepository
+func (u *instructorrepository) IncrementInstructorCodeAttempts(id string) error {
+	err := u.db.Model(&model.InstructorCode{}).Where("id = ?", id).Update("attempts", gorm.Expr("attempts + 1"))
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ActivateInstructor implements InstructorRepository
+func (u *instructorrepository) ActivateInstructor(email string) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Instructor{}).Where("email = ?", email).Update("is_active", true)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Unscoped().Where("email = ?", email).Delete(&model.InstructorCode{}).Error
+	})
+}
+
+// UpdateInstructorStatus implements InstructorRepository
+func (u *instructorrepository) UpdateInstructorStatus(id, status string) error {
+	err := u.db.Model(&model.Instructor{}).Where("id = ?", id).Update("status", status)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
 	return nil
 }
 
@@ -47,10 +131,115 @@ func (u *instructorrepository) LoginInstructor(instructor dto.InstructorLogin) (
 		Email:        instructorLogin.Email,
 		Password:     instructorLogin.Password,
 		ProfileImage: instructorLogin.ProfileImage,
+		IsActive:     instructorLogin.IsActive,
+		Status:       instructorLogin.Status,
+	}
+	if !instructorLoginResponse.IsActive {
+		return dto.InstructorResponseGet{}, errors.New(constantError.ErrorNoActive)
 	}
 	return instructorLoginResponse, nil
 }
 
+// GetInstructorByEmail implements InstructorRepository
+func (u *instructorrepository) GetInstructorByEmail(email string) (dto.InstructorResponseGet, error) {
+	var instructor dto.InstructorResponseGet
+	err := u.db.Model(&model.Instructor{}).Where("email = ?", email).Find(&instructor)
+	if err.Error != nil {
+		return dto.InstructorResponseGet{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.InstructorResponseGet{}, gorm.ErrRecordNotFound
+	}
+	return instructor, nil
+}
+
+// GetInstructorByID implements InstructorRepository
+func (u *instructorrepository) GetInstructorByID(id string) (dto.InstructorResponseGet, error) {
+	var instructor dto.InstructorResponseGet
+	err := u.db.Model(&model.Instructor{}).Where("id = ?", id).Find(&instructor)
+	if err.Error != nil {
+		return dto.InstructorResponseGet{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.InstructorResponseGet{}, gorm.ErrRecordNotFound
+	}
+	return instructor, nil
+}
+
+// UpdateInstructorPassword implements InstructorRepository
+func (u *instructorrepository) UpdateInstructorPassword(id, password string) error {
+	err := u.db.Model(&model.Instructor{}).Where("id = ?", id).Update("password", password)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateInstructorProfile implements InstructorRepository
+func (u *instructorrepository) UpdateInstructorProfile(id string, profile dto.InstructorProfileUpdate) error {
+	err := u.db.Model(&model.Instructor{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"name":     profile.Name,
+		"headline": profile.Headline,
+		"bio":      profile.Bio,
+	})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateInstructorEmail implements InstructorRepository
+func (u *instructorrepository) UpdateInstructorEmail(id, email string) error {
+	err := u.db.Model(&model.Instructor{}).Where("id = ?", id).Update("email", email)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateInstructorProfileImage implements InstructorRepository
+func (u *instructorrepository) UpdateInstructorProfileImage(id, profileImage string) error {
+	err := u.db.Model(&model.Instructor{}).Where("id = ?", id).Update("profile_image", profileImage)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetAllInstructor implements InstructorRepository
+func (u *instructorrepository) GetAllInstructor() ([]dto.InstructorAccount, error) {
+	var accounts []dto.InstructorAccount
+	err := u.db.Model(&model.Instructor{}).Order("created_at DESC").Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// DeleteInstructor implements InstructorRepository
+func (u *instructorrepository) DeleteInstructor(id string) error {
+	err := u.db.Where("id = ?", id).Delete(&model.Instructor{})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func Newinstructorrepository(db *gorm.DB) InstructorRepository {
 	return &instructorrepository{
 		db: db,
@@ -5,6 +5,19 @@ import (
 )
 
 type InstructorRepository interface {
-	CreateInstructor(instructor dto.InstructorRegister) error
+	CreateInstructor(instructor dto.InstructorRegister, code dto.InstructorCode) error
+	GetInstructorCode(email string) (dto.InstructorCode, error)
+	SaveInstructorCode(code dto.InstructorCode) error
+	IncrementInstructorCodeAttempts(id string) error
+	ActivateInstructor(email string) error
+	UpdateInstructorStatus(id, status string) error
 	LoginInstructor(instructor dto.InstructorLogin) (dto.InstructorResponseGet, error)
+	GetInstructorByEmail(email string) (dto.InstructorResponseGet, error)
+	GetInstructorByID(id string) (dto.InstructorResponseGet, error)
+	UpdateInstructorPassword(id, password string) error
+	UpdateInstructorProfile(id string, profile dto.InstructorProfileUpdate) error
+	UpdateInstructorEmail(id, email string) error
+	UpdateInstructorProfileImage(id, profileImage string) error
+	GetAllInstructor() ([]dto.InstructorAccount, error)
+	DeleteInstructor(id string) error
 }
@@ -0,0 +1,72 @@
+package learningPathRepository
+
+import (
+	"golang/models/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CompleteLearningPaths issues the completion of the learning paths with the course to the customers
+// who finished every course of the path, it is called when the customers finish the course
+func CompleteLearningPaths(tx *gorm.DB, courseID string, customerIDs ...string) error {
+	if len(customerIDs) == 0 {
+		return nil
+	}
+	var learningPathIDs []string
+	err := tx.Model(&model.LearningPathCourse{}).
+		Joins("JOIN learning_paths ON learning_paths.id = learning_path_courses.learning_path_id AND learning_paths.deleted_at IS NULL").
+		Where("learning_path_courses.course_id = ?", courseID).
+		Pluck("learning_path_courses.learning_path_id", &learningPathIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, learningPathID := range learningPathIDs {
+		err = completeLearningPath(tx, learningPathID, customerIDs...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completeLearningPath issues the completion of the learning path to the customers who finished every course of the path,
+// every customer is checked when no customer is given and a completion already issued is kept
+func completeLearningPath(tx *gorm.DB, learningPathID string, customerIDs ...string) error {
+	var courses int64
+	err := tx.Model(&model.LearningPathCourse{}).
+		Joins("JOIN courses ON courses.id = learning_path_courses.course_id AND courses.deleted_at IS NULL").
+		Where("learning_path_courses.learning_path_id = ?", learningPathID).
+		Count(&courses).Error
+	if err != nil {
+		return err
+	}
+	if courses == 0 {
+		return nil
+	}
+
+	query := tx.Model(&model.LearningPathCourse{}).
+		Joins("JOIN courses ON courses.id = learning_path_courses.course_id AND courses.deleted_at IS NULL").
+		Joins("JOIN customer_courses ON customer_courses.course_id = courses.id AND customer_courses.is_finish = ? AND customer_courses.deleted_at IS NULL", true).
+		Where("learning_path_courses.learning_path_id = ?", learningPathID)
+	if len(customerIDs) > 0 {
+		query = query.Where("customer_courses.customer_id IN ?", customerIDs)
+	}
+	var finishedCustomerIDs []string
+	err = query.Group("customer_courses.customer_id").Having("COUNT(*) = ?", courses).Pluck("customer_courses.customer_id", &finishedCustomerIDs).Error
+	if err != nil {
+		return err
+	}
+	if len(finishedCustomerIDs) == 0 {
+		return nil
+	}
+
+	completions := make([]model.LearningPathCompletion, 0, len(finishedCustomerIDs))
+	for _, customerID := range finishedCustomerIDs {
+		completions = append(completions, model.LearningPathCompletion{
+			LearningPathID: learningPathID,
+			CustomerID:     customerID,
+		})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&completions).Error
+}
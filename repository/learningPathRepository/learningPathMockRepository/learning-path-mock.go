@@ -0,0 +1,37 @@
+package learningPathMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LearningPathMock struct {
+	mock.Mock
+}
+
+func (l *LearningPathMock) CreateLearningPath(learningPath dto.LearningPathTransaction) error {
+	args := l.Called(learningPath)
+
+	return args.Error(0)
+}
+func (l *LearningPathMock) DeleteLearningPath(id string) error {
+	args := l.Called(id)
+
+	return args.Error(0)
+}
+func (l *LearningPathMock) GetLearningPathByID(id, customerID string) (dto.LearningPath, error) {
+	args := l.Called(id, customerID)
+
+	return args.Get(0).(dto.LearningPath), args.Error(1)
+}
+func (l *LearningPathMock) GetLearningPaths(instructorID, customerID string) ([]dto.LearningPath, error) {
+	args := l.Called(instructorID, customerID)
+
+	return args.Get(0).([]dto.LearningPath), args.Error(1)
+}
+func (l *LearningPathMock) UpdateLearningPath(learningPath dto.LearningPathTransaction) error {
+	args := l.Called(learningPath)
+
+	return args.Error(0)
+}
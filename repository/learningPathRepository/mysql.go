@@ -0,0 +1,205 @@
+package learningPathRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type learningPathRepository struct {
+	db *gorm.DB
+}
+
+// learningPathCourse is a course of a learning path with the enrollment of the customer
+type learningPathCourse struct {
+	LearningPathID string
+	CourseID       string
+	Name           string
+	NoCourse       int
+	StatusEnroll   bool
+	IsFinish       bool
+}
+
+// CreateLearningPath implements LearningPathRepository, the customers who already finished every course complete the path
+func (lpr *learningPathRepository) CreateLearningPath(learningPath dto.LearningPathTransaction) error {
+	return lpr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&model.LearningPath{
+			ID:           learningPath.ID,
+			Title:        learningPath.Title,
+			Description:  learningPath.Description,
+			InstructorID: learningPath.InstructorID,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = createLearningPathCourses(tx, learningPath.ID, learningPath.CourseIDs)
+		if err != nil {
+			return err
+		}
+		return completeLearningPath(tx, learningPath.ID)
+	})
+}
+
+// DeleteLearningPath implements LearningPathRepository, the completions already issued are kept
+func (lpr *learningPathRepository) DeleteLearningPath(id string) error {
+	return lpr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).Delete(&model.LearningPath{})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("learning_path_id = ?", id).Delete(&model.LearningPathCourse{}).Error
+	})
+}
+
+// GetLearningPathByID implements LearningPathRepository
+func (lpr *learningPathRepository) GetLearningPathByID(id, customerID string) (dto.LearningPath, error) {
+	learningPaths, err := lpr.getLearningPaths(lpr.db.Where("id = ?", id), customerID)
+	if err != nil {
+		return dto.LearningPath{}, err
+	}
+	if len(learningPaths) == 0 {
+		return dto.LearningPath{}, gorm.ErrRecordNotFound
+	}
+	return learningPaths[0], nil
+}
+
+// GetLearningPaths implements LearningPathRepository, the learning paths of every instructor are read when no instructor is given
+func (lpr *learningPathRepository) GetLearningPaths(instructorID, customerID string) ([]dto.LearningPath, error) {
+	query := lpr.db
+	if instructorID != "" {
+		query = query.Where("instructor_id = ?", instructorID)
+	}
+	return lpr.getLearningPaths(query, customerID)
+}
+
+// UpdateLearningPath implements LearningPathRepository, the courses of the path are replaced when courses are given
+func (lpr *learningPathRepository) UpdateLearningPath(learningPath dto.LearningPathTransaction) error {
+	return lpr.db.Transaction(func(tx *gorm.DB) error {
+		var learningPathModel model.LearningPath
+		err := tx.Where("id = ?", learningPath.ID).Find(&learningPathModel)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		errUpdate := tx.Model(&model.LearningPath{}).Where("id = ?", learningPath.ID).Updates(&model.LearningPath{
+			Title:       learningPath.Title,
+			Description: learningPath.Description,
+		}).Error
+		if errUpdate != nil {
+			return errUpdate
+		}
+		if len(learningPath.CourseIDs) == 0 {
+			return nil
+		}
+
+		errDelete := tx.Where("learning_path_id = ?", learningPath.ID).Delete(&model.LearningPathCourse{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errCreate := createLearningPathCourses(tx, learningPath.ID, learningPath.CourseIDs)
+		if errCreate != nil {
+			return errCreate
+		}
+		return completeLearningPath(tx, learningPath.ID)
+	})
+}
+
+// getLearningPaths reads the learning paths of the query with their courses in order,
+// the enrollments and the completion of the customer are read when a customer is given
+func (lpr *learningPathRepository) getLearningPaths(query *gorm.DB, customerID string) ([]dto.LearningPath, error) {
+	var learningPathModels []model.LearningPath
+	err := query.Order("created_at").Find(&learningPathModels).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(learningPathModels) == 0 {
+		return nil, nil
+	}
+	learningPathIDs := make([]string, 0, len(learningPathModels))
+	for _, learningPath := range learningPathModels {
+		learningPathIDs = append(learningPathIDs, learningPath.ID)
+	}
+
+	// the deleted courses are not in the path anymore
+	var courses []learningPathCourse
+	err = lpr.db.Model(&model.LearningPathCourse{}).
+		Select("learning_path_courses.learning_path_id", "courses.id AS course_id", "courses.name", "learning_path_courses.no_course",
+			"COALESCE(customer_courses.status, false) AS status_enroll", "COALESCE(customer_courses.is_finish, false) AS is_finish").
+		Joins("JOIN courses ON courses.id = learning_path_courses.course_id AND courses.deleted_at IS NULL").
+		Joins("LEFT JOIN customer_courses ON customer_courses.course_id = courses.id AND customer_courses.customer_id = ? AND customer_courses.deleted_at IS NULL", customerID).
+		Where("learning_path_courses.learning_path_id IN ?", learningPathIDs).
+		Order("learning_path_courses.no_course").
+		Scan(&courses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	completedAt := map[string]time.Time{}
+	if customerID != "" {
+		var completions []model.LearningPathCompletion
+		err = lpr.db.Where("customer_id = ? AND learning_path_id IN ?", customerID, learningPathIDs).Find(&completions).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, completion := range completions {
+			completedAt[completion.LearningPathID] = completion.CreatedAt
+		}
+	}
+
+	learningPaths := make([]dto.LearningPath, 0, len(learningPathModels))
+	for _, learningPathModel := range learningPathModels {
+		learningPath := dto.LearningPath{
+			ID:           learningPathModel.ID,
+			CreatedAt:    learningPathModel.CreatedAt,
+			UpdatedAt:    learningPathModel.UpdatedAt,
+			Title:        learningPathModel.Title,
+			Description:  learningPathModel.Description,
+			InstructorID: learningPathModel.InstructorID,
+			Courses:      []dto.LearningPathCourse{},
+		}
+		for _, course := range courses {
+			if course.LearningPathID != learningPath.ID {
+				continue
+			}
+			learningPath.Courses = append(learningPath.Courses, dto.LearningPathCourse{
+				CourseID:     course.CourseID,
+				Name:         course.Name,
+				NoCourse:     course.NoCourse,
+				StatusEnroll: course.StatusEnroll,
+				IsFinish:     course.IsFinish,
+			})
+		}
+		if completed, ok := completedAt[learningPath.ID]; ok {
+			learningPath.CompletedAt = &completed
+		}
+		learningPaths = append(learningPaths, learningPath)
+	}
+	return learningPaths, nil
+}
+
+// createLearningPathCourses adds the courses to the learning path in the given order
+func createLearningPathCourses(tx *gorm.DB, learningPathID string, courseIDs []string) error {
+	courses := make([]model.LearningPathCourse, 0, len(courseIDs))
+	for i, courseID := range courseIDs {
+		courses = append(courses, model.LearningPathCourse{
+			LearningPathID: learningPathID,
+			CourseID:       courseID,
+			NoCourse:       i + 1,
+		})
+	}
+	return tx.Create(&courses).Error
+}
+
+func NewLearningPathRepository(db *gorm.DB) LearningPathRepository {
+	return &learningPathRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,11 @@
+package learningPathRepository
+
+import "golang/models/dto"
+
+type LearningPathRepository interface {
+	CreateLearningPath(dto.LearningPathTransaction) error
+	DeleteLearningPath(id string) error
+	GetLearningPathByID(id, customerID string) (dto.LearningPath, error)
+	GetLearningPaths(instructorID, customerID string) ([]dto.LearningPath, error)
+	UpdateLearningPath(dto.LearningPathTransaction) error
+}
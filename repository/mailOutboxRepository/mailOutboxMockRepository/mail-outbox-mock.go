@@ -0,0 +1,48 @@
+package mailOutboxMockRepository
+
+import (
+	"golang/models/dto"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MailOutboxMock struct {
+	mock.Mock
+}
+
+func (m *MailOutboxMock) CreateMail(mail dto.Mail) error {
+	args := m.Called(mail)
+
+	return args.Error(0)
+}
+
+func (m *MailOutboxMock) ClaimPendingMails(now, lockedUntil time.Time, limit int) ([]dto.Mail, error) {
+	args := m.Called(now, lockedUntil, limit)
+
+	return args.Get(0).([]dto.Mail), args.Error(1)
+}
+
+func (m *MailOutboxMock) MarkMailSent(id string, sentAt time.Time) error {
+	args := m.Called(id, sentAt)
+
+	return args.Error(0)
+}
+
+func (m *MailOutboxMock) MarkMailRetry(id string, attempts int, nextAttemptAt time.Time, lastError string) error {
+	args := m.Called(id, attempts, nextAttemptAt, lastError)
+
+	return args.Error(0)
+}
+
+func (m *MailOutboxMock) MarkMailFailed(id string, attempts int, lastError string) error {
+	args := m.Called(id, attempts, lastError)
+
+	return args.Error(0)
+}
+
+func (m *MailOutboxMock) PurgeMails(before time.Time) (int64, error) {
+	args := m.Called(before)
+
+	return args.Get(0).(int64), args.Error(1)
+}
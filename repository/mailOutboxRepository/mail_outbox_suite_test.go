@@ -0,0 +1,139 @@
+package mailOutboxRepository
+
+import (
+	"errors"
+	"golang/models/dto"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type suiteMailOutbox struct {
+	suite.Suite
+	mailOutboxRepo MailOutboxRepository
+	mock           sqlmock.Sqlmock
+}
+
+func (s *suiteMailOutbox) SetupTest() {
+	sqlDB, mock, err := sqlmock.New()
+	s.NoError(err)
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	s.NoError(err)
+
+	s.mock = mock
+	s.mailOutboxRepo = NewMailOutboxRepository(db)
+}
+
+func (s *suiteMailOutbox) TestClaimPendingMails() {
+	now := time.Now()
+	lockedUntil := now.Add(5 * time.Minute)
+
+	testCase := []struct {
+		Name          string
+		Rows          *sqlmock.Rows
+		ExpectedMails int
+	}{
+		{"success claim mails", sqlmock.NewRows([]string{"id", "recipient", "status"}).AddRow("1", "customer@gmail.com", dto.MailStatusPending).AddRow("2", "instructor@gmail.com", dto.MailStatusSending), 2},
+		{"success no pending mail", sqlmock.NewRows([]string{"id", "recipient", "status"}), 0},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			s.mock.ExpectBegin()
+			// the rows locked by another worker are skipped
+			s.mock.ExpectQuery(regexp.QuoteMeta("WHERE ((status = ? AND next_attempt_at <= ?) OR (status = ? AND locked_until <= ?)) AND `mail_outboxes`.`deleted_at` IS NULL ORDER BY next_attempt_at ASC LIMIT 50 FOR UPDATE SKIP LOCKED")).
+				WithArgs(dto.MailStatusPending, now, dto.MailStatusSending, now).
+				WillReturnRows(v.Rows)
+			if v.ExpectedMails > 0 {
+				s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `mail_outboxes` SET `locked_until`=?,`status`=?,`updated_at`=? WHERE id IN (?,?)")).
+					WithArgs(lockedUntil, dto.MailStatusSending, sqlmock.AnyArg(), "1", "2").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			}
+			s.mock.ExpectCommit()
+
+			mails, err := s.mailOutboxRepo.ClaimPendingMails(now, lockedUntil, 50)
+			s.NoError(err)
+			s.Len(mails, v.ExpectedMails)
+			for _, mail := range mails {
+				s.Equal(dto.MailStatusSending, mail.Status)
+				s.Equal(lockedUntil, *mail.LockedUntil)
+			}
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *suiteMailOutbox) TestClaimPendingMailsFail() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(regexp.QuoteMeta("FOR UPDATE SKIP LOCKED")).
+		WillReturnError(errors.New("error"))
+	s.mock.ExpectRollback()
+
+	mails, err := s.mailOutboxRepo.ClaimPendingMails(time.Now(), time.Now(), 50)
+	s.EqualError(err, "error")
+	s.Nil(mails)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteMailOutbox) TestMarkMailSent() {
+	testCase := []struct {
+		Name          string
+		RowsAffected  int64
+		ExpectedError error
+	}{
+		{"success mark mail sent", 1, nil},
+		// a mail that is not claimed by the worker is not marked
+		{"fail mail not claimed", 0, gorm.ErrRecordNotFound},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			// the body is removed with the codes and the tokens in it
+			s.mock.ExpectBegin()
+			s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `mail_outboxes` SET `attempts`=attempts + 1,`html`=?,`last_error`=?,`locked_until`=?,`sent_at`=?,`status`=?,`text`=?,`updated_at`=? WHERE (id = ? AND status = ?)")).
+				WithArgs("", "", nil, sqlmock.AnyArg(), dto.MailStatusSent, "", sqlmock.AnyArg(), "1", dto.MailStatusSending).
+				WillReturnResult(sqlmock.NewResult(0, v.RowsAffected))
+			s.mock.ExpectCommit()
+
+			err := s.mailOutboxRepo.MarkMailSent("1", time.Now())
+			s.Equal(v.ExpectedError, err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *suiteMailOutbox) TestMarkMailFailed() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `mail_outboxes` SET `attempts`=?,`html`=?,`last_error`=?,`locked_until`=?,`status`=?,`text`=?,`updated_at`=? WHERE (id = ? AND status = ?)")).
+		WithArgs(5, "", "error", nil, dto.MailStatusFailed, "", sqlmock.AnyArg(), "1", dto.MailStatusSending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+
+	err := s.mailOutboxRepo.MarkMailFailed("1", 5, "error")
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteMailOutbox) TestPurgeMails() {
+	before := time.Now()
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mail_outboxes` WHERE status IN (?,?) AND updated_at < ?")).
+		WithArgs(dto.MailStatusSent, dto.MailStatusFailed, before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	s.mock.ExpectCommit()
+
+	count, err := s.mailOutboxRepo.PurgeMails(before)
+	s.NoError(err)
+	s.Equal(int64(3), count)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func TestSuiteMailOutbox(t *testing.T) {
+	suite.Run(t, new(suiteMailOutbox))
+}
@@ -0,0 +1,130 @@
+package mailOutboxRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mailOutboxRepository struct {
+	db *gorm.DB
+}
+
+// CreateMail implements MailOutboxRepository
+func (mr *mailOutboxRepository) CreateMail(mail dto.Mail) error {
+	var mailModel model.MailOutbox
+	err := copier.Copy(&mailModel, &mail)
+	if err != nil {
+		return err
+	}
+
+	err = mr.db.Model(&model.MailOutbox{}).Create(&mailModel).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClaimPendingMails implements MailOutboxRepository, the pending mails are locked and marked as sending until lockedUntil
+// so another worker skips them, the mails of a worker that stopped before marking them are claimed again after the lock
+func (mr *mailOutboxRepository) ClaimPendingMails(now, lockedUntil time.Time, limit int) ([]dto.Mail, error) {
+	var mails []dto.Mail
+	err := mr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.MailOutbox{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND locked_until <= ?)", dto.MailStatusPending, now, dto.MailStatusSending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&mails).Error
+		if err != nil {
+			return err
+		}
+		if len(mails) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(mails))
+		for i := range mails {
+			ids[i] = mails[i].ID
+			mails[i].Status = dto.MailStatusSending
+			mails[i].LockedUntil = &lockedUntil
+		}
+		return tx.Model(&model.MailOutbox{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":       dto.MailStatusSending,
+			"locked_until": lockedUntil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mails, nil
+}
+
+// MarkMailSent implements MailOutboxRepository, the body is removed because it can have codes and tokens
+func (mr *mailOutboxRepository) MarkMailSent(id string, sentAt time.Time) error {
+	return mr.updateMail(id, map[string]interface{}{
+		"status":       dto.MailStatusSent,
+		"html":         "",
+		"text":         "",
+		"attempts":     gorm.Expr("attempts + 1"),
+		"sent_at":      sentAt,
+		"last_error":   "",
+		"locked_until": nil,
+	})
+}
+
+// MarkMailRetry implements MailOutboxRepository
+func (mr *mailOutboxRepository) MarkMailRetry(id string, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return mr.updateMail(id, map[string]interface{}{
+		"status":          dto.MailStatusPending,
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+		"locked_until":    nil,
+	})
+}
+
+// MarkMailFailed implements MailOutboxRepository, the body is removed because it can have codes and tokens
+func (mr *mailOutboxRepository) MarkMailFailed(id string, attempts int, lastError string) error {
+	return mr.updateMail(id, map[string]interface{}{
+		"status":       dto.MailStatusFailed,
+		"html":         "",
+		"text":         "",
+		"attempts":     attempts,
+		"last_error":   lastError,
+		"locked_until": nil,
+	})
+}
+
+// PurgeMails implements MailOutboxRepository, the sent and failed mails last updated before the time are deleted
+func (mr *mailOutboxRepository) PurgeMails(before time.Time) (int64, error) {
+	result := mr.db.Unscoped().
+		Where("status IN ? AND updated_at < ?", []string{dto.MailStatusSent, dto.MailStatusFailed}, before).
+		Delete(&model.MailOutbox{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// updateMail updates the columns of a claimed mail
+func (mr *mailOutboxRepository) updateMail(id string, columns map[string]interface{}) error {
+	err := mr.db.Model(&model.MailOutbox{}).Where("id = ? AND status = ?", id, dto.MailStatusSending).Updates(columns)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func NewMailOutboxRepository(db *gorm.DB) MailOutboxRepository {
+	return &mailOutboxRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,15 @@
+package mailOutboxRepository
+
+import (
+	"golang/models/dto"
+	"time"
+)
+
+type MailOutboxRepository interface {
+	CreateMail(mail dto.Mail) error
+	ClaimPendingMails(now, lockedUntil time.Time, limit int) ([]dto.Mail, error)
+	MarkMailSent(id string, sentAt time.Time) error
+	MarkMailRetry(id string, attempts int, nextAttemptAt time.Time, lastError string) error
+	MarkMailFailed(id string, attempts int, lastError string) error
+	PurgeMails(before time.Time) (int64, error)
+}
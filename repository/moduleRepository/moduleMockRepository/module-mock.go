@@ -54,3 +54,15 @@ func (c *ModuleMock) UpdateModule(module dto.ModuleTransaction) error {
 
 	return args.Error(0)
 }
+
+func (c *ModuleMock) ReorderModules(courseID string, ids []string) error {
+	args := c.Called(courseID, ids)
+
+	return args.Error(0)
+}
+
+func (c *ModuleMock) GetFinishedModules(courseID, customerID string) ([]string, error) {
+	args := c.Called(courseID, customerID)
+
+	return args.Get(0).([]string), args.Error(1)
+}
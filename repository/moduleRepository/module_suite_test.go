@@ -0,0 +1,104 @@
+package modulerepository
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+type suiteModuleRepository struct {
+	suite.Suite
+	moduleRepo ModuleRepository
+	mock       sqlmock.Sqlmock
+}
+
+func (s *suiteModuleRepository) SetupTest() {
+	sqlDB, mock, err := sqlmock.New()
+	s.NoError(err)
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+	s.NoError(err)
+
+	s.mock = mock
+	s.moduleRepo = NewModuleRepository(db)
+}
+
+// expectNoModule expects the module to be numbered at the position
+func (s *suiteModuleRepository) expectNoModule(id string, position int) {
+	s.mock.ExpectExec(regexp.QuoteMeta("UPDATE `modules` SET `no_module`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(position, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *suiteModuleRepository) TestReorderModules() {
+	s.mock.ExpectBegin()
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `courses` WHERE id = ?")).
+		WithArgs("course1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("course1"))
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `modules` WHERE course_id = ?")).
+		WithArgs("course1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("module1").AddRow("module2").AddRow("module3"))
+
+	// the order puts module3 of section2 between the modules of section1
+	s.expectNoModule("module1", 1)
+	s.expectNoModule("module3", 2)
+	s.expectNoModule("module2", 3)
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`no_module`,`section_id` FROM `modules` WHERE course_id = ?")).
+		WithArgs("course1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "no_module", "section_id"}).
+			AddRow("module1", 1, "section1").
+			AddRow("module3", 2, "section2").
+			AddRow("module2", 3, "section1"))
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `sections` WHERE course_id = ?")).
+		WithArgs("course1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("section1").AddRow("section2"))
+
+	// the modules of section1 are put back together
+	s.expectNoModule("module2", 2)
+	s.expectNoModule("module3", 3)
+	s.mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `customer_courses` WHERE course_id = ?")).
+		WithArgs("course1").
+		WillReturnRows(sqlmock.NewRows([]string{"customer_id", "course_id"}))
+	s.mock.ExpectCommit()
+
+	err := s.moduleRepo.ReorderModules("course1", []string{"module1", "module3", "module2"})
+	s.NoError(err)
+	s.NoError(s.mock.ExpectationsWereMet())
+}
+
+func (s *suiteModuleRepository) TestReorderModulesInvalidOrder() {
+	testCase := []struct {
+		Name string
+		IDs  []string
+	}{
+		{"fail module missing", []string{"module1"}},
+		{"fail module of another course", []string{"module1", "module3"}},
+		{"fail module twice", []string{"module1", "module1"}},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			s.mock.ExpectBegin()
+			s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `courses` WHERE id = ?")).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("course1"))
+			s.mock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `modules` WHERE course_id = ?")).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("module1").AddRow("module2"))
+			s.mock.ExpectRollback()
+
+			err := s.moduleRepo.ReorderModules("course1", v.IDs)
+			s.Equal(errors.New(constantError.ErrorModuleOrder), err)
+			s.NoError(s.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSuiteModuleRepository(t *testing.T) {
+	suite.Run(t, new(suiteModuleRepository))
+}
@@ -1,8 +1,11 @@
 package modulerepository
 
 import (
+	"errors"
+	"golang/constant/constantError"
 	"golang/models/dto"
 	"golang/models/model"
+	"golang/repository/courseStatsRepository"
 
 	"github.com/jinzhu/copier"
 
@@ -23,44 +26,78 @@ func (mr *moduleRepository) CreateModule(module dto.ModuleTransaction) error {
 	if err != nil {
 		return err
 	}
-	var checkModule []model.Module
-
-	mr.db.Where("course_id=?", module.CourseID).Find(&checkModule)
-
-	for _, data := range checkModule {
-
-		if data.NoModule == module.NoModule {
-
-			return gorm.ErrPrimaryKeyRequired
+	return mr.db.Transaction(func(tx *gorm.DB) error {
+		err := lockCourse(tx, module.CourseID)
+		if err != nil {
+			return err
 		}
-	}
-	err = mr.db.Model(&model.Module{}).Create(&moduleModel).Error
-	var mediaModuleModel model.MediaModule = model.MediaModule{
-		ID:       module.MediaModuleID,
-		Url:      module.Url,
-		ModuleID: module.ID,
-	}
-	mr.db.Create(&mediaModuleModel)
+		if moduleModel.SectionID != "" {
+			err = checkSection(tx, moduleModel.SectionID, module.CourseID)
+			if err != nil {
+				return err
+			}
+		}
+		err = tx.Model(&model.Module{}).Create(&moduleModel).Error
+		if err != nil {
+			return err
+		}
+		var mediaModuleModel model.MediaModule = model.MediaModule{
+			ID:       module.MediaModuleID,
+			Url:      module.Url,
+			ModuleID: module.ID,
+		}
+		tx.Create(&mediaModuleModel)
 
-	if err != nil {
-		return err
-	}
+		// the module is inserted at its number and the next modules are moved down, without number it is the last module
+		err = renumberModules(tx, module.CourseID, module.ID, module.NoModule)
+		if err != nil {
+			return err
+		}
+		err = RefreshCustomerProgress(tx, module.CourseID)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return courseStatsRepository.RefreshCourseStats(tx, module.CourseID)
+	})
 }
 
 // DeleteModule implements ModuleRepository
 func (mr *moduleRepository) DeleteModule(id string) error {
-	// delete data Module from database by id
-	err := mr.db.Select("media_modules", "assignments").Where("id = ?", id).Delete(&model.Module{})
-	if err.Error != nil {
-		return err.Error
-	}
-	if err.RowsAffected <= 0 {
-		return gorm.ErrRecordNotFound
-	}
+	return mr.db.Transaction(func(tx *gorm.DB) error {
+		var courseIDs []string
+		errPluck := tx.Model(&model.Module{}).Where("id = ?", id).Pluck("course_id", &courseIDs).Error
+		if errPluck != nil {
+			return errPluck
+		}
+		if len(courseIDs) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errLock := lockCourse(tx, courseIDs[0])
+		if errLock != nil {
+			return errLock
+		}
+		// delete data Module from database by id
+		err := tx.Select("media_modules", "assignments").Where("id = ?", id).Delete(&model.Module{})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
 
-	return nil
+		// the next modules are moved up to close the gap
+		errNumber := renumberModules(tx, courseIDs[0], "", 0)
+		if errNumber != nil {
+			return errNumber
+		}
+		errProgress := RefreshCustomerProgress(tx, courseIDs[0])
+		if errProgress != nil {
+			return errProgress
+		}
+
+		return courseStatsRepository.RefreshCourseStats(tx, courseIDs...)
+	})
 }
 
 // GetAllModule implements ModuleRepository
@@ -101,8 +138,9 @@ func (mr *moduleRepository) GetModuleByIDifInstructor(id string) (dto.ModuleCour
 
 // GetModuleByID implements ModuleRepository
 func (mr *moduleRepository) GetModuleByID(id, customerID string) (dto.ModuleCourseAcc, error) {
+	// a deleted module can still be in the version the customer learns
 	var moduleModel model.Module
-	err := mr.db.Model(&model.Module{}).Preload("MediaModules").Preload("Course").Preload("Assignment").Where("id = ?", id).Find(&moduleModel)
+	err := mr.db.Model(&model.Module{}).Unscoped().Preload("MediaModules").Preload("Course").Preload("Assignment").Where("id = ?", id).Find(&moduleModel)
 	if err.Error != nil {
 		return dto.ModuleCourseAcc{}, err.Error
 	}
@@ -118,6 +156,21 @@ func (mr *moduleRepository) GetModuleByID(id, customerID string) (dto.ModuleCour
 		return dto.ModuleCourseAcc{}, gorm.ErrRecordNotFound
 	}
 
+	if CustomerCourses.CourseVersionID == "" {
+		if moduleModel.DeletedAt.Valid {
+			return dto.ModuleCourseAcc{}, gorm.ErrRecordNotFound
+		}
+	} else {
+		modules, errVersion := mr.getVersionModules(CustomerCourses.CourseVersionID, moduleModel.Course, id)
+		if errVersion != nil {
+			return dto.ModuleCourseAcc{}, errVersion
+		}
+		if len(modules) == 0 {
+			return dto.ModuleCourseAcc{}, gorm.ErrRecordNotFound
+		}
+		moduleModel = modules[0]
+	}
+
 	if moduleModel.NoModule > int(CustomerCourses.NoModule) {
 		return dto.ModuleCourseAcc{}, gorm.ErrRecordNotFound
 	}
@@ -133,7 +186,7 @@ func (mr *moduleRepository) GetModuleByID(id, customerID string) (dto.ModuleCour
 
 func (mr *moduleRepository) GetModuleByCourseIDifInstructror(courseID string) ([]dto.ModuleCourse, error) {
 	var moduleModels []model.Module
-	err := mr.db.Model(&model.Module{}).Where("course_id = ?", courseID).Preload("Course").Find(&moduleModels).Error
+	err := mr.db.Model(&model.Module{}).Where("course_id = ?", courseID).Preload("Course").Order("no_module").Find(&moduleModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +204,7 @@ func (mr *moduleRepository) GetModuleByCourseIDifInstructror(courseID string) ([
 func (mr *moduleRepository) GetModuleByCourseID(courseID, customerID string) ([]dto.ModuleCourse, error) {
 
 	var moduleModels []model.Module
-	err := mr.db.Model(&model.Module{}).Where("course_id = ?", courseID).Preload("Course").Find(&moduleModels).Error
+	err := mr.db.Model(&model.Module{}).Where("course_id = ?", courseID).Preload("Course").Order("no_module").Find(&moduleModels).Error
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +217,19 @@ func (mr *moduleRepository) GetModuleByCourseID(courseID, customerID string) ([]
 		return nil, err
 	}
 
+	// the customer learns the modules of the version the enrollment is pinned to
+	if CustomerCourses.CourseVersionID != "" {
+		var course model.Course
+		err = mr.db.Where("id = ?", courseID).Find(&course).Error
+		if err != nil {
+			return nil, err
+		}
+		moduleModels, err = mr.getVersionModules(CustomerCourses.CourseVersionID, course)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// copy data from model to dto
 	var modules []dto.ModuleCourse
 	err = copier.Copy(&modules, &moduleModels)
@@ -177,6 +243,70 @@ func (mr *moduleRepository) GetModuleByCourseID(courseID, customerID string) ([]
 
 }
 
+// getVersionModules get the modules of a course version as modules, only the modules with the given ids are returned when ids are given
+func (mr *moduleRepository) getVersionModules(versionID string, course model.Course, ids ...string) ([]model.Module, error) {
+	query := mr.db.Preload("Media").Where("course_version_id = ?", versionID).Order("no_module")
+	if len(ids) > 0 {
+		query = query.Where("module_id IN ?", ids)
+	}
+	var versionModules []model.CourseVersionModule
+	err := query.Find(&versionModules).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(versionModules) == 0 {
+		return nil, nil
+	}
+
+	// the assignments are not part of the version, they belong to the module
+	moduleIDs := make([]string, len(versionModules))
+	for i, versionModule := range versionModules {
+		moduleIDs[i] = versionModule.ModuleID
+	}
+	var assignments []model.Assignment
+	err = mr.db.Where("module_id IN ?", moduleIDs).Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// the sections are not part of the version, the module stays in the section of the course
+	var liveModules []model.Module
+	err = mr.db.Unscoped().Select("id", "section_id").Where("id IN ?", moduleIDs).Find(&liveModules).Error
+	if err != nil {
+		return nil, err
+	}
+	sectionIDs := map[string]string{}
+	for _, liveModule := range liveModules {
+		sectionIDs[liveModule.ID] = liveModule.SectionID
+	}
+
+	modules := make([]model.Module, len(versionModules))
+	for i, versionModule := range versionModules {
+		modules[i] = model.Module{
+			ID:        versionModule.ModuleID,
+			Name:      versionModule.Name,
+			Content:   versionModule.Content,
+			CourseID:  course.ID,
+			Course:    course,
+			SectionID: sectionIDs[versionModule.ModuleID],
+			NoModule:  versionModule.NoModule,
+		}
+		for _, media := range versionModule.Media {
+			modules[i].MediaModules = append(modules[i].MediaModules, model.MediaModule{
+				ID:       media.MediaModuleID,
+				Url:      media.Url,
+				ModuleID: versionModule.ModuleID,
+			})
+		}
+		for _, assignment := range assignments {
+			if assignment.ModuleID == versionModule.ModuleID {
+				modules[i].Assignment = assignment
+			}
+		}
+	}
+	return modules, nil
+}
+
 // UpdateModule implements ModuleRepository
 func (mr *moduleRepository) UpdateModule(module dto.ModuleTransaction) error {
 	var moduleModel model.Module
@@ -184,17 +314,133 @@ func (mr *moduleRepository) UpdateModule(module dto.ModuleTransaction) error {
 	if errCopy != nil {
 		return errCopy
 	}
+	// the number is set by renumbering the modules of the course and the section is updated on its own
+	// because an empty section removes the module from its section
+	moduleModel.NoModule = 0
+	moduleModel.SectionID = ""
+
+	return mr.db.Transaction(func(tx *gorm.DB) error {
+		// the module can be moved to another course, both courses get new stats
+		var oldModule model.Module
+		errFind := tx.Select("course_id", "no_module", "section_id").Where("id = ?", module.ID).Find(&oldModule)
+		if errFind.Error != nil {
+			return errFind.Error
+		}
+		if errFind.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		courseID := oldModule.CourseID
+		if module.CourseID != "" {
+			courseID = module.CourseID
+		}
+		courseIDs := []string{oldModule.CourseID}
+		if courseID != oldModule.CourseID {
+			courseIDs = append(courseIDs, courseID)
+		}
+		for _, id := range courseIDs {
+			errLock := lockCourse(tx, id)
+			if errLock != nil {
+				return errLock
+			}
+		}
 
-	// update account with new data
-	err := mr.db.Model(&model.Module{}).Where("id = ?", module.ID).Updates(&moduleModel)
-	if err.Error != nil {
-		return err.Error
-	}
-	if err.RowsAffected <= 0 {
-		return gorm.ErrRecordNotFound
-	}
+		// update account with new data
+		err := tx.Model(&model.Module{}).Where("id = ?", module.ID).Updates(&moduleModel)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// without section the module stays in its section, a module moved to another course leaves its section
+		sectionID := oldModule.SectionID
+		if module.SectionID != nil {
+			sectionID = *module.SectionID
+		} else if courseID != oldModule.CourseID {
+			sectionID = ""
+		}
+		if sectionID != "" {
+			errSection := checkSection(tx, sectionID, courseID)
+			if errSection != nil {
+				return errSection
+			}
+		}
+		if sectionID != oldModule.SectionID {
+			errSection := tx.Model(&model.Module{}).Where("id = ?", module.ID).Update("section_id", sectionID).Error
+			if errSection != nil {
+				return errSection
+			}
+		}
+
+		// without number the module keeps its place, a module moved to another course is the last module there
+		position := module.NoModule
+		if position == 0 && courseID == oldModule.CourseID {
+			position = oldModule.NoModule
+		}
+		errNumber := renumberModules(tx, courseID, module.ID, position)
+		if errNumber != nil {
+			return errNumber
+		}
+		if courseID != oldModule.CourseID {
+			errNumber = renumberModules(tx, oldModule.CourseID, "", 0)
+			if errNumber != nil {
+				return errNumber
+			}
+		}
+		for _, id := range courseIDs {
+			errProgress := RefreshCustomerProgress(tx, id)
+			if errProgress != nil {
+				return errProgress
+			}
+		}
+
+		return courseStatsRepository.RefreshCourseStats(tx, courseIDs...)
+	})
+}
+
+// ReorderModules implements ModuleRepository
+func (mr *moduleRepository) ReorderModules(courseID string, ids []string) error {
+	return mr.db.Transaction(func(tx *gorm.DB) error {
+		err := lockCourse(tx, courseID)
+		if err != nil {
+			return err
+		}
+		var moduleIDs []string
+		err = tx.Model(&model.Module{}).Where("course_id = ?", courseID).Pluck("id", &moduleIDs).Error
+		if err != nil {
+			return err
+		}
+
+		// every module of the course must be in the order once
+		if len(ids) != len(moduleIDs) {
+			return errors.New(constantError.ErrorModuleOrder)
+		}
+		courseModules := map[string]bool{}
+		for _, id := range moduleIDs {
+			courseModules[id] = true
+		}
+		for _, id := range ids {
+			if !courseModules[id] {
+				return errors.New(constantError.ErrorModuleOrder)
+			}
+			delete(courseModules, id)
+		}
+
+		for i, id := range ids {
+			err = tx.Model(&model.Module{}).Where("id = ?", id).Update("no_module", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		// the order is numbered again so a section split by the order is put back together
+		return RenumberModules(tx, courseID)
+	})
+}
 
-	return nil
+// GetFinishedModules implements ModuleRepository
+func (mr *moduleRepository) GetFinishedModules(courseID, customerID string) ([]string, error) {
+	return GetFinishedModules(mr.db, courseID, customerID)
 }
 
 func NewModuleRepository(db *gorm.DB) ModuleRepository {
@@ -0,0 +1,237 @@
+package modulerepository
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/model"
+	"golang/repository/learningPathRepository"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CompleteModule records the module as finished by the customer, a module finished again is ignored
+func CompleteModule(tx *gorm.DB, customerID string, module model.Module) error {
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ModuleProgress{
+		CustomerID: customerID,
+		ModuleID:   module.ID,
+		CourseID:   module.CourseID,
+	}).Error
+}
+
+// RefreshCustomerProgress computes the module number and the finish of the enrollments of the course again from the finished modules,
+// only the enrollments of the given customers are computed when customers are given
+func RefreshCustomerProgress(tx *gorm.DB, courseID string, customerIDs ...string) error {
+	query := tx.Where("course_id = ?", courseID)
+	if len(customerIDs) > 0 {
+		query = query.Where("customer_id IN ?", customerIDs)
+	}
+	var customerCourses []model.CustomerCourse
+	err := query.Find(&customerCourses).Error
+	if err != nil {
+		return err
+	}
+	if len(customerCourses) == 0 {
+		return nil
+	}
+
+	// the customers of a version learn the modules of the version, the others learn the modules of the course
+	modules := map[string][]model.Module{}
+	var customers []string
+	for _, customerCourse := range customerCourses {
+		customers = append(customers, customerCourse.CustomerID)
+		if _, ok := modules[customerCourse.CourseVersionID]; ok {
+			continue
+		}
+		var versionModules []model.Module
+		if customerCourse.CourseVersionID == "" {
+			err = tx.Select("id", "no_module").Where("course_id = ?", courseID).Order("no_module").Find(&versionModules).Error
+		} else {
+			err = tx.Model(&model.CourseVersionModule{}).Select("module_id AS id", "no_module").Where("course_version_id = ?", customerCourse.CourseVersionID).Order("no_module").Scan(&versionModules).Error
+		}
+		if err != nil {
+			return err
+		}
+		modules[customerCourse.CourseVersionID] = versionModules
+	}
+
+	var progresses []model.ModuleProgress
+	err = tx.Where("course_id = ? AND customer_id IN ?", courseID, customers).Find(&progresses).Error
+	if err != nil {
+		return err
+	}
+	finished := map[string]map[string]bool{}
+	for _, progress := range progresses {
+		if finished[progress.CustomerID] == nil {
+			finished[progress.CustomerID] = map[string]bool{}
+		}
+		finished[progress.CustomerID][progress.ModuleID] = true
+	}
+
+	var finishedCustomers []string
+	for _, customerCourse := range customerCourses {
+		noModule, isFinish := getProgress(modules[customerCourse.CourseVersionID], finished[customerCourse.CustomerID])
+		if noModule == customerCourse.NoModule && isFinish == customerCourse.IsFinish {
+			continue
+		}
+		err = tx.Model(&model.CustomerCourse{}).Where("id = ?", customerCourse.ID).Updates(map[string]interface{}{
+			"no_module": noModule,
+			"is_finish": isFinish,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if isFinish {
+			finishedCustomers = append(finishedCustomers, customerCourse.CustomerID)
+		}
+	}
+
+	// the customers who just finished the course may have finished a learning path
+	return learningPathRepository.CompleteLearningPaths(tx, courseID, finishedCustomers...)
+}
+
+// BackfillModuleProgress records the finished modules of the enrollments made when the progress was only the module number,
+// the modules before the number of the enrollment are finished, then the modules of every course are numbered again without gaps
+func BackfillModuleProgress(tx *gorm.DB) error {
+	var customerCourses []model.CustomerCourse
+	err := tx.Where("no_module > 1").Find(&customerCourses).Error
+	if err != nil {
+		return err
+	}
+	for _, customerCourse := range customerCourses {
+		var moduleIDs []string
+		if customerCourse.CourseVersionID == "" {
+			err = tx.Model(&model.Module{}).Where("course_id = ? AND no_module < ?", customerCourse.CourseID, customerCourse.NoModule).Pluck("id", &moduleIDs).Error
+		} else {
+			err = tx.Model(&model.CourseVersionModule{}).Where("course_version_id = ? AND no_module < ?", customerCourse.CourseVersionID, customerCourse.NoModule).Pluck("module_id", &moduleIDs).Error
+		}
+		if err != nil {
+			return err
+		}
+		for _, moduleID := range moduleIDs {
+			err = CompleteModule(tx, customerCourse.CustomerID, model.Module{ID: moduleID, CourseID: customerCourse.CourseID})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var courseIDs []string
+	err = tx.Model(&model.Module{}).Distinct().Pluck("course_id", &courseIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, courseID := range courseIDs {
+		err = renumberModules(tx, courseID, "", 0)
+		if err != nil {
+			return err
+		}
+		err = RefreshCustomerProgress(tx, courseID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getProgress returns the number of the first module the customer did not finish and if all modules are finished
+func getProgress(modules []model.Module, finished map[string]bool) (int, bool) {
+	for _, module := range modules {
+		if !finished[module.ID] {
+			return module.NoModule, false
+		}
+	}
+	if len(modules) == 0 {
+		return 1, false
+	}
+	return modules[len(modules)-1].NoModule + 1, true
+}
+
+// RenumberModules numbers the modules of the course again after the sections are changed and computes the progress again
+func RenumberModules(tx *gorm.DB, courseID string) error {
+	err := renumberModules(tx, courseID, "", 0)
+	if err != nil {
+		return err
+	}
+	return RefreshCustomerProgress(tx, courseID)
+}
+
+// GetFinishedModules returns the ids of the modules of the course the customer finished
+func GetFinishedModules(tx *gorm.DB, courseID, customerID string) ([]string, error) {
+	var moduleIDs []string
+	err := tx.Model(&model.ModuleProgress{}).Where("course_id = ? AND customer_id = ?", courseID, customerID).Pluck("module_id", &moduleIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return moduleIDs, nil
+}
+
+// renumberModules numbers the modules of the course from 1 without gaps, the module with the id is moved to the position
+// and a position out of the course puts it at the end, the modules of a section stay together in the order of the sections
+// after the modules without section
+func renumberModules(tx *gorm.DB, courseID, moduleID string, position int) error {
+	var courseModules []model.Module
+	err := tx.Select("id", "no_module", "section_id").Where("course_id = ?", courseID).Order("no_module").Order("created_at").Find(&courseModules).Error
+	if err != nil {
+		return err
+	}
+
+	modules := make([]model.Module, 0, len(courseModules))
+	var moved *model.Module
+	for i, module := range courseModules {
+		if module.ID == moduleID {
+			moved = &courseModules[i]
+			continue
+		}
+		modules = append(modules, module)
+	}
+	if moved != nil {
+		if position < 1 || position > len(modules)+1 {
+			position = len(modules) + 1
+		}
+		modules = append(modules[:position-1], append([]model.Module{*moved}, modules[position-1:]...)...)
+	}
+
+	var sectionIDs []string
+	err = tx.Model(&model.Section{}).Where("course_id = ?", courseID).Order("no_section").Pluck("id", &sectionIDs).Error
+	if err != nil {
+		return err
+	}
+	sectionOrder := map[string]int{}
+	for i, sectionID := range sectionIDs {
+		sectionOrder[sectionID] = i + 1
+	}
+	sort.SliceStable(modules, func(i, j int) bool {
+		return sectionOrder[modules[i].SectionID] < sectionOrder[modules[j].SectionID]
+	})
+
+	for i, module := range modules {
+		if module.NoModule == i+1 {
+			continue
+		}
+		err = tx.Model(&model.Module{}).Where("id = ?", module.ID).Update("no_module", i+1).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSection fails when the section is not a section of the course
+func checkSection(tx *gorm.DB, sectionID, courseID string) error {
+	var count int64
+	err := tx.Model(&model.Section{}).Where("id = ? AND course_id = ?", sectionID, courseID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New(constantError.ErrorSectionNotFound)
+	}
+	return nil
+}
+
+// lockCourse locks the course until the end of the transaction so the modules are numbered one change at a time
+func lockCourse(tx *gorm.DB, courseID string) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", courseID).Find(&model.Course{}).Error
+}
@@ -11,4 +11,6 @@ type ModuleRepository interface {
 	GetModuleByCourseID(courseID, customerID string) ([]dto.ModuleCourse, error)
 	GetModuleByCourseIDifInstructror(courseID string) ([]dto.ModuleCourse, error)
 	UpdateModule(dto.ModuleTransaction) error
+	ReorderModules(courseID string, ids []string) error
+	GetFinishedModules(courseID, customerID string) ([]string, error)
 }
@@ -0,0 +1,109 @@
+package ownershipRepository
+
+import (
+	"golang/models/model"
+
+	"gorm.io/gorm"
+)
+
+type ownershipRepository struct {
+	db *gorm.DB
+}
+
+// GetCourseInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetCourseInstructorID(courseID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.Course{}).
+		Where("courses.id = ?", courseID))
+}
+
+// GetModuleInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetModuleInstructorID(moduleID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.Module{}).
+		Joins("JOIN courses ON courses.id = modules.course_id AND courses.deleted_at IS NULL").
+		Where("modules.id = ?", moduleID))
+}
+
+// GetMediaModuleInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetMediaModuleInstructorID(mediaModuleID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.MediaModule{}).
+		Joins("JOIN modules ON modules.id = media_modules.module_id AND modules.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = modules.course_id AND courses.deleted_at IS NULL").
+		Where("media_modules.id = ?", mediaModuleID))
+}
+
+// GetAssignmentInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetAssignmentInstructorID(assignmentID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.Assignment{}).
+		Joins("JOIN modules ON modules.id = assignments.module_id AND modules.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = modules.course_id AND courses.deleted_at IS NULL").
+		Where("assignments.id = ?", assignmentID))
+}
+
+// GetQuizInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetQuizInstructorID(quizID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.Quiz{}).
+		Joins("JOIN courses ON courses.id = quizzes.course_id AND courses.deleted_at IS NULL").
+		Where("quizzes.id = ?", quizID))
+}
+
+// GetQuizQuestionInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetQuizQuestionInstructorID(questionID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.QuizQuestion{}).
+		Joins("JOIN quizzes ON quizzes.id = quiz_questions.quiz_id AND quizzes.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = quizzes.course_id AND courses.deleted_at IS NULL").
+		Where("quiz_questions.id = ?", questionID))
+}
+
+// GetQuestionBankInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetQuestionBankInstructorID(bankID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.QuestionBank{}).
+		Joins("JOIN courses ON courses.id = question_banks.course_id AND courses.deleted_at IS NULL").
+		Where("question_banks.id = ?", bankID))
+}
+
+// GetBankQuestionInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetBankQuestionInstructorID(questionID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.QuizQuestion{}).
+		Joins("JOIN question_banks ON question_banks.id = quiz_questions.bank_id AND question_banks.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = question_banks.course_id AND courses.deleted_at IS NULL").
+		Where("quiz_questions.id = ?", questionID))
+}
+
+// GetSectionInstructorID implements OwnershipRepository
+func (or *ownershipRepository) GetSectionInstructorID(sectionID string) (string, error) {
+	return or.findInstructorID(or.db.Model(&model.Section{}).
+		Joins("JOIN courses ON courses.id = sections.course_id AND courses.deleted_at IS NULL").
+		Where("sections.id = ?", sectionID))
+}
+
+// GetLearningPathInstructorID implements OwnershipRepository, the learning path has its own instructor
+func (or *ownershipRepository) GetLearningPathInstructorID(learningPathID string) (string, error) {
+	var instructorIDs []string
+	err := or.db.Model(&model.LearningPath{}).Where("id = ?", learningPathID).Limit(1).Pluck("instructor_id", &instructorIDs)
+	if err.Error != nil {
+		return "", err.Error
+	}
+	if len(instructorIDs) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return instructorIDs[0], nil
+}
+
+// findInstructorID get the instructor id of the course the query is joined with
+func (or *ownershipRepository) findInstructorID(query *gorm.DB) (string, error) {
+	var instructorIDs []string
+	err := query.Limit(1).Pluck("courses.instructor_id", &instructorIDs)
+	if err.Error != nil {
+		return "", err.Error
+	}
+	if len(instructorIDs) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return instructorIDs[0], nil
+}
+
+func NewOwnershipRepository(db *gorm.DB) OwnershipRepository {
+	return &ownershipRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,69 @@
+package ownershipMockRepository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type OwnershipMock struct {
+	mock.Mock
+}
+
+func (o *OwnershipMock) GetCourseInstructorID(courseID string) (string, error) {
+	args := o.Called(courseID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetModuleInstructorID(moduleID string) (string, error) {
+	args := o.Called(moduleID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetMediaModuleInstructorID(mediaModuleID string) (string, error) {
+	args := o.Called(mediaModuleID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetAssignmentInstructorID(assignmentID string) (string, error) {
+	args := o.Called(assignmentID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetQuizInstructorID(quizID string) (string, error) {
+	args := o.Called(quizID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetQuizQuestionInstructorID(questionID string) (string, error) {
+	args := o.Called(questionID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetQuestionBankInstructorID(bankID string) (string, error) {
+	args := o.Called(bankID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetBankQuestionInstructorID(questionID string) (string, error) {
+	args := o.Called(questionID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetSectionInstructorID(sectionID string) (string, error) {
+	args := o.Called(sectionID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (o *OwnershipMock) GetLearningPathInstructorID(learningPathID string) (string, error) {
+	args := o.Called(learningPathID)
+
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,14 @@
+package ownershipRepository
+
+type OwnershipRepository interface {
+	GetCourseInstructorID(courseID string) (string, error)
+	GetModuleInstructorID(moduleID string) (string, error)
+	GetMediaModuleInstructorID(mediaModuleID string) (string, error)
+	GetAssignmentInstructorID(assignmentID string) (string, error)
+	GetQuizInstructorID(quizID string) (string, error)
+	GetQuizQuestionInstructorID(questionID string) (string, error)
+	GetQuestionBankInstructorID(bankID string) (string, error)
+	GetBankQuestionInstructorID(questionID string) (string, error)
+	GetSectionInstructorID(sectionID string) (string, error)
+	GetLearningPathInstructorID(learningPathID string) (string, error)
+}
@@ -0,0 +1,65 @@
+package passwordResetRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+// CreatePasswordReset implements PasswordResetRepository
+func (pr *passwordResetRepository) CreatePasswordReset(reset dto.PasswordReset) error {
+	var resetModel model.PasswordReset
+	err := copier.Copy(&resetModel, &reset)
+	if err != nil {
+		return err
+	}
+
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		// only the newest token of the user can be used
+		err := tx.Model(&model.PasswordReset{}).
+			Where("user_id = ? AND role = ? AND used_at IS NULL", reset.UserID, reset.Role).
+			Update("used_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&resetModel).Error
+	})
+}
+
+// GetPasswordReset implements PasswordResetRepository
+func (pr *passwordResetRepository) GetPasswordReset(tokenHash string) (dto.PasswordReset, error) {
+	var reset dto.PasswordReset
+	err := pr.db.Model(&model.PasswordReset{}).Where("token_hash = ?", tokenHash).Find(&reset)
+	if err.Error != nil {
+		return dto.PasswordReset{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.PasswordReset{}, gorm.ErrRecordNotFound
+	}
+	return reset, nil
+}
+
+// UsePasswordReset implements PasswordResetRepository
+func (pr *passwordResetRepository) UsePasswordReset(id string) error {
+	err := pr.db.Model(&model.PasswordReset{}).Where("id = ? AND used_at IS NULL", id).Update("used_at", time.Now())
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
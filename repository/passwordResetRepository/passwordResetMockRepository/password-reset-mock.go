@@ -0,0 +1,29 @@
+package passwordResetMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type PasswordResetMock struct {
+	mock.Mock
+}
+
+func (p *PasswordResetMock) CreatePasswordReset(reset dto.PasswordReset) error {
+	args := p.Called(reset)
+
+	return args.Error(0)
+}
+
+func (p *PasswordResetMock) GetPasswordReset(tokenHash string) (dto.PasswordReset, error) {
+	args := p.Called(tokenHash)
+
+	return args.Get(0).(dto.PasswordReset), args.Error(1)
+}
+
+func (p *PasswordResetMock) UsePasswordReset(id string) error {
+	args := p.Called(id)
+
+	return args.Error(0)
+}
@@ -0,0 +1,9 @@
+package passwordResetRepository
+
+import "golang/models/dto"
+
+type PasswordResetRepository interface {
+	CreatePasswordReset(reset dto.PasswordReset) error
+	GetPasswordReset(tokenHash string) (dto.PasswordReset, error)
+	UsePasswordReset(id string) error
+}
@@ -0,0 +1,372 @@
+package progressionRepository
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/models/model"
+	"golang/repository/courseStatsRepository"
+	modulerepository "golang/repository/moduleRepository"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type progressionRepository struct {
+	db *gorm.DB
+}
+
+// GetModuleRules implements ProgressionRepository, a module without rule returns its default rules
+func (pr *progressionRepository) GetModuleRules(moduleID string) ([]dto.ModuleRule, error) {
+	return getModuleRules(pr.db, moduleID)
+}
+
+// ReplaceModuleRules implements ProgressionRepository, the customers of the course are evaluated with the new rules
+// so the customers who already meet them finish the module
+func (pr *progressionRepository) ReplaceModuleRules(moduleID string, rules []dto.ModuleRule) error {
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		var module model.Module
+		err := tx.Select("id", "course_id").Where("id = ?", moduleID).Find(&module)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		errDelete := tx.Where("module_id = ?", moduleID).Delete(&model.ModuleRule{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		for i, rule := range rules {
+			errTarget := checkRuleTarget(tx, moduleID, rule)
+			if errTarget != nil {
+				return errTarget
+			}
+			errCreate := tx.Create(&model.ModuleRule{
+				ID:       rule.ID,
+				ModuleID: moduleID,
+				Type:     rule.Type,
+				TargetID: rule.TargetID,
+				MinValue: rule.MinValue,
+				NoRule:   i + 1,
+			}).Error
+			if errCreate != nil {
+				return errCreate
+			}
+		}
+
+		var customerIDs []string
+		errCustomer := tx.Model(&model.CustomerCourse{}).Where("course_id = ?", module.CourseID).Pluck("customer_id", &customerIDs).Error
+		if errCustomer != nil {
+			return errCustomer
+		}
+		return EvaluateModule(tx, moduleID, customerIDs...)
+	})
+}
+
+// ViewModule implements ProgressionRepository, a module opened again is ignored
+func (pr *progressionRepository) ViewModule(moduleID, customerID string) error {
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ModuleView{
+			CustomerID: customerID,
+			ModuleID:   moduleID,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return EvaluateModule(tx, moduleID, customerID)
+	})
+}
+
+// WatchMedia implements ProgressionRepository, the highest percentage is kept so watching the media again from the start
+// does not lower the progress
+func (pr *progressionRepository) WatchMedia(progress dto.MediaProgressTransaction, customerID string) error {
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"watched":    gorm.Expr("GREATEST(watched, ?)", progress.Watched),
+				"updated_at": time.Now(),
+			}),
+		}).Create(&model.MediaProgress{
+			CustomerID:    customerID,
+			MediaModuleID: progress.MediaModuleID,
+			ModuleID:      progress.ModuleID,
+			Watched:       progress.Watched,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return EvaluateModule(tx, progress.ModuleID, customerID)
+	})
+}
+
+// GetModuleProgress implements ProgressionRepository
+func (pr *progressionRepository) GetModuleProgress(moduleID, customerID string) (dto.ModuleProgress, error) {
+	rules, err := getModuleRules(pr.db, moduleID)
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+	ruleProgress, err := getRuleProgress(pr.db, customerID, rules)
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+	var count int64
+	err = pr.db.Model(&model.ModuleProgress{}).Where("customer_id = ? AND module_id = ?", customerID, moduleID).Count(&count).Error
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+	return dto.ModuleProgress{
+		ModuleID: moduleID,
+		IsFinish: count > 0,
+		Rules:    ruleProgress,
+	}, nil
+}
+
+// GetProgressEvents implements ProgressionRepository, the last event is the first
+func (pr *progressionRepository) GetProgressEvents(customerID string) ([]dto.ProgressEvent, error) {
+	var events []dto.ProgressEvent
+	err := pr.db.Model(&model.ProgressEvent{}).Where("customer_id = ?", customerID).Order("created_at DESC").Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// EvaluateModule checks the rules of the module for the customers in the transaction, the enrolled customers who meet
+// all the rules finish the module and the progress events of the module and of the course they finished are saved.
+// A finished module stays finished, so it is not evaluated again
+func EvaluateModule(tx *gorm.DB, moduleID string, customerIDs ...string) error {
+	if len(customerIDs) == 0 {
+		return nil
+	}
+	// a deleted module can still be in the version the customer learns
+	var module model.Module
+	err := tx.Unscoped().Select("id", "course_id").Where("id = ?", moduleID).Find(&module)
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return nil
+	}
+	rules, errRules := getModuleRules(tx, moduleID)
+	if errRules != nil {
+		return errRules
+	}
+
+	var finished bool
+	for _, customerID := range customerIDs {
+		customerCourse, enrolled, errCourse := getCustomerCourse(tx, module.CourseID, customerID)
+		if errCourse != nil {
+			return errCourse
+		}
+		if !enrolled {
+			continue
+		}
+		var count int64
+		errCount := tx.Model(&model.ModuleProgress{}).Where("customer_id = ? AND module_id = ?", customerID, moduleID).Count(&count).Error
+		if errCount != nil {
+			return errCount
+		}
+		if count > 0 {
+			continue
+		}
+		ruleProgress, errProgress := getRuleProgress(tx, customerID, rules)
+		if errProgress != nil {
+			return errProgress
+		}
+		if !helper.IsModuleFinished(ruleProgress) {
+			continue
+		}
+
+		errComplete := modulerepository.CompleteModule(tx, customerID, module)
+		if errComplete != nil {
+			return errComplete
+		}
+		errEvent := createProgressEvent(tx, customerID, module.CourseID, moduleID, dto.ProgressModuleCompleted)
+		if errEvent != nil {
+			return errEvent
+		}
+		errRefresh := modulerepository.RefreshCustomerProgress(tx, module.CourseID, customerID)
+		if errRefresh != nil {
+			return errRefresh
+		}
+		finished = true
+
+		// the module can be the last module the customer had to finish
+		refreshed, _, errCourse := getCustomerCourse(tx, module.CourseID, customerID)
+		if errCourse != nil {
+			return errCourse
+		}
+		if !customerCourse.IsFinish && refreshed.IsFinish {
+			errEvent = createProgressEvent(tx, customerID, module.CourseID, "", dto.ProgressCourseCompleted)
+			if errEvent != nil {
+				return errEvent
+			}
+		}
+	}
+	if !finished {
+		return nil
+	}
+
+	// the course can be finished, so the completion count is updated
+	return courseStatsRepository.RefreshCourseStats(tx, module.CourseID)
+}
+
+// getModuleRules returns the rules of the module in their order, or the default rules of a module without rule
+func getModuleRules(tx *gorm.DB, moduleID string) ([]dto.ModuleRule, error) {
+	var rules []dto.ModuleRule
+	err := tx.Model(&model.ModuleRule{}).Where("module_id = ?", moduleID).Order("no_rule").Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) > 0 {
+		return rules, nil
+	}
+	var count int64
+	err = tx.Model(&model.Assignment{}).Where("module_id = ?", moduleID).Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	return helper.GetDefaultModuleRules(moduleID, count > 0), nil
+}
+
+// getRuleProgress checks every rule for the customer, a rule of a media, a quiz or an assignment
+// that was removed from the module is met so the customers are not stuck on it
+func getRuleProgress(tx *gorm.DB, customerID string, rules []dto.ModuleRule) ([]dto.ModuleRuleProgress, error) {
+	progress := make([]dto.ModuleRuleProgress, len(rules))
+	for i, rule := range rules {
+		progress[i] = dto.ModuleRuleProgress{
+			Type:     rule.Type,
+			TargetID: rule.TargetID,
+			MinValue: rule.MinValue,
+		}
+		switch rule.Type {
+		case dto.ModuleRuleViewed:
+			var count int64
+			err := tx.Model(&model.ModuleView{}).Where("customer_id = ? AND module_id = ?", customerID, rule.ModuleID).Count(&count).Error
+			if err != nil {
+				return nil, err
+			}
+			progress[i].IsMet = count > 0
+		case dto.ModuleRuleMediaWatched:
+			var count int64
+			err := tx.Model(&model.MediaModule{}).Where("id = ?", rule.TargetID).Count(&count).Error
+			if err != nil {
+				return nil, err
+			}
+			var media model.MediaProgress
+			err = tx.Where("customer_id = ? AND media_module_id = ?", customerID, rule.TargetID).Find(&media).Error
+			if err != nil {
+				return nil, err
+			}
+			progress[i].Value = media.Watched
+			progress[i].IsMet = count == 0 || media.Watched >= rule.MinValue
+		case dto.ModuleRuleAssignmentSubmitted, dto.ModuleRuleAssignmentPassed:
+			var assignment model.Assignment
+			err := tx.Select("id").Where("module_id = ?", rule.ModuleID).Find(&assignment)
+			if err.Error != nil {
+				return nil, err.Error
+			}
+			if err.RowsAffected <= 0 {
+				progress[i].IsMet = true
+				continue
+			}
+			var submission model.CustomerAssignment
+			errSubmission := tx.Select("status", "score").Where("assignment_id = ? AND customer_id = ?", assignment.ID, customerID).Find(&submission).Error
+			if errSubmission != nil {
+				return nil, errSubmission
+			}
+			progress[i].Value = submission.Score
+			if rule.Type == dto.ModuleRuleAssignmentSubmitted {
+				progress[i].IsMet = submission.Status == dto.AssignmentSubmitted || submission.Status == dto.AssignmentLate || submission.Status == dto.AssignmentGraded
+			} else {
+				progress[i].IsMet = submission.Status == dto.AssignmentGraded && submission.Score >= rule.MinValue
+			}
+		case dto.ModuleRuleQuizPassed:
+			var count int64
+			err := tx.Model(&model.Quiz{}).Where("id = ?", rule.TargetID).Count(&count).Error
+			if err != nil {
+				return nil, err
+			}
+			var attempts []model.QuizAttempt
+			err = tx.Select("percentage", "is_passed").Where("quiz_id = ? AND customer_id = ? AND status = ?", rule.TargetID, customerID, dto.QuizAttemptSubmitted).Find(&attempts).Error
+			if err != nil {
+				return nil, err
+			}
+			progress[i].IsMet = count == 0
+			for _, attempt := range attempts {
+				if attempt.Percentage > progress[i].Value {
+					progress[i].Value = attempt.Percentage
+				}
+				if attempt.IsPassed {
+					progress[i].IsMet = true
+				}
+			}
+		}
+	}
+	return progress, nil
+}
+
+// checkRuleTarget checks the media, the quiz or the assignment of the rule is in the module,
+// the score to pass an assignment is not higher than the max points of the assignment
+func checkRuleTarget(tx *gorm.DB, moduleID string, rule dto.ModuleRule) error {
+	var count int64
+	switch rule.Type {
+	case dto.ModuleRuleMediaWatched:
+		err := tx.Model(&model.MediaModule{}).Where("id = ? AND module_id = ?", rule.TargetID, moduleID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+	case dto.ModuleRuleQuizPassed:
+		err := tx.Model(&model.Quiz{}).Where("id = ? AND module_id = ?", rule.TargetID, moduleID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+	case dto.ModuleRuleAssignmentSubmitted, dto.ModuleRuleAssignmentPassed:
+		var assignment model.Assignment
+		err := tx.Select("id", "max_points").Where("module_id = ?", moduleID).Find(&assignment)
+		if err.Error != nil {
+			return err.Error
+		}
+		if rule.MinValue > assignment.MaxPoints && err.RowsAffected > 0 {
+			return errors.New(constantError.ErrorModuleRule)
+		}
+		count = err.RowsAffected
+	default:
+		return nil
+	}
+	if count <= 0 {
+		return errors.New(constantError.ErrorModuleRuleTarget)
+	}
+	return nil
+}
+
+// getCustomerCourse returns the enrollment of the customer in the course and if the customer is enrolled
+func getCustomerCourse(tx *gorm.DB, courseID, customerID string) (model.CustomerCourse, bool, error) {
+	var customerCourse model.CustomerCourse
+	err := tx.Select("id", "is_finish").Where("course_id = ? AND customer_id = ?", courseID, customerID).Find(&customerCourse)
+	if err.Error != nil {
+		return model.CustomerCourse{}, false, err.Error
+	}
+	return customerCourse, err.RowsAffected > 0, nil
+}
+
+// createProgressEvent saves the event of the progress in the transaction of the progress
+func createProgressEvent(tx *gorm.DB, customerID, courseID, moduleID, eventType string) error {
+	return tx.Create(&model.ProgressEvent{
+		ID:         helper.GenerateUUID(),
+		CustomerID: customerID,
+		CourseID:   courseID,
+		ModuleID:   moduleID,
+		Type:       eventType,
+	}).Error
+}
+
+func NewProgressionRepository(db *gorm.DB) ProgressionRepository {
+	return &progressionRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,42 @@
+package progressionMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ProgressionMock struct {
+	mock.Mock
+}
+
+func (c *ProgressionMock) GetModuleRules(moduleID string) ([]dto.ModuleRule, error) {
+	args := c.Called(moduleID)
+
+	return args.Get(0).([]dto.ModuleRule), args.Error(1)
+}
+func (c *ProgressionMock) ReplaceModuleRules(moduleID string, rules []dto.ModuleRule) error {
+	args := c.Called(moduleID, rules)
+
+	return args.Error(0)
+}
+func (c *ProgressionMock) ViewModule(moduleID, customerID string) error {
+	args := c.Called(moduleID, customerID)
+
+	return args.Error(0)
+}
+func (c *ProgressionMock) WatchMedia(progress dto.MediaProgressTransaction, customerID string) error {
+	args := c.Called(progress, customerID)
+
+	return args.Error(0)
+}
+func (c *ProgressionMock) GetModuleProgress(moduleID, customerID string) (dto.ModuleProgress, error) {
+	args := c.Called(moduleID, customerID)
+
+	return args.Get(0).(dto.ModuleProgress), args.Error(1)
+}
+func (c *ProgressionMock) GetProgressEvents(customerID string) ([]dto.ProgressEvent, error) {
+	args := c.Called(customerID)
+
+	return args.Get(0).([]dto.ProgressEvent), args.Error(1)
+}
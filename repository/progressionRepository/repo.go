@@ -0,0 +1,12 @@
+package progressionRepository
+
+import "golang/models/dto"
+
+type ProgressionRepository interface {
+	GetModuleRules(moduleID string) ([]dto.ModuleRule, error)
+	ReplaceModuleRules(moduleID string, rules []dto.ModuleRule) error
+	ViewModule(moduleID, customerID string) error
+	WatchMedia(progress dto.MediaProgressTransaction, customerID string) error
+	GetModuleProgress(moduleID, customerID string) (dto.ModuleProgress, error)
+	GetProgressEvents(customerID string) ([]dto.ProgressEvent, error)
+}
@@ -0,0 +1,177 @@
+package questionBankRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+	quizrepository "golang/repository/quizRepository"
+
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type questionBankRepository struct {
+	db *gorm.DB
+}
+
+// CreateQuestionBank implements QuestionBankRepository
+func (qbr *questionBankRepository) CreateQuestionBank(input dto.QuestionBankTransaction) error {
+	return qbr.db.Create(&model.QuestionBank{
+		ID:          input.ID,
+		CourseID:    input.CourseID,
+		Title:       input.Title,
+		Description: input.Description,
+	}).Error
+}
+
+// DeleteQuestionBank implements QuestionBankRepository, the questions of the bank are deleted with it
+// and the quizzes stop drawing from it, the questions stay readable for the attempts that got them
+func (qbr *questionBankRepository) DeleteQuestionBank(id string) error {
+	return qbr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).Delete(&model.QuestionBank{})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errDelete := tx.Where("bank_id = ?", id).Delete(&model.QuizQuestion{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		return tx.Where("bank_id = ?", id).Delete(&model.QuizPool{}).Error
+	})
+}
+
+// GetQuestionBankByID implements QuestionBankRepository
+func (qbr *questionBankRepository) GetQuestionBankByID(id string) (dto.QuestionBank, error) {
+	var bankModel model.QuestionBank
+	err := qbr.db.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at")
+	}).Preload("Questions.Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_option")
+	}).Where("id = ?", id).Find(&bankModel)
+	if err.Error != nil {
+		return dto.QuestionBank{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.QuestionBank{}, gorm.ErrRecordNotFound
+	}
+
+	var bank dto.QuestionBank
+	errCopy := copier.Copy(&bank, &bankModel)
+	if errCopy != nil {
+		return dto.QuestionBank{}, errCopy
+	}
+	return bank, nil
+}
+
+// GetQuestionBanksByCourseID implements QuestionBankRepository, the questions are not read
+func (qbr *questionBankRepository) GetQuestionBanksByCourseID(courseID string) ([]dto.QuestionBank, error) {
+	var bankModel []model.QuestionBank
+	err := qbr.db.Where("course_id = ?", courseID).Order("created_at").Find(&bankModel).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var banks []dto.QuestionBank
+	err = copier.Copy(&banks, &bankModel)
+	if err != nil {
+		return nil, err
+	}
+	return banks, nil
+}
+
+// UpdateQuestionBank implements QuestionBankRepository, the course of the bank does not change
+func (qbr *questionBankRepository) UpdateQuestionBank(input dto.QuestionBankTransaction) error {
+	err := qbr.db.Model(&model.QuestionBank{}).Where("id = ?", input.ID).
+		Select("title", "description").
+		Updates(&model.QuestionBank{
+			Title:       input.Title,
+			Description: input.Description,
+		})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateBankQuestion implements QuestionBankRepository
+func (qbr *questionBankRepository) CreateBankQuestion(input dto.QuizQuestionTransaction) error {
+	return qbr.db.Create(&model.QuizQuestion{
+		ID:         input.ID,
+		BankID:     input.BankID,
+		Topic:      input.Topic,
+		Difficulty: input.Difficulty,
+		Type:       input.Type,
+		Text:       input.Text,
+		Points:     input.Points,
+		Answer:     input.Answer,
+		Tolerance:  input.Tolerance,
+		Options:    quizrepository.GetQuizOptions(input.Options),
+	}).Error
+}
+
+// CreateBankQuestions implements QuestionBankRepository
+func (qbr *questionBankRepository) CreateBankQuestions(input []dto.QuizQuestionTransaction) error {
+	var questions []model.QuizQuestion
+	for _, question := range input {
+		questions = append(questions, model.QuizQuestion{
+			ID:         question.ID,
+			BankID:     question.BankID,
+			Topic:      question.Topic,
+			Difficulty: question.Difficulty,
+			Type:       question.Type,
+			Text:       question.Text,
+			Points:     question.Points,
+			Answer:     question.Answer,
+			Tolerance:  question.Tolerance,
+			Options:    quizrepository.GetQuizOptions(question.Options),
+		})
+	}
+	return qbr.db.Create(&questions).Error
+}
+
+// DeleteBankQuestion implements QuestionBankRepository, the question stays readable for the attempts that got it
+func (qbr *questionBankRepository) DeleteBankQuestion(id string) error {
+	err := qbr.db.Where("id = ? AND bank_id <> ''", id).Delete(&model.QuizQuestion{})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateBankQuestion implements QuestionBankRepository, the bank of the question does not change
+func (qbr *questionBankRepository) UpdateBankQuestion(input dto.QuizQuestionTransaction) error {
+	return qbr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.QuizQuestion{}).Where("id = ? AND bank_id <> ''", input.ID).
+			Select("topic", "difficulty", "type", "text", "points", "answer", "tolerance").
+			Updates(&model.QuizQuestion{
+				Topic:      input.Topic,
+				Difficulty: input.Difficulty,
+				Type:       input.Type,
+				Text:       input.Text,
+				Points:     input.Points,
+				Answer:     input.Answer,
+				Tolerance:  input.Tolerance,
+			})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return quizrepository.ReplaceQuizOptions(tx, input.ID, input.Options)
+	})
+}
+
+func NewQuestionBankRepository(db *gorm.DB) QuestionBankRepository {
+	return &questionBankRepository{
+		db: db,
+	}
+}
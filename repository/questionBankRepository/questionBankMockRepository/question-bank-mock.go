@@ -0,0 +1,57 @@
+package questionBankMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type QuestionBankMock struct {
+	mock.Mock
+}
+
+func (q *QuestionBankMock) CreateQuestionBank(bank dto.QuestionBankTransaction) error {
+	args := q.Called(bank)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) DeleteQuestionBank(id string) error {
+	args := q.Called(id)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) GetQuestionBankByID(id string) (dto.QuestionBank, error) {
+	args := q.Called(id)
+
+	return args.Get(0).(dto.QuestionBank), args.Error(1)
+}
+func (q *QuestionBankMock) GetQuestionBanksByCourseID(courseID string) ([]dto.QuestionBank, error) {
+	args := q.Called(courseID)
+
+	return args.Get(0).([]dto.QuestionBank), args.Error(1)
+}
+func (q *QuestionBankMock) UpdateQuestionBank(bank dto.QuestionBankTransaction) error {
+	args := q.Called(bank)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) CreateBankQuestion(question dto.QuizQuestionTransaction) error {
+	args := q.Called(question)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) CreateBankQuestions(questions []dto.QuizQuestionTransaction) error {
+	args := q.Called(questions)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) DeleteBankQuestion(id string) error {
+	args := q.Called(id)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) UpdateBankQuestion(question dto.QuizQuestionTransaction) error {
+	args := q.Called(question)
+
+	return args.Error(0)
+}
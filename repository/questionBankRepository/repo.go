@@ -0,0 +1,15 @@
+package questionBankRepository
+
+import "golang/models/dto"
+
+type QuestionBankRepository interface {
+	CreateQuestionBank(dto.QuestionBankTransaction) error
+	DeleteQuestionBank(id string) error
+	GetQuestionBankByID(id string) (dto.QuestionBank, error)
+	GetQuestionBanksByCourseID(courseID string) ([]dto.QuestionBank, error)
+	UpdateQuestionBank(dto.QuestionBankTransaction) error
+	CreateBankQuestion(dto.QuizQuestionTransaction) error
+	CreateBankQuestions([]dto.QuizQuestionTransaction) error
+	DeleteBankQuestion(id string) error
+	UpdateBankQuestion(dto.QuizQuestionTransaction) error
+}
@@ -4,17 +4,22 @@ import (
 	"errors"
 	"fmt"
 	"golang/constant/constantError"
+	"golang/helper"
 	"golang/models/dto"
 	"golang/models/model"
+	"golang/repository/progressionRepository"
+	"time"
 
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type quizRepository struct {
 	db *gorm.DB
 }
 
+// CreateQuiz implements QuizRepository, the module of the quiz has to be a module of its course
 func (ctr *quizRepository) CreateQuiz(input dto.QuizTransaction) error {
 	var quizModel model.Quiz
 	err := copier.Copy(&quizModel, &input)
@@ -22,12 +27,17 @@ func (ctr *quizRepository) CreateQuiz(input dto.QuizTransaction) error {
 		return err
 	}
 
-	err = ctr.db.Create(&quizModel).Error
-	if err != nil {
-		return err
-	}
-	return nil
-
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := checkQuizModule(tx, quizModel.CourseID, quizModel.ModuleID)
+		if err != nil {
+			return err
+		}
+		err = tx.Omit("Pools").Create(&quizModel).Error
+		if err != nil {
+			return err
+		}
+		return replaceQuizPools(tx, quizModel.ID, quizModel.CourseID, input.Pools)
+	})
 }
 
 func (ctr *quizRepository) GetAllQuiz() ([]dto.Quiz, error) {
@@ -67,18 +77,592 @@ func (ctr *quizRepository) TakeQuiz(input dto.TakeQuizTransaction) (dto.Quiz, er
 	return quiz, nil
 }
 
+// DeleteQuiz implements QuizRepository, the questions, the draws and the attempts of the quiz are deleted with it
 func (ctr *quizRepository) DeleteQuiz(id string) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("id = ?", id).Unscoped().Delete(&model.Quiz{})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
 
-	err := ctr.db.Where("id = ?", id).Unscoped().Delete(&model.Quiz{})
+		questionIDs := tx.Unscoped().Model(&model.QuizQuestion{}).Select("id").Where("quiz_id = ?", id)
+		errDelete := tx.Where("question_id IN (?)", questionIDs).Delete(&model.QuizOption{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Unscoped().Where("quiz_id = ?", id).Delete(&model.QuizQuestion{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errDelete = tx.Where("quiz_id = ?", id).Delete(&model.QuizPool{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		attemptIDs := tx.Model(&model.QuizAttempt{}).Select("id").Where("quiz_id = ?", id)
+		errDelete = tx.Where("quiz_attempt_id IN (?)", attemptIDs).Delete(&model.QuizAttemptAnswer{}).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		return tx.Where("quiz_id = ?", id).Delete(&model.QuizAttempt{}).Error
+	})
+}
+
+// GetQuizByID implements QuizRepository, the questions are in their order with their answers
+func (ctr *quizRepository) GetQuizByID(id string) (dto.Quiz, error) {
+	var quizModel model.Quiz
+	err := ctr.db.Preload("Questions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_question")
+	}).Preload("Questions.Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_option")
+	}).Preload("Pools", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_pool")
+	}).Where("id = ?", id).Find(&quizModel)
 	if err.Error != nil {
-		return err.Error
+		return dto.Quiz{}, err.Error
 	}
 	if err.RowsAffected <= 0 {
-		return gorm.ErrRecordNotFound
+		return dto.Quiz{}, gorm.ErrRecordNotFound
+	}
+
+	var quiz dto.Quiz
+	errCopy := copier.Copy(&quiz, &quizModel)
+	if errCopy != nil {
+		return dto.Quiz{}, errCopy
+	}
+	return quiz, nil
+}
+
+// GetQuizzesByCourseID implements QuizRepository, the quizzes are without their questions
+func (ctr *quizRepository) GetQuizzesByCourseID(courseID string) ([]dto.Quiz, error) {
+	var quizModel []model.Quiz
+	err := ctr.db.Where("course_id = ?", courseID).Order("created_at").Find(&quizModel).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var quizzes []dto.Quiz
+	err = copier.Copy(&quizzes, &quizModel)
+	if err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
+
+// UpdateQuiz implements QuizRepository, the settings and the draws of the quiz are replaced and the course of the quiz does not change
+func (ctr *quizRepository) UpdateQuiz(input dto.QuizTransaction) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		var quizModel model.Quiz
+		err := tx.Where("id = ?", input.ID).Find(&quizModel)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errModule := checkQuizModule(tx, quizModel.CourseID, input.ModuleID)
+		if errModule != nil {
+			return errModule
+		}
+
+		errUpdate := tx.Model(&model.Quiz{}).Where("id = ?", input.ID).
+			Select("module_id", "title", "description", "link", "time_limit", "passing_score", "max_attempts", "shuffle_questions", "shuffle_options").
+			Updates(&model.Quiz{
+				ModuleID:         input.ModuleID,
+				Title:            input.Title,
+				Description:      input.Description,
+				Link:             input.Link,
+				TimeLimit:        input.TimeLimit,
+				PassingScore:     input.PassingScore,
+				MaxAttempts:      input.MaxAttempts,
+				ShuffleQuestions: input.ShuffleQuestions,
+				ShuffleOptions:   input.ShuffleOptions,
+			}).Error
+		if errUpdate != nil {
+			return errUpdate
+		}
+		return replaceQuizPools(tx, input.ID, quizModel.CourseID, input.Pools)
+	})
+}
+
+// CreateQuizQuestion implements QuizRepository, the question is inserted at its number and without number it is the last question
+func (ctr *quizRepository) CreateQuizQuestion(input dto.QuizQuestionTransaction) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := lockQuiz(tx, input.QuizID)
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&model.QuizQuestion{
+			ID:        input.ID,
+			QuizID:    input.QuizID,
+			Type:      input.Type,
+			Text:      input.Text,
+			Points:    input.Points,
+			Answer:    input.Answer,
+			Tolerance: input.Tolerance,
+			Options:   GetQuizOptions(input.Options),
+		}).Error
+		if err != nil {
+			return err
+		}
+		return renumberQuizQuestions(tx, input.QuizID, input.ID, input.NoQuestion)
+	})
+}
+
+// CreateQuizQuestions implements QuizRepository, the questions are added after the questions of the quiz in the order they are given
+func (ctr *quizRepository) CreateQuizQuestions(quizID string, input []dto.QuizQuestionTransaction) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := lockQuiz(tx, quizID)
+		if err != nil {
+			return err
+		}
+		var count int64
+		err = tx.Model(&model.QuizQuestion{}).Where("quiz_id = ?", quizID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		var questions []model.QuizQuestion
+		for i, question := range input {
+			questions = append(questions, model.QuizQuestion{
+				ID:         question.ID,
+				QuizID:     quizID,
+				Type:       question.Type,
+				Text:       question.Text,
+				Points:     question.Points,
+				NoQuestion: int(count) + i + 1,
+				Answer:     question.Answer,
+				Tolerance:  question.Tolerance,
+				Options:    GetQuizOptions(question.Options),
+			})
+		}
+		return tx.Create(&questions).Error
+	})
+}
+
+// DeleteQuizQuestion implements QuizRepository, the question is soft deleted so the attempts that had it keep their answers
+func (ctr *quizRepository) DeleteQuizQuestion(id string) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		var question model.QuizQuestion
+		err := tx.Where("id = ?", id).Find(&question)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errLock := lockQuiz(tx, question.QuizID)
+		if errLock != nil {
+			return errLock
+		}
+
+		errDelete := tx.Delete(&question).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		return renumberQuizQuestions(tx, question.QuizID, "", 0)
+	})
+}
+
+// GetQuizQuestions implements QuizRepository, the deleted questions are found too for the attempts that had them
+func (ctr *quizRepository) GetQuizQuestions(ids []string) ([]dto.QuizQuestion, error) {
+	var questionModel []model.QuizQuestion
+	err := ctr.db.Unscoped().Preload("Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_option")
+	}).Where("id IN ?", ids).Find(&questionModel).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var questions []dto.QuizQuestion
+	err = copier.Copy(&questions, &questionModel)
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// GetPoolQuestions implements QuizRepository, the questions are in the order of their id so a seed always draws the same questions
+func (ctr *quizRepository) GetPoolQuestions(pool dto.QuizPool) ([]dto.QuizQuestion, error) {
+	query := ctr.db.Preload("Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_option")
+	}).Where("bank_id = ?", pool.BankID)
+	if pool.Topic != "" {
+		query = query.Where("topic = ?", pool.Topic)
+	}
+	if pool.Difficulty != "" {
+		query = query.Where("difficulty = ?", pool.Difficulty)
+	}
+	var questionModel []model.QuizQuestion
+	err := query.Order("id").Find(&questionModel).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var questions []dto.QuizQuestion
+	err = copier.Copy(&questions, &questionModel)
+	if err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// UpdateQuizQuestion implements QuizRepository, the options are replaced and without number the question keeps its place
+func (ctr *quizRepository) UpdateQuizQuestion(input dto.QuizQuestionTransaction) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		var question model.QuizQuestion
+		err := tx.Where("id = ?", input.ID).Find(&question)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errLock := lockQuiz(tx, question.QuizID)
+		if errLock != nil {
+			return errLock
+		}
+
+		errUpdate := tx.Model(&model.QuizQuestion{}).Where("id = ?", input.ID).
+			Select("type", "text", "points", "answer", "tolerance").
+			Updates(&model.QuizQuestion{
+				Type:      input.Type,
+				Text:      input.Text,
+				Points:    input.Points,
+				Answer:    input.Answer,
+				Tolerance: input.Tolerance,
+			}).Error
+		if errUpdate != nil {
+			return errUpdate
+		}
+		errOptions := ReplaceQuizOptions(tx, input.ID, input.Options)
+		if errOptions != nil {
+			return errOptions
+		}
+		if input.NoQuestion == 0 || input.NoQuestion == question.NoQuestion {
+			return nil
+		}
+		return renumberQuizQuestions(tx, question.QuizID, input.ID, input.NoQuestion)
+	})
+}
+
+// CreateQuizAttempt implements QuizRepository, the attempt in progress of the customer is returned instead of a new attempt
+// and an attempt in progress after its time limit is closed as expired before the attempts are counted
+func (ctr *quizRepository) CreateQuizAttempt(attempt dto.QuizAttempt, maxAttempts int) (dto.QuizAttempt, error) {
+	var result dto.QuizAttempt
+	err := ctr.db.Transaction(func(tx *gorm.DB) error {
+		// the quiz is locked so the customer cannot start two attempts at the same time
+		err := lockQuiz(tx, attempt.QuizID)
+		if err != nil {
+			return err
+		}
+
+		var open model.QuizAttempt
+		errFind := tx.Preload("Answers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("no_question")
+		}).Where("quiz_id = ? AND customer_id = ? AND status = ?", attempt.QuizID, attempt.CustomerID, dto.QuizAttemptInProgress).Limit(1).Find(&open)
+		if errFind.Error != nil {
+			return errFind.Error
+		}
+		if errFind.RowsAffected > 0 {
+			if open.ExpiresAt == nil || time.Now().Before(*open.ExpiresAt) {
+				return copier.Copy(&result, &open)
+			}
+			errExpire := tx.Model(&open).Update("status", dto.QuizAttemptExpired).Error
+			if errExpire != nil {
+				return errExpire
+			}
+		}
+
+		var count int64
+		errCount := tx.Model(&model.QuizAttempt{}).Where("quiz_id = ? AND customer_id = ?", attempt.QuizID, attempt.CustomerID).Count(&count).Error
+		if errCount != nil {
+			return errCount
+		}
+		if maxAttempts > 0 && count >= int64(maxAttempts) {
+			return errors.New(constantError.ErrorQuizAttemptLimit)
+		}
+
+		var attemptModel model.QuizAttempt
+		errCopy := copier.Copy(&attemptModel, &attempt)
+		if errCopy != nil {
+			return errCopy
+		}
+		errCreate := tx.Create(&attemptModel).Error
+		if errCreate != nil {
+			return errCreate
+		}
+		return copier.Copy(&result, &attemptModel)
+	})
+	if err != nil {
+		return dto.QuizAttempt{}, err
 	}
+	return result, nil
+}
 
+// GetQuizAttemptByID implements QuizRepository
+func (ctr *quizRepository) GetQuizAttemptByID(id string) (dto.QuizAttempt, error) {
+	var attemptModel model.QuizAttempt
+	err := ctr.db.Preload("Answers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("no_question")
+	}).Where("id = ?", id).Find(&attemptModel)
+	if err.Error != nil {
+		return dto.QuizAttempt{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.QuizAttempt{}, gorm.ErrRecordNotFound
+	}
+
+	var attempt dto.QuizAttempt
+	errCopy := copier.Copy(&attempt, &attemptModel)
+	if errCopy != nil {
+		return dto.QuizAttempt{}, errCopy
+	}
+	return attempt, nil
+}
+
+// GetQuizAttempts implements QuizRepository, without customer id it returns the attempts of all customers
+func (ctr *quizRepository) GetQuizAttempts(quizID, customerID string) ([]dto.QuizAttempt, error) {
+	query := ctr.db.Where("quiz_id = ?", quizID)
+	if customerID != "" {
+		query = query.Where("customer_id = ?", customerID)
+	}
+	var attemptModel []model.QuizAttempt
+	err := query.Order("created_at").Find(&attemptModel).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var attempts []dto.QuizAttempt
+	err = copier.Copy(&attempts, &attemptModel)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// SubmitQuizAttempt implements QuizRepository, only an attempt in progress is saved so an attempt is graded once
+func (ctr *quizRepository) SubmitQuizAttempt(attempt dto.QuizAttempt) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.QuizAttempt{}).Where("id = ? AND status = ?", attempt.ID, dto.QuizAttemptInProgress).
+			Select("status", "submitted_at", "score", "max_score", "percentage", "is_passed").
+			Updates(&model.QuizAttempt{
+				Status:      attempt.Status,
+				SubmittedAt: attempt.SubmittedAt,
+				Score:       attempt.Score,
+				MaxScore:    attempt.MaxScore,
+				Percentage:  attempt.Percentage,
+				IsPassed:    attempt.IsPassed,
+			})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return errors.New(constantError.ErrorQuizAttemptSubmitted)
+		}
+
+		errAnswer := updateQuizAttemptAnswers(tx, attempt)
+		if errAnswer != nil {
+			return errAnswer
+		}
+		return evaluateQuizModule(tx, attempt)
+	})
+}
+
+// RegradeQuizAttempt implements QuizRepository, only a submitted attempt is graded again
+func (ctr *quizRepository) RegradeQuizAttempt(attempt dto.QuizAttempt) error {
+	return ctr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.QuizAttempt{}).Where("id = ? AND status = ?", attempt.ID, dto.QuizAttemptSubmitted).
+			Select("score", "max_score", "percentage", "is_passed").
+			Updates(&model.QuizAttempt{
+				Score:      attempt.Score,
+				MaxScore:   attempt.MaxScore,
+				Percentage: attempt.Percentage,
+				IsPassed:   attempt.IsPassed,
+			})
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return errors.New(constantError.ErrorQuizAttemptNotSubmitted)
+		}
+		errAnswer := updateQuizAttemptAnswers(tx, attempt)
+		if errAnswer != nil {
+			return errAnswer
+		}
+		return evaluateQuizModule(tx, attempt)
+	})
+}
+
+// checkQuizModule checks the module is a module of the course, a quiz without module is a quiz of the whole course
+func checkQuizModule(tx *gorm.DB, courseID, moduleID string) error {
+	if moduleID == "" {
+		return nil
+	}
+	var count int64
+	err := tx.Model(&model.Module{}).Where("id = ? AND course_id = ?", moduleID, courseID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count <= 0 {
+		return errors.New(constantError.ErrorQuizModule)
+	}
+	return nil
+}
+
+// evaluateQuizModule evaluates the completion rules of the module of the quiz for the customer of the attempt,
+// a quiz without module is a quiz of the whole course
+func evaluateQuizModule(tx *gorm.DB, attempt dto.QuizAttempt) error {
+	var quiz model.Quiz
+	err := tx.Select("module_id").Where("id = ?", attempt.QuizID).Find(&quiz).Error
+	if err != nil || quiz.ModuleID == "" {
+		return err
+	}
+	return progressionRepository.EvaluateModule(tx, quiz.ModuleID, attempt.CustomerID)
+}
+
+// updateQuizAttemptAnswers saves the graded answers of the attempt
+func updateQuizAttemptAnswers(tx *gorm.DB, attempt dto.QuizAttempt) error {
+	for _, answer := range attempt.Answers {
+		err := tx.Model(&model.QuizAttemptAnswer{}).Where("quiz_attempt_id = ? AND question_id = ?", attempt.ID, answer.QuestionID).
+			Select("answer", "is_correct", "score").
+			Updates(&model.QuizAttemptAnswer{
+				Answer:    answer.Answer,
+				IsCorrect: answer.IsCorrect,
+				Score:     answer.Score,
+			}).Error
+		if err != nil {
+			return err
+		}
+	}
 	return nil
+}
 
+// replaceQuizPools replaces the draws of the quiz, the banks have to be banks of the course of the quiz
+func replaceQuizPools(tx *gorm.DB, quizID, courseID string, pools []dto.QuizPoolTransaction) error {
+	bankIDs := map[string]bool{}
+	for _, pool := range pools {
+		bankIDs[pool.BankID] = true
+	}
+	if len(bankIDs) > 0 {
+		var ids []string
+		for bankID := range bankIDs {
+			ids = append(ids, bankID)
+		}
+		var count int64
+		err := tx.Model(&model.QuestionBank{}).Where("id IN ? AND course_id = ?", ids, courseID).Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count != int64(len(ids)) {
+			return errors.New(constantError.ErrorQuestionBankNotFound)
+		}
+	}
+
+	err := tx.Where("quiz_id = ?", quizID).Delete(&model.QuizPool{}).Error
+	if err != nil {
+		return err
+	}
+	var quizPools []model.QuizPool
+	for i, pool := range pools {
+		quizPools = append(quizPools, model.QuizPool{
+			ID:         helper.GenerateUUID(),
+			QuizID:     quizID,
+			BankID:     pool.BankID,
+			Topic:      pool.Topic,
+			Difficulty: pool.Difficulty,
+			Count:      pool.Count,
+			NoPool:     i + 1,
+		})
+	}
+	if len(quizPools) == 0 {
+		return nil
+	}
+	return tx.Create(&quizPools).Error
+}
+
+// GetQuizOptions makes the options of a question with new ids in the order they are given
+func GetQuizOptions(options []dto.QuizOptionTransaction) []model.QuizOption {
+	var quizOptions []model.QuizOption
+	for i, option := range options {
+		quizOptions = append(quizOptions, model.QuizOption{
+			ID:        helper.GenerateUUID(),
+			Text:      option.Text,
+			IsCorrect: option.IsCorrect,
+			NoOption:  i + 1,
+		})
+	}
+	return quizOptions
+}
+
+// ReplaceQuizOptions replaces the options of the question, the options with the id of an option of the question keep it
+func ReplaceQuizOptions(tx *gorm.DB, questionID string, options []dto.QuizOptionTransaction) error {
+	var optionIDs []string
+	err := tx.Model(&model.QuizOption{}).Where("question_id = ?", questionID).Pluck("id", &optionIDs).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Where("question_id = ?", questionID).Delete(&model.QuizOption{}).Error
+	if err != nil {
+		return err
+	}
+	quizOptions := GetQuizOptions(options)
+	if len(quizOptions) == 0 {
+		return nil
+	}
+	existing := map[string]bool{}
+	for _, optionID := range optionIDs {
+		existing[optionID] = true
+	}
+	for i := range quizOptions {
+		quizOptions[i].QuestionID = questionID
+		if existing[options[i].ID] {
+			quizOptions[i].ID = options[i].ID
+			existing[options[i].ID] = false
+		}
+	}
+	return tx.Create(&quizOptions).Error
+}
+
+// renumberQuizQuestions numbers the questions of the quiz from 1 without gaps, the question with the id is moved to the position
+// and a position out of the quiz puts it at the end
+func renumberQuizQuestions(tx *gorm.DB, quizID, questionID string, position int) error {
+	var questions []model.QuizQuestion
+	err := tx.Select("id", "no_question").Where("quiz_id = ? AND id <> ?", quizID, questionID).Order("no_question").Order("created_at").Find(&questions).Error
+	if err != nil {
+		return err
+	}
+
+	if questionID != "" {
+		if position < 1 || position > len(questions)+1 {
+			position = len(questions) + 1
+		}
+		questions = append(questions[:position-1], append([]model.QuizQuestion{{ID: questionID}}, questions[position-1:]...)...)
+	}
+	for i, question := range questions {
+		if question.NoQuestion == i+1 {
+			continue
+		}
+		err = tx.Model(&model.QuizQuestion{}).Where("id = ?", question.ID).Update("no_question", i+1).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockQuiz locks the quiz until the end of the transaction so its questions and attempts change one at a time
+func lockQuiz(tx *gorm.DB, quizID string) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", quizID).Find(&model.Quiz{})
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 func NewQuizRepository(db *gorm.DB) QuizRepository {
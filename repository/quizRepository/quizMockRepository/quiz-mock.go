@@ -0,0 +1,102 @@
+package quizMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type QuizMock struct {
+	mock.Mock
+}
+
+func (c *QuizMock) CreateQuiz(input dto.QuizTransaction) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+func (c *QuizMock) GetAllQuiz() ([]dto.Quiz, error) {
+	args := c.Called()
+
+	return args.Get(0).([]dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) TakeQuiz(input dto.TakeQuizTransaction) (dto.Quiz, error) {
+	args := c.Called(input)
+
+	return args.Get(0).(dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) DeleteQuiz(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
+func (c *QuizMock) GetQuizByID(id string) (dto.Quiz, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) GetQuizzesByCourseID(courseID string) ([]dto.Quiz, error) {
+	args := c.Called(courseID)
+
+	return args.Get(0).([]dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) UpdateQuiz(input dto.QuizTransaction) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+func (c *QuizMock) CreateQuizQuestion(input dto.QuizQuestionTransaction) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+func (c *QuizMock) CreateQuizQuestions(quizID string, questions []dto.QuizQuestionTransaction) error {
+	args := c.Called(quizID, questions)
+
+	return args.Error(0)
+}
+func (c *QuizMock) DeleteQuizQuestion(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
+func (c *QuizMock) GetQuizQuestions(ids []string) ([]dto.QuizQuestion, error) {
+	args := c.Called(ids)
+
+	return args.Get(0).([]dto.QuizQuestion), args.Error(1)
+}
+func (c *QuizMock) GetPoolQuestions(pool dto.QuizPool) ([]dto.QuizQuestion, error) {
+	args := c.Called(pool)
+
+	return args.Get(0).([]dto.QuizQuestion), args.Error(1)
+}
+func (c *QuizMock) UpdateQuizQuestion(input dto.QuizQuestionTransaction) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+func (c *QuizMock) CreateQuizAttempt(attempt dto.QuizAttempt, maxAttempts int) (dto.QuizAttempt, error) {
+	args := c.Called(attempt, maxAttempts)
+
+	return args.Get(0).(dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) GetQuizAttemptByID(id string) (dto.QuizAttempt, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) GetQuizAttempts(quizID, customerID string) ([]dto.QuizAttempt, error) {
+	args := c.Called(quizID, customerID)
+
+	return args.Get(0).([]dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) SubmitQuizAttempt(attempt dto.QuizAttempt) error {
+	args := c.Called(attempt)
+
+	return args.Error(0)
+}
+func (c *QuizMock) RegradeQuizAttempt(attempt dto.QuizAttempt) error {
+	args := c.Called(attempt)
+
+	return args.Error(0)
+}
@@ -7,4 +7,18 @@ type QuizRepository interface {
 	GetAllQuiz() ([]dto.Quiz, error)
 	TakeQuiz(dto.TakeQuizTransaction) (dto.Quiz, error)
 	DeleteQuiz(id string) error
+	GetQuizByID(id string) (dto.Quiz, error)
+	GetQuizzesByCourseID(courseID string) ([]dto.Quiz, error)
+	UpdateQuiz(dto.QuizTransaction) error
+	CreateQuizQuestion(dto.QuizQuestionTransaction) error
+	CreateQuizQuestions(quizID string, questions []dto.QuizQuestionTransaction) error
+	DeleteQuizQuestion(id string) error
+	GetQuizQuestions(ids []string) ([]dto.QuizQuestion, error)
+	GetPoolQuestions(pool dto.QuizPool) ([]dto.QuizQuestion, error)
+	UpdateQuizQuestion(dto.QuizQuestionTransaction) error
+	CreateQuizAttempt(attempt dto.QuizAttempt, maxAttempts int) (dto.QuizAttempt, error)
+	GetQuizAttemptByID(id string) (dto.QuizAttempt, error)
+	GetQuizAttempts(quizID, customerID string) ([]dto.QuizAttempt, error)
+	SubmitQuizAttempt(dto.QuizAttempt) error
+	RegradeQuizAttempt(dto.QuizAttempt) error
 }
@@ -3,6 +3,7 @@ package ratingRepository
 import (
 	"golang/models/dto"
 	"golang/models/model"
+	"golang/repository/courseStatsRepository"
 
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
@@ -19,22 +20,30 @@ func (rr *ratingRepository) AddRating(rating dto.RatingTransaction) error {
 	if err != nil {
 		return err
 	}
-	// save rating to database
-	err = rr.db.Model(&model.Rating{}).Create(&ratingModel).Error
-	if err != nil {
-		return err
-	}
-	return nil
+	// save rating to database and update the stats of the course
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Rating{}).Create(&ratingModel).Error
+		if err != nil {
+			return err
+		}
+		return courseStatsRepository.RefreshCourseStats(tx, ratingModel.CourseID)
+	})
 }
 
 // DeleteRating implements RatingRepository
 func (rr *ratingRepository) DeleteRating(id string) error {
-	err := rr.db.Unscoped().Delete(&model.Rating{}, "id = ?", id)
-	if err.Error != nil {
-		return err.Error
-	}
-
-	return nil
+	return rr.db.Transaction(func(tx *gorm.DB) error {
+		var courseIDs []string
+		err := tx.Model(&model.Rating{}).Unscoped().Where("id = ?", id).Pluck("course_id", &courseIDs).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Unscoped().Delete(&model.Rating{}, "id = ?", id).Error
+		if err != nil {
+			return err
+		}
+		return courseStatsRepository.RefreshCourseStats(tx, courseIDs...)
+	})
 }
 
 // GetRatingByCourseID implements RatingRepository
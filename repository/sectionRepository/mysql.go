@@ -0,0 +1,163 @@
+package sectionRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+	modulerepository "golang/repository/moduleRepository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sectionRepository struct {
+	db *gorm.DB
+}
+
+// CreateSection implements SectionRepository, the section is inserted at its number and without number it is the last section
+func (sr *sectionRepository) CreateSection(section dto.SectionTransaction) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		err := lockCourse(tx, section.CourseID)
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&model.Section{
+			ID:          section.ID,
+			Title:       section.Title,
+			Description: section.Description,
+			CourseID:    section.CourseID,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return renumberSections(tx, section.CourseID, section.ID, section.NoSection)
+	})
+}
+
+// DeleteSection implements SectionRepository, the modules of the section stay in the course without section
+func (sr *sectionRepository) DeleteSection(id string) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		var section model.Section
+		err := tx.Where("id = ?", id).Find(&section)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errLock := lockCourse(tx, section.CourseID)
+		if errLock != nil {
+			return errLock
+		}
+
+		errUpdate := tx.Model(&model.Module{}).Where("section_id = ?", id).Update("section_id", "").Error
+		if errUpdate != nil {
+			return errUpdate
+		}
+		errDelete := tx.Delete(&section).Error
+		if errDelete != nil {
+			return errDelete
+		}
+		errNumber := renumberSections(tx, section.CourseID, "", 0)
+		if errNumber != nil {
+			return errNumber
+		}
+		return modulerepository.RenumberModules(tx, section.CourseID)
+	})
+}
+
+// GetSectionByID implements SectionRepository
+func (sr *sectionRepository) GetSectionByID(id string) (dto.Section, error) {
+	var section dto.Section
+	err := sr.db.Model(&model.Section{}).Where("id = ?", id).Find(&section)
+	if err.Error != nil {
+		return dto.Section{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.Section{}, gorm.ErrRecordNotFound
+	}
+	return section, nil
+}
+
+// GetSectionsByCourseID implements SectionRepository
+func (sr *sectionRepository) GetSectionsByCourseID(courseID string) ([]dto.Section, error) {
+	var sections []dto.Section
+	err := sr.db.Model(&model.Section{}).Where("course_id = ?", courseID).Order("no_section").Find(&sections).Error
+	if err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// UpdateSection implements SectionRepository, without number the section keeps its place
+func (sr *sectionRepository) UpdateSection(section dto.SectionTransaction) error {
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		var sectionModel model.Section
+		err := tx.Where("id = ?", section.ID).Find(&sectionModel)
+		if err.Error != nil {
+			return err.Error
+		}
+		if err.RowsAffected <= 0 {
+			return gorm.ErrRecordNotFound
+		}
+		errLock := lockCourse(tx, sectionModel.CourseID)
+		if errLock != nil {
+			return errLock
+		}
+
+		errUpdate := tx.Model(&model.Section{}).Where("id = ?", section.ID).Updates(&model.Section{
+			Title:       section.Title,
+			Description: section.Description,
+		}).Error
+		if errUpdate != nil {
+			return errUpdate
+		}
+		if section.NoSection == 0 || section.NoSection == sectionModel.NoSection {
+			return nil
+		}
+
+		// the modules follow their section to its new place
+		errNumber := renumberSections(tx, sectionModel.CourseID, section.ID, section.NoSection)
+		if errNumber != nil {
+			return errNumber
+		}
+		return modulerepository.RenumberModules(tx, sectionModel.CourseID)
+	})
+}
+
+// renumberSections numbers the sections of the course from 1 without gaps, the section with the id is moved to the position
+// and a position out of the course puts it at the end
+func renumberSections(tx *gorm.DB, courseID, sectionID string, position int) error {
+	var sections []model.Section
+	err := tx.Select("id", "no_section").Where("course_id = ? AND id <> ?", courseID, sectionID).Order("no_section").Order("created_at").Find(&sections).Error
+	if err != nil {
+		return err
+	}
+
+	if sectionID != "" {
+		if position < 1 || position > len(sections)+1 {
+			position = len(sections) + 1
+		}
+		sections = append(sections[:position-1], append([]model.Section{{ID: sectionID}}, sections[position-1:]...)...)
+	}
+	for i, section := range sections {
+		if section.NoSection == i+1 {
+			continue
+		}
+		err = tx.Model(&model.Section{}).Where("id = ?", section.ID).Update("no_section", i+1).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockCourse locks the course until the end of the transaction so the sections are numbered one change at a time
+func lockCourse(tx *gorm.DB, courseID string) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", courseID).Find(&model.Course{}).Error
+}
+
+func NewSectionRepository(db *gorm.DB) SectionRepository {
+	return &sectionRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,11 @@
+package sectionRepository
+
+import "golang/models/dto"
+
+type SectionRepository interface {
+	CreateSection(dto.SectionTransaction) error
+	DeleteSection(id string) error
+	GetSectionByID(id string) (dto.Section, error)
+	GetSectionsByCourseID(courseID string) ([]dto.Section, error)
+	UpdateSection(dto.SectionTransaction) error
+}
@@ -0,0 +1,37 @@
+package sectionMockRepository
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SectionMock struct {
+	mock.Mock
+}
+
+func (c *SectionMock) CreateSection(section dto.SectionTransaction) error {
+	args := c.Called(section)
+
+	return args.Error(0)
+}
+func (c *SectionMock) DeleteSection(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
+func (c *SectionMock) GetSectionByID(id string) (dto.Section, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.Section), args.Error(1)
+}
+func (c *SectionMock) GetSectionsByCourseID(courseID string) ([]dto.Section, error) {
+	args := c.Called(courseID)
+
+	return args.Get(0).([]dto.Section), args.Error(1)
+}
+func (c *SectionMock) UpdateSection(section dto.SectionTransaction) error {
+	args := c.Called(section)
+
+	return args.Error(0)
+}
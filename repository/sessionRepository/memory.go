@@ -0,0 +1,163 @@
+package sessionRepository
+
+import (
+	"golang/models/dto"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memorySessionStore keeps sessions in process memory, it is meant for tests and local development
+type memorySessionStore struct {
+	mu            sync.RWMutex
+	sessions      map[string]dto.Session
+	refreshTokens map[string]dto.RefreshToken
+}
+
+// CreateSession implements SessionStore
+func (ms *memorySessionStore) CreateSession(session dto.Session) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.sessions[session.ID] = session
+	return nil
+}
+
+// GetSession implements SessionStore
+func (ms *memorySessionStore) GetSession(id string) (dto.Session, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	session, ok := ms.sessions[id]
+	if !ok {
+		return dto.Session{}, gorm.ErrRecordNotFound
+	}
+	return session, nil
+}
+
+// RevokeSession implements SessionStore
+func (ms *memorySessionStore) RevokeSession(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	session, ok := ms.sessions[id]
+	if !ok || session.RevokedAt != nil {
+		return gorm.ErrRecordNotFound
+	}
+	now := time.Now()
+	session.RevokedAt = &now
+	ms.sessions[id] = session
+	return nil
+}
+
+// RevokeUserSessions implements SessionStore
+func (ms *memorySessionStore) RevokeUserSessions(userID, role string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	now := time.Now()
+	for id, session := range ms.sessions {
+		if session.UserID == userID && session.Role == role && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			ms.sessions[id] = session
+		}
+	}
+	for hash, token := range ms.refreshTokens {
+		if token.UserID == userID && token.Role == role && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			ms.refreshTokens[hash] = token
+		}
+	}
+	return nil
+}
+
+// RevokeTokenFamily implements SessionStore
+func (ms *memorySessionStore) RevokeTokenFamily(familyID string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	now := time.Now()
+	for id, session := range ms.sessions {
+		if session.FamilyID == familyID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			ms.sessions[id] = session
+		}
+	}
+	for hash, token := range ms.refreshTokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			ms.refreshTokens[hash] = token
+		}
+	}
+	return nil
+}
+
+// DeleteExpiredSessions implements SessionStore
+func (ms *memorySessionStore) DeleteExpiredSessions(now time.Time) (int64, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	var deleted int64
+	for id, session := range ms.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(ms.sessions, id)
+			deleted++
+		}
+	}
+	for hash, token := range ms.refreshTokens {
+		if !now.Before(token.ExpiresAt) {
+			delete(ms.refreshTokens, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CreateRefreshToken implements SessionStore
+func (ms *memorySessionStore) CreateRefreshToken(token dto.RefreshToken) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.refreshTokens[token.TokenHash] = token
+	return nil
+}
+
+// GetRefreshToken implements SessionStore
+func (ms *memorySessionStore) GetRefreshToken(tokenHash string) (dto.RefreshToken, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	token, ok := ms.refreshTokens[tokenHash]
+	if !ok {
+		return dto.RefreshToken{}, gorm.ErrRecordNotFound
+	}
+	return token, nil
+}
+
+// RotateRefreshToken implements SessionStore
+func (ms *memorySessionStore) RotateRefreshToken(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for hash, token := range ms.refreshTokens {
+		if token.ID != id {
+			continue
+		}
+		if token.RotatedAt != nil || token.RevokedAt != nil {
+			return gorm.ErrRecordNotFound
+		}
+		now := time.Now()
+		token.RotatedAt = &now
+		ms.refreshTokens[hash] = token
+		return nil
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func NewMemorySessionStore() SessionStore {
+	return &memorySessionStore{
+		sessions:      make(map[string]dto.Session),
+		refreshTokens: make(map[string]dto.RefreshToken),
+	}
+}
@@ -0,0 +1,148 @@
+package sessionRepository
+
+import (
+	"golang/models/dto"
+	"golang/models/model"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// CreateSession implements SessionStore
+func (sr *sessionRepository) CreateSession(session dto.Session) error {
+	var sessionModel model.Session
+	err := copier.Copy(&sessionModel, &session)
+	if err != nil {
+		return err
+	}
+
+	err = sr.db.Model(&model.Session{}).Create(&sessionModel).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSession implements SessionStore
+func (sr *sessionRepository) GetSession(id string) (dto.Session, error) {
+	var session dto.Session
+	err := sr.db.Model(&model.Session{}).Where("id = ?", id).Find(&session)
+	if err.Error != nil {
+		return dto.Session{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.Session{}, gorm.ErrRecordNotFound
+	}
+	return session, nil
+}
+
+// RevokeSession implements SessionStore
+func (sr *sessionRepository) RevokeSession(id string) error {
+	err := sr.db.Model(&model.Session{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RevokeUserSessions implements SessionStore
+func (sr *sessionRepository) RevokeUserSessions(userID, role string) error {
+	now := time.Now()
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Session{}).Where("user_id = ? AND role = ? AND revoked_at IS NULL", userID, role).Update("revoked_at", now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.RefreshToken{}).Where("user_id = ? AND role = ? AND revoked_at IS NULL", userID, role).Update("revoked_at", now).Error
+	})
+}
+
+// RevokeTokenFamily implements SessionStore
+func (sr *sessionRepository) RevokeTokenFamily(familyID string) error {
+	now := time.Now()
+	return sr.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Session{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.RefreshToken{}).Where("family_id = ? AND revoked_at IS NULL", familyID).Update("revoked_at", now).Error
+	})
+}
+
+// DeleteExpiredSessions implements SessionStore
+func (sr *sessionRepository) DeleteExpiredSessions(now time.Time) (int64, error) {
+	var deleted int64
+	err := sr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("expires_at <= ?", now).Delete(&model.Session{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted += result.RowsAffected
+
+		result = tx.Unscoped().Where("expires_at <= ?", now).Delete(&model.RefreshToken{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted += result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// CreateRefreshToken implements SessionStore
+func (sr *sessionRepository) CreateRefreshToken(token dto.RefreshToken) error {
+	var tokenModel model.RefreshToken
+	err := copier.Copy(&tokenModel, &token)
+	if err != nil {
+		return err
+	}
+
+	err = sr.db.Model(&model.RefreshToken{}).Create(&tokenModel).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetRefreshToken implements SessionStore
+func (sr *sessionRepository) GetRefreshToken(tokenHash string) (dto.RefreshToken, error) {
+	var token dto.RefreshToken
+	err := sr.db.Model(&model.RefreshToken{}).Where("token_hash = ?", tokenHash).Find(&token)
+	if err.Error != nil {
+		return dto.RefreshToken{}, err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return dto.RefreshToken{}, gorm.ErrRecordNotFound
+	}
+	return token, nil
+}
+
+// RotateRefreshToken implements SessionStore
+func (sr *sessionRepository) RotateRefreshToken(id string) error {
+	// only one caller can rotate the token, the others see no affected rows
+	err := sr.db.Model(&model.RefreshToken{}).Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).Update("rotated_at", time.Now())
+	if err.Error != nil {
+		return err.Error
+	}
+	if err.RowsAffected <= 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func NewSessionRepository(db *gorm.DB) SessionStore {
+	return &sessionRepository{
+		db: db,
+	}
+}
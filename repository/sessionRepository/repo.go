@@ -0,0 +1,19 @@
+package sessionRepository
+
+import (
+	"golang/models/dto"
+	"time"
+)
+
+// SessionStore keeps track of every issued token so it can be validated and revoked
+type SessionStore interface {
+	CreateSession(session dto.Session) error
+	GetSession(id string) (dto.Session, error)
+	RevokeSession(id string) error
+	RevokeUserSessions(userID, role string) error
+	RevokeTokenFamily(familyID string) error
+	DeleteExpiredSessions(now time.Time) (int64, error)
+	CreateRefreshToken(token dto.RefreshToken) error
+	GetRefreshToken(tokenHash string) (dto.RefreshToken, error)
+	RotateRefreshToken(id string) error
+}
@@ -0,0 +1,72 @@
+package adminService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/adminRepository"
+
+	"gorm.io/gorm"
+)
+
+// minAdminPasswordLength is the shortest password accepted for the seeded admin account
+const minAdminPasswordLength = 8
+
+type AdminService interface {
+	EnsureAdmin(admin dto.AdminRegister) error
+	LoginAdmin(admin dto.AdminLogin) (dto.Admin, error)
+}
+
+type adminService struct {
+	adminRepo adminRepository.AdminRepository
+}
+
+// EnsureAdmin implements AdminService
+func (as *adminService) EnsureAdmin(admin dto.AdminRegister) error {
+	// never seed an admin that anyone could log in as
+	if len(admin.Password) < minAdminPasswordLength {
+		return errors.New(constantError.ErrorAdminPassword)
+	}
+
+	// do nothing if the admin is already exists
+	_, err := as.adminRepo.GetAdminByEmail(admin.Email)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	admin.ID = helper.GenerateUUID()
+	password, err := helper.HashPassword(admin.Password)
+	if err != nil {
+		return err
+	}
+	admin.Password = password
+
+	return as.adminRepo.CreateAdmin(admin)
+}
+
+// LoginAdmin implements AdminService
+func (as *adminService) LoginAdmin(input dto.AdminLogin) (dto.Admin, error) {
+	admin, err := as.adminRepo.GetAdminByEmail(input.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.Admin{}, errors.New(constantError.ErrorEmailOrPasswordNotMatch)
+		}
+		return dto.Admin{}, err
+	}
+
+	match := helper.CheckPasswordHash(input.Password, admin.Password)
+	if !match {
+		return dto.Admin{}, errors.New(constantError.ErrorEmailOrPasswordNotMatch)
+	}
+	return admin, nil
+}
+
+func NewAdminService(adminRepo adminRepository.AdminRepository) AdminService {
+	return &adminService{
+		adminRepo: adminRepo,
+	}
+}
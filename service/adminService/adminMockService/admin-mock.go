@@ -0,0 +1,23 @@
+package adminMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AdminMock struct {
+	mock.Mock
+}
+
+func (a *AdminMock) EnsureAdmin(admin dto.AdminRegister) error {
+	args := a.Called(admin)
+
+	return args.Error(0)
+}
+
+func (a *AdminMock) LoginAdmin(admin dto.AdminLogin) (dto.Admin, error) {
+	args := a.Called(admin)
+
+	return args.Get(0).(dto.Admin), args.Error(1)
+}
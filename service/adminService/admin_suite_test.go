@@ -0,0 +1,99 @@
+package adminService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/adminRepository/adminMockRepository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteAdmin struct {
+	suite.Suite
+	adminService AdminService
+	mock         *adminMockRepository.AdminMock
+}
+
+func (s *suiteAdmin) SetupTest() {
+	s.mock = &adminMockRepository.AdminMock{}
+	s.adminService = NewAdminService(s.mock)
+}
+
+func (s *suiteAdmin) TestEnsureAdmin() {
+	testCase := []struct {
+		Name                 string
+		Password             string
+		MockReturnGetError   error
+		MockReturnCreateErr  error
+		ExpectedCreateCalled bool
+		ExpectedError        error
+	}{
+		{"success admin already exists", "admin123", nil, nil, false, nil},
+		{"success create admin", "admin123", gorm.ErrRecordNotFound, nil, true, nil},
+		{"fail get admin", "admin123", errors.New("error"), nil, false, errors.New("error")},
+		{"fail create admin", "admin123", gorm.ErrRecordNotFound, errors.New("error"), true, errors.New("error")},
+		{"fail empty password", "", gorm.ErrRecordNotFound, nil, false, errors.New(constantError.ErrorAdminPassword)},
+		{"fail short password", "admin", gorm.ErrRecordNotFound, nil, false, errors.New(constantError.ErrorAdminPassword)},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetAdminByEmail", "admin@gmail.com").Return(dto.Admin{}, v.MockReturnGetError)
+		mockCallCreate := s.mock.On("CreateAdmin", mock.Anything).Return(v.MockReturnCreateErr)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.adminService.EnsureAdmin(dto.AdminRegister{
+				Name:     "admin",
+				Email:    "admin@gmail.com",
+				Password: v.Password,
+			})
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedCreateCalled {
+				s.mock.AssertCalled(t, "CreateAdmin", mock.Anything)
+			} else {
+				s.mock.AssertNotCalled(t, "CreateAdmin", mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallCreate.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteAdmin) TestLoginAdmin() {
+	password, err := helper.HashPassword("admin")
+	s.NoError(err)
+
+	testCase := []struct {
+		Name            string
+		Body            dto.AdminLogin
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{"success login", dto.AdminLogin{Email: "admin@gmail.com", Password: "admin"}, nil, false, nil},
+		{"fail wrong password", dto.AdminLogin{Email: "admin@gmail.com", Password: "wrong"}, nil, true, errors.New(constantError.ErrorEmailOrPasswordNotMatch)},
+		{"fail admin not found", dto.AdminLogin{Email: "admin@gmail.com", Password: "admin"}, gorm.ErrRecordNotFound, true, errors.New(constantError.ErrorEmailOrPasswordNotMatch)},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetAdminByEmail", v.Body.Email).Return(dto.Admin{ID: "abcde", Email: v.Body.Email, Password: password}, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			admin, err := s.adminService.LoginAdmin(v.Body)
+			if v.HasReturnError {
+				s.Equal(v.ExpectedError, err)
+			} else {
+				s.NoError(err)
+				s.Equal("abcde", admin.ID)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteAdmin(t *testing.T) {
+	suite.Run(t, new(suiteAdmin))
+}
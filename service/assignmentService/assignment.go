@@ -4,26 +4,39 @@ import (
 	"golang/helper"
 	"golang/models/dto"
 	assignmentRepository "golang/repository/assignmentRepository"
+	"golang/service/ownershipService"
 )
 
 type AssignmentService interface {
-	CreateAssignment(dto.AssignmentTransaction) error
-	DeleteAssignment(id string) error
+	CreateAssignment(assignment dto.AssignmentTransaction, instructorID string) error
+	DeleteAssignment(id, instructorID string) error
 	GetAllAssignment() ([]dto.Assignment, error)
 	GetAssignmentByID(id string) (dto.Assignment, error)
-	UpdateAssignment(dto.AssignmentTransaction) error
+	UpdateAssignment(assignment dto.AssignmentTransaction, instructorID string) error
 	GetAssignmentByCourse(id string) ([]dto.AssignmentCourse, error)
 }
 
 type assignmentService struct {
-	assignmentRepo assignmentRepository.AssignmentRepository
+	assignmentRepo   assignmentRepository.AssignmentRepository
+	ownershipService ownershipService.OwnershipService
 }
 
 // CreateModule implements ModuleService
-func (as *assignmentService) CreateAssignment(assignment dto.AssignmentTransaction) error {
+func (as *assignmentService) CreateAssignment(assignment dto.AssignmentTransaction, instructorID string) error {
+	// check if the target module is owned by the instructor
+	err := as.ownershipService.CheckModuleOwner(assignment.ModuleID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	err = helper.CheckAssignment(&assignment)
+	if err != nil {
+		return err
+	}
+
 	id := helper.GenerateUUID()
 	assignment.ID = id
-	err := as.assignmentRepo.CreateAssignment(assignment)
+	err = as.assignmentRepo.CreateAssignment(assignment)
 	if err != nil {
 		return err
 	}
@@ -31,9 +44,15 @@ func (as *assignmentService) CreateAssignment(assignment dto.AssignmentTransacti
 }
 
 // DeleteModule implements ModuleService
-func (as *assignmentService) DeleteAssignment(id string) error {
+func (as *assignmentService) DeleteAssignment(id, instructorID string) error {
+	// check if the assignment is owned by the instructor
+	err := as.ownershipService.CheckAssignmentOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
 	// call repository to delete account
-	err := as.assignmentRepo.DeleteAssignment(id)
+	err = as.assignmentRepo.DeleteAssignment(id)
 	if err != nil {
 		return err
 	}
@@ -66,17 +85,37 @@ func (as *assignmentService) GetAssignmentByCourse(id string) ([]dto.AssignmentC
 }
 
 // UpdateModule implements ModuleService
-func (as *assignmentService) UpdateAssignment(assignment dto.AssignmentTransaction) error {
+func (as *assignmentService) UpdateAssignment(assignment dto.AssignmentTransaction, instructorID string) error {
+	// check if the assignment is owned by the instructor
+	err := as.ownershipService.CheckAssignmentOwner(assignment.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	// check if the assignment is not moved to a module of another instructor
+	if assignment.ModuleID != "" {
+		err = as.ownershipService.CheckModuleOwner(assignment.ModuleID, instructorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = helper.CheckAssignment(&assignment)
+	if err != nil {
+		return err
+	}
+
 	// call repository to update Module
-	err := as.assignmentRepo.UpdateAssignment(assignment)
+	err = as.assignmentRepo.UpdateAssignment(assignment)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func NewAssignmentService(assignmentRepo assignmentRepository.AssignmentRepository) AssignmentService {
+func NewAssignmentService(assignmentRepo assignmentRepository.AssignmentRepository, ownershipService ownershipService.OwnershipService) AssignmentService {
 	return &assignmentService{
-		assignmentRepo: assignmentRepo,
+		assignmentRepo:   assignmentRepo,
+		ownershipService: ownershipService,
 	}
 }
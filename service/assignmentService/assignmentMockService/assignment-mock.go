@@ -10,14 +10,14 @@ type AssignmentMock struct {
 	mock.Mock
 }
 
-func (c *AssignmentMock) CreateAssignment(assignment dto.AssignmentTransaction) error {
-	args := c.Called(assignment)
+func (c *AssignmentMock) CreateAssignment(assignment dto.AssignmentTransaction, instructorID string) error {
+	args := c.Called(assignment, instructorID)
 
 	return args.Error(0)
 }
 
-func (c *AssignmentMock) DeleteAssignment(id string) error {
-	args := c.Called(id)
+func (c *AssignmentMock) DeleteAssignment(id, instructorID string) error {
+	args := c.Called(id, instructorID)
 
 	return args.Error(0)
 }
@@ -38,8 +38,8 @@ func (c *AssignmentMock) GetAllAssignment() ([]dto.Assignment, error) {
 	return args.Get(0).([]dto.Assignment), args.Error(1)
 }
 
-func (c *AssignmentMock) UpdateAssignment(assignment dto.AssignmentTransaction) error {
-	args := c.Called(assignment)
+func (c *AssignmentMock) UpdateAssignment(assignment dto.AssignmentTransaction, instructorID string) error {
+	args := c.Called(assignment, instructorID)
 
 	return args.Error(0)
 }
@@ -2,8 +2,10 @@ package assignmentservice
 
 import (
 	"errors"
+	"golang/constant/constantError"
 	"golang/models/dto"
 	assignmentmockrepository "golang/repository/assignmentRepository/assignmentMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -15,12 +17,17 @@ type suiteAssignment struct {
 	suite.Suite
 	assignmentService AssignmentService
 	mock              *assignmentmockrepository.AssignmentMock
+	ownershipMock     *ownershipMockService.OwnershipMock
 }
 
 func (s *suiteAssignment) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckModuleOwner", mock.Anything, mock.Anything).Return(nil)
+	s.ownershipMock.On("CheckAssignmentOwner", mock.Anything, mock.Anything).Return(nil)
+
 	mock := &assignmentmockrepository.AssignmentMock{}
 	s.mock = mock
-	NewAssignmentService := NewAssignmentService(s.mock)
+	NewAssignmentService := NewAssignmentService(s.mock, s.ownershipMock)
 	s.assignmentService = NewAssignmentService
 }
 
@@ -67,7 +74,7 @@ func (s *suiteAssignment) TestCreateAssignment() {
 	for _, v := range testCase {
 		mockCall := s.mock.On("CreateAssignment", mock.Anything).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.assignmentService.CreateAssignment(v.Body)
+			err := s.assignmentService.CreateAssignment(v.Body, v.User.ID)
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
@@ -116,7 +123,7 @@ func (s *suiteAssignment) TestDeleteAssignment() {
 	for _, v := range testCase {
 		mockCall := s.mock.On("DeleteAssignment", mock.Anything).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.assignmentService.DeleteAssignment(v.ParamID)
+			err := s.assignmentService.DeleteAssignment(v.ParamID, v.User.ID)
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
@@ -333,7 +340,7 @@ func (s *suiteAssignment) TestUpdateAssignment() {
 	for _, v := range testCase {
 		mockCall := s.mock.On("UpdateAssignment", mock.Anything).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.assignmentService.UpdateAssignment(v.Body)
+			err := s.assignmentService.UpdateAssignment(v.Body, "1")
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
@@ -347,6 +354,54 @@ func (s *suiteAssignment) TestUpdateAssignment() {
 	}
 }
 
+func (s *suiteAssignment) TestAssignmentNotOwner() {
+	ownershipMock := &ownershipMockService.OwnershipMock{}
+	ownershipMock.On("CheckModuleOwner", "abcde", "1").Return(nil)
+	ownershipMock.On("CheckModuleOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	ownershipMock.On("CheckAssignmentOwner", "abcde", "1").Return(nil)
+	ownershipMock.On("CheckAssignmentOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	assignmentService := NewAssignmentService(s.mock, ownershipMock)
+
+	testCase := []struct {
+		Name string
+		Call func() error
+	}{
+		{
+			"fail create assignment in other instructor",
+			func() error {
+				return assignmentService.CreateAssignment(dto.AssignmentTransaction{ModuleID: "other"}, "1")
+			},
+		},
+		{
+			"fail update assignment of other instructor",
+			func() error {
+				return assignmentService.UpdateAssignment(dto.AssignmentTransaction{ID: "other", ModuleID: "other"}, "1")
+			},
+		},
+		{
+			"fail move assignment to other instructor",
+			func() error {
+				return assignmentService.UpdateAssignment(dto.AssignmentTransaction{ID: "abcde", ModuleID: "other"}, "1")
+			},
+		},
+		{
+			"fail delete assignment of other instructor",
+			func() error {
+				return assignmentService.DeleteAssignment("other", "1")
+			},
+		},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := v.Call()
+			s.EqualError(err, constantError.ErrorNotAuthorized)
+		})
+	}
+	s.mock.AssertNotCalled(s.T(), "CreateAssignment", mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "UpdateAssignment", mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "DeleteAssignment", mock.Anything)
+}
+
 func TestSuiteAssignment(t *testing.T) {
 	suite.Run(t, new(suiteAssignment))
 }
@@ -1,6 +1,7 @@
 package categoryService
 
 import (
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/repository/categoryRepository"
@@ -57,16 +58,11 @@ func (cs *categoryService) GetCategoryByID(id string, user dto.User) (dto.GetCat
 		return dto.GetCategory{}, err
 	}
 
-	// get rating of all courses
 	for i, course := range category.Courses {
-		rating := helper.GetRatingCourse(course)
-		category.Courses[i].Rating = rating
+		// get rating and number of module of all courses
+		helper.GetCourseStats(&category.Courses[i])
 
-		// get number of module
-		numberOfModule := len(course.Modules)
-		category.Courses[i].NumberOfModules = numberOfModule
-
-		if user.Role == "customer" {
+		if user.Role == constantRole.RoleCustomer {
 			// get favorite of all courses
 			favorite := helper.GetFavoriteCourse(course, user.ID)
 			category.Courses[i].Favorite = favorite
@@ -273,6 +273,7 @@ func (s *suiteCategory) TestGetCategoryByID() {
 								CourseID: "abcde",
 							},
 						},
+						Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 					},
 				},
 			},
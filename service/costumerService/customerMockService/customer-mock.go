@@ -2,6 +2,7 @@ package customermockservice
 
 import (
 	"golang/models/dto"
+	"io"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -20,8 +21,85 @@ func (c *CustomerMock) VerifikasiCustomer(input dto.CustomerVerif) error {
 
 	return args.Error(0)
 }
+func (c *CustomerMock) ResendVerificationCode(input dto.ResendCode) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
 func (c *CustomerMock) LoginCostumer(customer dto.CostumerLogin) (dto.CostumerResponseGet, error) {
 	args := c.Called(customer)
 
 	return args.Get(0).(dto.CostumerResponseGet), args.Error(0)
 }
+
+func (c *CustomerMock) GetAllCustomer() ([]dto.CustomerAccount, error) {
+	args := c.Called()
+
+	return args.Get(0).([]dto.CustomerAccount), args.Error(1)
+}
+
+func (c *CustomerMock) DeleteCustomer(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) ForgotPassword(input dto.ForgotPassword) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) ResetPassword(input dto.ResetPassword) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) ChangePassword(id string, input dto.ChangePassword) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) GetProfile(id string) (dto.CustomerProfile, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.CustomerProfile), args.Error(1)
+}
+
+func (c *CustomerMock) UpdateProfile(id string, input dto.CustomerProfileUpdate) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) ChangeEmail(id string, input dto.ChangeEmail) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) VerifyEmailChange(id string, input dto.VerifyEmailChange) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) UpdateProfileImage(id string, image io.Reader) (string, error) {
+	args := c.Called(id, image)
+
+	return args.String(0), args.Error(1)
+}
+
+func (c *CustomerMock) DeleteAccount(id string, input dto.DeleteAccount) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *CustomerMock) ExportAccount(id string) (dto.CustomerExport, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.CustomerExport), args.Error(1)
+}
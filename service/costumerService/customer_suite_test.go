@@ -0,0 +1,645 @@
+package costumerService
+
+import (
+	"errors"
+	"golang/app/middlewares/session"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/drivers/storage"
+	"golang/helper"
+	"golang/models/dto"
+	customermockrepository "golang/repository/customerRepository/customerMockRepository"
+	"golang/repository/sessionRepository"
+	"golang/service/emailChangeService/emailChangeMockService"
+	"golang/service/mailService/mailMockService"
+	"golang/service/passwordResetService"
+	"golang/service/passwordResetService/passwordResetMockService"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteCustomer struct {
+	suite.Suite
+	customerService   CostumerService
+	mock              *customermockrepository.CustomerMock
+	mockMail          *mailMockService.MailMock
+	mockPasswordReset *passwordResetMockService.PasswordResetMock
+	mockEmailChange   *emailChangeMockService.EmailChangeMock
+	storage           *storage.FakeStorage
+}
+
+func (s *suiteCustomer) SetupTest() {
+	s.mock = &customermockrepository.CustomerMock{}
+	s.mockMail = &mailMockService.MailMock{}
+	s.mockPasswordReset = &passwordResetMockService.PasswordResetMock{}
+	s.mockEmailChange = &emailChangeMockService.EmailChangeMock{}
+	s.storage = &storage.FakeStorage{}
+	s.customerService = NewcostumerService(s.mock, s.mockMail, s.mockPasswordReset, s.mockEmailChange, s.storage, dto.VerificationPolicy{
+		CodeLength:     6,
+		CodeExpiry:     15 * time.Minute,
+		MaxAttempts:    3,
+		ResendCooldown: time.Minute,
+	}, dto.RetentionPolicy{KeepRatings: true, KeepGrades: true})
+}
+
+func (s *suiteCustomer) TestVerifikasiCustomer() {
+	testCase := []struct {
+		Name                    string
+		Body                    dto.CustomerVerif
+		MockReturnCode          dto.CustomerCode
+		MockReturnCodeError     error
+		ExpectedIncrementCalled bool
+		ExpectedActivateCalled  bool
+		ExpectedError           error
+	}{
+		{
+			"success verif customer",
+			dto.CustomerVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.CustomerCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			false,
+			true,
+			nil,
+		},
+		{
+			"fail code not found",
+			dto.CustomerVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.CustomerCode{},
+			gorm.ErrRecordNotFound,
+			false,
+			false,
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+		},
+		{
+			"fail code invalid",
+			dto.CustomerVerif{Email: "tes@gmail.com", Code: "654321"},
+			dto.CustomerCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			true,
+			false,
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+		},
+		{
+			"fail code expired",
+			dto.CustomerVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.CustomerCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(-time.Minute)},
+			nil,
+			false,
+			false,
+			errors.New(constantError.ErrorVerificationCodeExpired),
+		},
+		{
+			"fail too many attempts",
+			dto.CustomerVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.CustomerCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(time.Minute), Attempts: 3},
+			nil,
+			false,
+			false,
+			errors.New(constantError.ErrorTooManyAttempts),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetCustomerCode", v.Body.Email).Return(v.MockReturnCode, v.MockReturnCodeError)
+		mockCallIncrement := s.mock.On("IncrementCustomerCodeAttempts", "abcde").Return(nil)
+		mockCallActivate := s.mock.On("ActivateCustomer", v.Body.Email).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.VerifikasiCustomer(v.Body)
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedIncrementCalled {
+				s.mock.AssertCalled(t, "IncrementCustomerCodeAttempts", "abcde")
+			} else {
+				s.mock.AssertNotCalled(t, "IncrementCustomerCodeAttempts", "abcde")
+			}
+			if v.ExpectedActivateCalled {
+				s.mock.AssertCalled(t, "ActivateCustomer", v.Body.Email)
+			} else {
+				s.mock.AssertNotCalled(t, "ActivateCustomer", v.Body.Email)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallIncrement.Unset()
+		mockCallActivate.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestResendVerificationCode() {
+	testCase := []struct {
+		Name                    string
+		MockReturnCustomer      dto.CostumerResponseGet
+		MockReturnCustomerError error
+		MockReturnCode          dto.CustomerCode
+		MockReturnCodeError     error
+		ExpectedSent            bool
+		ExpectedError           error
+	}{
+		{
+			"success resend code",
+			dto.CostumerResponseGet{Name: "tes", Email: "tes@gmail.com"},
+			nil,
+			dto.CustomerCode{ID: "abcde", SentAt: time.Now().Add(-2 * time.Minute), Attempts: 3},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"success resend code without previous code",
+			dto.CostumerResponseGet{Name: "tes", Email: "tes@gmail.com"},
+			nil,
+			dto.CustomerCode{},
+			gorm.ErrRecordNotFound,
+			true,
+			nil,
+		},
+		{
+			"success unknown email is ignored",
+			dto.CostumerResponseGet{},
+			gorm.ErrRecordNotFound,
+			dto.CustomerCode{},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"success active customer is ignored",
+			dto.CostumerResponseGet{Name: "tes", Email: "tes@gmail.com", IsActive: true},
+			nil,
+			dto.CustomerCode{},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail code recently sent",
+			dto.CostumerResponseGet{Name: "tes", Email: "tes@gmail.com"},
+			nil,
+			dto.CustomerCode{ID: "abcde", SentAt: time.Now().Add(-30 * time.Second)},
+			nil,
+			false,
+			errors.New(constantError.ErrorResendCooldown),
+		},
+	}
+	for _, v := range testCase {
+		var saved dto.CustomerCode
+		mockCallGetCustomer := s.mock.On("GetCustomerByEmail", "tes@gmail.com").Return(v.MockReturnCustomer, v.MockReturnCustomerError)
+		mockCallGetCode := s.mock.On("GetCustomerCode", "tes@gmail.com").Return(v.MockReturnCode, v.MockReturnCodeError)
+		mockCallSave := s.mock.On("SaveCustomerCode", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(dto.CustomerCode)
+		}).Return(nil)
+		mockCallSend := s.mockMail.On("SendVerification", "tes@gmail.com", "tes", mock.Anything, 15*time.Minute).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.ResendVerificationCode(dto.ResendCode{Email: "tes@gmail.com"})
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedSent {
+				s.mockMail.AssertNumberOfCalls(t, "SendVerification", 1)
+				s.Len(saved.Code, 6)
+				s.Equal(0, saved.Attempts)
+				s.NotEmpty(saved.ID)
+				s.True(saved.ExpiresAt.After(time.Now()))
+			} else {
+				s.mockMail.AssertNotCalled(t, "SendVerification", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGetCustomer.Unset()
+		mockCallGetCode.Unset()
+		mockCallSave.Unset()
+		mockCallSend.Unset()
+		s.mockMail.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestForgotPassword() {
+	testCase := []struct {
+		Name                    string
+		MockReturnCustomer      dto.CostumerResponseGet
+		MockReturnCustomerError error
+		ExpectedSent            bool
+		ExpectedError           error
+	}{
+		{
+			"success send reset token",
+			dto.CostumerResponseGet{ID: "abcde", Name: "tes", Email: "tes@gmail.com"},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"success unknown email is ignored",
+			dto.CostumerResponseGet{},
+			gorm.ErrRecordNotFound,
+			false,
+			nil,
+		},
+		{
+			"fail get customer",
+			dto.CostumerResponseGet{},
+			errors.New("error"),
+			false,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetCustomerByEmail", "tes@gmail.com").Return(v.MockReturnCustomer, v.MockReturnCustomerError)
+		mockCallToken := s.mockPasswordReset.On("CreateResetToken", "abcde", constantRole.RoleCustomer).Return("token", nil)
+		mockCallSend := s.mockMail.On("SendPasswordReset", "tes@gmail.com", "tes", "token", passwordResetService.PasswordResetTokenDuration).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.ForgotPassword(dto.ForgotPassword{Email: "tes@gmail.com"})
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedSent {
+				s.mockMail.AssertNumberOfCalls(t, "SendPasswordReset", 1)
+			} else {
+				s.mockMail.AssertNotCalled(t, "SendPasswordReset", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallToken.Unset()
+		mockCallSend.Unset()
+		s.mockMail.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestResetPassword() {
+	testCase := []struct {
+		Name                 string
+		MockReturnConsume    string
+		MockReturnConsumeErr error
+		ExpectedUpdated      bool
+		ExpectedError        error
+	}{
+		{
+			"success reset password",
+			"abcde",
+			nil,
+			true,
+			nil,
+		},
+		{
+			"fail invalid token",
+			"",
+			errors.New(constantError.ErrorInvalidResetToken),
+			false,
+			errors.New(constantError.ErrorInvalidResetToken),
+		},
+	}
+	for _, v := range testCase {
+		var password string
+		mockCallConsume := s.mockPasswordReset.On("ConsumeResetToken", "token", constantRole.RoleCustomer).Return(v.MockReturnConsume, v.MockReturnConsumeErr)
+		mockCallUpdate := s.mock.On("UpdateCustomerPassword", "abcde", mock.Anything).Run(func(args mock.Arguments) {
+			password = args.String(1)
+		}).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.ResetPassword(dto.ResetPassword{Token: "token", Password: "newpassword"})
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedUpdated {
+				s.True(helper.CheckPasswordHash("newpassword", password))
+			} else {
+				s.mock.AssertNotCalled(t, "UpdateCustomerPassword", mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallConsume.Unset()
+		mockCallUpdate.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestChangePassword() {
+	oldPassword, _ := helper.HashPassword("oldpassword")
+	testCase := []struct {
+		Name            string
+		Body            dto.ChangePassword
+		ExpectedUpdated bool
+		ExpectedError   error
+	}{
+		{
+			"success change password",
+			dto.ChangePassword{OldPassword: "oldpassword", NewPassword: "newpassword"},
+			true,
+			nil,
+		},
+		{
+			"fail old password not match",
+			dto.ChangePassword{OldPassword: "wrongpassword", NewPassword: "newpassword"},
+			false,
+			errors.New(constantError.ErrorOldPasswordNotMatch),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetCustomerByID", "abcde").Return(dto.CostumerResponseGet{ID: "abcde", Password: oldPassword}, nil)
+		mockCallUpdate := s.mock.On("UpdateCustomerPassword", "abcde", mock.Anything).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.ChangePassword("abcde", v.Body)
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedUpdated {
+				s.mock.AssertCalled(t, "UpdateCustomerPassword", "abcde", mock.Anything)
+			} else {
+				s.mock.AssertNotCalled(t, "UpdateCustomerPassword", mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallUpdate.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestChangeEmail() {
+	testCase := []struct {
+		Name                 string
+		MockReturnEmailError error
+		ExpectedRequested    bool
+		ExpectedError        error
+	}{
+		{
+			"success request email change",
+			gorm.ErrRecordNotFound,
+			true,
+			nil,
+		},
+		{
+			"fail email already used",
+			nil,
+			false,
+			errors.New(constantError.ErrorEmailAlreadyUsed),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetCustomerByID", "abcde").Return(dto.CostumerResponseGet{ID: "abcde", Name: "tes"}, nil)
+		mockCallGetEmail := s.mock.On("GetCustomerByEmail", "new@gmail.com").Return(dto.CostumerResponseGet{}, v.MockReturnEmailError)
+		mockCallRequest := s.mockEmailChange.On("RequestEmailChange", "abcde", constantRole.RoleCustomer, "tes", "new@gmail.com").Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.ChangeEmail("abcde", dto.ChangeEmail{Email: "new@gmail.com"})
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedRequested {
+				s.mockEmailChange.AssertNumberOfCalls(t, "RequestEmailChange", 1)
+			} else {
+				s.mockEmailChange.AssertNotCalled(t, "RequestEmailChange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallGetEmail.Unset()
+		mockCallRequest.Unset()
+		s.mockEmailChange.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestVerifyEmailChange() {
+	testCase := []struct {
+		Name                 string
+		MockReturnConfirmErr error
+		MockReturnEmailError error
+		ExpectedUpdated      bool
+		ExpectedError        error
+	}{
+		{
+			"success change email",
+			nil,
+			gorm.ErrRecordNotFound,
+			true,
+			nil,
+		},
+		{
+			"fail code invalid",
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+			gorm.ErrRecordNotFound,
+			false,
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+		},
+		{
+			"fail email taken while pending",
+			nil,
+			nil,
+			false,
+			errors.New(constantError.ErrorEmailAlreadyUsed),
+		},
+	}
+	for _, v := range testCase {
+		mockCallConfirm := s.mockEmailChange.On("ConfirmEmailChange", "abcde", constantRole.RoleCustomer, "123456").Return("new@gmail.com", v.MockReturnConfirmErr)
+		mockCallGetEmail := s.mock.On("GetCustomerByEmail", "new@gmail.com").Return(dto.CostumerResponseGet{}, v.MockReturnEmailError)
+		mockCallUpdate := s.mock.On("UpdateCustomerEmail", "abcde", "new@gmail.com").Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.VerifyEmailChange("abcde", dto.VerifyEmailChange{Code: "123456"})
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedUpdated {
+				s.mock.AssertCalled(t, "UpdateCustomerEmail", "abcde", "new@gmail.com")
+			} else {
+				s.mock.AssertNotCalled(t, "UpdateCustomerEmail", mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallConfirm.Unset()
+		mockCallGetEmail.Unset()
+		mockCallUpdate.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestUpdateProfileImage() {
+	testCase := []struct {
+		Name          string
+		Image         string
+		ExpectedSaved bool
+		ExpectedError error
+	}{
+		{
+			"success upload png",
+			"\x89PNG\r\n\x1a\nimage",
+			true,
+			nil,
+		},
+		{
+			"fail not an image",
+			"hello world",
+			false,
+			errors.New(constantError.ErrorInvalidImage),
+		},
+		{
+			"fail image too large",
+			"\x89PNG\r\n\x1a\n" + strings.Repeat("a", helper.MaxProfileImageSize),
+			false,
+			errors.New(constantError.ErrorImageTooLarge),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetCustomerByID", "abcde").Return(dto.CostumerResponseGet{ID: "abcde", ProfileImage: "https://example.com/stock.jpg"}, nil)
+		mockCallUpdate := s.mock.On("UpdateCustomerProfileImage", "abcde", mock.Anything).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			url, err := s.customerService.UpdateProfileImage("abcde", strings.NewReader(v.Image))
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedSaved {
+				s.True(strings.HasPrefix(url, "/profiles/customer/abcde/"))
+				s.True(strings.HasSuffix(url, ".png"))
+				s.Contains(s.storage.Files(), url)
+				s.mock.AssertCalled(t, "UpdateCustomerProfileImage", "abcde", url)
+			} else {
+				s.mock.AssertNotCalled(t, "UpdateCustomerProfileImage", mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallUpdate.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestDeleteAccount() {
+	password, _ := helper.HashPassword("password")
+	testCase := []struct {
+		Name            string
+		Body            dto.DeleteAccount
+		ExpectedDeleted bool
+		ExpectedError   error
+	}{
+		{
+			"success delete account",
+			dto.DeleteAccount{Password: "password"},
+			true,
+			nil,
+		},
+		{
+			"fail password not match",
+			dto.DeleteAccount{Password: "wrongpassword"},
+			false,
+			errors.New(constantError.ErrorPasswordNotMatch),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetCustomerByID", "abcde").Return(dto.CostumerResponseGet{ID: "abcde", Password: password}, nil)
+		mockCallDelete := s.mock.On("DeleteCustomerAccount", "abcde", dto.RetentionPolicy{KeepRatings: true, KeepGrades: true}).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.DeleteAccount("abcde", v.Body)
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedDeleted {
+				s.mock.AssertCalled(t, "DeleteCustomerAccount", "abcde", dto.RetentionPolicy{KeepRatings: true, KeepGrades: true})
+			} else {
+				s.mock.AssertNotCalled(t, "DeleteCustomerAccount", mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallDelete.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteCustomer) TestExportAccount() {
+	export := dto.CustomerExport{
+		Profile: dto.CustomerProfile{ID: "abcde", Name: "test", Email: "test@gmail.com"},
+		Enrollments: []dto.ExportEnrollment{
+			{CourseID: "course1", CourseName: "golang", NoModule: 2},
+		},
+		QuizAttempts: []dto.ExportQuizAttempt{
+			{AttemptID: "attempt1", QuizID: "quiz1", QuizTitle: "golang quiz", Status: "submitted", Score: 1, MaxScore: 1, Answers: []dto.ExportQuizAnswer{
+				{AttemptID: "attempt1", QuestionID: "question1", NoQuestion: 1, Answer: "a language", IsCorrect: true, Score: 1},
+			}},
+		},
+		ModuleProgress: []dto.ExportModuleProgress{
+			{CourseID: "course1", CourseName: "golang", ModuleID: "module1", ModuleName: "intro"},
+		},
+		ModuleViews: []dto.ExportModuleView{
+			{ModuleID: "module1", ModuleName: "intro"},
+		},
+		MediaProgress: []dto.ExportMediaProgress{
+			{ModuleID: "module1", ModuleName: "intro", MediaModuleID: "media1", Watched: 40},
+		},
+		ProgressEvents: []dto.ExportProgressEvent{
+			{CourseID: "course1", CourseName: "golang", ModuleID: "module1", Type: "module_completed"},
+		},
+		LearningPathCompletions: []dto.ExportLearningPathCompletion{
+			{LearningPathID: "path1", LearningPathTitle: "backend"},
+		},
+	}
+	testCase := []struct {
+		Name          string
+		MockReturn    dto.CustomerExport
+		MockError     error
+		ExpectedError error
+	}{
+		{
+			"success export account",
+			export,
+			nil,
+			nil,
+		},
+		{
+			"fail customer not found",
+			dto.CustomerExport{},
+			gorm.ErrRecordNotFound,
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetCustomerExport", "abcde").Return(v.MockReturn, v.MockError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			result, err := s.customerService.ExportAccount("abcde")
+			s.Equal(v.ExpectedError, err)
+			if err == nil {
+				s.Equal(v.MockReturn.Profile, result.Profile)
+				s.Equal(v.MockReturn.Enrollments, result.Enrollments)
+				s.Equal(v.MockReturn.QuizAttempts, result.QuizAttempts)
+				s.Equal(v.MockReturn.ModuleProgress, result.ModuleProgress)
+				s.Equal(v.MockReturn.ModuleViews, result.ModuleViews)
+				s.Equal(v.MockReturn.MediaProgress, result.MediaProgress)
+				s.Equal(v.MockReturn.ProgressEvents, result.ProgressEvents)
+				s.Equal(v.MockReturn.LearningPathCompletions, result.LearningPathCompletions)
+				s.False(result.ExportedAt.IsZero())
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomer) TestDeleteCustomer() {
+	testCase := []struct {
+		Name            string
+		MockReturnError error
+		RevokeFails     bool
+		ExpectedActive  bool
+		ExpectedError   error
+	}{
+		{"success delete customer", nil, false, false, nil},
+		{"fail delete customer", errors.New("error"), false, true, errors.New("error")},
+		{"fail revoke sessions", nil, true, true, errors.New("error")},
+	}
+	now := time.Now()
+	for _, v := range testCase {
+		var store sessionRepository.SessionStore = sessionRepository.NewMemorySessionStore()
+		if v.RevokeFails {
+			store = failingSessionStore{store}
+		}
+		session.SetStore(store)
+		s.NoError(session.Create("customer-token", "abcde", constantRole.RoleCustomer, "", now, now.Add(time.Hour)))
+		mockCall := s.mock.On("DeleteCustomer", "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerService.DeleteCustomer("abcde")
+			s.Equal(v.ExpectedError, err)
+			s.Equal(v.ExpectedActive, session.IsActive("customer-token", constantRole.RoleCustomer))
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+	session.SetStore(sessionRepository.NewMemorySessionStore())
+}
+
+// failingSessionStore is a session store that can not revoke the sessions of a user
+type failingSessionStore struct {
+	sessionRepository.SessionStore
+}
+
+func (f failingSessionStore) RevokeUserSessions(userID, role string) error {
+	return errors.New("error")
+}
+
+func TestSuiteCustomer(t *testing.T) {
+	suite.Run(t, new(suiteCustomer))
+}
@@ -1,23 +1,70 @@
 package costumerService
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang/app/middlewares/session"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/drivers/storage"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/repository/customerRepository"
+	"golang/service/emailChangeService"
+	"golang/service/mailService"
+	"golang/service/passwordResetService"
+	"io"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type CostumerService interface {
 	CreateCustomer(user dto.CostumerRegister) error
 	VerifikasiCustomer(input dto.CustomerVerif) error
+	ResendVerificationCode(input dto.ResendCode) error
 	LoginCostumer(user dto.CostumerLogin) (dto.CostumerResponseGet, error)
+	ForgotPassword(input dto.ForgotPassword) error
+	ResetPassword(input dto.ResetPassword) error
+	ChangePassword(customerID string, input dto.ChangePassword) error
+	GetProfile(customerID string) (dto.CustomerProfile, error)
+	UpdateProfile(customerID string, input dto.CustomerProfileUpdate) error
+	ChangeEmail(customerID string, input dto.ChangeEmail) error
+	VerifyEmailChange(customerID string, input dto.VerifyEmailChange) error
+	UpdateProfileImage(customerID string, image io.Reader) (string, error)
+	DeleteAccount(customerID string, input dto.DeleteAccount) error
+	ExportAccount(customerID string) (dto.CustomerExport, error)
+	GetAllCustomer() ([]dto.CustomerAccount, error)
+	DeleteCustomer(id string) error
 }
 
 type costumerService struct {
-	customerRepo customerRepository.CustomerRepository
+	customerRepo         customerRepository.CustomerRepository
+	mailService          mailService.MailService
+	passwordResetService passwordResetService.PasswordResetService
+	emailChangeService   emailChangeService.EmailChangeService
+	storage              storage.Storage
+	verificationPolicy   dto.VerificationPolicy
+	retentionPolicy      dto.RetentionPolicy
 }
 
 // CreateCustomer implements costumerService
 func (u *costumerService) CreateCustomer(user dto.CostumerRegister) error {
+	// an account that is not verified yet only gets a new verification code
+	customer, err := u.customerRepo.GetCustomerByEmail(user.Email)
+	if err == nil && !customer.IsActive {
+		err = u.sendVerificationCode(customer)
+		if err != nil && err.Error() != constantError.ErrorResendCooldown {
+			log.Printf("fail send verification mail to %s: %s", customer.Email, err)
+		}
+		return nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
 	id := helper.GenerateUUID()
 	codeId := helper.GenerateUUID()
 	user.ID = id
@@ -29,20 +76,76 @@ func (u *costumerService) CreateCustomer(user dto.CostumerRegister) error {
 		return errPassword
 	}
 
+	// generate the verification code
+	code, err := helper.GenerateVerificationCode(u.verificationPolicy.CodeLength)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	customerCode := dto.CustomerCode{
+		ID:        codeId,
+		Email:     user.Email,
+		Code:      code,
+		ExpiresAt: now.Add(u.verificationPolicy.CodeExpiry),
+		SentAt:    now,
+	}
+
 	// call repository to save user
-	err := u.customerRepo.CreateCustomer(user)
+	err = u.customerRepo.CreateCustomer(user, customerCode)
 	if err != nil {
 		return err
 	}
+
+	// the mail is delivered by the outbox, the customer can ask for a new code if it is lost
+	err = u.mailService.SendVerification(user.Email, user.Name, code, u.verificationPolicy.CodeExpiry)
+	if err != nil {
+		log.Printf("fail send verification mail to %s: %s", user.Email, err)
+	}
 	return nil
 }
 
+// VerifikasiCustomer implements costumerService
 func (u *costumerService) VerifikasiCustomer(input dto.CustomerVerif) error {
-	err := u.customerRepo.VerifikasiCustomer(input)
+	customerCode, err := u.customerRepo.GetCustomerCode(input.Email)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(constantError.ErrorVerificationCodeInvalid)
+		}
 		return err
 	}
-	return nil
+
+	// the code is locked after too many wrong guesses until a new one is sent
+	if customerCode.Attempts >= u.verificationPolicy.MaxAttempts {
+		return errors.New(constantError.ErrorTooManyAttempts)
+	}
+	if !time.Now().Before(customerCode.ExpiresAt) {
+		return errors.New(constantError.ErrorVerificationCodeExpired)
+	}
+	if !helper.CompareVerificationCode(customerCode.Code, input.Code) {
+		err = u.customerRepo.IncrementCustomerCodeAttempts(customerCode.ID)
+		if err != nil {
+			return err
+		}
+		return errors.New(constantError.ErrorVerificationCodeInvalid)
+	}
+
+	return u.customerRepo.ActivateCustomer(input.Email)
+}
+
+// ResendVerificationCode implements costumerService
+func (u *costumerService) ResendVerificationCode(input dto.ResendCode) error {
+	customer, err := u.customerRepo.GetCustomerByEmail(input.Email)
+	if err != nil {
+		// do not tell whether the email is registered
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if customer.IsActive {
+		return nil
+	}
+	return u.sendVerificationCode(customer)
 }
 
 // LoginCostumer implements costumerService
@@ -50,13 +153,255 @@ func (u *costumerService) LoginCostumer(user dto.CostumerLogin) (dto.CostumerRes
 	// call repository to get user
 	CostumerLogin, err := u.customerRepo.LoginCustomer(user)
 	if err != nil {
+		// send a new verification code if the email is not verified yet
+		if err.Error() == constantError.ErrorNoActive {
+			u.resendOnLogin(user.Email)
+		}
 		return dto.CostumerResponseGet{}, err
 	}
 	return CostumerLogin, nil
 }
 
-func NewcostumerService(customerRepo customerRepository.CustomerRepository) CostumerService {
+// ForgotPassword implements CostumerService
+func (u *costumerService) ForgotPassword(input dto.ForgotPassword) error {
+	customer, err := u.customerRepo.GetCustomerByEmail(input.Email)
+	if err != nil {
+		// do not tell whether the email is registered
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := u.passwordResetService.CreateResetToken(customer.ID, constantRole.RoleCustomer)
+	if err != nil {
+		return err
+	}
+	return u.mailService.SendPasswordReset(customer.Email, customer.Name, token, passwordResetService.PasswordResetTokenDuration)
+}
+
+// ResetPassword implements CostumerService
+func (u *costumerService) ResetPassword(input dto.ResetPassword) error {
+	customerID, err := u.passwordResetService.ConsumeResetToken(input.Token, constantRole.RoleCustomer)
+	if err != nil {
+		return err
+	}
+	return u.updatePassword(customerID, input.Password)
+}
+
+// ChangePassword implements CostumerService
+func (u *costumerService) ChangePassword(customerID string, input dto.ChangePassword) error {
+	customer, err := u.customerRepo.GetCustomerByID(customerID)
+	if err != nil {
+		return err
+	}
+	if !helper.CheckPasswordHash(input.OldPassword, customer.Password) {
+		return errors.New(constantError.ErrorOldPasswordNotMatch)
+	}
+	return u.updatePassword(customerID, input.NewPassword)
+}
+
+// GetProfile implements CostumerService
+func (u *costumerService) GetProfile(customerID string) (dto.CustomerProfile, error) {
+	customer, err := u.customerRepo.GetCustomerByID(customerID)
+	if err != nil {
+		return dto.CustomerProfile{}, err
+	}
+	return dto.CustomerProfile{
+		ID:           customer.ID,
+		Name:         customer.Name,
+		Email:        customer.Email,
+		ProfileImage: customer.ProfileImage,
+	}, nil
+}
+
+// UpdateProfile implements CostumerService
+func (u *costumerService) UpdateProfile(customerID string, input dto.CustomerProfileUpdate) error {
+	return u.customerRepo.UpdateCustomerProfile(customerID, input)
+}
+
+// ChangeEmail implements CostumerService, the email is only changed once the new one is verified
+func (u *costumerService) ChangeEmail(customerID string, input dto.ChangeEmail) error {
+	customer, err := u.customerRepo.GetCustomerByID(customerID)
+	if err != nil {
+		return err
+	}
+	err = u.checkEmailAvailable(input.Email)
+	if err != nil {
+		return err
+	}
+	return u.emailChangeService.RequestEmailChange(customerID, constantRole.RoleCustomer, customer.Name, input.Email)
+}
+
+// VerifyEmailChange implements CostumerService
+func (u *costumerService) VerifyEmailChange(customerID string, input dto.VerifyEmailChange) error {
+	email, err := u.emailChangeService.ConfirmEmailChange(customerID, constantRole.RoleCustomer, input.Code)
+	if err != nil {
+		return err
+	}
+	// another account could take the email while the code was pending
+	err = u.checkEmailAvailable(email)
+	if err != nil {
+		return err
+	}
+	return u.customerRepo.UpdateCustomerEmail(customerID, email)
+}
+
+// UpdateProfileImage implements CostumerService, it returns the url of the new image
+func (u *costumerService) UpdateProfileImage(customerID string, image io.Reader) (string, error) {
+	customer, err := u.customerRepo.GetCustomerByID(customerID)
+	if err != nil {
+		return "", err
+	}
+	data, extension, err := helper.ReadProfileImage(image)
+	if err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("profiles/customer/%s/%s%s", customerID, helper.GenerateUUID(), extension)
+	url, err := u.storage.Put(key, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	err = u.customerRepo.UpdateCustomerProfileImage(customerID, url)
+	if err != nil {
+		u.storage.Delete(url)
+		return "", err
+	}
+
+	err = u.storage.Delete(customer.ProfileImage)
+	if err != nil {
+		log.Printf("fail delete profile image %s: %s", customer.ProfileImage, err)
+	}
+	return url, nil
+}
+
+// DeleteAccount implements CostumerService, the customer is anonymized and the learning records follow the retention policy
+func (u *costumerService) DeleteAccount(customerID string, input dto.DeleteAccount) error {
+	customer, err := u.customerRepo.GetCustomerByID(customerID)
+	if err != nil {
+		return err
+	}
+	if !helper.CheckPasswordHash(input.Password, customer.Password) {
+		return errors.New(constantError.ErrorPasswordNotMatch)
+	}
+
+	err = u.customerRepo.DeleteCustomerAccount(customerID, u.retentionPolicy)
+	if err != nil {
+		return err
+	}
+
+	err = u.storage.Delete(customer.ProfileImage)
+	if err != nil {
+		log.Printf("fail delete profile image %s: %s", customer.ProfileImage, err)
+	}
+	return session.RevokeUser(customerID, constantRole.RoleCustomer)
+}
+
+// ExportAccount implements CostumerService
+func (u *costumerService) ExportAccount(customerID string) (dto.CustomerExport, error) {
+	export, err := u.customerRepo.GetCustomerExport(customerID)
+	if err != nil {
+		return dto.CustomerExport{}, err
+	}
+	export.ExportedAt = time.Now()
+	return export, nil
+}
+
+// GetAllCustomer implements CostumerService
+func (u *costumerService) GetAllCustomer() ([]dto.CustomerAccount, error) {
+	accounts, err := u.customerRepo.GetAllCustomer()
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// DeleteCustomer implements CostumerService
+func (u *costumerService) DeleteCustomer(id string) error {
+	err := u.customerRepo.DeleteCustomer(id)
+	if err != nil {
+		return err
+	}
+	// the deleted account can not use its tokens anymore
+	return session.RevokeUser(id, constantRole.RoleCustomer)
+}
+
+// checkEmailAvailable fails when the email already belongs to a customer
+func (u *costumerService) checkEmailAvailable(email string) error {
+	_, err := u.customerRepo.GetCustomerByEmail(email)
+	if err == nil {
+		return errors.New(constantError.ErrorEmailAlreadyUsed)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// updatePassword saves the new password and logs the customer out of every session
+func (u *costumerService) updatePassword(customerID, password string) error {
+	hash, err := helper.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	err = u.customerRepo.UpdateCustomerPassword(customerID, hash)
+	if err != nil {
+		return err
+	}
+	return session.RevokeUser(customerID, constantRole.RoleCustomer)
+}
+
+// resendOnLogin sends a new verification code to a customer that is not verified yet
+func (u *costumerService) resendOnLogin(email string) {
+	customer, err := u.customerRepo.GetCustomerByEmail(email)
+	if err == nil {
+		err = u.sendVerificationCode(customer)
+	}
+	if err != nil && err.Error() != constantError.ErrorResendCooldown {
+		log.Printf("fail send verification mail to %s: %s", email, err)
+	}
+}
+
+// sendVerificationCode replaces the verification code of the customer and mail it, unless the last one was sent too recently
+func (u *costumerService) sendVerificationCode(customer dto.CostumerResponseGet) error {
+	now := time.Now()
+	customerCode, err := u.customerRepo.GetCustomerCode(customer.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err == nil && now.Before(customerCode.SentAt.Add(u.verificationPolicy.ResendCooldown)) {
+		return errors.New(constantError.ErrorResendCooldown)
+	}
+	if customerCode.ID == "" {
+		customerCode.ID = helper.GenerateUUID()
+	}
+
+	code, err := helper.GenerateVerificationCode(u.verificationPolicy.CodeLength)
+	if err != nil {
+		return err
+	}
+	customerCode.Email = customer.Email
+	customerCode.Code = code
+	customerCode.ExpiresAt = now.Add(u.verificationPolicy.CodeExpiry)
+	customerCode.Attempts = 0
+	customerCode.SentAt = now
+	err = u.customerRepo.SaveCustomerCode(customerCode)
+	if err != nil {
+		return err
+	}
+
+	return u.mailService.SendVerification(customer.Email, customer.Name, code, u.verificationPolicy.CodeExpiry)
+}
+
+func NewcostumerService(customerRepo customerRepository.CustomerRepository, mailService mailService.MailService, passwordResetService passwordResetService.PasswordResetService, emailChangeService emailChangeService.EmailChangeService, storage storage.Storage, verificationPolicy dto.VerificationPolicy, retentionPolicy dto.RetentionPolicy) CostumerService {
 	return &costumerService{
-		customerRepo: customerRepo,
+		customerRepo:         customerRepo,
+		mailService:          mailService,
+		passwordResetService: passwordResetService,
+		emailChangeService:   emailChangeService,
+		storage:              storage,
+		verificationPolicy:   verificationPolicy,
+		retentionPolicy:      retentionPolicy,
 	}
 }
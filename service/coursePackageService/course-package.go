@@ -0,0 +1,139 @@
+package coursePackageService
+
+import (
+	"errors"
+	"fmt"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/categoryRepository"
+	"golang/repository/courseRepository"
+	instructorrepository "golang/repository/instructorRepository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CoursePackageService interface {
+	ExportCourse(id string, user dto.User) (dto.CoursePackage, error)
+	ImportCourse(data []byte, input dto.CourseImport, user dto.User) (dto.CourseImport, error)
+}
+
+type coursePackageService struct {
+	courseRepo     courseRepository.CourseRepository
+	categoryRepo   categoryRepository.CategoryRepository
+	instructorRepo instructorrepository.InstructorRepository
+}
+
+// ExportCourse implements CoursePackageService
+func (cps *coursePackageService) ExportCourse(id string, user dto.User) (dto.CoursePackage, error) {
+	course, err := cps.courseRepo.GetCourseByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return dto.CoursePackage{}, errors.New(constantError.ErrorCourseNotFound)
+		}
+		return dto.CoursePackage{}, err
+	}
+
+	// check if instructor id in the course is the same as the instructor id in the token
+	if course.InstructorID != user.ID {
+		return dto.CoursePackage{}, errors.New(constantError.ErrorNotAuthorized)
+	}
+
+	coursePackage, err := cps.courseRepo.GetCoursePackage(id)
+	if err != nil {
+		return dto.CoursePackage{}, err
+	}
+	coursePackage.ExportedAt = time.Now()
+	return coursePackage, nil
+}
+
+// ImportCourse implements CoursePackageService
+func (cps *coursePackageService) ImportCourse(data []byte, input dto.CourseImport, user dto.User) (dto.CourseImport, error) {
+	instructor, err := cps.instructorRepo.GetInstructorByID(user.ID)
+	if err != nil {
+		return dto.CourseImport{}, err
+	}
+	err = helper.CheckCourseInstructor(instructor)
+	if err != nil {
+		return dto.CourseImport{}, err
+	}
+
+	coursePackage, err := helper.DecodeCoursePackage(data)
+	if err != nil {
+		return dto.CourseImport{}, err
+	}
+
+	// the name and category given with the import replace the ones of the package to solve the conflicts
+	if input.Name != "" {
+		coursePackage.Course.Name = input.Name
+	}
+	input.Name = coursePackage.Course.Name
+	input.Conflicts = helper.ValidateCoursePackage(coursePackage)
+
+	if input.CategoryID != "" {
+		_, err = cps.categoryRepo.GetCategoryByID(input.CategoryID, user)
+		if err == gorm.ErrRecordNotFound {
+			input.Conflicts = append(input.Conflicts, fmt.Sprintf("category %s not found", input.CategoryID))
+		} else if err != nil {
+			return dto.CourseImport{}, err
+		}
+	} else if coursePackage.Course.Category == "" {
+		input.Conflicts = append(input.Conflicts, "package has no category")
+	} else {
+		category, err := cps.categoryRepo.GetCategoryByName(coursePackage.Course.Category)
+		if err == gorm.ErrRecordNotFound {
+			input.Conflicts = append(input.Conflicts, fmt.Sprintf("category %q not found", coursePackage.Course.Category))
+		} else if err != nil {
+			return dto.CourseImport{}, err
+		}
+		input.CategoryID = category.ID
+	}
+
+	if input.Name != "" {
+		used, err := cps.courseRepo.IsCourseNameUsed(input.Name)
+		if err != nil {
+			return dto.CourseImport{}, err
+		}
+		if used {
+			input.Conflicts = append(input.Conflicts, fmt.Sprintf("course name %q already used", input.Name))
+		}
+	}
+
+	if len(input.Conflicts) > 0 {
+		return input, errors.New(constantError.ErrorCoursePackageConflict)
+	}
+
+	input.ID = helper.GenerateUUID()
+	course := dto.CourseTransaction{
+		ID:           input.ID,
+		Name:         input.Name,
+		Description:  coursePackage.Course.Description,
+		Objective:    coursePackage.Course.Objective,
+		Price:        coursePackage.Course.Price,
+		Discount:     coursePackage.Course.Discount,
+		Thumbnail:    coursePackage.Course.Thumbnail,
+		Capacity:     coursePackage.Course.Capacity,
+		InstructorID: user.ID,
+		CategoryID:   input.CategoryID,
+	}
+	// default thumbnail course
+	if course.Thumbnail == "" {
+		course.Thumbnail = "https://via.placeholder.com/150x100"
+	}
+
+	// call repository to create the course with its content
+	err = cps.courseRepo.ImportCoursePackage(course, coursePackage)
+	if err != nil {
+		return dto.CourseImport{}, err
+	}
+	return input, nil
+}
+
+func NewCoursePackageService(courseRepo courseRepository.CourseRepository, categoryRepo categoryRepository.CategoryRepository, instructorRepo instructorrepository.InstructorRepository) CoursePackageService {
+	return &coursePackageService{
+		courseRepo:     courseRepo,
+		categoryRepo:   categoryRepo,
+		instructorRepo: instructorRepo,
+	}
+}
@@ -0,0 +1,317 @@
+package coursePackageService
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/categoryRepository/categoryMockRepository"
+	"golang/repository/courseRepository/courseMockRepository"
+	instructormockrepository "golang/repository/instructorRepository/instructorMockRepository"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteCoursePackage struct {
+	suite.Suite
+	coursePackageService CoursePackageService
+	mockCourse           *courseMockRepository.CourseMock
+	mockCategory         *categoryMockRepository.CategoryMock
+	mockInstructor       *instructormockrepository.InstructorMock
+}
+
+func (s *suiteCoursePackage) SetupTest() {
+	s.mockCourse = &courseMockRepository.CourseMock{}
+	s.mockCategory = &categoryMockRepository.CategoryMock{}
+	s.mockInstructor = &instructormockrepository.InstructorMock{}
+	s.mockInstructor.On("GetInstructorByID", mock.Anything).Return(dto.InstructorResponseGet{IsActive: true, Status: dto.InstructorStatusApproved}, nil)
+	s.coursePackageService = NewCoursePackageService(s.mockCourse, s.mockCategory, s.mockInstructor)
+}
+
+func newCoursePackage() dto.CoursePackage {
+	return dto.CoursePackage{
+		FormatVersion: dto.CoursePackageVersion,
+		Course: dto.CoursePackageCourse{
+			Name:        "golang",
+			Description: "learn golang",
+			Capacity:    10,
+			Category:    "programming",
+		},
+		Modules: []dto.CoursePackageModule{
+			{Name: "intro", Content: "<p>hello</p>", NoModule: 1, Media: []string{"https://video/1"}, Assignment: &dto.CoursePackageAssignment{Title: "task", Description: "do it"}},
+			{Name: "basic", Content: "<p>basic</p>", NoModule: 2, Media: []string{}},
+		},
+		QuestionBanks: []dto.CoursePackageBank{
+			{Title: "basic", Questions: []dto.CoursePackageQuizQuestion{
+				{Type: dto.QuizTrueFalse, Text: "go has classes", Points: 1, Topic: "types", Difficulty: dto.QuizEasy, Answer: "false"},
+				{Type: dto.QuizNumeric, Text: "len of go", Points: 1, Topic: "strings", Difficulty: dto.QuizMedium, Answer: "2"},
+			}},
+		},
+		Quizzes: []dto.CoursePackageQuiz{
+			{Link: "https://quiz"},
+			{Title: "final", Module: 2, PassingScore: 70, Questions: []dto.CoursePackageQuizQuestion{
+				{Type: dto.QuizTrueFalse, Text: "go is typed", Points: 1, Answer: "true"},
+				{Type: dto.QuizSingleChoice, Text: "keyword", Points: 2, Options: []dto.CoursePackageQuizOption{{Text: "func", IsCorrect: true}, {Text: "def"}}},
+			}, ShuffleQuestions: true, Pools: []dto.CoursePackageQuizPool{{Bank: 1, Difficulty: dto.QuizEasy, Count: 1}}},
+		},
+	}
+}
+
+func (s *suiteCoursePackage) TestExportCourse() {
+	testCase := []struct {
+		Name                  string
+		MockReturnCourse      dto.Course
+		MockReturnCourseError error
+		HasReturnError        bool
+		ExpectedError         error
+	}{
+		{
+			"success export course",
+			dto.Course{ID: "abcde", InstructorID: "abcde"},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail export course not found",
+			dto.Course{},
+			gorm.ErrRecordNotFound,
+			true,
+			errors.New(constantError.ErrorCourseNotFound),
+		},
+		{
+			"fail export course of other instructor",
+			dto.Course{ID: "abcde", InstructorID: "other"},
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGetCourse := s.mockCourse.On("GetCourseByID", "abcde").Return(v.MockReturnCourse, v.MockReturnCourseError)
+		mockCallPackage := s.mockCourse.On("GetCoursePackage", "abcde").Return(newCoursePackage(), nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			coursePackage, err := s.coursePackageService.ExportCourse("abcde", dto.User{ID: "abcde", Role: "instructor"})
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal("golang", coursePackage.Course.Name)
+				s.False(coursePackage.ExportedAt.IsZero())
+			}
+		})
+		// remove mock
+		mockCallGetCourse.Unset()
+		mockCallPackage.Unset()
+	}
+}
+
+func (s *suiteCoursePackage) TestImportCourse() {
+	instructor := dto.User{ID: "abcde", Role: "instructor"}
+	jsonPackage, err := helper.EncodeCoursePackage(newCoursePackage())
+	s.NoError(err)
+	zipPackage, err := helper.EncodeCoursePackageZip(newCoursePackage())
+	s.NoError(err)
+	newerPackage := newCoursePackage()
+	newerPackage.FormatVersion = dto.CoursePackageVersion + 1
+	newerData, err := helper.EncodeCoursePackage(newerPackage)
+	s.NoError(err)
+	invalidQuizPackage := newCoursePackage()
+	invalidQuizPackage.Quizzes = append(invalidQuizPackage.Quizzes, dto.CoursePackageQuiz{Module: 3, Questions: []dto.CoursePackageQuizQuestion{
+		{Type: dto.QuizSingleChoice, Text: "pick", Options: []dto.CoursePackageQuizOption{{Text: "a"}}},
+	}})
+	invalidQuizData, err := helper.EncodeCoursePackage(invalidQuizPackage)
+	s.NoError(err)
+	invalidBankPackage := newCoursePackage()
+	invalidBankPackage.QuestionBanks[0].Questions[0].Difficulty = "expert"
+	invalidBankPackage.Quizzes[1].Pools = append(invalidBankPackage.Quizzes[1].Pools, dto.CoursePackageQuizPool{Bank: 2})
+	invalidBankData, err := helper.EncodeCoursePackage(invalidBankPackage)
+	s.NoError(err)
+
+	testCase := []struct {
+		Name                  string
+		Data                  []byte
+		Input                 dto.CourseImport
+		MockReturnCategoryErr error
+		MockReturnNameUsed    bool
+		HasReturnError        bool
+		ExpectedConflicts     []string
+		ExpectedError         error
+	}{
+		{
+			"success import json package",
+			jsonPackage,
+			dto.CourseImport{},
+			nil,
+			false,
+			false,
+			nil,
+			nil,
+		},
+		{
+			"success import zip package with new name",
+			zipPackage,
+			dto.CourseImport{Name: "golang 2"},
+			nil,
+			false,
+			false,
+			nil,
+			nil,
+		},
+		{
+			"fail import package with used name and missing category",
+			jsonPackage,
+			dto.CourseImport{},
+			gorm.ErrRecordNotFound,
+			true,
+			true,
+			[]string{`category "programming" not found`, `course name "golang" already used`},
+			errors.New(constantError.ErrorCoursePackageConflict),
+		},
+		{
+			"fail import package of newer version",
+			newerData,
+			dto.CourseImport{},
+			nil,
+			false,
+			true,
+			[]string{"package format version 2 is not supported, the latest version is 1"},
+			errors.New(constantError.ErrorCoursePackageConflict),
+		},
+		{
+			"fail import package with invalid quiz",
+			invalidQuizData,
+			dto.CourseImport{},
+			nil,
+			false,
+			true,
+			[]string{"quiz 3 has no title or link", "quiz 3 is in module 3 that is not in the package", "question 1 of quiz 3 is invalid"},
+			errors.New(constantError.ErrorCoursePackageConflict),
+		},
+		{
+			"fail import package with invalid question bank",
+			invalidBankData,
+			dto.CourseImport{},
+			nil,
+			false,
+			true,
+			[]string{"question 1 of question bank 1 is invalid", "pool 2 of quiz 2 draws from question bank 2 that is not in the package", "pool 2 of quiz 2 is invalid"},
+			errors.New(constantError.ErrorCoursePackageConflict),
+		},
+		{
+			"fail import invalid package",
+			[]byte("not a package"),
+			dto.CourseImport{},
+			nil,
+			false,
+			true,
+			nil,
+			errors.New(constantError.ErrorCoursePackageInvalid),
+		},
+	}
+	for _, v := range testCase {
+		mockCallCategory := s.mockCategory.On("GetCategoryByName", "programming").Return(dto.CategoryTransaction{ID: "category", Name: "programming"}, v.MockReturnCategoryErr)
+		mockCallName := s.mockCourse.On("IsCourseNameUsed", mock.Anything).Return(v.MockReturnNameUsed, nil)
+		mockCallImport := s.mockCourse.On("ImportCoursePackage", mock.Anything, mock.Anything).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			course, err := s.coursePackageService.ImportCourse(v.Data, v.Input, instructor)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+				s.Equal(v.ExpectedConflicts, course.Conflicts)
+				s.mockCourse.AssertNotCalled(t, "ImportCoursePackage", mock.Anything, mock.Anything)
+			} else {
+				s.NoError(err)
+				s.NotEmpty(course.ID)
+				s.Equal("category", course.CategoryID)
+				expected := newCoursePackage()
+				if v.Input.Name != "" {
+					expected.Course.Name = v.Input.Name
+				}
+				s.Equal(expected.Course.Name, course.Name)
+				s.mockCourse.AssertCalled(t, "ImportCoursePackage", mock.MatchedBy(func(course dto.CourseTransaction) bool {
+					return course.Name == expected.Course.Name && course.InstructorID == instructor.ID && course.CategoryID == "category"
+				}), expected)
+			}
+		})
+		// remove mock
+		mockCallCategory.Unset()
+		mockCallName.Unset()
+		mockCallImport.Unset()
+		s.mockCourse.Calls = nil
+	}
+}
+
+func (s *suiteCoursePackage) TestImportCommonCartridge() {
+	// a cartridge made by another LMS has no json of the course
+	zipPackage, err := helper.EncodeCoursePackageZip(newCoursePackage())
+	s.NoError(err)
+	reader, err := zip.NewReader(bytes.NewReader(zipPackage), int64(len(zipPackage)))
+	s.NoError(err)
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	for _, file := range reader.File {
+		if file.Name == "course.json" {
+			continue
+		}
+		src, err := file.Open()
+		s.NoError(err)
+		dst, err := writer.Create(file.Name)
+		s.NoError(err)
+		_, err = io.Copy(dst, src)
+		s.NoError(err)
+	}
+	s.NoError(writer.Close())
+
+	coursePackage, err := helper.DecodeCoursePackage(buffer.Bytes())
+	s.NoError(err)
+	s.Equal("golang", coursePackage.Course.Name)
+	s.Equal("learn golang", coursePackage.Course.Description)
+	s.Len(coursePackage.Modules, 2)
+	s.Equal("intro", coursePackage.Modules[0].Name)
+	s.Equal("<p>hello</p>", coursePackage.Modules[0].Content)
+	s.Equal([]string{"https://video/1"}, coursePackage.Modules[0].Media)
+	s.Equal(2, coursePackage.Modules[1].NoModule)
+
+	// the cartridge has no category, the category is given with the import
+	mockCallCategory := s.mockCategory.On("GetCategoryByID", "category", dto.User{ID: "abcde", Role: "instructor"}).Return(dto.Category{ID: "category"}, nil)
+	mockCallName := s.mockCourse.On("IsCourseNameUsed", "golang").Return(false, nil)
+	mockCallImport := s.mockCourse.On("ImportCoursePackage", mock.Anything, coursePackage).Return(nil)
+	course, err := s.coursePackageService.ImportCourse(buffer.Bytes(), dto.CourseImport{CategoryID: "category"}, dto.User{ID: "abcde", Role: "instructor"})
+	s.NoError(err)
+	s.Equal("category", course.CategoryID)
+	mockCallCategory.Unset()
+	mockCallName.Unset()
+	mockCallImport.Unset()
+}
+
+func (s *suiteCoursePackage) TestImportNestedZip() {
+	// the json of the course is a zip package again, it is never read as a package
+	zipPackage, err := helper.EncodeCoursePackageZip(newCoursePackage())
+	s.NoError(err)
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+	dst, err := writer.Create("course.json")
+	s.NoError(err)
+	_, err = dst.Write(zipPackage)
+	s.NoError(err)
+	s.NoError(writer.Close())
+
+	_, err = helper.DecodeCoursePackage(buffer.Bytes())
+	s.EqualError(err, constantError.ErrorCoursePackageInvalid)
+
+	_, err = s.coursePackageService.ImportCourse(buffer.Bytes(), dto.CourseImport{CategoryID: "category"}, dto.User{ID: "abcde", Role: "instructor"})
+	s.EqualError(err, constantError.ErrorCoursePackageInvalid)
+	s.mockCourse.AssertNotCalled(s.T(), "ImportCoursePackage", mock.Anything, mock.Anything)
+}
+
+func TestSuiteCoursePackage(t *testing.T) {
+	suite.Run(t, new(suiteCoursePackage))
+}
@@ -0,0 +1,22 @@
+package coursePackageMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CoursePackageMock struct {
+	mock.Mock
+}
+
+func (c *CoursePackageMock) ExportCourse(id string, user dto.User) (dto.CoursePackage, error) {
+	args := c.Called(id, user)
+
+	return args.Get(0).(dto.CoursePackage), args.Error(1)
+}
+func (c *CoursePackageMock) ImportCourse(data []byte, input dto.CourseImport, user dto.User) (dto.CourseImport, error) {
+	args := c.Called(data, input, user)
+
+	return args.Get(0).(dto.CourseImport), args.Error(1)
+}
@@ -3,10 +3,15 @@ package courseService
 import (
 	"errors"
 	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	"golang/repository/categoryRepository"
 	"golang/repository/courseRepository"
+	"golang/repository/courseVersionRepository"
+	instructorrepository "golang/repository/instructorRepository"
+	"golang/service/searchService"
+	"log"
 
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
@@ -16,20 +21,54 @@ type CourseService interface {
 	CreateCourse(dto.CourseTransaction, dto.User) error
 	DeleteCourse(id, instructorId string) error
 	GetAllCourse(dto.User) ([]dto.GetCourse, error)
+	GetCourseCatalog(user dto.User, query dto.CourseCatalogQuery) (dto.CourseCatalog, error)
 	GetCourseByID(id string, user dto.User) (dto.GetCourseByID, error)
 	GetCourseEnrollByID(id string, user dto.User) ([]dto.CustomerCourseEnroll, error)
 	UpdateCourse(dto.CourseTransaction) error
+	UpdateCourseStatus(id string, input dto.CourseStatus, user dto.User) error
+	DuplicateCourse(id string, input dto.CourseDuplicate, user dto.User) (dto.CourseDuplicate, error)
+	GetCourseTemplates(user dto.User) ([]dto.GetCourse, error)
+	AddCoursePrerequisite(courseID string, input dto.CoursePrerequisiteTransaction, user dto.User) error
+	DeleteCoursePrerequisite(courseID, prerequisiteID string, user dto.User) error
+	GetCoursePrerequisites(courseID string, user dto.User) ([]dto.CoursePrerequisite, error)
+}
+
+// courseTransitions is the lifecycle of a course, for every status the next status and the roles that can make the change
+var courseTransitions = map[string]map[string][]string{
+	dto.CourseStatusDraft: {
+		dto.CourseStatusInReview: {constantRole.RoleInstructor},
+	},
+	dto.CourseStatusInReview: {
+		dto.CourseStatusDraft:     {constantRole.RoleInstructor, constantRole.RoleAdmin},
+		dto.CourseStatusPublished: {constantRole.RoleAdmin},
+	},
+	dto.CourseStatusPublished: {
+		dto.CourseStatusArchived: {constantRole.RoleInstructor, constantRole.RoleAdmin},
+	},
+	// an instructor sends an archived course to review again, only an admin publishes it again directly
+	dto.CourseStatusArchived: {
+		dto.CourseStatusInReview:  {constantRole.RoleInstructor},
+		dto.CourseStatusPublished: {constantRole.RoleAdmin},
+	},
 }
 
 type courseService struct {
-	courseRepo   courseRepository.CourseRepository
-	categoryRepo categoryRepository.CategoryRepository
+	courseRepo        courseRepository.CourseRepository
+	categoryRepo      categoryRepository.CategoryRepository
+	instructorRepo    instructorrepository.InstructorRepository
+	courseVersionRepo courseVersionRepository.CourseVersionRepository
+	searchService     searchService.SearchService
 }
 
 // CreateCourse implements CourseService
 func (cs *courseService) CreateCourse(course dto.CourseTransaction, user dto.User) error {
+	err := cs.checkInstructor(user)
+	if err != nil {
+		return err
+	}
+
 	// check if category is not found
-	_, err := cs.categoryRepo.GetCategoryByID(course.CategoryID, user)
+	_, err = cs.categoryRepo.GetCategoryByID(course.CategoryID, user)
 	if err != nil {
 		return errors.New(constantError.ErrorCategoryNotFound)
 	}
@@ -55,6 +94,7 @@ func (cs *courseService) CreateCourse(course dto.CourseTransaction, user dto.Use
 	if err != nil {
 		return err
 	}
+	cs.indexCourse(course.ID)
 	return nil
 }
 
@@ -75,6 +115,11 @@ func (cs *courseService) DeleteCourse(id string, instructorId string) error {
 	if err != nil {
 		return err
 	}
+
+	err = cs.searchService.RemoveCourse(id)
+	if err != nil {
+		log.Printf("fail remove course %s from search index: %s", id, err)
+	}
 	return nil
 }
 
@@ -90,19 +135,10 @@ func (cs *courseService) GetAllCourse(user dto.User) ([]dto.GetCourse, error) {
 	}
 
 	for i, course := range courses {
-		// get rating of all courses
-		rating := helper.GetRatingCourse(course)
-		courses[i].Rating = rating
-
-		// get number of module
-		numberOfModule := len(course.Modules)
-		courses[i].NumberOfModules = numberOfModule
-
-		// get sum of customer course
-		sumCustomer := len(course.CustomerCourses)
-		courses[i].AmountCustomer = sumCustomer
+		// get rating, number of module and sum of customer of all courses
+		helper.GetCourseStats(&courses[i])
 
-		if user.Role == "customer" {
+		if user.Role == constantRole.RoleCustomer {
 			// get favorite of all courses
 			favorite := helper.GetFavoriteCourse(course, user.ID)
 			courses[i].Favorite = favorite
@@ -127,6 +163,76 @@ func (cs *courseService) GetAllCourse(user dto.User) ([]dto.GetCourse, error) {
 	return getCourses, nil
 }
 
+// GetCourseCatalog implements CourseService
+func (cs *courseService) GetCourseCatalog(user dto.User, query dto.CourseCatalogQuery) (dto.CourseCatalog, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = dto.DefaultCatalogLimit
+	}
+	if query.Sort == "" {
+		query.Sort = dto.CatalogSortNewest
+	}
+
+	// the cursor is only valid for the sort it was made with
+	var after *dto.CatalogCursor
+	if query.Cursor != "" {
+		cursor, err := helper.DecodeCatalogCursor(query.Cursor)
+		if err != nil || cursor.Sort != query.Sort {
+			return dto.CourseCatalog{}, errors.New(constantError.ErrorInvalidCursor)
+		}
+		after = &cursor
+	}
+
+	courses, total, err := cs.courseRepo.GetCourseCatalog(user, query, after)
+	if err != nil {
+		return dto.CourseCatalog{}, err
+	}
+
+	pagination := dto.CatalogPagination{
+		Total: total,
+		Limit: query.Limit,
+	}
+	if after == nil {
+		pagination.Page = query.Page
+	}
+	// the repository returns one more course when there is a next page
+	if len(courses) > query.Limit {
+		courses = courses[:query.Limit]
+		last := courses[len(courses)-1]
+		cursor := dto.CatalogCursor{
+			Sort:      query.Sort,
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+		switch query.Sort {
+		case dto.CatalogSortPopular:
+			cursor.Value = float64(last.AmountCustomer)
+		case dto.CatalogSortTopRated:
+			cursor.Value = last.Rating
+		}
+		pagination.NextCursor, err = helper.EncodeCatalogCursor(cursor)
+		if err != nil {
+			return dto.CourseCatalog{}, err
+		}
+	}
+
+	for i := range courses {
+		courses[i].ProgressPercentage = helper.GetProgressCourse(&courses[i])
+	}
+	getCourses := []dto.GetCourse{}
+	err = copier.Copy(&getCourses, &courses)
+	if err != nil {
+		return dto.CourseCatalog{}, err
+	}
+
+	return dto.CourseCatalog{
+		Courses:    getCourses,
+		Pagination: pagination,
+	}, nil
+}
+
 // GetCourseByID implements CourseService
 func (cs *courseService) GetCourseByID(id string, user dto.User) (dto.GetCourseByID, error) {
 	course, err := cs.courseRepo.GetCourseByID(id)
@@ -134,36 +240,50 @@ func (cs *courseService) GetCourseByID(id string, user dto.User) (dto.GetCourseB
 		return dto.GetCourseByID{}, err
 	}
 
-	if user.Role == "instructor" {
+	if user.Role == constantRole.RoleInstructor {
 		// check if instructor id in the course is the same as the instructor id in the token
 		if course.InstructorID != user.ID {
 			return dto.GetCourseByID{}, errors.New(constantError.ErrorNotAuthorized)
 		}
 	}
-	// get rating of course
-	rating := helper.GetRatingCourse(course)
-	course.Rating = rating
-
-	// get number of module
-	numberOfModule := len(course.Modules)
-	course.NumberOfModules = numberOfModule
+	// get rating and number of module of course
+	helper.GetCourseStats(&course)
 
-	if user.Role == "customer" {
+	var finishedModules []string
+	if user.Role == constantRole.RoleCustomer {
 		// get favorites of course
 		favorite := helper.GetFavoriteCourse(course, user.ID)
 		course.Favorite = favorite
 
 		// get enrolled of course
 		helper.GetEnrolledCourse(&course, user.ID)
+
+		// customers only see published courses, the enrolled customers can still learn archived courses
+		if !helper.IsCourseVisible(course, user.ID) {
+			return dto.GetCourseByID{}, errors.New(constantError.ErrorCourseNotFound)
+		}
+
+		err = cs.applyCourseVersion(&course, user.ID)
+		if err != nil {
+			return dto.GetCourseByID{}, err
+		}
+
 		// get progress of all courses
 		course.ProgressPercentage = helper.GetProgressCourse(&course)
 
+		if course.StatusEnroll {
+			finishedModules, err = cs.courseRepo.GetFinishedModules(course.ID, user.ID)
+			if err != nil {
+				return dto.GetCourseByID{}, err
+			}
+		}
 	}
 	var getCourses dto.GetCourseByID
 	err = copier.Copy(&getCourses, &course)
 	if err != nil {
 		return dto.GetCourseByID{}, err
 	}
+	getCourses.Sections = helper.GetCourseSections(course.Sections, course.Modules, finishedModules)
 
 	return getCourses, nil
 }
@@ -209,12 +329,272 @@ func (cs *courseService) UpdateCourse(course dto.CourseTransaction) error {
 	if err != nil {
 		return err
 	}
+	cs.indexCourse(course.ID)
+	return nil
+}
+
+// UpdateCourseStatus implements CourseService
+func (cs *courseService) UpdateCourseStatus(id string, input dto.CourseStatus, user dto.User) error {
+	course, err := cs.courseRepo.GetCourseByID(id)
+	if err != nil {
+		return err
+	}
+
+	// check if instructor id in the course is the same as the instructor id in the token
+	if user.Role == constantRole.RoleInstructor && course.InstructorID != user.ID {
+		return errors.New(constantError.ErrorNotAuthorized)
+	}
+
+	// a template is only copied into new courses
+	if course.IsTemplate {
+		return errors.New(constantError.ErrorCourseTemplate)
+	}
+
+	// only an active and approved instructor changes the status of a course
+	if user.Role == constantRole.RoleInstructor {
+		err = cs.checkInstructor(user)
+		if err != nil {
+			return err
+		}
+	}
+
+	roles, ok := courseTransitions[course.Status][input.Status]
+	if !ok {
+		return errors.New(constantError.ErrorCourseStatus)
+	}
+	allowed := false
+	for _, role := range roles {
+		if role == user.Role {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return errors.New(constantError.ErrorNotAuthorized)
+	}
+
+	// a course needs content before it is reviewed and published
+	if input.Status == dto.CourseStatusInReview || input.Status == dto.CourseStatusPublished {
+		content, err := cs.courseRepo.GetCourseContent(id)
+		if err != nil {
+			return err
+		}
+		err = helper.CheckCourseContent(content)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the first version is made when the course is published for the first time
+	if input.Status == dto.CourseStatusPublished && course.Version == 0 {
+		_, err = cs.courseVersionRepo.PublishCourseVersion(id)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cs.courseRepo.UpdateCourseStatus(id, input.Status)
+	if err != nil {
+		return err
+	}
+	// only published courses are in the search index
+	cs.indexCourse(id)
+	return nil
+}
+
+// DuplicateCourse implements CourseService
+func (cs *courseService) DuplicateCourse(id string, input dto.CourseDuplicate, user dto.User) (dto.CourseDuplicate, error) {
+	err := cs.checkInstructor(user)
+	if err != nil {
+		return dto.CourseDuplicate{}, err
+	}
+
+	course, err := cs.courseRepo.GetCourseByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return dto.CourseDuplicate{}, errors.New(constantError.ErrorCourseNotFound)
+		}
+		return dto.CourseDuplicate{}, err
+	}
+
+	// check if instructor id in the course is the same as the instructor id in the token
+	if course.InstructorID != user.ID {
+		return dto.CourseDuplicate{}, errors.New(constantError.ErrorNotAuthorized)
+	}
+
+	// the copy stays in the category of the course when no other category is given
+	if input.CategoryID == "" {
+		input.CategoryID = course.CategoryID
+	} else {
+		_, err = cs.categoryRepo.GetCategoryByID(input.CategoryID, user)
+		if err != nil {
+			return dto.CourseDuplicate{}, errors.New(constantError.ErrorCategoryNotFound)
+		}
+	}
+
+	input.ID = helper.GenerateUUID()
+	input.InstructorID = user.ID
+
+	// call repository to copy the course
+	err = cs.courseRepo.DuplicateCourse(id, input)
+	if err != nil {
+		return dto.CourseDuplicate{}, err
+	}
+	return input, nil
+}
+
+// GetCourseTemplates implements CourseService
+func (cs *courseService) GetCourseTemplates(user dto.User) ([]dto.GetCourse, error) {
+	courses, err := cs.courseRepo.GetCourseTemplates(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range courses {
+		// get rating, number of module and sum of customer of all templates
+		helper.GetCourseStats(&courses[i])
+	}
+	getCourses := []dto.GetCourse{}
+	err = copier.Copy(&getCourses, &courses)
+	if err != nil {
+		return nil, err
+	}
+
+	return getCourses, nil
+}
+
+// AddCoursePrerequisite implements CourseService
+func (cs *courseService) AddCoursePrerequisite(courseID string, input dto.CoursePrerequisiteTransaction, user dto.User) error {
+	err := cs.checkCourseOwner(courseID, user)
+	if err != nil {
+		return err
+	}
+
+	// a course can't be its own prerequisite
+	if input.PrerequisiteID == courseID {
+		return errors.New(constantError.ErrorPrerequisiteCycle)
+	}
+
+	// a template is never taken so it can't be finished
+	prerequisite, err := cs.courseRepo.GetCourseByID(input.PrerequisiteID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.New(constantError.ErrorCourseNotFound)
+		}
+		return err
+	}
+	if prerequisite.IsTemplate {
+		return errors.New(constantError.ErrorCourseNotFound)
+	}
+
+	return cs.courseRepo.AddCoursePrerequisite(courseID, input.PrerequisiteID)
+}
+
+// DeleteCoursePrerequisite implements CourseService
+func (cs *courseService) DeleteCoursePrerequisite(courseID, prerequisiteID string, user dto.User) error {
+	err := cs.checkCourseOwner(courseID, user)
+	if err != nil {
+		return err
+	}
+	return cs.courseRepo.DeleteCoursePrerequisite(courseID, prerequisiteID)
+}
+
+// GetCoursePrerequisites implements CourseService, the customers see which prerequisites they finished
+func (cs *courseService) GetCoursePrerequisites(courseID string, user dto.User) ([]dto.CoursePrerequisite, error) {
+	var customerID string
+	if user.Role == constantRole.RoleCustomer {
+		customerID = user.ID
+	} else {
+		err := cs.checkCourseOwner(courseID, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	prerequisites, err := cs.courseRepo.GetCoursePrerequisites(courseID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(prerequisites) == 0 {
+		return []dto.CoursePrerequisite{}, nil
+	}
+	return prerequisites, nil
+}
+
+// checkInstructor checks the instructor can make courses
+func (cs *courseService) checkInstructor(user dto.User) error {
+	instructor, err := cs.instructorRepo.GetInstructorByID(user.ID)
+	if err != nil {
+		return err
+	}
+	return helper.CheckCourseInstructor(instructor)
+}
+
+// checkCourseOwner checks the course is a course of the instructor
+func (cs *courseService) checkCourseOwner(courseID string, user dto.User) error {
+	course, err := cs.courseRepo.GetCourseByID(courseID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.New(constantError.ErrorCourseNotFound)
+		}
+		return err
+	}
+	if course.InstructorID != user.ID {
+		return errors.New(constantError.ErrorNotAuthorized)
+	}
 	return nil
 }
 
-func NewCourseService(courseRepo courseRepository.CourseRepository, categoryRepo categoryRepository.CategoryRepository) CourseService {
+// applyCourseVersion replaces the modules of the course with the modules of the version the customer learns,
+// a customer not enrolled sees the latest version and a course without version keeps its modules
+func (cs *courseService) applyCourseVersion(course *dto.Course, customerID string) error {
+	var versionID string
+	for _, customerCourse := range course.CustomerCourses {
+		if customerCourse.CustomerID == customerID {
+			versionID = customerCourse.CourseVersionID
+		}
+	}
+
+	var version dto.CourseVersion
+	var err error
+	if versionID != "" {
+		version, err = cs.courseVersionRepo.GetCourseVersion(versionID)
+	} else {
+		version, err = cs.courseVersionRepo.GetLatestCourseVersion(course.ID)
+	}
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// the sections are not part of the version, the module stays in the section of the course
+	sectionIDs := map[string]string{}
+	for _, module := range course.Modules {
+		sectionIDs[module.ID] = module.SectionID
+	}
+	course.Modules = helper.GetVersionModules(version)
+	for i := range course.Modules {
+		course.Modules[i].SectionID = sectionIDs[course.Modules[i].ID]
+	}
+	course.NumberOfModules = len(course.Modules)
+	return nil
+}
+
+// indexCourse updates the course in the search index, the course is already saved so a failure is only logged
+func (cs *courseService) indexCourse(id string) {
+	err := cs.searchService.IndexCourse(id)
+	if err != nil {
+		log.Printf("fail index course %s: %s", id, err)
+	}
+}
+
+func NewCourseService(courseRepo courseRepository.CourseRepository, categoryRepo categoryRepository.CategoryRepository, instructorRepo instructorrepository.InstructorRepository, courseVersionRepo courseVersionRepository.CourseVersionRepository, searchService searchService.SearchService) CourseService {
 	return &courseService{
-		courseRepo:   courseRepo,
-		categoryRepo: categoryRepo,
+		courseRepo:        courseRepo,
+		categoryRepo:      categoryRepo,
+		instructorRepo:    instructorRepo,
+		courseVersionRepo: courseVersionRepo,
+		searchService:     searchService,
 	}
 }
@@ -25,6 +25,11 @@ func (c *CourseMock)GetAllCourse(user dto.User) ([]dto.GetCourse, error) {
 
 	return args.Get(0).([]dto.GetCourse), args.Error(1)
 }
+func (c *CourseMock)GetCourseCatalog(user dto.User, query dto.CourseCatalogQuery) (dto.CourseCatalog, error) {
+	args := c.Called(user, query)
+
+	return args.Get(0).(dto.CourseCatalog), args.Error(1)
+}
 func (c *CourseMock)GetCourseByID(id string, user dto.User) (dto.GetCourseByID, error) {
 	args := c.Called(id, user)
 
@@ -39,4 +44,34 @@ func (c *CourseMock)UpdateCourse(course dto.CourseTransaction) error {
 	args := c.Called(course)
 
 	return args.Error(0)
-}
\ No newline at end of file
+}
+func (c *CourseMock)UpdateCourseStatus(id string, input dto.CourseStatus, user dto.User) error {
+	args := c.Called(id, input, user)
+
+	return args.Error(0)
+}
+func (c *CourseMock) DuplicateCourse(id string, input dto.CourseDuplicate, user dto.User) (dto.CourseDuplicate, error) {
+	args := c.Called(id, input, user)
+
+	return args.Get(0).(dto.CourseDuplicate), args.Error(1)
+}
+func (c *CourseMock) GetCourseTemplates(user dto.User) ([]dto.GetCourse, error) {
+	args := c.Called(user)
+
+	return args.Get(0).([]dto.GetCourse), args.Error(1)
+}
+func (c *CourseMock) AddCoursePrerequisite(courseID string, input dto.CoursePrerequisiteTransaction, user dto.User) error {
+	args := c.Called(courseID, input, user)
+
+	return args.Error(0)
+}
+func (c *CourseMock) DeleteCoursePrerequisite(courseID, prerequisiteID string, user dto.User) error {
+	args := c.Called(courseID, prerequisiteID, user)
+
+	return args.Error(0)
+}
+func (c *CourseMock) GetCoursePrerequisites(courseID string, user dto.User) ([]dto.CoursePrerequisite, error) {
+	args := c.Called(courseID, user)
+
+	return args.Get(0).([]dto.CoursePrerequisite), args.Error(1)
+}
@@ -3,9 +3,13 @@ package courseService
 import (
 	"errors"
 	"golang/constant/constantError"
+	"golang/helper"
 	"golang/models/dto"
 	"golang/repository/categoryRepository/categoryMockRepository"
 	"golang/repository/courseRepository/courseMockRepository"
+	"golang/repository/courseVersionRepository/courseVersionMockRepository"
+	instructormockrepository "golang/repository/instructorRepository/instructorMockRepository"
+	"golang/service/searchService/searchMockService"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -18,12 +22,24 @@ type suiteCourse struct {
 	courseService   CourseService
 	mockCourse      *courseMockRepository.CourseMock
 	mockCategory    *categoryMockRepository.CategoryMock
+	mockInstructor  *instructormockrepository.InstructorMock
+	mockSearch      *searchMockService.SearchMock
+	mockVersion     *courseVersionMockRepository.CourseVersionMock
 }
 
 func (s *suiteCourse) SetupTest() {
 	s.mockCourse = &courseMockRepository.CourseMock{}
+	s.mockCourse.On("GetFinishedModules", mock.Anything, mock.Anything).Return([]string{}, nil)
 	s.mockCategory = &categoryMockRepository.CategoryMock{}
-	NewCourseService := NewCourseService(s.mockCourse, s.mockCategory)
+	s.mockInstructor = &instructormockrepository.InstructorMock{}
+	s.mockInstructor.On("GetInstructorByID", mock.Anything).Return(dto.InstructorResponseGet{IsActive: true, Status: dto.InstructorStatusApproved}, nil)
+	s.mockSearch = &searchMockService.SearchMock{}
+	s.mockSearch.On("IndexCourse", mock.Anything).Return(nil)
+	s.mockSearch.On("RemoveCourse", mock.Anything).Return(nil)
+	s.mockVersion = &courseVersionMockRepository.CourseVersionMock{}
+	s.mockVersion.On("GetLatestCourseVersion", mock.Anything).Return(dto.CourseVersion{}, gorm.ErrRecordNotFound)
+	s.mockVersion.On("PublishCourseVersion", mock.Anything).Return(dto.CourseVersion{}, nil)
+	NewCourseService := NewCourseService(s.mockCourse, s.mockCategory, s.mockInstructor, s.mockVersion, s.mockSearch)
 	s.courseService = NewCourseService
 }
 
@@ -133,6 +149,51 @@ func (s *suiteCourse) TestCreateCourse() {
 	}
 }
 
+func (s *suiteCourse) TestCreateCourseInstructorStatus() {
+	testCase := []struct {
+		Name                      string
+		MockReturnInstructor      dto.InstructorResponseGet
+		MockReturnInstructorError error
+		ExpectedError             error
+	}{
+		{
+			"fail instructor not found",
+			dto.InstructorResponseGet{},
+			gorm.ErrRecordNotFound,
+			gorm.ErrRecordNotFound,
+		},
+		{
+			"fail instructor not verified",
+			dto.InstructorResponseGet{IsActive: false, Status: dto.InstructorStatusApproved},
+			nil,
+			errors.New(constantError.ErrorNoActive),
+		},
+		{
+			"fail instructor pending",
+			dto.InstructorResponseGet{IsActive: true, Status: dto.InstructorStatusPending},
+			nil,
+			errors.New(constantError.ErrorInstructorNotApproved),
+		},
+		{
+			"fail instructor suspended",
+			dto.InstructorResponseGet{IsActive: true, Status: dto.InstructorStatusSuspended},
+			nil,
+			errors.New(constantError.ErrorInstructorSuspended),
+		},
+	}
+	s.mockInstructor.ExpectedCalls = nil
+	for _, v := range testCase {
+		mockCallInstructor := s.mockInstructor.On("GetInstructorByID", "abcde").Return(v.MockReturnInstructor, v.MockReturnInstructorError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.courseService.CreateCourse(dto.CourseTransaction{Name: "test", CategoryID: "abcde"}, dto.User{ID: "abcde", Role: "instructor"})
+			s.Equal(v.ExpectedError, err)
+			s.mockCourse.AssertNotCalled(t, "CreateCourse", mock.Anything)
+		})
+		// remove mock
+		mockCallInstructor.Unset()
+	}
+}
+
 func (s *suiteCourse) TestDeleteCourse() {
 	testCase := []struct {
 		Name                     string
@@ -312,6 +373,7 @@ func (s *suiteCourse) TestGetAllCourse() {
 							CourseID: "abcde",
 						},
 					},
+					Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 				},
 			},
 			nil,
@@ -382,6 +444,110 @@ func (s *suiteCourse) TestGetAllCourse() {
 	}
 }
 
+func (s *suiteCourse) TestGetCourseCatalog() {
+	user := dto.User{ID: "abcde", Role: "customer"}
+	courses := []dto.Course{
+		{ID: "course3", Name: "course3", Rating: 5, NumberOfModules: 4, ProgressModule: 3},
+		{ID: "course2", Name: "course2", Rating: 4.5},
+		{ID: "course1", Name: "course1", Rating: 4},
+	}
+	cursor, _ := helper.EncodeCatalogCursor(dto.CatalogCursor{Sort: dto.CatalogSortTopRated, Value: 4.5, ID: "course2"})
+	newestCursor, _ := helper.EncodeCatalogCursor(dto.CatalogCursor{Sort: dto.CatalogSortNewest, ID: "course2"})
+	testCase := []struct {
+		Name               string
+		Query              dto.CourseCatalogQuery
+		ExpectedRepoQuery  dto.CourseCatalogQuery
+		ExpectedAfter      *dto.CatalogCursor
+		MockReturnBody     []dto.Course
+		MockReturnError    error
+		ExpectedCourses    int
+		ExpectedPagination dto.CatalogPagination
+		ExpectedError      error
+	}{
+		{
+			"success get first page with next page",
+			dto.CourseCatalogQuery{Limit: 2, Sort: dto.CatalogSortTopRated},
+			dto.CourseCatalogQuery{Page: 1, Limit: 2, Sort: dto.CatalogSortTopRated},
+			nil,
+			courses,
+			nil,
+			2,
+			dto.CatalogPagination{Total: 3, Page: 1, Limit: 2, NextCursor: cursor},
+			nil,
+		},
+		{
+			"success get last page",
+			dto.CourseCatalogQuery{Page: 2, Limit: 2, Sort: dto.CatalogSortTopRated},
+			dto.CourseCatalogQuery{Page: 2, Limit: 2, Sort: dto.CatalogSortTopRated},
+			nil,
+			courses[2:],
+			nil,
+			1,
+			dto.CatalogPagination{Total: 3, Page: 2, Limit: 2},
+			nil,
+		},
+		{
+			"success get page after cursor",
+			dto.CourseCatalogQuery{Limit: 2, Cursor: cursor, Sort: dto.CatalogSortTopRated},
+			dto.CourseCatalogQuery{Page: 1, Limit: 2, Cursor: cursor, Sort: dto.CatalogSortTopRated},
+			&dto.CatalogCursor{Sort: dto.CatalogSortTopRated, Value: 4.5, ID: "course2"},
+			courses[2:],
+			nil,
+			1,
+			dto.CatalogPagination{Total: 3, Limit: 2},
+			nil,
+		},
+		{
+			"fail cursor can't be read",
+			dto.CourseCatalogQuery{Cursor: "not a cursor"},
+			dto.CourseCatalogQuery{},
+			nil,
+			nil,
+			nil,
+			0,
+			dto.CatalogPagination{},
+			errors.New(constantError.ErrorInvalidCursor),
+		},
+		{
+			"fail cursor of another sort",
+			dto.CourseCatalogQuery{Cursor: newestCursor, Sort: dto.CatalogSortTopRated},
+			dto.CourseCatalogQuery{},
+			nil,
+			nil,
+			nil,
+			0,
+			dto.CatalogPagination{},
+			errors.New(constantError.ErrorInvalidCursor),
+		},
+		{
+			"fail get catalog",
+			dto.CourseCatalogQuery{},
+			dto.CourseCatalogQuery{Page: 1, Limit: dto.DefaultCatalogLimit, Sort: dto.CatalogSortNewest},
+			nil,
+			[]dto.Course{},
+			errors.New("error"),
+			0,
+			dto.CatalogPagination{},
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mockCourse.On("GetCourseCatalog", user, v.ExpectedRepoQuery, v.ExpectedAfter).Return(v.MockReturnBody, int64(3), v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			catalog, err := s.courseService.GetCourseCatalog(user, v.Query)
+			s.Equal(v.ExpectedError, err)
+			s.Len(catalog.Courses, v.ExpectedCourses)
+			s.Equal(v.ExpectedPagination, catalog.Pagination)
+			if v.ExpectedCourses > 0 && v.Query.Page == 0 && v.Query.Cursor == "" {
+				// progress is computed from the aggregates of the repository
+				s.Equal(float64(50), catalog.Courses[0].ProgressPercentage)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
 func (s *suiteCourse) TestGetCourseByID() {
 	testCase := []struct {
 		Name            string
@@ -402,6 +568,7 @@ func (s *suiteCourse) TestGetCourseByID() {
 			},
 			dto.Course{
 				ID:                 "abcde",
+				Status:             dto.CourseStatusPublished,
 				Name:               "test",
 				Description:        "test",
 				Objective:          "test",
@@ -447,11 +614,13 @@ func (s *suiteCourse) TestGetCourseByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			true,
 			dto.GetCourseByID{
 				ID:                 "abcde",
+				Status:             dto.CourseStatusPublished,
 				Name:               "test",
 				Description:        "test",
 				Objective:          "test",
@@ -483,6 +652,7 @@ func (s *suiteCourse) TestGetCourseByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 		},
@@ -495,6 +665,7 @@ func (s *suiteCourse) TestGetCourseByID() {
 			},
 			dto.Course{
 				ID:                 "abcde",
+				Status:             dto.CourseStatusPublished,
 				Name:               "test",
 				Description:        "test",
 				Objective:          "test",
@@ -540,11 +711,13 @@ func (s *suiteCourse) TestGetCourseByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			true,
 			dto.GetCourseByID{
 				ID:                 "abcde",
+				Status:             dto.CourseStatusPublished,
 				Name:               "test",
 				Description:        "test",
 				Objective:          "test",
@@ -576,6 +749,7 @@ func (s *suiteCourse) TestGetCourseByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 		},
@@ -588,6 +762,7 @@ func (s *suiteCourse) TestGetCourseByID() {
 			},
 			dto.Course{
 				ID:                 "abcde",
+				Status:             dto.CourseStatusPublished,
 				Name:               "test",
 				Description:        "test",
 				Objective:          "test",
@@ -633,6 +808,7 @@ func (s *suiteCourse) TestGetCourseByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			false,
@@ -652,6 +828,67 @@ func (s *suiteCourse) TestGetCourseByID() {
 			dto.GetCourseByID{},
 			gorm.ErrRecordNotFound,
 		},
+		{
+			"fail get draft course by customer",
+			"abcde",
+			dto.User{
+				ID:   "abcde",
+				Role: "customer",
+			},
+			dto.Course{
+				ID:     "abcde",
+				Status: dto.CourseStatusDraft,
+			},
+			nil,
+			false,
+			dto.GetCourseByID{},
+			errors.New(constantError.ErrorCourseNotFound),
+		},
+		{
+			"success get archived course by enrolled customer",
+			"abcde",
+			dto.User{
+				ID:   "abcde",
+				Role: "customer",
+			},
+			dto.Course{
+				ID:     "abcde",
+				Status: dto.CourseStatusArchived,
+				CustomerCourses: []dto.CustomerCourse{
+					{
+						CustomerID: "abcde",
+						CourseID:   "abcde",
+						Status:     true,
+						NoModule:   1,
+					},
+				},
+			},
+			nil,
+			true,
+			dto.GetCourseByID{
+				ID:             "abcde",
+				Status:         dto.CourseStatusArchived,
+				StatusEnroll:   true,
+				ProgressModule: 1,
+			},
+			nil,
+		},
+		{
+			"fail get archived course by customer not enrolled",
+			"abcde",
+			dto.User{
+				ID:   "abcde",
+				Role: "customer",
+			},
+			dto.Course{
+				ID:     "abcde",
+				Status: dto.CourseStatusArchived,
+			},
+			nil,
+			false,
+			dto.GetCourseByID{},
+			errors.New(constantError.ErrorCourseNotFound),
+		},
 	}
 	for _, v := range testCase {
 		mockCall := s.mockCourse.On("GetCourseByID", v.ParamID).Return(v.MockReturnBody, v.MockReturnError)
@@ -737,6 +974,7 @@ func (s *suiteCourse) TestGetCourseEnrollByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			[]dto.CustomerCourseEnroll{
@@ -859,6 +1097,7 @@ func (s *suiteCourse) TestGetCourseEnrollByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			[]dto.CustomerCourseEnroll{},
@@ -921,6 +1160,7 @@ func (s *suiteCourse) TestGetCourseEnrollByID() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			[]dto.CustomerCourseEnroll{},
@@ -1022,6 +1262,7 @@ func (s *suiteCourse) TestUpdateCourse() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			nil,
@@ -1107,6 +1348,7 @@ func (s *suiteCourse) TestUpdateCourse() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			errors.New("fail get course"),
 			nil,
@@ -1173,6 +1415,7 @@ func (s *suiteCourse) TestUpdateCourse() {
 						CourseID: "abcde",
 					},
 				},
+				Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 			},
 			nil,
 			errors.New(constantError.ErrorNotAuthorized),
@@ -1199,6 +1442,630 @@ func (s *suiteCourse) TestUpdateCourse() {
 	}
 }
 
+func (s *suiteCourse) TestCourseSearchIndex() {
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	s.mockCategory.On("GetCategoryByID", "category1", user).Return(dto.Category{}, nil)
+	s.mockCourse.On("CreateCourse", mock.Anything).Return(nil)
+	s.mockCourse.On("GetCourseByID", "course1").Return(dto.Course{ID: "course1", InstructorID: "abcde"}, nil)
+	s.mockCourse.On("UpdateCourse", mock.Anything).Return(nil)
+	s.mockCourse.On("DeleteCourse", "course1").Return(nil)
+
+	// a failing index doesn't fail the change of the course
+	s.mockSearch.ExpectedCalls = nil
+	s.mockSearch.On("IndexCourse", mock.Anything).Return(errors.New("error"))
+	s.mockSearch.On("RemoveCourse", "course1").Return(nil)
+
+	err := s.courseService.CreateCourse(dto.CourseTransaction{Name: "golang", CategoryID: "category1", Capacity: 10}, user)
+	s.NoError(err)
+	s.mockSearch.AssertNumberOfCalls(s.T(), "IndexCourse", 1)
+
+	err = s.courseService.UpdateCourse(dto.CourseTransaction{ID: "course1", Name: "golang", InstructorID: "abcde"})
+	s.NoError(err)
+	s.mockSearch.AssertCalled(s.T(), "IndexCourse", "course1")
+
+	err = s.courseService.DeleteCourse("course1", "abcde")
+	s.NoError(err)
+	s.mockSearch.AssertCalled(s.T(), "RemoveCourse", "course1")
+}
+
+func (s *suiteCourse) TestUpdateCourseStatus() {
+	instructor := dto.User{ID: "abcde", Role: "instructor"}
+	admin := dto.User{ID: "admin", Role: "admin"}
+	testCase := []struct {
+		Name              string
+		User              dto.User
+		Status            string
+		MockReturnCourse  dto.Course
+		MockReturnContent dto.CourseContent
+		HasReturnError    bool
+		ExpectedError     error
+	}{
+		{
+			"success send course to review",
+			instructor,
+			dto.CourseStatusInReview,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusDraft},
+			dto.CourseContent{Modules: 2, MediaModules: 1},
+			false,
+			nil,
+		},
+		{
+			"fail send course without module to review",
+			instructor,
+			dto.CourseStatusInReview,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusDraft},
+			dto.CourseContent{},
+			true,
+			errors.New(constantError.ErrorCourseNoModule),
+		},
+		{
+			"fail send course without media to review",
+			instructor,
+			dto.CourseStatusInReview,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusDraft},
+			dto.CourseContent{Modules: 1},
+			true,
+			errors.New(constantError.ErrorCourseNoMedia),
+		},
+		{
+			"success publish course by admin",
+			admin,
+			dto.CourseStatusPublished,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusInReview},
+			dto.CourseContent{Modules: 1, MediaModules: 1},
+			false,
+			nil,
+		},
+		{
+			"success send archived course to review",
+			instructor,
+			dto.CourseStatusInReview,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusArchived, Version: 1},
+			dto.CourseContent{Modules: 1, MediaModules: 1},
+			false,
+			nil,
+		},
+		{
+			"success publish course again after archived by admin",
+			admin,
+			dto.CourseStatusPublished,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusArchived, Version: 1},
+			dto.CourseContent{Modules: 1, MediaModules: 1},
+			false,
+			nil,
+		},
+		{
+			"fail publish course again after archived by instructor",
+			instructor,
+			dto.CourseStatusPublished,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusArchived, Version: 1},
+			dto.CourseContent{Modules: 1, MediaModules: 1},
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail publish course by instructor",
+			instructor,
+			dto.CourseStatusPublished,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusInReview},
+			dto.CourseContent{Modules: 1, MediaModules: 1},
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail publish draft course",
+			admin,
+			dto.CourseStatusPublished,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusDraft},
+			dto.CourseContent{Modules: 1, MediaModules: 1},
+			true,
+			errors.New(constantError.ErrorCourseStatus),
+		},
+		{
+			"success archive course",
+			instructor,
+			dto.CourseStatusArchived,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusPublished},
+			dto.CourseContent{},
+			false,
+			nil,
+		},
+		{
+			"fail send course template to review",
+			instructor,
+			dto.CourseStatusInReview,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusDraft, IsTemplate: true},
+			dto.CourseContent{Modules: 1, MediaModules: 1},
+			true,
+			errors.New(constantError.ErrorCourseTemplate),
+		},
+		{
+			"fail archive course of other instructor",
+			instructor,
+			dto.CourseStatusArchived,
+			dto.Course{ID: "abcde", InstructorID: "other", Status: dto.CourseStatusPublished},
+			dto.CourseContent{},
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGetCourse := s.mockCourse.On("GetCourseByID", "abcde").Return(v.MockReturnCourse, nil)
+		mockCallGetContent := s.mockCourse.On("GetCourseContent", "abcde").Return(v.MockReturnContent, nil)
+		mockCallUpdate := s.mockCourse.On("UpdateCourseStatus", "abcde", v.Status).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.courseService.UpdateCourseStatus("abcde", dto.CourseStatus{Status: v.Status}, v.User)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.mockCourse.AssertCalled(t, "UpdateCourseStatus", "abcde", v.Status)
+				s.mockSearch.AssertCalled(t, "IndexCourse", "abcde")
+			}
+			// the first version is made only when the course is published for the first time
+			if !v.HasReturnError && v.Status == dto.CourseStatusPublished && v.MockReturnCourse.Version == 0 {
+				s.mockVersion.AssertCalled(t, "PublishCourseVersion", "abcde")
+			} else {
+				s.mockVersion.AssertNotCalled(t, "PublishCourseVersion", "abcde")
+			}
+		})
+		// remove mock
+		mockCallGetCourse.Unset()
+		mockCallGetContent.Unset()
+		mockCallUpdate.Unset()
+		s.mockVersion.Calls = nil
+	}
+}
+
+func (s *suiteCourse) TestUpdateCourseStatusInstructorStatus() {
+	testCase := []struct {
+		Name                 string
+		Status               string
+		MockReturnCourse     dto.Course
+		MockReturnInstructor dto.InstructorResponseGet
+		ExpectedError        error
+	}{
+		{
+			"fail send course to review by suspended instructor",
+			dto.CourseStatusInReview,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusDraft},
+			dto.InstructorResponseGet{IsActive: true, Status: dto.InstructorStatusSuspended},
+			errors.New(constantError.ErrorInstructorSuspended),
+		},
+		{
+			"fail send archived course to review by pending instructor",
+			dto.CourseStatusInReview,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusArchived, Version: 1},
+			dto.InstructorResponseGet{IsActive: true, Status: dto.InstructorStatusPending},
+			errors.New(constantError.ErrorInstructorNotApproved),
+		},
+		{
+			"fail archive course by instructor not verified",
+			dto.CourseStatusArchived,
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusPublished},
+			dto.InstructorResponseGet{IsActive: false, Status: dto.InstructorStatusApproved},
+			errors.New(constantError.ErrorNoActive),
+		},
+	}
+	s.mockInstructor.ExpectedCalls = nil
+	for _, v := range testCase {
+		mockCallInstructor := s.mockInstructor.On("GetInstructorByID", "abcde").Return(v.MockReturnInstructor, nil)
+		mockCallGetCourse := s.mockCourse.On("GetCourseByID", "abcde").Return(v.MockReturnCourse, nil)
+		mockCallGetContent := s.mockCourse.On("GetCourseContent", "abcde").Return(dto.CourseContent{Modules: 1, MediaModules: 1}, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.courseService.UpdateCourseStatus("abcde", dto.CourseStatus{Status: v.Status}, dto.User{ID: "abcde", Role: "instructor"})
+			s.Equal(v.ExpectedError, err)
+			s.mockCourse.AssertNotCalled(t, "UpdateCourseStatus", mock.Anything, mock.Anything)
+		})
+		// remove mock
+		mockCallInstructor.Unset()
+		mockCallGetCourse.Unset()
+		mockCallGetContent.Unset()
+	}
+}
+
+func (s *suiteCourse) TestDuplicateCourse() {
+	instructor := dto.User{ID: "abcde", Role: "instructor"}
+	testCase := []struct {
+		Name                   string
+		Input                  dto.CourseDuplicate
+		MockReturnCourse       dto.Course
+		MockReturnCourseError  error
+		MockReturnCategoryErr  error
+		MockReturnDuplicateErr error
+		HasReturnError         bool
+		ExpectedCategoryID     string
+		ExpectedError          error
+	}{
+		{
+			"success duplicate course in the same category",
+			dto.CourseDuplicate{Name: "copy"},
+			dto.Course{ID: "abcde", InstructorID: "abcde", CategoryID: "category"},
+			nil,
+			nil,
+			nil,
+			false,
+			"category",
+			nil,
+		},
+		{
+			"success save course as template in other category",
+			dto.CourseDuplicate{Name: "template", CategoryID: "other", IsTemplate: true},
+			dto.Course{ID: "abcde", InstructorID: "abcde", CategoryID: "category"},
+			nil,
+			nil,
+			nil,
+			false,
+			"other",
+			nil,
+		},
+		{
+			"fail duplicate course not found",
+			dto.CourseDuplicate{Name: "copy"},
+			dto.Course{},
+			gorm.ErrRecordNotFound,
+			nil,
+			nil,
+			true,
+			"",
+			errors.New(constantError.ErrorCourseNotFound),
+		},
+		{
+			"fail duplicate course of other instructor",
+			dto.CourseDuplicate{Name: "copy"},
+			dto.Course{ID: "abcde", InstructorID: "other", CategoryID: "category"},
+			nil,
+			nil,
+			nil,
+			true,
+			"",
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail duplicate course to category not found",
+			dto.CourseDuplicate{Name: "copy", CategoryID: "other"},
+			dto.Course{ID: "abcde", InstructorID: "abcde", CategoryID: "category"},
+			nil,
+			gorm.ErrRecordNotFound,
+			nil,
+			true,
+			"",
+			errors.New(constantError.ErrorCategoryNotFound),
+		},
+		{
+			"fail duplicate course with used name",
+			dto.CourseDuplicate{Name: "copy"},
+			dto.Course{ID: "abcde", InstructorID: "abcde", CategoryID: "category"},
+			nil,
+			nil,
+			errors.New(constantError.ErrorCourseNameUsed),
+			true,
+			"",
+			errors.New(constantError.ErrorCourseNameUsed),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGetCourse := s.mockCourse.On("GetCourseByID", "abcde").Return(v.MockReturnCourse, v.MockReturnCourseError)
+		mockCallCategory := s.mockCategory.On("GetCategoryByID", "other", instructor).Return(dto.Category{}, v.MockReturnCategoryErr)
+		mockCallDuplicate := s.mockCourse.On("DuplicateCourse", "abcde", mock.Anything).Return(v.MockReturnDuplicateErr)
+		s.T().Run(v.Name, func(t *testing.T) {
+			course, err := s.courseService.DuplicateCourse("abcde", v.Input, instructor)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.NotEmpty(course.ID)
+				s.Equal(v.Input.Name, course.Name)
+				s.Equal(v.ExpectedCategoryID, course.CategoryID)
+				s.Equal(instructor.ID, course.InstructorID)
+				s.Equal(v.Input.IsTemplate, course.IsTemplate)
+				s.mockCourse.AssertCalled(t, "DuplicateCourse", "abcde", course)
+			}
+		})
+		// remove mock
+		mockCallGetCourse.Unset()
+		mockCallCategory.Unset()
+		mockCallDuplicate.Unset()
+	}
+}
+
+func (s *suiteCourse) TestGetCourseTemplates() {
+	instructor := dto.User{ID: "abcde", Role: "instructor"}
+	mockCall := s.mockCourse.On("GetCourseTemplates", "abcde").Return([]dto.Course{
+		{ID: "abcde", Name: "template", IsTemplate: true, Stats: dto.CourseStats{ModuleCount: 2}},
+	}, nil)
+	courses, err := s.courseService.GetCourseTemplates(instructor)
+	s.NoError(err)
+	s.Len(courses, 1)
+	s.True(courses[0].IsTemplate)
+	s.Equal(2, courses[0].NumberOfModules)
+	mockCall.Unset()
+}
+
+func (s *suiteCourse) TestGetCourseByIDVersion() {
+	customer := dto.User{ID: "abcde", Role: "customer"}
+	latest := dto.CourseVersion{
+		ID:       "v2",
+		CourseID: "abcde",
+		Version:  2,
+		Modules: []dto.CourseVersionModule{
+			{ModuleID: "m1", NoModule: 1, Name: "module 1"},
+			{ModuleID: "m2", NoModule: 2, Name: "module 2"},
+			{ModuleID: "m3", NoModule: 3, Name: "module 3"},
+		},
+	}
+	pinned := dto.CourseVersion{
+		ID:       "v1",
+		CourseID: "abcde",
+		Version:  1,
+		Modules: []dto.CourseVersionModule{
+			{ModuleID: "m1", NoModule: 1, Name: "module 1"},
+			{ModuleID: "m2", NoModule: 2, Name: "module 2"},
+		},
+	}
+	testCase := []struct {
+		Name              string
+		CustomerCourses   []dto.CustomerCourse
+		ExpectedModules   []string
+		ExpectedNoModules int
+		ExpectedProgress  float64
+	}{
+		{
+			"customer not enrolled sees the latest version",
+			nil,
+			[]string{"m1", "m2", "m3"},
+			3,
+			0,
+		},
+		{
+			"enrolled customer sees the pinned version",
+			[]dto.CustomerCourse{{CustomerID: "abcde", CourseID: "abcde", Status: true, NoModule: 2, CourseVersionID: "v1"}},
+			[]string{"m1", "m2"},
+			2,
+			50,
+		},
+	}
+	// replace the course without version of the setup
+	s.mockVersion.ExpectedCalls = nil
+	mockCallLatest := s.mockVersion.On("GetLatestCourseVersion", "abcde").Return(latest, nil)
+	mockCallPinned := s.mockVersion.On("GetCourseVersion", "v1").Return(pinned, nil)
+	for _, v := range testCase {
+		mockCall := s.mockCourse.On("GetCourseByID", "abcde").Return(dto.Course{
+			ID:              "abcde",
+			Status:          dto.CourseStatusPublished,
+			Modules:         []dto.Module{{ID: "m1", NoModule: 1}, {ID: "m4", NoModule: 2}},
+			CustomerCourses: v.CustomerCourses,
+			Stats:           dto.CourseStats{ModuleCount: 2},
+		}, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			course, err := s.courseService.GetCourseByID("abcde", customer)
+			s.NoError(err)
+			var modules []string
+			for _, module := range course.Modules {
+				modules = append(modules, module.ID)
+			}
+			s.Equal(v.ExpectedModules, modules)
+			s.Equal(v.ExpectedNoModules, course.NumberOfModules)
+			s.Equal(v.ExpectedProgress, course.ProgressPercentage)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+	mockCallLatest.Unset()
+	mockCallPinned.Unset()
+}
+
+func (s *suiteCourse) TestGetCourseByIDSections() {
+	testCase := []struct {
+		Name             string
+		User             dto.User
+		CustomerCourses  []dto.CustomerCourse
+		ExpectedProgress []float64
+	}{
+		{
+			"enrolled customer sees the progress of every section",
+			dto.User{ID: "customer1", Role: "customer"},
+			[]dto.CustomerCourse{{CustomerID: "customer1", CourseID: "abcde", Status: true, NoModule: 2}},
+			[]float64{50, 0},
+		},
+		{
+			"instructor sees the sections without progress",
+			dto.User{ID: "instructor1", Role: "instructor"},
+			nil,
+			[]float64{0, 0},
+		},
+	}
+	// replace the customer without finished modules of the setup
+	s.mockCourse.ExpectedCalls = nil
+	mockCallFinished := s.mockCourse.On("GetFinishedModules", "abcde", "customer1").Return([]string{"m1"}, nil)
+	for _, v := range testCase {
+		mockCall := s.mockCourse.On("GetCourseByID", "abcde").Return(dto.Course{
+			ID:           "abcde",
+			InstructorID: "instructor1",
+			Status:       dto.CourseStatusPublished,
+			Modules: []dto.Module{
+				{ID: "m1", SectionID: "s1", NoModule: 1},
+				{ID: "m2", SectionID: "s1", NoModule: 2},
+				{ID: "m3", SectionID: "s2", NoModule: 3},
+			},
+			Sections: []dto.Section{
+				{ID: "s1", Title: "section 1", NoSection: 1},
+				{ID: "s2", Title: "section 2", NoSection: 2},
+			},
+			CustomerCourses: v.CustomerCourses,
+		}, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			course, err := s.courseService.GetCourseByID("abcde", v.User)
+			s.NoError(err)
+			s.Len(course.Sections, 2)
+			var progress []float64
+			for _, section := range course.Sections {
+				progress = append(progress, section.ProgressPercentage)
+			}
+			s.Equal(v.ExpectedProgress, progress)
+			s.Equal([]dto.Module{{ID: "m1", SectionID: "s1", NoModule: 1}, {ID: "m2", SectionID: "s1", NoModule: 2}}, course.Sections[0].Modules)
+			s.Equal([]dto.Module{{ID: "m3", SectionID: "s2", NoModule: 3}}, course.Sections[1].Modules)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+	mockCallFinished.Unset()
+}
+
+func (s *suiteCourse) TestAddCoursePrerequisite() {
+	testCase := []struct {
+		Name                  string
+		CourseID              string
+		Body                  dto.CoursePrerequisiteTransaction
+		MockReturnCourse      dto.Course
+		MockReturnCourseError error
+		MockReturnError       error
+		HasReturnError        bool
+		ExpectedError         error
+	}{
+		{
+			"success add course prerequisite",
+			"abcde",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			dto.Course{ID: "basic", InstructorID: "other"},
+			nil,
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail add course as its own prerequisite",
+			"abcde",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "abcde"},
+			dto.Course{},
+			nil,
+			nil,
+			true,
+			errors.New(constantError.ErrorPrerequisiteCycle),
+		},
+		{
+			"fail add prerequisite not found",
+			"abcde",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			dto.Course{},
+			gorm.ErrRecordNotFound,
+			nil,
+			true,
+			errors.New(constantError.ErrorCourseNotFound),
+		},
+		{
+			"fail add template as prerequisite",
+			"abcde",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			dto.Course{ID: "basic", IsTemplate: true},
+			nil,
+			nil,
+			true,
+			errors.New(constantError.ErrorCourseNotFound),
+		},
+		{
+			"fail add prerequisite making a cycle",
+			"abcde",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			dto.Course{ID: "basic"},
+			nil,
+			errors.New(constantError.ErrorPrerequisiteCycle),
+			true,
+			errors.New(constantError.ErrorPrerequisiteCycle),
+		},
+		{
+			"fail add prerequisite to course of other instructor",
+			"other",
+			dto.CoursePrerequisiteTransaction{PrerequisiteID: "basic"},
+			dto.Course{ID: "basic"},
+			nil,
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+	}
+	user := dto.User{ID: "abcde", Role: "instructor"}
+	s.mockCourse.On("GetCourseByID", "abcde").Return(dto.Course{ID: "abcde", InstructorID: "abcde"}, nil)
+	s.mockCourse.On("GetCourseByID", "other").Return(dto.Course{ID: "other", InstructorID: "other"}, nil)
+	for _, v := range testCase {
+		mockCallPrerequisite := s.mockCourse.On("GetCourseByID", v.Body.PrerequisiteID).Return(v.MockReturnCourse, v.MockReturnCourseError)
+		mockCallAdd := s.mockCourse.On("AddCoursePrerequisite", v.CourseID, v.Body.PrerequisiteID).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.courseService.AddCoursePrerequisite(v.CourseID, v.Body, user)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+		})
+		// remove mock
+		if v.Body.PrerequisiteID != v.CourseID {
+			mockCallPrerequisite.Unset()
+		}
+		mockCallAdd.Unset()
+	}
+}
+
+func (s *suiteCourse) TestGetCoursePrerequisites() {
+	prerequisites := []dto.CoursePrerequisite{{ID: "basic", Name: "basic", IsFinish: true}}
+	testCase := []struct {
+		Name           string
+		User           dto.User
+		CustomerID     string
+		MockReturnBody []dto.CoursePrerequisite
+		HasReturnError bool
+		ExpectedBody   []dto.CoursePrerequisite
+		ExpectedError  error
+	}{
+		{
+			"success get course prerequisites as customer",
+			dto.User{ID: "customer", Role: "customer"},
+			"customer",
+			prerequisites,
+			false,
+			prerequisites,
+			nil,
+		},
+		{
+			"success get course prerequisites as instructor",
+			dto.User{ID: "abcde", Role: "instructor"},
+			"",
+			[]dto.CoursePrerequisite{},
+			false,
+			[]dto.CoursePrerequisite{},
+			nil,
+		},
+		{
+			"fail get course prerequisites of other instructor",
+			dto.User{ID: "other", Role: "instructor"},
+			"",
+			prerequisites,
+			true,
+			nil,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+	}
+	s.mockCourse.On("GetCourseByID", "abcde").Return(dto.Course{ID: "abcde", InstructorID: "abcde"}, nil)
+	for _, v := range testCase {
+		mockCall := s.mockCourse.On("GetCoursePrerequisites", "abcde", v.CustomerID).Return(v.MockReturnBody, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			result, err := s.courseService.GetCoursePrerequisites("abcde", v.User)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedBody, result)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
 func TestSuiteCourse(t *testing.T) {
 	suite.Run(t, new(suiteCourse))
 }
@@ -0,0 +1,152 @@
+package courseVersionService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/courseRepository"
+	"golang/repository/courseVersionRepository"
+	"golang/repository/customerCourseRepository"
+
+	"gorm.io/gorm"
+)
+
+type CourseVersionService interface {
+	PublishCourseVersion(courseID string, user dto.User) (dto.CourseVersion, error)
+	GetCourseVersions(courseID string, user dto.User) ([]dto.CourseVersion, error)
+	GetEnrollmentVersion(courseID, customerID string) (dto.EnrollmentVersion, error)
+	MigrateEnrollment(courseID, customerID string) (dto.EnrollmentVersion, error)
+}
+
+type courseVersionService struct {
+	courseRepo         courseRepository.CourseRepository
+	courseVersionRepo  courseVersionRepository.CourseVersionRepository
+	customerCourseRepo customerCourseRepository.CustomerCourseRepository
+}
+
+// PublishCourseVersion implements CourseVersionService
+func (cvs *courseVersionService) PublishCourseVersion(courseID string, user dto.User) (dto.CourseVersion, error) {
+	course, err := cvs.getInstructorCourse(courseID, user)
+	if err != nil {
+		return dto.CourseVersion{}, err
+	}
+
+	// a new version is released to the customers, so the course must already be published
+	if course.Status != dto.CourseStatusPublished {
+		return dto.CourseVersion{}, errors.New(constantError.ErrorCourseNotPublished)
+	}
+
+	content, err := cvs.courseRepo.GetCourseContent(courseID)
+	if err != nil {
+		return dto.CourseVersion{}, err
+	}
+	err = helper.CheckCourseContent(content)
+	if err != nil {
+		return dto.CourseVersion{}, err
+	}
+
+	return cvs.courseVersionRepo.PublishCourseVersion(courseID)
+}
+
+// GetCourseVersions implements CourseVersionService
+func (cvs *courseVersionService) GetCourseVersions(courseID string, user dto.User) ([]dto.CourseVersion, error) {
+	_, err := cvs.getInstructorCourse(courseID, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return cvs.courseVersionRepo.GetCourseVersions(courseID)
+}
+
+// GetEnrollmentVersion implements CourseVersionService
+func (cvs *courseVersionService) GetEnrollmentVersion(courseID, customerID string) (dto.EnrollmentVersion, error) {
+	customerCourse, pinned, latest, err := cvs.getEnrollment(courseID, customerID)
+	if err != nil {
+		return dto.EnrollmentVersion{}, err
+	}
+
+	return getEnrollmentVersion(customerCourse, pinned, latest), nil
+}
+
+// MigrateEnrollment implements CourseVersionService
+func (cvs *courseVersionService) MigrateEnrollment(courseID, customerID string) (dto.EnrollmentVersion, error) {
+	customerCourse, pinned, latest, err := cvs.getEnrollment(courseID, customerID)
+	if err != nil {
+		return dto.EnrollmentVersion{}, err
+	}
+	if latest.ID == "" || pinned.ID == latest.ID {
+		return dto.EnrollmentVersion{}, errors.New(constantError.ErrorEnrollmentUpToDate)
+	}
+
+	// the modules finished in the old version stay finished and the customer continues from the first module not finished yet
+	customerCourse.CourseVersionID = latest.ID
+	customerCourse, err = cvs.courseVersionRepo.MigrateEnrollment(customerCourse)
+	if err != nil {
+		return dto.EnrollmentVersion{}, err
+	}
+
+	return getEnrollmentVersion(customerCourse, latest, latest), nil
+}
+
+// getInstructorCourse gets the course and checks the instructor owns it
+func (cvs *courseVersionService) getInstructorCourse(courseID string, user dto.User) (dto.Course, error) {
+	course, err := cvs.courseRepo.GetCourseByID(courseID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return dto.Course{}, errors.New(constantError.ErrorCourseNotFound)
+		}
+		return dto.Course{}, err
+	}
+
+	// check if instructor id in the course is the same as the instructor id in the token
+	if course.InstructorID != user.ID {
+		return dto.Course{}, errors.New(constantError.ErrorNotAuthorized)
+	}
+	return course, nil
+}
+
+// getEnrollment gets the enrollment of the customer with the version it is pinned to and the latest version of the course,
+// the versions are empty when the enrollment is not pinned or the course has no version yet
+func (cvs *courseVersionService) getEnrollment(courseID, customerID string) (dto.CustomerCourse, dto.CourseVersion, dto.CourseVersion, error) {
+	customerCourse, err := cvs.customerCourseRepo.GetCustomerCourse(courseID, customerID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return dto.CustomerCourse{}, dto.CourseVersion{}, dto.CourseVersion{}, errors.New(constantError.ErrorCustomerNotEnrolled)
+		}
+		return dto.CustomerCourse{}, dto.CourseVersion{}, dto.CourseVersion{}, err
+	}
+
+	latest, err := cvs.courseVersionRepo.GetLatestCourseVersion(courseID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return dto.CustomerCourse{}, dto.CourseVersion{}, dto.CourseVersion{}, err
+	}
+
+	var pinned dto.CourseVersion
+	if customerCourse.CourseVersionID != "" {
+		pinned, err = cvs.courseVersionRepo.GetCourseVersion(customerCourse.CourseVersionID)
+		if err != nil {
+			return dto.CustomerCourse{}, dto.CourseVersion{}, dto.CourseVersion{}, err
+		}
+	}
+	return customerCourse, pinned, latest, nil
+}
+
+func getEnrollmentVersion(customerCourse dto.CustomerCourse, pinned, latest dto.CourseVersion) dto.EnrollmentVersion {
+	return dto.EnrollmentVersion{
+		CourseID:        customerCourse.CourseID,
+		PinnedVersion:   pinned.Version,
+		LatestVersion:   latest.Version,
+		UpdateAvailable: latest.Version > pinned.Version,
+		NoModule:        customerCourse.NoModule,
+		IsFinish:        customerCourse.IsFinish,
+	}
+}
+
+func NewCourseVersionService(courseRepo courseRepository.CourseRepository, courseVersionRepo courseVersionRepository.CourseVersionRepository, customerCourseRepo customerCourseRepository.CustomerCourseRepository) CourseVersionService {
+	return &courseVersionService{
+		courseRepo:         courseRepo,
+		courseVersionRepo:  courseVersionRepo,
+		customerCourseRepo: customerCourseRepo,
+	}
+}
@@ -0,0 +1,260 @@
+package courseVersionService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"golang/repository/courseRepository/courseMockRepository"
+	"golang/repository/courseVersionRepository/courseVersionMockRepository"
+	"golang/repository/customerCourseRepository/customerCourseMockRepository"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteCourseVersion struct {
+	suite.Suite
+	courseVersionService CourseVersionService
+	mockCourse           *courseMockRepository.CourseMock
+	mockVersion          *courseVersionMockRepository.CourseVersionMock
+	mockCustomerCourse   *customerCourseMockRepository.CustomerCourseMock
+}
+
+func (s *suiteCourseVersion) SetupTest() {
+	s.mockCourse = &courseMockRepository.CourseMock{}
+	s.mockVersion = &courseVersionMockRepository.CourseVersionMock{}
+	s.mockCustomerCourse = &customerCourseMockRepository.CustomerCourseMock{}
+	s.courseVersionService = NewCourseVersionService(s.mockCourse, s.mockVersion, s.mockCustomerCourse)
+}
+
+func (s *suiteCourseVersion) TestPublishCourseVersion() {
+	testCase := []struct {
+		Name              string
+		User              dto.User
+		MockReturnCourse  dto.Course
+		MockReturnError   error
+		MockReturnContent dto.CourseContent
+		HasReturnError    bool
+		ExpectedError     error
+	}{
+		{
+			"success publish course version",
+			dto.User{ID: "abcde", Role: "instructor"},
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusPublished, Version: 1},
+			nil,
+			dto.CourseContent{Modules: 2, MediaModules: 1},
+			false,
+			nil,
+		},
+		{
+			"fail publish version of course not found",
+			dto.User{ID: "abcde", Role: "instructor"},
+			dto.Course{},
+			gorm.ErrRecordNotFound,
+			dto.CourseContent{},
+			true,
+			errors.New(constantError.ErrorCourseNotFound),
+		},
+		{
+			"fail publish version of course of other instructor",
+			dto.User{ID: "abcde", Role: "instructor"},
+			dto.Course{ID: "abcde", InstructorID: "other", Status: dto.CourseStatusPublished, Version: 1},
+			nil,
+			dto.CourseContent{Modules: 2, MediaModules: 1},
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail publish version of draft course",
+			dto.User{ID: "abcde", Role: "instructor"},
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusDraft},
+			nil,
+			dto.CourseContent{Modules: 2, MediaModules: 1},
+			true,
+			errors.New(constantError.ErrorCourseNotPublished),
+		},
+		{
+			"fail publish version without module",
+			dto.User{ID: "abcde", Role: "instructor"},
+			dto.Course{ID: "abcde", InstructorID: "abcde", Status: dto.CourseStatusPublished, Version: 1},
+			nil,
+			dto.CourseContent{},
+			true,
+			errors.New(constantError.ErrorCourseNoModule),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGetCourse := s.mockCourse.On("GetCourseByID", "abcde").Return(v.MockReturnCourse, v.MockReturnError)
+		mockCallGetContent := s.mockCourse.On("GetCourseContent", "abcde").Return(v.MockReturnContent, nil)
+		mockCallPublish := s.mockVersion.On("PublishCourseVersion", "abcde").Return(dto.CourseVersion{ID: "v2", CourseID: "abcde", Version: 2}, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			version, err := s.courseVersionService.PublishCourseVersion("abcde", v.User)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+				s.mockVersion.AssertNotCalled(t, "PublishCourseVersion", "abcde")
+			} else {
+				s.NoError(err)
+				s.Equal(2, version.Version)
+			}
+		})
+		// remove mock
+		mockCallGetCourse.Unset()
+		mockCallGetContent.Unset()
+		mockCallPublish.Unset()
+		s.mockVersion.Calls = nil
+	}
+}
+
+func (s *suiteCourseVersion) TestGetEnrollmentVersion() {
+	testCase := []struct {
+		Name                 string
+		MockReturnEnrollment dto.CustomerCourse
+		MockReturnError      error
+		HasReturnError       bool
+		ExpectedBody         dto.EnrollmentVersion
+		ExpectedError        error
+	}{
+		{
+			"success get enrollment with update available",
+			dto.CustomerCourse{CourseID: "abcde", CustomerID: "abcde", NoModule: 2, CourseVersionID: "v1"},
+			nil,
+			false,
+			dto.EnrollmentVersion{CourseID: "abcde", PinnedVersion: 1, LatestVersion: 2, UpdateAvailable: true, NoModule: 2},
+			nil,
+		},
+		{
+			"success get enrollment on latest version",
+			dto.CustomerCourse{CourseID: "abcde", CustomerID: "abcde", NoModule: 3, CourseVersionID: "v2"},
+			nil,
+			false,
+			dto.EnrollmentVersion{CourseID: "abcde", PinnedVersion: 2, LatestVersion: 2, NoModule: 3},
+			nil,
+		},
+		{
+			"fail get enrollment of course not enrolled",
+			dto.CustomerCourse{},
+			gorm.ErrRecordNotFound,
+			true,
+			dto.EnrollmentVersion{},
+			errors.New(constantError.ErrorCustomerNotEnrolled),
+		},
+	}
+	mockCallLatest := s.mockVersion.On("GetLatestCourseVersion", "abcde").Return(dto.CourseVersion{ID: "v2", Version: 2}, nil)
+	mockCallV1 := s.mockVersion.On("GetCourseVersion", "v1").Return(dto.CourseVersion{ID: "v1", Version: 1}, nil)
+	mockCallV2 := s.mockVersion.On("GetCourseVersion", "v2").Return(dto.CourseVersion{ID: "v2", Version: 2}, nil)
+	for _, v := range testCase {
+		mockCall := s.mockCustomerCourse.On("GetCustomerCourse", "abcde", "abcde").Return(v.MockReturnEnrollment, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			version, err := s.courseVersionService.GetEnrollmentVersion("abcde", "abcde")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal(v.ExpectedBody, version)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+	mockCallLatest.Unset()
+	mockCallV1.Unset()
+	mockCallV2.Unset()
+}
+
+func (s *suiteCourseVersion) TestMigrateEnrollment() {
+	v1 := dto.CourseVersion{
+		ID:      "v1",
+		Version: 1,
+		Modules: []dto.CourseVersionModule{
+			{ModuleID: "m1", NoModule: 1},
+			{ModuleID: "m2", NoModule: 2},
+			{ModuleID: "m3", NoModule: 3},
+		},
+	}
+	// module m2 is removed and module m4 is added before module m3
+	v2 := dto.CourseVersion{
+		ID:      "v2",
+		Version: 2,
+		Modules: []dto.CourseVersionModule{
+			{ModuleID: "m1", NoModule: 1},
+			{ModuleID: "m4", NoModule: 2},
+			{ModuleID: "m3", NoModule: 3},
+		},
+	}
+	testCase := []struct {
+		Name                 string
+		MockReturnEnrollment dto.CustomerCourse
+		MockReturnMigrate    dto.CustomerCourse
+		HasReturnError       bool
+		ExpectedNoModule     int
+		ExpectedIsFinish     bool
+		ExpectedError        error
+	}{
+		{
+			"success migrate enrollment to the first module not finished",
+			dto.CustomerCourse{CourseID: "abcde", CustomerID: "abcde", NoModule: 3, CourseVersionID: "v1"},
+			dto.CustomerCourse{CourseID: "abcde", CustomerID: "abcde", NoModule: 2, CourseVersionID: "v2"},
+			false,
+			2,
+			false,
+			nil,
+		},
+		{
+			"success migrate finished enrollment with new module",
+			dto.CustomerCourse{CourseID: "abcde", CustomerID: "abcde", NoModule: 4, IsFinish: true, CourseVersionID: "v1"},
+			dto.CustomerCourse{CourseID: "abcde", CustomerID: "abcde", NoModule: 2, CourseVersionID: "v2"},
+			false,
+			2,
+			false,
+			nil,
+		},
+		{
+			"fail migrate enrollment on latest version",
+			dto.CustomerCourse{CourseID: "abcde", CustomerID: "abcde", NoModule: 2, CourseVersionID: "v2"},
+			dto.CustomerCourse{},
+			true,
+			0,
+			false,
+			errors.New(constantError.ErrorEnrollmentUpToDate),
+		},
+	}
+	mockCallLatest := s.mockVersion.On("GetLatestCourseVersion", "abcde").Return(v2, nil)
+	mockCallV1 := s.mockVersion.On("GetCourseVersion", "v1").Return(v1, nil)
+	mockCallV2 := s.mockVersion.On("GetCourseVersion", "v2").Return(v2, nil)
+	for _, v := range testCase {
+		mockCall := s.mockCustomerCourse.On("GetCustomerCourse", "abcde", "abcde").Return(v.MockReturnEnrollment, nil)
+		mockCallMigrate := s.mockVersion.On("MigrateEnrollment", mock.Anything).Return(v.MockReturnMigrate, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			version, err := s.courseVersionService.MigrateEnrollment("abcde", "abcde")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal(2, version.PinnedVersion)
+				s.False(version.UpdateAvailable)
+				s.Equal(v.ExpectedNoModule, version.NoModule)
+				s.Equal(v.ExpectedIsFinish, version.IsFinish)
+
+				// the progress is computed by the repository from the finished modules
+				enrollment := v.MockReturnEnrollment
+				enrollment.CourseVersionID = "v2"
+				s.mockVersion.AssertCalled(t, "MigrateEnrollment", enrollment)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+		mockCallMigrate.Unset()
+	}
+	mockCallLatest.Unset()
+	mockCallV1.Unset()
+	mockCallV2.Unset()
+}
+
+func TestSuiteCourseVersion(t *testing.T) {
+	suite.Run(t, new(suiteCourseVersion))
+}
@@ -0,0 +1,32 @@
+package courseVersionMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CourseVersionMock struct {
+	mock.Mock
+}
+
+func (c *CourseVersionMock) PublishCourseVersion(courseID string, user dto.User) (dto.CourseVersion, error) {
+	args := c.Called(courseID, user)
+
+	return args.Get(0).(dto.CourseVersion), args.Error(1)
+}
+func (c *CourseVersionMock) GetCourseVersions(courseID string, user dto.User) ([]dto.CourseVersion, error) {
+	args := c.Called(courseID, user)
+
+	return args.Get(0).([]dto.CourseVersion), args.Error(1)
+}
+func (c *CourseVersionMock) GetEnrollmentVersion(courseID, customerID string) (dto.EnrollmentVersion, error) {
+	args := c.Called(courseID, customerID)
+
+	return args.Get(0).(dto.EnrollmentVersion), args.Error(1)
+}
+func (c *CourseVersionMock) MigrateEnrollment(courseID, customerID string) (dto.EnrollmentVersion, error) {
+	args := c.Called(courseID, customerID)
+
+	return args.Get(0).(dto.EnrollmentVersion), args.Error(1)
+}
@@ -2,9 +2,13 @@ package customerAssignmentService
 
 import (
 	"errors"
+	"golang/constant/constantError"
 	"golang/models/dto"
-	customerAssignmentMockrepository "golang/repository/customerAssignmentRepository/customerAssignmentMockRepository"
+	"golang/repository/customerAssignmentRepository/customerAssignmentMockRepository"
+	"golang/repository/customerCourseRepository/customerCourseMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -15,336 +19,561 @@ type suiteCustomerAssignment struct {
 	suite.Suite
 	customerAssignmentService CustomerAssignmentService
 	mock                      *customerAssignmentMockrepository.CustomerAssignmentMock
+	customerCourseMock        *customerCourseMockRepository.CustomerCourseMock
+	ownershipMock             *ownershipMockService.OwnershipMock
 }
 
 func (s *suiteCustomerAssignment) SetupTest() {
-	mock := &customerAssignmentMockrepository.CustomerAssignmentMock{}
-	s.mock = mock
-	NewcustomerAssignmentService := NewcustomerAssignmentService(s.mock)
-	s.customerAssignmentService = NewcustomerAssignmentService
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckAssignmentOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckAssignmentOwner", "abcde", "other").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.customerCourseMock = &customerCourseMockRepository.CustomerCourseMock{}
+	s.customerCourseMock.On("GetCustomerCourse", "course", "1").Return(dto.CustomerCourse{CourseID: "course", CustomerID: "1", Status: true}, nil)
+	s.customerCourseMock.On("GetCustomerCourse", "course", "2").Return(dto.CustomerCourse{}, gorm.ErrRecordNotFound)
+	s.mock = &customerAssignmentMockrepository.CustomerAssignmentMock{}
+	s.customerAssignmentService = NewcustomerAssignmentService(s.mock, s.customerCourseMock, s.ownershipMock)
 }
 
-func (s *suiteCustomerAssignment) TestCreateCustomerAssignment() {
+// getAssignment get an assignment that opened an hour ago, was due after the open time and closes after the due time
+func getAssignment(opened, due, closes time.Duration) dto.Assignment {
+	now := time.Now()
+	openAt, dueAt, closeAt := now.Add(opened), now.Add(due), now.Add(closes)
+	return dto.Assignment{
+		ID:               "abcde",
+		CourseID:         "course",
+		OpenAt:           &openAt,
+		DueAt:            &dueAt,
+		CloseAt:          &closeAt,
+		MaxPoints:        80,
+		MaxResubmissions: 1,
+		LatePenalty:      10,
+	}
+}
+
+func (s *suiteCustomerAssignment) TestSaveDraft() {
 	testCase := []struct {
 		Name            string
-		User            dto.User
-		Body            dto.CustomerAssignmentTransaction
-		MockReturnError error
+		CustomerID      string
+		File            string
+		Submission      dto.CustomerAssignment
+		SubmissionError error
 		HasReturnError  bool
 		ExpectedError   error
+		ExpectedCall    string
 	}{
 		{
-			"success create customer assignment",
-			dto.User{
-				ID:   "1",
-				Role: "customer",
-			},
-			dto.CustomerAssignmentTransaction{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "abcde",
-			},
+			"success save first draft",
+			"1",
+			"draft.pdf",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			false,
+			nil,
+			"CreateCustomerAssignment",
+		},
+		{
+			"success save draft of returned submission",
+			"1",
+			"draft.pdf",
+			dto.CustomerAssignment{ID: "s1", Status: dto.AssignmentReturned, Attempts: 1},
 			nil,
 			false,
 			nil,
+			"UpdateCustomerAssignmentDraft",
 		},
 		{
-			"fail create customer assignment",
-			dto.User{
-				ID:   "1",
-				Role: "customer",
-			},
-			dto.CustomerAssignmentTransaction{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "abcde",
-			},
-			errors.New("error"),
+			"fail save draft of submitted submission",
+			"1",
+			"draft.pdf",
+			dto.CustomerAssignment{ID: "s1", Status: dto.AssignmentSubmitted, Attempts: 1},
+			nil,
 			true,
-			errors.New("error"),
+			errors.New(constantError.ErrorAssignmentSubmitted),
+			"",
+		},
+		{
+			"fail save draft without file",
+			"1",
+			"",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			true,
+			errors.New(constantError.ErrorAssignmentNoFile),
+			"",
+		},
+		{
+			"fail save draft of customer not enrolled",
+			"2",
+			"draft.pdf",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			true,
+			errors.New(constantError.ErrorCustomerNotEnrolled),
+			"",
 		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("CreateCustomerAssignment", mock.Anything).Return(v.MockReturnError)
+		mockAssignment := s.mock.On("GetAssignment", "abcde").Return(getAssignment(-time.Hour, time.Hour, 2*time.Hour), nil)
+		mockSubmission := s.mock.On("GetCustomerAssignment", "abcde", v.CustomerID).Return(v.Submission, v.SubmissionError)
+		mockCreate := s.mock.On("CreateCustomerAssignment", mock.Anything).Return(nil)
+		mockUpdate := s.mock.On("UpdateCustomerAssignmentDraft", "s1", v.File).Return(nil)
+		mockGet := s.mock.On("GetCustomerAssignmentByID", mock.Anything).Return(dto.CustomerAssignment{ID: "s1"}, nil)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.customerAssignmentService.CreateCustomerAssignment(v.Body)
+			_, err := s.customerAssignmentService.SaveDraft(dto.CustomerAssignmentTransaction{AssignmentID: "abcde", File: v.File}, v.CustomerID)
 			if v.HasReturnError {
 				s.Error(err)
-				s.Equal(v.ExpectedError, err)
 				s.EqualError(err, v.ExpectedError.Error())
 			} else {
 				s.NoError(err)
+				s.Equal(v.ExpectedCall, s.mock.Calls[len(s.mock.Calls)-2].Method)
 			}
 		})
+		if v.ExpectedCall == "CreateCustomerAssignment" {
+			// the first draft is a new submission of the customer
+			draft := s.mock.Calls[len(s.mock.Calls)-2].Arguments.Get(0).(dto.CustomerAssignment)
+			s.NotEmpty(draft.ID)
+			s.Equal("draft.pdf", draft.File)
+			s.Equal(v.CustomerID, draft.CustomerID)
+		}
 		// remove mock
-		mockCall.Unset()
+		mockAssignment.Unset()
+		mockSubmission.Unset()
+		mockCreate.Unset()
+		mockUpdate.Unset()
+		mockGet.Unset()
 	}
 }
 
-func (s *suiteCustomerAssignment) TestDeleteCustomerAssignment() {
+func (s *suiteCustomerAssignment) TestSubmitAssignment() {
 	testCase := []struct {
-		Name            string
-		User            dto.User
-		ParamID         string
-		MockReturnError error
-		HasReturnError  bool
-		ExpectedError   error
+		Name                string
+		Assignment          dto.Assignment
+		File                string
+		Submission          dto.CustomerAssignment
+		SubmissionError     error
+		MockReturnError     error
+		HasReturnError      bool
+		ExpectedError       error
+		ExpectedStatus      string
+		ExpectedLatePenalty float64
+		ExpectedAttempts    int
 	}{
 		{
-			"success delete customer assignment",
-			dto.User{
-				ID:   "1",
-				Role: "customer",
-			},
-			"abcde",
+			"success submit assignment",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"answer.pdf",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
 			nil,
 			false,
 			nil,
+			dto.AssignmentSubmitted,
+			0,
+			1,
 		},
 		{
-			"fail delete customer assignment",
-			dto.User{
-				ID:   "1",
-				Role: "customer",
-			},
-			"abcde",
-			errors.New("error"),
+			"success submit draft of assignment",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"",
+			dto.CustomerAssignment{ID: "s1", File: "draft.pdf", Status: dto.AssignmentDraft},
+			nil,
+			nil,
+			false,
+			nil,
+			dto.AssignmentSubmitted,
+			0,
+			1,
+		},
+		{
+			"success submit assignment late",
+			getAssignment(-72*time.Hour, -25*time.Hour, time.Hour),
+			"answer.pdf",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			nil,
+			false,
+			nil,
+			dto.AssignmentLate,
+			20,
+			1,
+		},
+		{
+			"success resubmit assignment",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"answer-2.pdf",
+			dto.CustomerAssignment{ID: "s1", File: "answer.pdf", Status: dto.AssignmentSubmitted, Attempts: 1},
+			nil,
+			nil,
+			false,
+			nil,
+			dto.AssignmentSubmitted,
+			0,
+			2,
+		},
+		{
+			"success resubmit returned assignment without resubmission left",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"answer-3.pdf",
+			dto.CustomerAssignment{ID: "s1", File: "answer-2.pdf", Status: dto.AssignmentReturned, Attempts: 2},
+			nil,
+			nil,
+			false,
+			nil,
+			dto.AssignmentSubmitted,
+			0,
+			3,
+		},
+		{
+			"fail resubmit assignment without resubmission left",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"answer-3.pdf",
+			dto.CustomerAssignment{ID: "s1", File: "answer-2.pdf", Status: dto.AssignmentLate, Attempts: 2},
+			nil,
+			nil,
 			true,
-			errors.New("error"),
+			errors.New(constantError.ErrorAssignmentResubmission),
+			"",
+			0,
+			0,
+		},
+		{
+			"fail resubmit graded assignment",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"answer-2.pdf",
+			dto.CustomerAssignment{ID: "s1", File: "answer.pdf", Status: dto.AssignmentGraded, Attempts: 1},
+			nil,
+			nil,
+			true,
+			errors.New(constantError.ErrorAssignmentGraded),
+			"",
+			0,
+			0,
+		},
+		{
+			"fail submit assignment not open",
+			getAssignment(time.Hour, 2*time.Hour, 3*time.Hour),
+			"answer.pdf",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			nil,
+			true,
+			errors.New(constantError.ErrorAssignmentNotOpen),
+			"",
+			0,
+			0,
+		},
+		{
+			"fail submit assignment closed",
+			getAssignment(-3*time.Hour, -2*time.Hour, -time.Hour),
+			"answer.pdf",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			nil,
+			true,
+			errors.New(constantError.ErrorAssignmentClosed),
+			"",
+			0,
+			0,
+		},
+		{
+			"fail submit assignment without file",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			nil,
+			true,
+			errors.New(constantError.ErrorAssignmentNoFile),
+			"",
+			0,
+			0,
+		},
+		{
+			"fail submit assignment",
+			getAssignment(-time.Hour, time.Hour, 2*time.Hour),
+			"answer.pdf",
+			dto.CustomerAssignment{},
+			gorm.ErrRecordNotFound,
+			errors.New(constantError.ErrorAssignmentChanged),
+			true,
+			errors.New(constantError.ErrorAssignmentChanged),
+			"",
+			0,
+			0,
 		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("DeleteCustomerAssignment", mock.Anything).Return(v.MockReturnError)
+		mockAssignment := s.mock.On("GetAssignment", "abcde").Return(v.Assignment, nil)
+		mockSubmission := s.mock.On("GetCustomerAssignment", "abcde", "1").Return(v.Submission, v.SubmissionError)
+		mockCall := s.mock.On("SubmitCustomerAssignment", mock.Anything, mock.Anything).Return(v.MockReturnError)
+		mockGet := s.mock.On("GetCustomerAssignmentByID", mock.Anything).Return(dto.CustomerAssignment{ID: "s1"}, nil)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.customerAssignmentService.DeleteCustomerAssignment(v.ParamID)
+			_, err := s.customerAssignmentService.SubmitAssignment(dto.CustomerAssignmentTransaction{AssignmentID: "abcde", File: v.File}, "1")
 			if v.HasReturnError {
 				s.Error(err)
-				s.Equal(v.ExpectedError, err)
 				s.EqualError(err, v.ExpectedError.Error())
 			} else {
 				s.NoError(err)
+				// the submission is saved with a new version of its file
+				call := s.mock.Calls[len(s.mock.Calls)-2]
+				submission := call.Arguments.Get(0).(dto.CustomerAssignment)
+				version := call.Arguments.Get(1).(dto.CustomerAssignmentVersion)
+				s.NotEmpty(submission.ID)
+				s.Equal(v.ExpectedStatus, submission.Status)
+				s.Equal(v.ExpectedLatePenalty, submission.LatePenalty)
+				s.Equal(v.ExpectedAttempts, submission.Attempts)
+				s.NotNil(submission.SubmittedAt)
+				s.Equal(v.ExpectedAttempts, version.NoVersion)
+				s.Equal(submission.File, version.File)
+				s.Equal(v.ExpectedStatus, version.Status)
+				s.NotEmpty(submission.File)
 			}
 		})
 		// remove mock
+		mockAssignment.Unset()
+		mockSubmission.Unset()
 		mockCall.Unset()
+		mockGet.Unset()
 	}
 }
 
-func (s *suiteCustomerAssignment) TestGetCustomerAssignmentByID() {
+func (s *suiteCustomerAssignment) TestGradeCustomerAssignment() {
 	testCase := []struct {
-		Name            string
-		ParamID         string
-		MockReturnBody  dto.CustomerAssignmentAcc
-		MockReturnError error
-		HasReturnBody   bool
-		ExpectedBody    dto.CustomerAssignmentAcc
-		ExpectedError   error
+		Name           string
+		InstructorID   string
+		Grade          float64
+		Submission     dto.CustomerAssignment
+		HasReturnError bool
+		ExpectedError  error
+		ExpectedScore  float64
 	}{
 		{
-			"success get customer assignment by id ",
-			"abcde",
-			dto.CustomerAssignmentAcc{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "avcde",
-				Customer: struct {
-					Name string "json:\"name\" gorm:\"notNull;size:255\""
-				}{
-					Name: "tes",
-				},
-			},
-			nil,
-			true,
-			dto.CustomerAssignmentAcc{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "avcde",
-				Customer: struct {
-					Name string "json:\"name\" gorm:\"notNull;size:255\""
-				}{
-					Name: "tes",
-				},
-			},
+			"success grade customer assignment",
+			"1",
+			70,
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentSubmitted, Attempts: 1},
+			false,
 			nil,
+			70,
 		},
 		{
-			"failed get customer assignment by id",
-			"abcde",
-
-			dto.CustomerAssignmentAcc{},
-			gorm.ErrRecordNotFound,
+			"success grade late customer assignment",
+			"1",
+			70,
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentLate, Attempts: 1, LatePenalty: 30},
 			false,
-			dto.CustomerAssignmentAcc{},
-			gorm.ErrRecordNotFound,
+			nil,
+			49,
+		},
+		{
+			"fail grade customer assignment higher than max points",
+			"1",
+			90,
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentSubmitted, Attempts: 1},
+			true,
+			errors.New(constantError.ErrorAssignmentGrade),
+			0,
+		},
+		{
+			"fail grade draft",
+			"1",
+			70,
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentDraft},
+			true,
+			errors.New(constantError.ErrorAssignmentNotSubmitted),
+			0,
+		},
+		{
+			"fail grade customer assignment of other instructor",
+			"other",
+			70,
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentSubmitted, Attempts: 1},
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+			0,
 		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("GetCustomerAssignmentByID", v.ParamID).Return(v.MockReturnBody, v.MockReturnError)
+		mockGet := s.mock.On("GetCustomerAssignmentByID", "s1").Return(v.Submission, nil)
+		mockAssignment := s.mock.On("GetAssignment", "abcde").Return(getAssignment(-time.Hour, time.Hour, 2*time.Hour), nil)
+		mockCall := s.mock.On("GradeCustomerAssignment", mock.Anything).Return(nil)
 		s.T().Run(v.Name, func(t *testing.T) {
-			customerAssignment, err := s.customerAssignmentService.GetCustomerAssignmentByID(v.ParamID)
-			if v.HasReturnBody {
-				s.NoError(err)
-				s.Equal(v.ExpectedBody, customerAssignment)
-			} else {
+			graded, err := s.customerAssignmentService.GradeCustomerAssignment(dto.AssignmentGrade{ID: "s1", Grade: v.Grade, Feedback: "good"}, v.InstructorID)
+			if v.HasReturnError {
 				s.Error(err)
 				s.EqualError(err, v.ExpectedError.Error())
-				s.Equal(v.ExpectedBody, customerAssignment)
+			} else {
+				s.NoError(err)
+				s.Equal(dto.AssignmentGraded, graded.Status)
+				s.Equal(v.Grade, graded.Grade)
+				s.Equal(v.ExpectedScore, graded.Score)
+				s.Equal("good", graded.Feedback)
+				s.NotNil(graded.GradedAt)
 			}
 		})
 		// remove mock
+		mockGet.Unset()
+		mockAssignment.Unset()
 		mockCall.Unset()
 	}
 }
 
-func (s *suiteCustomerAssignment) TestGetAllCustomerAssignment() {
+func (s *suiteCustomerAssignment) TestReturnCustomerAssignment() {
 	testCase := []struct {
 		Name            string
-		User            dto.User
-		MockReturnBody  []dto.CustomerAssignmentAcc
+		Submission      dto.CustomerAssignment
 		MockReturnError error
-		HasReturnBody   bool
-		ExpectedBody    []dto.CustomerAssignmentAcc
+		HasReturnError  bool
 		ExpectedError   error
 	}{
 		{
-			"success get all customer assignment",
-			dto.User{
-				ID:   "abcde",
-				Role: "customer",
-			},
-			[]dto.CustomerAssignmentAcc{
-				{
-					ID:           "abcde",
-					File:         "tes",
-					Grade:        1,
-					AssignmentID: "abcde",
-					CustomerID:   "avcde",
-				},
-				{
-					ID:           "abcde",
-					File:         "tes",
-					Grade:        1,
-					AssignmentID: "abcde",
-					CustomerID:   "avcde",
-				},
-			},
+			"success return graded customer assignment",
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentGraded, Attempts: 1, Grade: 70, Score: 70},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail return returned customer assignment",
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentReturned, Attempts: 1},
 			nil,
 			true,
-			[]dto.CustomerAssignmentAcc{
-				{
-					ID:           "abcde",
-					File:         "tes",
-					Grade:        1,
-					AssignmentID: "abcde",
-					CustomerID:   "avcde",
-				},
-				{
-					ID:           "abcde",
-					File:         "tes",
-					Grade:        1,
-					AssignmentID: "abcde",
-					CustomerID:   "avcde",
-				},
-			},
+			errors.New(constantError.ErrorAssignmentNotSubmitted),
+		},
+		{
+			"fail return customer assignment",
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", Status: dto.AssignmentSubmitted, Attempts: 1},
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockGet := s.mock.On("GetCustomerAssignmentByID", "s1").Return(v.Submission, nil)
+		mockCall := s.mock.On("ReturnCustomerAssignment", "s1", "add the sources").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			returned, err := s.customerAssignmentService.ReturnCustomerAssignment(dto.AssignmentReturn{ID: "s1", Feedback: "add the sources"}, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal(dto.AssignmentReturned, returned.Status)
+				s.Zero(returned.Grade)
+				s.Zero(returned.Score)
+				s.Equal("add the sources", returned.Feedback)
+			}
+		})
+		// remove mock
+		mockGet.Unset()
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomerAssignment) TestDeleteCustomerAssignment() {
+	testCase := []struct {
+		Name           string
+		User           dto.User
+		Submission     dto.CustomerAssignment
+		HasReturnError bool
+		ExpectedError  error
+	}{
+		{
+			"success delete draft",
+			dto.User{ID: "1", Role: "customer"},
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", CustomerID: "1", Status: dto.AssignmentDraft},
+			false,
 			nil,
 		},
 		{
-			"failed get all customer assignment",
-			dto.User{
-				ID:   "abcde",
-				Role: "customer",
-			},
-			[]dto.CustomerAssignmentAcc{},
-			gorm.ErrRecordNotFound,
+			"success delete submitted customer assignment by instructor",
+			dto.User{ID: "1", Role: "instructor"},
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", CustomerID: "2", Status: dto.AssignmentSubmitted},
 			false,
 			nil,
-			gorm.ErrRecordNotFound,
+		},
+		{
+			"fail delete submitted customer assignment",
+			dto.User{ID: "1", Role: "customer"},
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", CustomerID: "1", Status: dto.AssignmentSubmitted},
+			true,
+			errors.New(constantError.ErrorAssignmentSubmitted),
+		},
+		{
+			"fail delete draft of other customer",
+			dto.User{ID: "1", Role: "customer"},
+			dto.CustomerAssignment{ID: "s1", AssignmentID: "abcde", CustomerID: "2", Status: dto.AssignmentDraft},
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
 		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("GetAllCustomerAssignment").Return(v.MockReturnBody, v.MockReturnError)
+		mockGet := s.mock.On("GetCustomerAssignmentByID", "s1").Return(v.Submission, nil)
+		mockCall := s.mock.On("DeleteCustomerAssignment", "s1").Return(nil)
 		s.T().Run(v.Name, func(t *testing.T) {
-			CustomerAssignment, err := s.customerAssignmentService.GetAllCustomerAssignment()
-			if v.HasReturnBody {
-				s.NoError(err)
-				s.Equal(v.ExpectedBody, CustomerAssignment)
-			} else {
+			err := s.customerAssignmentService.DeleteCustomerAssignment("s1", v.User)
+			if v.HasReturnError {
 				s.Error(err)
 				s.EqualError(err, v.ExpectedError.Error())
-				s.Equal(v.ExpectedBody, CustomerAssignment)
+			} else {
+				s.NoError(err)
 			}
 		})
 		// remove mock
+		mockGet.Unset()
 		mockCall.Unset()
 	}
 }
 
-func (s *suiteCustomerAssignment) TestUpdateCustomerAssignment() {
+func (s *suiteCustomerAssignment) TestGetCustomerAssignmentsByAssignmentID() {
 	testCase := []struct {
 		Name            string
-		User            dto.User
-		ParamID         string
-		Body            dto.CustomerAssignmentTransaction
+		InstructorID    string
+		MockReturnBody  []dto.CustomerAssignment
 		MockReturnError error
 		HasReturnError  bool
+		ExpectedBody    []dto.CustomerAssignment
 		ExpectedError   error
 	}{
 		{
-			"success update customer assignment",
-			dto.User{
-				ID:   "1",
-				Role: "user",
-			},
-			"abcde",
-			dto.CustomerAssignmentTransaction{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "avcde",
-			},
+			"success get customer assignments by assignment id",
+			"1",
+			[]dto.CustomerAssignment{{ID: "s1", AssignmentID: "abcde", CustomerName: "tes"}},
 			nil,
 			false,
+			[]dto.CustomerAssignment{{ID: "s1", AssignmentID: "abcde", CustomerName: "tes"}},
 			nil,
 		},
 		{
-			"fail update customer assignment",
-			dto.User{
-				ID:   "1",
-				Role: "user",
-			},
-			"abcde",
-			dto.CustomerAssignmentTransaction{
-				ID:           "abcde",
-				File:         "tes",
-				Grade:        1,
-				AssignmentID: "abcde",
-				CustomerID:   "avcde",
-			},
-			errors.New("error"),
+			"success get customer assignments by assignment id without submission",
+			"1",
+			nil,
+			nil,
+			false,
+			[]dto.CustomerAssignment{},
+			nil,
+		},
+		{
+			"fail get customer assignments of assignment of other instructor",
+			"other",
+			nil,
+			nil,
 			true,
-			errors.New("error"),
+			nil,
+			errors.New(constantError.ErrorNotAuthorized),
 		},
 	}
 	for _, v := range testCase {
-		mockCall := s.mock.On("UpdateCustomerAssignment", mock.Anything).Return(v.MockReturnError)
+		mockCall := s.mock.On("GetCustomerAssignmentsByAssignmentID", "abcde").Return(v.MockReturnBody, v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.customerAssignmentService.UpdateCustomerAssignment(v.Body)
+			customerAssignments, err := s.customerAssignmentService.GetCustomerAssignmentsByAssignmentID("abcde", v.InstructorID)
 			if v.HasReturnError {
 				s.Error(err)
-				s.Equal(v.ExpectedError, err)
 				s.EqualError(err, v.ExpectedError.Error())
 			} else {
 				s.NoError(err)
 			}
+			s.Equal(v.ExpectedBody, customerAssignments)
 		})
 		// remove mock
 		mockCall.Unset()
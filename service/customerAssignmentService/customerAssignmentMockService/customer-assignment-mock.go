@@ -10,32 +10,50 @@ type CustomerAssignmentMock struct {
 	mock.Mock
 }
 
-func (c *CustomerAssignmentMock) CreateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
-	args := c.Called(customerAssignment)
+func (c *CustomerAssignmentMock) SaveDraft(input dto.CustomerAssignmentTransaction, customerID string) (dto.CustomerAssignment, error) {
+	args := c.Called(input, customerID)
 
-	return args.Error(0)
+	return args.Get(0).(dto.CustomerAssignment), args.Error(1)
 }
 
-func (c *CustomerAssignmentMock) DeleteCustomerAssignment(id string) error {
-	args := c.Called(id)
+func (c *CustomerAssignmentMock) SubmitAssignment(input dto.CustomerAssignmentTransaction, customerID string) (dto.CustomerAssignment, error) {
+	args := c.Called(input, customerID)
 
-	return args.Error(0)
+	return args.Get(0).(dto.CustomerAssignment), args.Error(1)
 }
 
-func (c *CustomerAssignmentMock) GetAllCustomerAssignment() ([]dto.CustomerAssignmentAcc, error) {
-	args := c.Called()
+func (c *CustomerAssignmentMock) GradeCustomerAssignment(input dto.AssignmentGrade, instructorID string) (dto.CustomerAssignment, error) {
+	args := c.Called(input, instructorID)
 
-	return args.Get(0).([]dto.CustomerAssignmentAcc), args.Error(1)
+	return args.Get(0).(dto.CustomerAssignment), args.Error(1)
 }
 
-func (c *CustomerAssignmentMock) GetCustomerAssignmentByID(id string) (dto.CustomerAssignmentAcc, error) {
-	args := c.Called(id)
+func (c *CustomerAssignmentMock) ReturnCustomerAssignment(input dto.AssignmentReturn, instructorID string) (dto.CustomerAssignment, error) {
+	args := c.Called(input, instructorID)
 
-	return args.Get(0).(dto.CustomerAssignmentAcc), args.Error(1)
+	return args.Get(0).(dto.CustomerAssignment), args.Error(1)
 }
 
-func (c *CustomerAssignmentMock) UpdateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
-	args := c.Called(customerAssignment)
+func (c *CustomerAssignmentMock) DeleteCustomerAssignment(id string, user dto.User) error {
+	args := c.Called(id, user)
 
 	return args.Error(0)
 }
+
+func (c *CustomerAssignmentMock) GetCustomerAssignments(customerID string) ([]dto.CustomerAssignment, error) {
+	args := c.Called(customerID)
+
+	return args.Get(0).([]dto.CustomerAssignment), args.Error(1)
+}
+
+func (c *CustomerAssignmentMock) GetCustomerAssignmentsByAssignmentID(assignmentID, instructorID string) ([]dto.CustomerAssignment, error) {
+	args := c.Called(assignmentID, instructorID)
+
+	return args.Get(0).([]dto.CustomerAssignment), args.Error(1)
+}
+
+func (c *CustomerAssignmentMock) GetCustomerAssignmentByID(id string, user dto.User) (dto.CustomerAssignment, error) {
+	args := c.Called(id, user)
+
+	return args.Get(0).(dto.CustomerAssignment), args.Error(1)
+}
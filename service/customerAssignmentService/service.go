@@ -1,74 +1,274 @@
 package customerAssignmentService
 
 import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
 	customerAssignmentrepository "golang/repository/customerAssignmentRepository"
+	"golang/repository/customerCourseRepository"
+	"golang/service/ownershipService"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type CustomerAssignmentService interface {
-	CreateCustomerAssignment(dto.CustomerAssignmentTransaction) error
-	DeleteCustomerAssignment(id string) error
-	GetAllCustomerAssignment() ([]dto.CustomerAssignmentAcc, error)
-	GetCustomerAssignmentByID(id string) (dto.CustomerAssignmentAcc, error)
-	UpdateCustomerAssignment(dto.CustomerAssignmentTransaction) error
+	SaveDraft(input dto.CustomerAssignmentTransaction, customerID string) (dto.CustomerAssignment, error)
+	SubmitAssignment(input dto.CustomerAssignmentTransaction, customerID string) (dto.CustomerAssignment, error)
+	GradeCustomerAssignment(input dto.AssignmentGrade, instructorID string) (dto.CustomerAssignment, error)
+	ReturnCustomerAssignment(input dto.AssignmentReturn, instructorID string) (dto.CustomerAssignment, error)
+	DeleteCustomerAssignment(id string, user dto.User) error
+	GetCustomerAssignments(customerID string) ([]dto.CustomerAssignment, error)
+	GetCustomerAssignmentsByAssignmentID(assignmentID, instructorID string) ([]dto.CustomerAssignment, error)
+	GetCustomerAssignmentByID(id string, user dto.User) (dto.CustomerAssignment, error)
 }
 
 type customerAssignmentService struct {
 	customerAssignmentRepo customerAssignmentrepository.CustomerAssignmentRepository
+	customerCourseRepo     customerCourseRepository.CustomerCourseRepository
+	ownershipService       ownershipService.OwnershipService
 }
 
-// CreateCustomerAssignment implements CustomerAssignmentService
-func (cas *customerAssignmentService) CreateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
-	id := helper.GenerateUUID()
-	customerAssignment.ID = id
-	err := cas.customerAssignmentRepo.CreateCustomerAssignment(customerAssignment)
+// SaveDraft implements CustomerAssignmentService, the draft is kept until the customer submits it
+// and a returned submission gets its new file as a draft too
+func (cas *customerAssignmentService) SaveDraft(input dto.CustomerAssignmentTransaction, customerID string) (dto.CustomerAssignment, error) {
+	assignment, err := cas.getAssignment(input.AssignmentID, customerID)
 	if err != nil {
-		return err
+		return dto.CustomerAssignment{}, err
+	}
+	if input.File == "" {
+		return dto.CustomerAssignment{}, errors.New(constantError.ErrorAssignmentNoFile)
+	}
+	if assignment.CloseAt != nil && time.Now().After(*assignment.CloseAt) {
+		return dto.CustomerAssignment{}, errors.New(constantError.ErrorAssignmentClosed)
+	}
+
+	submission, err := cas.customerAssignmentRepo.GetCustomerAssignment(input.AssignmentID, customerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		submission = dto.CustomerAssignment{
+			ID:           helper.GenerateUUID(),
+			File:         input.File,
+			AssignmentID: input.AssignmentID,
+			CustomerID:   customerID,
+		}
+		err = cas.customerAssignmentRepo.CreateCustomerAssignment(submission)
+	} else if err == nil {
+		if submission.Status != dto.AssignmentDraft && submission.Status != dto.AssignmentReturned {
+			return dto.CustomerAssignment{}, errors.New(constantError.ErrorAssignmentSubmitted)
+		}
+		err = cas.customerAssignmentRepo.UpdateCustomerAssignmentDraft(submission.ID, input.File)
+	}
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	return cas.customerAssignmentRepo.GetCustomerAssignmentByID(submission.ID)
+}
+
+// SubmitAssignment implements CustomerAssignmentService, the file or the draft is submitted as a new version.
+// A version after the due time is late and its grade gets the late penalty of the assignment
+func (cas *customerAssignmentService) SubmitAssignment(input dto.CustomerAssignmentTransaction, customerID string) (dto.CustomerAssignment, error) {
+	assignment, err := cas.getAssignment(input.AssignmentID, customerID)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+
+	submission, err := cas.customerAssignmentRepo.GetCustomerAssignment(input.AssignmentID, customerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		submission = dto.CustomerAssignment{
+			ID:           helper.GenerateUUID(),
+			AssignmentID: input.AssignmentID,
+			CustomerID:   customerID,
+		}
+	} else if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	now := time.Now()
+	err = helper.CheckAssignmentSubmission(assignment, submission, now)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	if input.File != "" {
+		submission.File = input.File
+	}
+	if submission.File == "" {
+		return dto.CustomerAssignment{}, errors.New(constantError.ErrorAssignmentNoFile)
+	}
+
+	// the grade of the version before is removed, the new version is graded again
+	submission.Status, submission.LatePenalty = helper.GetAssignmentStatus(assignment, now)
+	submission.Attempts++
+	submission.SubmittedAt = &now
+	submission.Grade = 0
+	submission.Score = 0
+	submission.GradedAt = nil
+	version := dto.CustomerAssignmentVersion{
+		ID:                   helper.GenerateUUID(),
+		CustomerAssignmentID: submission.ID,
+		NoVersion:            submission.Attempts,
+		File:                 submission.File,
+		Status:               submission.Status,
+		LatePenalty:          submission.LatePenalty,
+		SubmittedAt:          now,
+	}
+	err = cas.customerAssignmentRepo.SubmitCustomerAssignment(submission, version)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	return cas.customerAssignmentRepo.GetCustomerAssignmentByID(submission.ID)
+}
+
+// GradeCustomerAssignment implements CustomerAssignmentService, the late penalty of the last version is taken from the grade
+func (cas *customerAssignmentService) GradeCustomerAssignment(input dto.AssignmentGrade, instructorID string) (dto.CustomerAssignment, error) {
+	submission, err := cas.getSubmittedAssignment(input.ID, instructorID)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	assignment, err := cas.customerAssignmentRepo.GetAssignment(submission.AssignmentID)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	if input.Grade > assignment.MaxPoints {
+		return dto.CustomerAssignment{}, errors.New(constantError.ErrorAssignmentGrade)
+	}
+
+	now := time.Now()
+	submission.Status = dto.AssignmentGraded
+	submission.Grade = input.Grade
+	submission.Score = helper.GetAssignmentScore(input.Grade, submission.LatePenalty)
+	submission.Feedback = input.Feedback
+	submission.GradedAt = &now
+	err = cas.customerAssignmentRepo.GradeCustomerAssignment(submission)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	return submission, nil
+}
+
+// ReturnCustomerAssignment implements CustomerAssignmentService, the customer can submit the returned submission again
+func (cas *customerAssignmentService) ReturnCustomerAssignment(input dto.AssignmentReturn, instructorID string) (dto.CustomerAssignment, error) {
+	submission, err := cas.getSubmittedAssignment(input.ID, instructorID)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
 	}
-	return nil
+
+	err = cas.customerAssignmentRepo.ReturnCustomerAssignment(submission.ID, input.Feedback)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	submission.Status = dto.AssignmentReturned
+	submission.Grade = 0
+	submission.Score = 0
+	submission.Feedback = input.Feedback
+	submission.GradedAt = nil
+	return submission, nil
 }
 
-// DeleteCustomerAssignment implements CustomerAssignmentService
-func (cas *customerAssignmentService) DeleteCustomerAssignment(id string) error {
-	// call repository to delete account
-	err := cas.customerAssignmentRepo.DeleteCustomerAssignment(id)
+// DeleteCustomerAssignment implements CustomerAssignmentService, the customer can only delete a draft
+// and the instructor can delete the submissions of their assignments
+func (cas *customerAssignmentService) DeleteCustomerAssignment(id string, user dto.User) error {
+	submission, err := cas.GetCustomerAssignmentByID(id, user)
 	if err != nil {
 		return err
 	}
-	return nil
+	if user.Role == constantRole.RoleCustomer && submission.Status != dto.AssignmentDraft {
+		return errors.New(constantError.ErrorAssignmentSubmitted)
+	}
+
+	// call repository to delete the submission
+	return cas.customerAssignmentRepo.DeleteCustomerAssignment(id)
 }
 
-// GetAllCustomerAssignment implements CustomerAssignmentService
-func (cas *customerAssignmentService) GetAllCustomerAssignment() ([]dto.CustomerAssignmentAcc, error) {
-	customerAssignments, err := cas.customerAssignmentRepo.GetAllCustomerAssignment()
+// GetCustomerAssignments implements CustomerAssignmentService
+func (cas *customerAssignmentService) GetCustomerAssignments(customerID string) ([]dto.CustomerAssignment, error) {
+	customerAssignments, err := cas.customerAssignmentRepo.GetCustomerAssignments(customerID)
 	if err != nil {
 		return nil, err
 	}
+	if len(customerAssignments) == 0 {
+		return []dto.CustomerAssignment{}, nil
+	}
 	return customerAssignments, nil
 }
 
-// GetCustomerAssignmentByID implements CustomerAssignmentService
-func (cas *customerAssignmentService) GetCustomerAssignmentByID(id string) (dto.CustomerAssignmentAcc, error) {
+// GetCustomerAssignmentsByAssignmentID implements CustomerAssignmentService
+func (cas *customerAssignmentService) GetCustomerAssignmentsByAssignmentID(assignmentID, instructorID string) ([]dto.CustomerAssignment, error) {
+	// check if the assignment is owned by the instructor
+	err := cas.ownershipService.CheckAssignmentOwner(assignmentID, instructorID)
+	if err != nil {
+		return nil, err
+	}
+
+	customerAssignments, err := cas.customerAssignmentRepo.GetCustomerAssignmentsByAssignmentID(assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(customerAssignments) == 0 {
+		return []dto.CustomerAssignment{}, nil
+	}
+	return customerAssignments, nil
+}
+
+// GetCustomerAssignmentByID implements CustomerAssignmentService, the customer gets their own submission
+// and the instructor gets the submissions of their assignments
+func (cas *customerAssignmentService) GetCustomerAssignmentByID(id string, user dto.User) (dto.CustomerAssignment, error) {
 	customerAssignment, err := cas.customerAssignmentRepo.GetCustomerAssignmentByID(id)
 	if err != nil {
-		return dto.CustomerAssignmentAcc{}, err
+		return dto.CustomerAssignment{}, err
+	}
+	if user.Role == constantRole.RoleCustomer {
+		if customerAssignment.CustomerID != user.ID {
+			return dto.CustomerAssignment{}, errors.New(constantError.ErrorNotAuthorized)
+		}
+		return customerAssignment, nil
+	}
+
+	// check if the assignment is owned by the instructor
+	err = cas.ownershipService.CheckAssignmentOwner(customerAssignment.AssignmentID, user.ID)
+	if err != nil {
+		return dto.CustomerAssignment{}, err
 	}
 	return customerAssignment, nil
 }
 
-// UpdateCustomerAssignment implements CustomerAssignmentService
-func (cas *customerAssignmentService) UpdateCustomerAssignment(customerAssignment dto.CustomerAssignmentTransaction) error {
-	// call repository to update CustomerAssignment
-	err := cas.customerAssignmentRepo.UpdateCustomerAssignment(customerAssignment)
+// getAssignment gets the assignment of the submission of the customer, the customer has to be enrolled in its course
+func (cas *customerAssignmentService) getAssignment(assignmentID, customerID string) (dto.Assignment, error) {
+	assignment, err := cas.customerAssignmentRepo.GetAssignment(assignmentID)
 	if err != nil {
-		return err
+		return dto.Assignment{}, err
+	}
+	customerCourse, err := cas.customerCourseRepo.GetCustomerCourse(assignment.CourseID, customerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.Assignment{}, errors.New(constantError.ErrorCustomerNotEnrolled)
+		}
+		return dto.Assignment{}, err
+	}
+	if !customerCourse.Status {
+		return dto.Assignment{}, errors.New(constantError.ErrorCustomerNotEnrolled)
+	}
+	return assignment, nil
+}
+
+// getSubmittedAssignment gets a submission the instructor can grade or return
+func (cas *customerAssignmentService) getSubmittedAssignment(id, instructorID string) (dto.CustomerAssignment, error) {
+	submission, err := cas.GetCustomerAssignmentByID(id, dto.User{ID: instructorID, Role: constantRole.RoleInstructor})
+	if err != nil {
+		return dto.CustomerAssignment{}, err
+	}
+	switch submission.Status {
+	case dto.AssignmentSubmitted, dto.AssignmentLate, dto.AssignmentGraded:
+		return submission, nil
 	}
-	return nil
+	return dto.CustomerAssignment{}, errors.New(constantError.ErrorAssignmentNotSubmitted)
 }
 
-func NewcustomerAssignmentService(customerAssignmentRepo customerAssignmentrepository.CustomerAssignmentRepository) CustomerAssignmentService {
+func NewcustomerAssignmentService(customerAssignmentRepo customerAssignmentrepository.CustomerAssignmentRepository, customerCourseRepo customerCourseRepository.CustomerCourseRepository,
+	ownershipService ownershipService.OwnershipService) CustomerAssignmentService {
 	return &customerAssignmentService{
 		customerAssignmentRepo: customerAssignmentRepo,
+		customerCourseRepo:     customerCourseRepo,
+		ownershipService:       ownershipService,
 	}
 }
@@ -7,6 +7,9 @@ import (
 	"golang/models/dto"
 	"golang/repository/courseRepository"
 	"golang/repository/customerCourseRepository"
+	"golang/repository/customerRepository"
+	"golang/service/mailService"
+	"log"
 
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
@@ -23,6 +26,8 @@ type CustomerCourseService interface {
 type customerCourseService struct {
 	courseRepo         courseRepository.CourseRepository
 	customerCourseRepo customerCourseRepository.CustomerCourseRepository
+	customerRepo       customerRepository.CustomerRepository
+	mailService        mailService.MailService
 }
 
 // DeleteCustomerCourse implements CustomerCourseService
@@ -74,24 +79,16 @@ func (ccs *customerCourseService) GetHistoryCourseByCustomerID(customerID string
 	}
 
 	for i, course := range courses {
-		// get rating of all courses
-		rating := helper.GetRatingCourse(course)
-		courses[i].Rating = rating
+		// get rating, number of module and sum of customer of all courses
+		helper.GetCourseStats(&courses[i])
 
 		// get favorite of all courses
 		favorite := helper.GetFavoriteCourse(course, customerID)
 		courses[i].Favorite = favorite
 
-		// get number of module
-		numberOfModule := len(course.Modules)
-		courses[i].NumberOfModules = numberOfModule
-
 		// get status enroll of all courses
 		courses[i].StatusEnroll = course.CustomerCourses[0].Status
 
-		sumCustomer := len(course.CustomerCourses)
-		courses[i].AmountCustomer = sumCustomer
-
 		helper.GetEnrolledCourse(&course, customerID)
 		courses[i].ProgressModule = course.ProgressModule
 		courses[i].IsFinish = course.IsFinish
@@ -117,6 +114,13 @@ func (ccs *customerCourseService) TakeCourse(customerCourse dto.CustomerCourseTr
 	if err != nil {
 		return err
 	}
+	// only published courses accept new customers
+	if course.Status == dto.CourseStatusArchived {
+		return errors.New(constantError.ErrorCourseArchived)
+	}
+	if course.Status != dto.CourseStatusPublished {
+		return errors.New(constantError.ErrorCourseNotFound)
+	}
 	// check capacity course
 	if course.Capacity == 0 {
 		return errors.New(constantError.ErrorCourseCapacity)
@@ -128,6 +132,17 @@ func (ccs *customerCourseService) TakeCourse(customerCourse dto.CustomerCourseTr
 		return errors.New(constantError.ErrorCustomerAlreadyTakeCourse)
 	}
 
+	// check if customer finished the prerequisites of the course
+	prerequisites, err := ccs.courseRepo.GetCoursePrerequisites(customerCourse.CourseID, customerCourse.CustomerID)
+	if err != nil {
+		return err
+	}
+	for _, prerequisite := range prerequisites {
+		if !prerequisite.IsFinish {
+			return errors.New(constantError.ErrorPrerequisiteNotCompleted)
+		}
+	}
+
 	// create uuid for customer course
 	id := helper.GenerateUUID()
 	customerCourse.ID = id
@@ -153,6 +168,16 @@ func (ccs *customerCourseService) TakeCourse(customerCourse dto.CustomerCourseTr
 		return err
 	}
 
+	// the customer is already enrolled, a lost mail must not fail the enrollment
+	customer, err := ccs.customerRepo.GetCustomerByID(customerCourse.CustomerID)
+	if err != nil {
+		log.Printf("fail get customer %s for enrollment mail: %s", customerCourse.CustomerID, err)
+		return nil
+	}
+	err = ccs.mailService.SendEnrollment(customer.Email, customer.Name, course.Name)
+	if err != nil {
+		log.Printf("fail send enrollment mail to %s: %s", customer.Email, err)
+	}
 	return nil
 }
 
@@ -183,9 +208,11 @@ func (ccs *customerCourseService) UpdateEnrollmentStatus(customerCourse dto.Cust
 }
 
 func NewCustomerCourseService(customerCourseRepo customerCourseRepository.CustomerCourseRepository,
-	courseRepo courseRepository.CourseRepository) CustomerCourseService {
+	courseRepo courseRepository.CourseRepository, customerRepo customerRepository.CustomerRepository, mailService mailService.MailService) CustomerCourseService {
 	return &customerCourseService{
 		customerCourseRepo: customerCourseRepo,
 		courseRepo:         courseRepo,
+		customerRepo:       customerRepo,
+		mailService:        mailService,
 	}
 }
@@ -6,6 +6,8 @@ import (
 	"golang/models/dto"
 	"golang/repository/courseRepository/courseMockRepository"
 	"golang/repository/customerCourseRepository/customerCourseMockRepository"
+	customermockrepository "golang/repository/customerRepository/customerMockRepository"
+	"golang/service/mailService/mailMockService"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -18,12 +20,19 @@ type suiteCustomerCourse struct {
 	customerCourseService CustomerCourseService
 	mockCustomerCourse    *customerCourseMockRepository.CustomerCourseMock
 	mockCourse            *courseMockRepository.CourseMock
+	mockCustomer          *customermockrepository.CustomerMock
+	mockMail              *mailMockService.MailMock
 }
 
 func (s *suiteCustomerCourse) SetupTest() {
 	s.mockCustomerCourse = &customerCourseMockRepository.CustomerCourseMock{}
 	s.mockCourse = &courseMockRepository.CourseMock{}
-	NewCustomerCourseService := NewCustomerCourseService(s.mockCustomerCourse, s.mockCourse)
+	s.mockCustomer = &customermockrepository.CustomerMock{}
+	s.mockMail = &mailMockService.MailMock{}
+	s.mockCourse.On("GetCoursePrerequisites", mock.Anything, mock.Anything).Return([]dto.CoursePrerequisite{}, nil)
+	s.mockCustomer.On("GetCustomerByID", mock.Anything).Return(dto.CostumerResponseGet{ID: "abcde", Name: "customer", Email: "customer@gmail.com"}, nil)
+	s.mockMail.On("SendEnrollment", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	NewCustomerCourseService := NewCustomerCourseService(s.mockCustomerCourse, s.mockCourse, s.mockCustomer, s.mockMail)
 	s.customerCourseService = NewCustomerCourseService
 }
 
@@ -311,6 +320,7 @@ func (s *suiteCustomerCourse) TestGetHistoryCourseByCustomerID() {
 							CourseID: "abcde",
 						},
 					},
+					Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 				},
 			},
 			nil,
@@ -471,6 +481,7 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 			gorm.ErrRecordNotFound, 
 			dto.Course{
 				ID:           "abcde",
+				Status:       dto.CourseStatusPublished,
 				Name:         "test",
 				Description:  "test",
 				Objective:    "test",
@@ -533,6 +544,7 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 			gorm.ErrRecordNotFound, 
 			dto.Course{
 				ID:           "abcde",
+				Status:       dto.CourseStatusPublished,
 				Name:         "test",
 				Description:  "test",
 				Objective:    "test",
@@ -564,6 +576,7 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 			nil, 
 			dto.Course{
 				ID:           "abcde",
+				Status:       dto.CourseStatusPublished,
 				Name:         "test",
 				Description:  "test",
 				Objective:    "test",
@@ -595,6 +608,7 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 			gorm.ErrRecordNotFound, 
 			dto.Course{
 				ID:           "abcde",
+				Status:       dto.CourseStatusPublished,
 				Name:         "test",
 				Description:  "test",
 				Objective:    "test",
@@ -626,6 +640,7 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 			gorm.ErrRecordNotFound, 
 			dto.Course{
 				ID:           "abcde",
+				Status:       dto.CourseStatusPublished,
 				Name:         "test",
 				Description:  "test",
 				Objective:    "test",
@@ -648,6 +663,54 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 			true,
 			errors.New("error"),
 		},
+		{
+			"fail take archived course",
+			dto.User{
+				ID:   "abcde",
+				Role: "customer",
+			},
+			dto.CustomerCourseTransaction{
+				CustomerID: "abcde",
+				CourseID:   "abcde",
+			},
+			dto.CustomerCourse{},
+			gorm.ErrRecordNotFound,
+			dto.Course{
+				ID:       "abcde",
+				Status:   dto.CourseStatusArchived,
+				Capacity: 10,
+			},
+			nil,
+			nil,
+			dto.CourseTransaction{},
+			nil,
+			true,
+			errors.New(constantError.ErrorCourseArchived),
+		},
+		{
+			"fail take draft course",
+			dto.User{
+				ID:   "abcde",
+				Role: "customer",
+			},
+			dto.CustomerCourseTransaction{
+				CustomerID: "abcde",
+				CourseID:   "abcde",
+			},
+			dto.CustomerCourse{},
+			gorm.ErrRecordNotFound,
+			dto.Course{
+				ID:       "abcde",
+				Status:   dto.CourseStatusDraft,
+				Capacity: 10,
+			},
+			nil,
+			nil,
+			dto.CourseTransaction{},
+			nil,
+			true,
+			errors.New(constantError.ErrorCourseNotFound),
+		},
 	}
 	for _, v := range testCase {
 		mockCallGetCourseByID := s.mockCourse.On("GetCourseByID", v.Body.CourseID).Return(v.MockReturnGetCourse, v.MockReturnGetCourseError)
@@ -672,6 +735,106 @@ func (s *suiteCustomerCourse) TestTakeCourse() {
 	}
 }
 
+func (s *suiteCustomerCourse) TestTakeCourseWithPrerequisites() {
+	testCase := []struct {
+		Name                         string
+		MockReturnPrerequisites      []dto.CoursePrerequisite
+		MockReturnPrerequisitesError error
+		HasReturnError               bool
+		ExpectedError                error
+	}{
+		{
+			"success take course after prerequisites",
+			[]dto.CoursePrerequisite{
+				{ID: "basic", Name: "basic", IsFinish: true},
+			},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail take course before prerequisites",
+			[]dto.CoursePrerequisite{
+				{ID: "basic", Name: "basic", IsFinish: true},
+				{ID: "intermediate", Name: "intermediate", IsFinish: false},
+			},
+			nil,
+			true,
+			errors.New(constantError.ErrorPrerequisiteNotCompleted),
+		},
+		{
+			"fail get prerequisites",
+			[]dto.CoursePrerequisite{},
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	body := dto.CustomerCourseTransaction{
+		CustomerID: "abcde",
+		CourseID:   "abcde",
+	}
+	s.mockCourse.ExpectedCalls = nil
+	s.mockCourse.On("GetCourseByID", "abcde").Return(dto.Course{ID: "abcde", Status: dto.CourseStatusPublished, Capacity: 10}, nil)
+	s.mockCourse.On("UpdateCourse", mock.Anything).Return(nil)
+	s.mockCustomerCourse.On("GetCustomerCourse", "abcde", "abcde").Return(dto.CustomerCourse{}, gorm.ErrRecordNotFound)
+	s.mockCustomerCourse.On("TakeCourse", mock.Anything).Return(nil)
+	for _, v := range testCase {
+		mockCall := s.mockCourse.On("GetCoursePrerequisites", "abcde", "abcde").Return(v.MockReturnPrerequisites, v.MockReturnPrerequisitesError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerCourseService.TakeCourse(body)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteCustomerCourse) TestTakeCourseSendEnrollment() {
+	testCase := []struct {
+		Name                     string
+		MockReturnGetCustomerErr error
+		MockReturnSendErr        error
+		ExpectedSendCalled       bool
+	}{
+		{"success send enrollment mail", nil, nil, true},
+		{"success take course when mail fails", nil, errors.New("error"), true},
+		{"success take course when customer not found", gorm.ErrRecordNotFound, nil, false},
+	}
+	body := dto.CustomerCourseTransaction{
+		CustomerID: "abcde",
+		CourseID:   "abcde",
+	}
+	s.mockCourse.On("GetCourseByID", "abcde").Return(dto.Course{ID: "abcde", Name: "golang", Status: dto.CourseStatusPublished, Capacity: 10}, nil)
+	s.mockCourse.On("UpdateCourse", mock.Anything).Return(nil)
+	s.mockCustomerCourse.On("GetCustomerCourse", "abcde", "abcde").Return(dto.CustomerCourse{}, gorm.ErrRecordNotFound)
+	s.mockCustomerCourse.On("TakeCourse", mock.Anything).Return(nil)
+	s.mockCustomer.ExpectedCalls = nil
+	s.mockMail.ExpectedCalls = nil
+	for _, v := range testCase {
+		mockCallGetCustomer := s.mockCustomer.On("GetCustomerByID", "abcde").Return(dto.CostumerResponseGet{ID: "abcde", Name: "customer", Email: "customer@gmail.com"}, v.MockReturnGetCustomerErr)
+		mockCallSend := s.mockMail.On("SendEnrollment", "customer@gmail.com", "customer", "golang").Return(v.MockReturnSendErr)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.customerCourseService.TakeCourse(body)
+			s.NoError(err)
+			if v.ExpectedSendCalled {
+				s.mockMail.AssertCalled(t, "SendEnrollment", "customer@gmail.com", "customer", "golang")
+			} else {
+				s.mockMail.AssertNotCalled(t, "SendEnrollment", "customer@gmail.com", "customer", "golang")
+			}
+		})
+		// remove mock
+		mockCallGetCustomer.Unset()
+		mockCallSend.Unset()
+		s.mockMail.Calls = nil
+	}
+}
+
 func (s *suiteCustomerCourse) TestUpdateEnrollmentStatus() {
 	testCase := []struct {
 		Name                     string
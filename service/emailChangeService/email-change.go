@@ -0,0 +1,97 @@
+package emailChangeService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/emailChangeRepository"
+	"golang/service/mailService"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmailChangeService interface {
+	RequestEmailChange(userID, role, name, newEmail string) error
+	ConfirmEmailChange(userID, role, code string) (string, error)
+}
+
+type emailChangeService struct {
+	emailChangeRepo    emailChangeRepository.EmailChangeRepository
+	mailService        mailService.MailService
+	verificationPolicy dto.VerificationPolicy
+}
+
+// RequestEmailChange implements EmailChangeService, the code is mailed to the new email so the user proves owning it
+func (es *emailChangeService) RequestEmailChange(userID, role, name, newEmail string) error {
+	now := time.Now()
+	change, err := es.emailChangeRepo.GetEmailChange(userID, role)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err == nil && change.NewEmail == newEmail && now.Before(change.SentAt.Add(es.verificationPolicy.ResendCooldown)) {
+		return errors.New(constantError.ErrorResendCooldown)
+	}
+	if change.ID == "" {
+		change.ID = helper.GenerateUUID()
+	}
+
+	code, err := helper.GenerateVerificationCode(es.verificationPolicy.CodeLength)
+	if err != nil {
+		return err
+	}
+	change.UserID = userID
+	change.Role = role
+	change.NewEmail = newEmail
+	change.Code = code
+	change.ExpiresAt = now.Add(es.verificationPolicy.CodeExpiry)
+	change.Attempts = 0
+	change.SentAt = now
+	err = es.emailChangeRepo.SaveEmailChange(change)
+	if err != nil {
+		return err
+	}
+
+	return es.mailService.SendVerification(newEmail, name, code, es.verificationPolicy.CodeExpiry)
+}
+
+// ConfirmEmailChange implements EmailChangeService, it returns the new email once the code matches
+func (es *emailChangeService) ConfirmEmailChange(userID, role, code string) (string, error) {
+	change, err := es.emailChangeRepo.GetEmailChange(userID, role)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New(constantError.ErrorVerificationCodeInvalid)
+		}
+		return "", err
+	}
+
+	// the code is locked after too many wrong guesses until a new one is sent
+	if change.Attempts >= es.verificationPolicy.MaxAttempts {
+		return "", errors.New(constantError.ErrorTooManyAttempts)
+	}
+	if !time.Now().Before(change.ExpiresAt) {
+		return "", errors.New(constantError.ErrorVerificationCodeExpired)
+	}
+	if !helper.CompareVerificationCode(change.Code, code) {
+		err = es.emailChangeRepo.IncrementEmailChangeAttempts(change.ID)
+		if err != nil {
+			return "", err
+		}
+		return "", errors.New(constantError.ErrorVerificationCodeInvalid)
+	}
+
+	err = es.emailChangeRepo.DeleteEmailChange(change.ID)
+	if err != nil {
+		return "", err
+	}
+	return change.NewEmail, nil
+}
+
+func NewEmailChangeService(emailChangeRepo emailChangeRepository.EmailChangeRepository, mailService mailService.MailService, verificationPolicy dto.VerificationPolicy) EmailChangeService {
+	return &emailChangeService{
+		emailChangeRepo:    emailChangeRepo,
+		mailService:        mailService,
+		verificationPolicy: verificationPolicy,
+	}
+}
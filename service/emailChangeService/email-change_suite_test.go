@@ -0,0 +1,160 @@
+package emailChangeService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"golang/repository/emailChangeRepository/emailChangeMockRepository"
+	"golang/service/mailService/mailMockService"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteEmailChange struct {
+	suite.Suite
+	emailChangeService EmailChangeService
+	mock               *emailChangeMockRepository.EmailChangeMock
+	mockMail           *mailMockService.MailMock
+}
+
+func (s *suiteEmailChange) SetupTest() {
+	s.mock = &emailChangeMockRepository.EmailChangeMock{}
+	s.mockMail = &mailMockService.MailMock{}
+	s.emailChangeService = NewEmailChangeService(s.mock, s.mockMail, dto.VerificationPolicy{
+		CodeLength:     6,
+		CodeExpiry:     15 * time.Minute,
+		MaxAttempts:    3,
+		ResendCooldown: time.Minute,
+	})
+}
+
+func (s *suiteEmailChange) TestRequestEmailChange() {
+	testCase := []struct {
+		Name                  string
+		MockReturnChange      dto.EmailChange
+		MockReturnChangeError error
+		ExpectedSent          bool
+		ExpectedError         error
+	}{
+		{
+			"success request email change",
+			dto.EmailChange{},
+			gorm.ErrRecordNotFound,
+			true,
+			nil,
+		},
+		{
+			"success request another email right away",
+			dto.EmailChange{ID: "change", NewEmail: "old@gmail.com", SentAt: time.Now()},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"fail code recently sent",
+			dto.EmailChange{ID: "change", NewEmail: "new@gmail.com", SentAt: time.Now()},
+			nil,
+			false,
+			errors.New(constantError.ErrorResendCooldown),
+		},
+	}
+	for _, v := range testCase {
+		var saved dto.EmailChange
+		mockCallGet := s.mock.On("GetEmailChange", "abcde", "customer").Return(v.MockReturnChange, v.MockReturnChangeError)
+		mockCallSave := s.mock.On("SaveEmailChange", mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(dto.EmailChange)
+		}).Return(nil)
+		mockCallSend := s.mockMail.On("SendVerification", "new@gmail.com", "tes", mock.Anything, 15*time.Minute).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.emailChangeService.RequestEmailChange("abcde", "customer", "tes", "new@gmail.com")
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedSent {
+				s.mockMail.AssertCalled(t, "SendVerification", "new@gmail.com", "tes", saved.Code, 15*time.Minute)
+				s.Equal("new@gmail.com", saved.NewEmail)
+				s.NotEmpty(saved.ID)
+				s.Len(saved.Code, 6)
+			} else {
+				s.mockMail.AssertNotCalled(t, "SendVerification", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallSave.Unset()
+		mockCallSend.Unset()
+		s.mockMail.Calls = nil
+	}
+}
+
+func (s *suiteEmailChange) TestConfirmEmailChange() {
+	testCase := []struct {
+		Name                  string
+		Code                  string
+		MockReturnChange      dto.EmailChange
+		MockReturnChangeError error
+		ExpectedEmail         string
+		ExpectedError         error
+	}{
+		{
+			"success confirm email change",
+			"123456",
+			dto.EmailChange{ID: "change", NewEmail: "new@gmail.com", Code: "123456", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			"new@gmail.com",
+			nil,
+		},
+		{
+			"fail no pending change",
+			"123456",
+			dto.EmailChange{},
+			gorm.ErrRecordNotFound,
+			"",
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+		},
+		{
+			"fail code invalid",
+			"654321",
+			dto.EmailChange{ID: "change", NewEmail: "new@gmail.com", Code: "123456", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			"",
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+		},
+		{
+			"fail code expired",
+			"123456",
+			dto.EmailChange{ID: "change", NewEmail: "new@gmail.com", Code: "123456", ExpiresAt: time.Now().Add(-time.Minute)},
+			nil,
+			"",
+			errors.New(constantError.ErrorVerificationCodeExpired),
+		},
+		{
+			"fail too many attempts",
+			"123456",
+			dto.EmailChange{ID: "change", NewEmail: "new@gmail.com", Code: "123456", ExpiresAt: time.Now().Add(time.Minute), Attempts: 3},
+			nil,
+			"",
+			errors.New(constantError.ErrorTooManyAttempts),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetEmailChange", "abcde", "customer").Return(v.MockReturnChange, v.MockReturnChangeError)
+		mockCallIncrement := s.mock.On("IncrementEmailChangeAttempts", "change").Return(nil)
+		mockCallDelete := s.mock.On("DeleteEmailChange", "change").Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			email, err := s.emailChangeService.ConfirmEmailChange("abcde", "customer", v.Code)
+			s.Equal(v.ExpectedError, err)
+			s.Equal(v.ExpectedEmail, email)
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallIncrement.Unset()
+		mockCallDelete.Unset()
+	}
+}
+
+func TestSuiteEmailChange(t *testing.T) {
+	suite.Run(t, new(suiteEmailChange))
+}
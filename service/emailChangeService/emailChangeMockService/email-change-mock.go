@@ -0,0 +1,21 @@
+package emailChangeMockService
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type EmailChangeMock struct {
+	mock.Mock
+}
+
+func (e *EmailChangeMock) RequestEmailChange(userID, role, name, newEmail string) error {
+	args := e.Called(userID, role, name, newEmail)
+
+	return args.Error(0)
+}
+
+func (e *EmailChangeMock) ConfirmEmailChange(userID, role, code string) (string, error) {
+	args := e.Called(userID, role, code)
+
+	return args.String(0), args.Error(1)
+}
@@ -26,10 +26,13 @@ type favoriteService struct {
 // AddFavorite implements FavoriteService
 func (fs *favoriteService) AddFavorite(favorite dto.FavoriteTransaction) error {
 	// check if course is not found
-	_, err := fs.courseRepo.GetCourseByID(favorite.CourseID)
+	course, err := fs.courseRepo.GetCourseByID(favorite.CourseID)
 	if err != nil {
 		return err
 	}
+	if !helper.IsCourseVisible(course, favorite.CustomerID) {
+		return errors.New(constantError.ErrorCourseNotFound)
+	}
 
 	// check if customer already favorite the course
 	_, err = fs.favoriteRepo.GetFavorite(favorite.CourseID, favorite.CustomerID)
@@ -82,22 +85,13 @@ func (fs *favoriteService) GetFavoriteByCustomerID(customerID string) ([]dto.Get
 		return nil, err
 	}
 
-	// get rating of all courses
 	for i, course := range courses {
-		rating := helper.GetRatingCourse(course)
-		courses[i].Rating = rating
+		// get rating, number of module and sum of customer of all courses
+		helper.GetCourseStats(&courses[i])
 
 		// get favorite of all courses
 		courses[i].Favorite = true
 
-		// get number of module
-		numberOfModule := len(course.Modules)
-		courses[i].NumberOfModules = numberOfModule
-
-		// get sum of customer course
-		sumCustomer := len(course.CustomerCourses)
-		courses[i].AmountCustomer = sumCustomer
-
 		// get enrolled of all courses
 		helper.GetEnrolledCourse(&course, customerID)
 		courses[i].StatusEnroll = course.StatusEnroll
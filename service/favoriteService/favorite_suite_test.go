@@ -104,7 +104,7 @@ func (s *suiteFavorite) TestAddFavorite() {
 		},
 	}
 	for _, v := range testCase {
-		mockCallGetCourseByID := s.mockCourse.On("GetCourseByID", v.Body.CourseID).Return(dto.Course{}, v.MockReturnGetCourseError)
+		mockCallGetCourseByID := s.mockCourse.On("GetCourseByID", v.Body.CourseID).Return(dto.Course{Status: dto.CourseStatusPublished}, v.MockReturnGetCourseError)
 		mockCallGetFavorite := s.mockFavorite.On("GetFavorite", v.Body.CourseID, v.Body.CustomerID).Return(dto.Favorite{}, v.MockReturnGetFavoriteError)
 		mockCallAddFavorite := s.mockFavorite.On("AddFavorite", mock.Anything).Return(v.MockReturnAddFavoriteError)
 		s.T().Run(v.Name, func(t *testing.T) {
@@ -296,6 +296,7 @@ func (s *suiteFavorite) TestGetFavoriteByCustomerID() {
 							CourseID: "course1",
 						},
 					},
+					Stats: dto.CourseStats{RatingAverage: 5, RatingCount: 1, Rating5: 1, EnrolledCount: 1, ModuleCount: 1},
 				},
 			},
 			nil,
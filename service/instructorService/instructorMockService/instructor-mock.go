@@ -2,6 +2,7 @@ package instructormockservice
 
 import (
 	"golang/models/dto"
+	"io"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -21,3 +22,87 @@ func (c *InstructorMock) LoginInstructor(instructor dto.InstructorLogin) (dto.In
 
 	return args.Get(0).(dto.InstructorResponseGet), args.Error(0)
 }
+
+func (c *InstructorMock) GetAllInstructor() ([]dto.InstructorAccount, error) {
+	args := c.Called()
+
+	return args.Get(0).([]dto.InstructorAccount), args.Error(1)
+}
+
+func (c *InstructorMock) DeleteInstructor(id string) error {
+	args := c.Called(id)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) ForgotPassword(input dto.ForgotPassword) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) ResetPassword(input dto.ResetPassword) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) ChangePassword(id string, input dto.ChangePassword) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) VerifikasiInstructor(input dto.InstructorVerif) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) ResendVerificationCode(input dto.ResendCode) error {
+	args := c.Called(input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) UpdateInstructorStatus(id string, input dto.InstructorStatus) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) GetProfile(id string) (dto.InstructorProfile, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.InstructorProfile), args.Error(1)
+}
+
+func (c *InstructorMock) UpdateProfile(id string, input dto.InstructorProfileUpdate) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) ChangeEmail(id string, input dto.ChangeEmail) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) VerifyEmailChange(id string, input dto.VerifyEmailChange) error {
+	args := c.Called(id, input)
+
+	return args.Error(0)
+}
+
+func (c *InstructorMock) UpdateProfileImage(id string, image io.Reader) (string, error) {
+	args := c.Called(id, image)
+
+	return args.String(0), args.Error(1)
+}
+
+func (c *InstructorMock) GetPublicProfile(id string) (dto.InstructorPublicProfile, error) {
+	args := c.Called(id)
+
+	return args.Get(0).(dto.InstructorPublicProfile), args.Error(1)
+}
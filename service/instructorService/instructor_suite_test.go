@@ -0,0 +1,313 @@
+package instructorservice
+
+import (
+	"errors"
+	"golang/app/middlewares/session"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/drivers/storage"
+	"golang/models/dto"
+	"golang/repository/courseRepository/courseMockRepository"
+	instructormockrepository "golang/repository/instructorRepository/instructorMockRepository"
+	"golang/repository/sessionRepository"
+	"golang/service/emailChangeService/emailChangeMockService"
+	"golang/service/mailService/mailMockService"
+	"golang/service/passwordResetService/passwordResetMockService"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteInstructor struct {
+	suite.Suite
+	instructorService InstructorService
+	mock              *instructormockrepository.InstructorMock
+	mockMail          *mailMockService.MailMock
+	mockPasswordReset *passwordResetMockService.PasswordResetMock
+	mockCourse        *courseMockRepository.CourseMock
+	mockEmailChange   *emailChangeMockService.EmailChangeMock
+	storage           *storage.FakeStorage
+	policy            dto.VerificationPolicy
+}
+
+func (s *suiteInstructor) SetupTest() {
+	s.mock = &instructormockrepository.InstructorMock{}
+	s.mockMail = &mailMockService.MailMock{}
+	s.mockPasswordReset = &passwordResetMockService.PasswordResetMock{}
+	s.mockCourse = &courseMockRepository.CourseMock{}
+	s.mockEmailChange = &emailChangeMockService.EmailChangeMock{}
+	s.storage = &storage.FakeStorage{}
+	s.policy = dto.VerificationPolicy{
+		CodeLength:     6,
+		CodeExpiry:     15 * time.Minute,
+		MaxAttempts:    3,
+		ResendCooldown: time.Minute,
+	}
+	s.instructorService = NewinstructorService(s.mock, s.mockCourse, s.mockMail, s.mockPasswordReset, s.mockEmailChange, s.storage, s.policy, true)
+}
+
+func (s *suiteInstructor) TestCreateInstructor() {
+	testCase := []struct {
+		Name             string
+		ApprovalRequired bool
+		ExpectedStatus   string
+	}{
+		{
+			"success create pending instructor",
+			true,
+			dto.InstructorStatusPending,
+		},
+		{
+			"success create approved instructor",
+			false,
+			dto.InstructorStatusApproved,
+		},
+	}
+	for _, v := range testCase {
+		var saved dto.InstructorRegister
+		var savedCode dto.InstructorCode
+		mockCallGet := s.mock.On("GetInstructorByEmail", "tes@gmail.com").Return(dto.InstructorResponseGet{}, gorm.ErrRecordNotFound)
+		mockCallCreate := s.mock.On("CreateInstructor", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(0).(dto.InstructorRegister)
+			savedCode = args.Get(1).(dto.InstructorCode)
+		}).Return(nil)
+		mockCallSend := s.mockMail.On("SendVerification", "tes@gmail.com", "tes", mock.Anything, 15*time.Minute).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			service := NewinstructorService(s.mock, s.mockCourse, s.mockMail, s.mockPasswordReset, s.mockEmailChange, s.storage, s.policy, v.ApprovalRequired)
+			err := service.CreateInstructor(dto.InstructorRegister{Name: "tes", Email: "tes@gmail.com", Password: "password"})
+			s.NoError(err)
+			s.Equal(v.ExpectedStatus, saved.Status)
+			s.NotEqual("password", saved.Password)
+			s.Equal(saved.InstructorCodeID, savedCode.ID)
+			s.Len(savedCode.Code, 6)
+			s.mockMail.AssertCalled(t, "SendVerification", "tes@gmail.com", "tes", savedCode.Code, 15*time.Minute)
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallCreate.Unset()
+		mockCallSend.Unset()
+		s.mockMail.Calls = nil
+	}
+}
+
+func (s *suiteInstructor) TestVerifikasiInstructor() {
+	testCase := []struct {
+		Name                    string
+		Body                    dto.InstructorVerif
+		MockReturnCode          dto.InstructorCode
+		MockReturnCodeError     error
+		ExpectedIncrementCalled bool
+		ExpectedActivateCalled  bool
+		ExpectedError           error
+	}{
+		{
+			"success verif instructor",
+			dto.InstructorVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.InstructorCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			false,
+			true,
+			nil,
+		},
+		{
+			"fail code not found",
+			dto.InstructorVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.InstructorCode{},
+			gorm.ErrRecordNotFound,
+			false,
+			false,
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+		},
+		{
+			"fail code invalid",
+			dto.InstructorVerif{Email: "tes@gmail.com", Code: "654321"},
+			dto.InstructorCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			true,
+			false,
+			errors.New(constantError.ErrorVerificationCodeInvalid),
+		},
+		{
+			"fail code expired",
+			dto.InstructorVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.InstructorCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(-time.Minute)},
+			nil,
+			false,
+			false,
+			errors.New(constantError.ErrorVerificationCodeExpired),
+		},
+		{
+			"fail too many attempts",
+			dto.InstructorVerif{Email: "tes@gmail.com", Code: "123456"},
+			dto.InstructorCode{ID: "abcde", Code: "123456", ExpiresAt: time.Now().Add(time.Minute), Attempts: 3},
+			nil,
+			false,
+			false,
+			errors.New(constantError.ErrorTooManyAttempts),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetInstructorCode", v.Body.Email).Return(v.MockReturnCode, v.MockReturnCodeError)
+		mockCallIncrement := s.mock.On("IncrementInstructorCodeAttempts", "abcde").Return(nil)
+		mockCallActivate := s.mock.On("ActivateInstructor", v.Body.Email).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.instructorService.VerifikasiInstructor(v.Body)
+			s.Equal(v.ExpectedError, err)
+			if v.ExpectedIncrementCalled {
+				s.mock.AssertCalled(t, "IncrementInstructorCodeAttempts", "abcde")
+			} else {
+				s.mock.AssertNotCalled(t, "IncrementInstructorCodeAttempts", "abcde")
+			}
+			if v.ExpectedActivateCalled {
+				s.mock.AssertCalled(t, "ActivateInstructor", v.Body.Email)
+			} else {
+				s.mock.AssertNotCalled(t, "ActivateInstructor", v.Body.Email)
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallIncrement.Unset()
+		mockCallActivate.Unset()
+		s.mock.Calls = nil
+	}
+}
+
+func (s *suiteInstructor) TestGetPublicProfile() {
+	testCase := []struct {
+		Name                 string
+		MockReturnInstructor dto.InstructorResponseGet
+		MockReturnCourses    []dto.Course
+		ExpectedProfile      dto.InstructorPublicProfile
+		ExpectedError        error
+	}{
+		{
+			"success get public profile",
+			dto.InstructorResponseGet{ID: "abcde", Name: "tes", Headline: "teacher", IsActive: true},
+			[]dto.Course{
+				{ID: "course1", Name: "course 1", Status: dto.CourseStatusPublished, Stats: dto.CourseStats{RatingAverage: 4.5, RatingCount: 2, Rating4: 1, Rating5: 1, ModuleCount: 1}},
+				{ID: "course2", Name: "course 2", Status: dto.CourseStatusPublished, Stats: dto.CourseStats{RatingAverage: 3, RatingCount: 1, Rating3: 1}},
+				{ID: "course3", Name: "course 3", Status: dto.CourseStatusDraft},
+			},
+			dto.InstructorPublicProfile{
+				ID:          "abcde",
+				Name:        "tes",
+				Headline:    "teacher",
+				Rating:      4,
+				TotalRating: 3,
+				Courses: []dto.InstructorPublicCourse{
+					{ID: "course1", Name: "course 1", Rating: 4.5, NumberOfModules: 1},
+					{ID: "course2", Name: "course 2", Rating: 3},
+				},
+			},
+			nil,
+		},
+		{
+			"success get public profile without courses",
+			dto.InstructorResponseGet{ID: "abcde", Name: "tes", IsActive: true},
+			[]dto.Course{},
+			dto.InstructorPublicProfile{ID: "abcde", Name: "tes", Courses: []dto.InstructorPublicCourse{}},
+			nil,
+		},
+		{
+			"fail instructor not verified",
+			dto.InstructorResponseGet{ID: "abcde", Name: "tes"},
+			[]dto.Course{},
+			dto.InstructorPublicProfile{},
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetInstructorByID", "abcde").Return(v.MockReturnInstructor, nil)
+		mockCallCourse := s.mockCourse.On("GetAllCourse", dto.User{ID: "abcde", Role: "instructor"}).Return(v.MockReturnCourses, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			profile, err := s.instructorService.GetPublicProfile("abcde")
+			s.Equal(v.ExpectedError, err)
+			s.Equal(v.ExpectedProfile, profile)
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallCourse.Unset()
+	}
+}
+
+func (s *suiteInstructor) TestDeleteInstructor() {
+	testCase := []struct {
+		Name            string
+		MockReturnError error
+		RevokeFails     bool
+		ExpectedActive  bool
+		ExpectedError   error
+	}{
+		{"success delete instructor", nil, false, false, nil},
+		{"fail delete instructor", errors.New("error"), false, true, errors.New("error")},
+		{"fail revoke sessions", nil, true, true, errors.New("error")},
+	}
+	now := time.Now()
+	for _, v := range testCase {
+		var store sessionRepository.SessionStore = sessionRepository.NewMemorySessionStore()
+		if v.RevokeFails {
+			store = failingSessionStore{store}
+		}
+		session.SetStore(store)
+		s.NoError(session.Create("instructor-token", "abcde", constantRole.RoleInstructor, "", now, now.Add(time.Hour)))
+		mockCall := s.mock.On("DeleteInstructor", "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.instructorService.DeleteInstructor("abcde")
+			s.Equal(v.ExpectedError, err)
+			s.Equal(v.ExpectedActive, session.IsActive("instructor-token", constantRole.RoleInstructor))
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+	session.SetStore(sessionRepository.NewMemorySessionStore())
+}
+
+func (s *suiteInstructor) TestUpdateInstructorStatus() {
+	testCase := []struct {
+		Name            string
+		Status          string
+		MockReturnError error
+		RevokeFails     bool
+		ExpectedActive  bool
+		ExpectedError   error
+	}{
+		{"success suspend instructor", dto.InstructorStatusSuspended, nil, false, false, nil},
+		{"success approve instructor", dto.InstructorStatusApproved, nil, false, true, nil},
+		{"fail update status", dto.InstructorStatusSuspended, errors.New("error"), false, true, errors.New("error")},
+		{"fail revoke sessions", dto.InstructorStatusSuspended, nil, true, true, errors.New("error")},
+	}
+	now := time.Now()
+	for _, v := range testCase {
+		var store sessionRepository.SessionStore = sessionRepository.NewMemorySessionStore()
+		if v.RevokeFails {
+			store = failingSessionStore{store}
+		}
+		session.SetStore(store)
+		s.NoError(session.Create("instructor-token", "abcde", constantRole.RoleInstructor, "", now, now.Add(time.Hour)))
+		mockCall := s.mock.On("UpdateInstructorStatus", "abcde", v.Status).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.instructorService.UpdateInstructorStatus("abcde", dto.InstructorStatus{Status: v.Status})
+			s.Equal(v.ExpectedError, err)
+			s.Equal(v.ExpectedActive, session.IsActive("instructor-token", constantRole.RoleInstructor))
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+	session.SetStore(sessionRepository.NewMemorySessionStore())
+}
+
+// failingSessionStore is a session store that can not revoke the sessions of a user
+type failingSessionStore struct {
+	sessionRepository.SessionStore
+}
+
+func (f failingSessionStore) RevokeUserSessions(userID, role string) error {
+	return errors.New("error")
+}
+
+func TestSuiteInstructor(t *testing.T) {
+	suite.Run(t, new(suiteInstructor))
+}
@@ -1,52 +1,450 @@
 package instructorservice
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang/app/middlewares/session"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/drivers/storage"
 	"golang/helper"
 	"golang/models/dto"
+	"golang/repository/courseRepository"
 	instructorrepository "golang/repository/instructorRepository"
+	"golang/service/emailChangeService"
+	"golang/service/mailService"
+	"golang/service/passwordResetService"
+	"io"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type InstructorService interface {
 	CreateInstructor(user dto.InstructorRegister) error
+	VerifikasiInstructor(input dto.InstructorVerif) error
+	ResendVerificationCode(input dto.ResendCode) error
 	LoginInstructor(user dto.InstructorLogin) (dto.InstructorResponseGet, error)
+	ForgotPassword(input dto.ForgotPassword) error
+	ResetPassword(input dto.ResetPassword) error
+	ChangePassword(instructorID string, input dto.ChangePassword) error
+	GetProfile(instructorID string) (dto.InstructorProfile, error)
+	GetPublicProfile(instructorID string) (dto.InstructorPublicProfile, error)
+	UpdateProfile(instructorID string, input dto.InstructorProfileUpdate) error
+	ChangeEmail(instructorID string, input dto.ChangeEmail) error
+	VerifyEmailChange(instructorID string, input dto.VerifyEmailChange) error
+	UpdateProfileImage(instructorID string, image io.Reader) (string, error)
+	GetAllInstructor() ([]dto.InstructorAccount, error)
+	UpdateInstructorStatus(id string, input dto.InstructorStatus) error
+	DeleteInstructor(id string) error
 }
 
 type instructorService struct {
-	instructorRepo instructorrepository.InstructorRepository
+	instructorRepo       instructorrepository.InstructorRepository
+	courseRepo           courseRepository.CourseRepository
+	mailService          mailService.MailService
+	passwordResetService passwordResetService.PasswordResetService
+	emailChangeService   emailChangeService.EmailChangeService
+	storage              storage.Storage
+	verificationPolicy   dto.VerificationPolicy
+	// approvalRequired keeps new instructors pending until an admin approves them
+	approvalRequired bool
 }
 
 // CreateInstructor implements instructorService
 func (u *instructorService) CreateInstructor(user dto.InstructorRegister) error {
-	// hash password
-	id := helper.GenerateUUID()
+	// an account that is not verified yet only gets a new verification code
+	instructor, err := u.instructorRepo.GetInstructorByEmail(user.Email)
+	if err == nil && !instructor.IsActive {
+		err = u.sendVerificationCode(instructor)
+		if err != nil && err.Error() != constantError.ErrorResendCooldown {
+			log.Printf("fail send verification mail to %s: %s", instructor.Email, err)
+		}
+		return nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
 
+	id := helper.GenerateUUID()
+	codeId := helper.GenerateUUID()
 	user.ID = id
+	user.InstructorCodeID = codeId
+	// hash password
 	password, errPassword := helper.HashPassword(user.Password)
 	user.Password = password
 	if errPassword != nil {
 		return errPassword
 	}
 
+	user.Status = dto.InstructorStatusApproved
+	if u.approvalRequired {
+		user.Status = dto.InstructorStatusPending
+	}
+
+	// generate the verification code
+	code, err := helper.GenerateVerificationCode(u.verificationPolicy.CodeLength)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	instructorCode := dto.InstructorCode{
+		ID:        codeId,
+		Email:     user.Email,
+		Code:      code,
+		ExpiresAt: now.Add(u.verificationPolicy.CodeExpiry),
+		SentAt:    now,
+	}
+
 	// call repository to save user
-	err := u.instructorRepo.CreateInstructor(user)
+	err = u.instructorRepo.CreateInstructor(user, instructorCode)
 	if err != nil {
 		return err
 	}
+
+	// the mail is delivered by the outbox, the instructor can ask for a new code if it is lost
+	err = u.mailService.SendVerification(user.Email, user.Name, code, u.verificationPolicy.CodeExpiry)
+	if err != nil {
+		log.Printf("fail send verification mail to %s: %s", user.Email, err)
+	}
 	return nil
 }
 
+// VerifikasiInstructor implements InstructorService
+func (u *instructorService) VerifikasiInstructor(input dto.InstructorVerif) error {
+	instructorCode, err := u.instructorRepo.GetInstructorCode(input.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(constantError.ErrorVerificationCodeInvalid)
+		}
+		return err
+	}
+
+	// the code is locked after too many wrong guesses until a new one is sent
+	if instructorCode.Attempts >= u.verificationPolicy.MaxAttempts {
+		return errors.New(constantError.ErrorTooManyAttempts)
+	}
+	if !time.Now().Before(instructorCode.ExpiresAt) {
+		return errors.New(constantError.ErrorVerificationCodeExpired)
+	}
+	if !helper.CompareVerificationCode(instructorCode.Code, input.Code) {
+		err = u.instructorRepo.IncrementInstructorCodeAttempts(instructorCode.ID)
+		if err != nil {
+			return err
+		}
+		return errors.New(constantError.ErrorVerificationCodeInvalid)
+	}
+
+	return u.instructorRepo.ActivateInstructor(input.Email)
+}
+
+// ResendVerificationCode implements InstructorService
+func (u *instructorService) ResendVerificationCode(input dto.ResendCode) error {
+	instructor, err := u.instructorRepo.GetInstructorByEmail(input.Email)
+	if err != nil {
+		// do not tell whether the email is registered
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if instructor.IsActive {
+		return nil
+	}
+	return u.sendVerificationCode(instructor)
+}
+
 // LoginInstructor implements instructorService
 func (u *instructorService) LoginInstructor(user dto.InstructorLogin) (dto.InstructorResponseGet, error) {
 	// call repository to get user
 	InstructorLogin, err := u.instructorRepo.LoginInstructor(user)
 	if err != nil {
+		// send a new verification code if the email is not verified yet
+		if err.Error() == constantError.ErrorNoActive {
+			u.resendOnLogin(user.Email)
+		}
 		return dto.InstructorResponseGet{}, err
 	}
 	return InstructorLogin, nil
 }
 
-func NewinstructorService(instructorRepo instructorrepository.InstructorRepository) InstructorService {
+// ForgotPassword implements InstructorService
+func (u *instructorService) ForgotPassword(input dto.ForgotPassword) error {
+	instructor, err := u.instructorRepo.GetInstructorByEmail(input.Email)
+	if err != nil {
+		// do not tell whether the email is registered
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := u.passwordResetService.CreateResetToken(instructor.ID, constantRole.RoleInstructor)
+	if err != nil {
+		return err
+	}
+	return u.mailService.SendPasswordReset(instructor.Email, instructor.Name, token, passwordResetService.PasswordResetTokenDuration)
+}
+
+// ResetPassword implements InstructorService
+func (u *instructorService) ResetPassword(input dto.ResetPassword) error {
+	instructorID, err := u.passwordResetService.ConsumeResetToken(input.Token, constantRole.RoleInstructor)
+	if err != nil {
+		return err
+	}
+	return u.updatePassword(instructorID, input.Password)
+}
+
+// ChangePassword implements InstructorService
+func (u *instructorService) ChangePassword(instructorID string, input dto.ChangePassword) error {
+	instructor, err := u.instructorRepo.GetInstructorByID(instructorID)
+	if err != nil {
+		return err
+	}
+	if !helper.CheckPasswordHash(input.OldPassword, instructor.Password) {
+		return errors.New(constantError.ErrorOldPasswordNotMatch)
+	}
+	return u.updatePassword(instructorID, input.NewPassword)
+}
+
+// GetProfile implements InstructorService
+func (u *instructorService) GetProfile(instructorID string) (dto.InstructorProfile, error) {
+	instructor, err := u.instructorRepo.GetInstructorByID(instructorID)
+	if err != nil {
+		return dto.InstructorProfile{}, err
+	}
+	return dto.InstructorProfile{
+		ID:           instructor.ID,
+		Name:         instructor.Name,
+		Email:        instructor.Email,
+		ProfileImage: instructor.ProfileImage,
+		Headline:     instructor.Headline,
+		Bio:          instructor.Bio,
+		Status:       instructor.Status,
+	}, nil
+}
+
+// GetPublicProfile implements InstructorService, it shows the instructor to customers with the courses and their ratings
+func (u *instructorService) GetPublicProfile(instructorID string) (dto.InstructorPublicProfile, error) {
+	instructor, err := u.instructorRepo.GetInstructorByID(instructorID)
+	if err != nil {
+		return dto.InstructorPublicProfile{}, err
+	}
+	// accounts that are not verified are not shown
+	if !instructor.IsActive {
+		return dto.InstructorPublicProfile{}, gorm.ErrRecordNotFound
+	}
+
+	courses, err := u.courseRepo.GetAllCourse(dto.User{ID: instructorID, Role: constantRole.RoleInstructor})
+	if err != nil {
+		return dto.InstructorPublicProfile{}, err
+	}
+
+	profile := dto.InstructorPublicProfile{
+		ID:           instructor.ID,
+		Name:         instructor.Name,
+		ProfileImage: instructor.ProfileImage,
+		Headline:     instructor.Headline,
+		Bio:          instructor.Bio,
+		Courses:      []dto.InstructorPublicCourse{},
+	}
+	var sumRating float64
+	for _, course := range courses {
+		// the profile is public, so only the published courses are shown
+		if course.Status != dto.CourseStatusPublished {
+			continue
+		}
+		profile.Courses = append(profile.Courses, dto.InstructorPublicCourse{
+			ID:              course.ID,
+			Name:            course.Name,
+			Description:     course.Description,
+			Thumbnail:       course.Thumbnail,
+			Price:           course.Price,
+			Discount:        course.Discount,
+			Rating:          course.Stats.RatingAverage,
+			NumberOfModules: course.Stats.ModuleCount,
+		})
+		sumRating += course.Stats.RatingAverage * float64(course.Stats.RatingCount)
+		profile.TotalRating += course.Stats.RatingCount
+	}
+	// every rating counts the same, whichever course it is given to
+	if profile.TotalRating > 0 {
+		profile.Rating = sumRating / float64(profile.TotalRating)
+	}
+	return profile, nil
+}
+
+// UpdateProfile implements InstructorService
+func (u *instructorService) UpdateProfile(instructorID string, input dto.InstructorProfileUpdate) error {
+	return u.instructorRepo.UpdateInstructorProfile(instructorID, input)
+}
+
+// ChangeEmail implements InstructorService, the email is only changed once the new one is verified
+func (u *instructorService) ChangeEmail(instructorID string, input dto.ChangeEmail) error {
+	instructor, err := u.instructorRepo.GetInstructorByID(instructorID)
+	if err != nil {
+		return err
+	}
+	err = u.checkEmailAvailable(input.Email)
+	if err != nil {
+		return err
+	}
+	return u.emailChangeService.RequestEmailChange(instructorID, constantRole.RoleInstructor, instructor.Name, input.Email)
+}
+
+// VerifyEmailChange implements InstructorService
+func (u *instructorService) VerifyEmailChange(instructorID string, input dto.VerifyEmailChange) error {
+	email, err := u.emailChangeService.ConfirmEmailChange(instructorID, constantRole.RoleInstructor, input.Code)
+	if err != nil {
+		return err
+	}
+	// another account could take the email while the code was pending
+	err = u.checkEmailAvailable(email)
+	if err != nil {
+		return err
+	}
+	return u.instructorRepo.UpdateInstructorEmail(instructorID, email)
+}
+
+// UpdateProfileImage implements InstructorService, it returns the url of the new image
+func (u *instructorService) UpdateProfileImage(instructorID string, image io.Reader) (string, error) {
+	instructor, err := u.instructorRepo.GetInstructorByID(instructorID)
+	if err != nil {
+		return "", err
+	}
+	data, extension, err := helper.ReadProfileImage(image)
+	if err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("profiles/instructor/%s/%s%s", instructorID, helper.GenerateUUID(), extension)
+	url, err := u.storage.Put(key, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	err = u.instructorRepo.UpdateInstructorProfileImage(instructorID, url)
+	if err != nil {
+		u.storage.Delete(url)
+		return "", err
+	}
+
+	err = u.storage.Delete(instructor.ProfileImage)
+	if err != nil {
+		log.Printf("fail delete profile image %s: %s", instructor.ProfileImage, err)
+	}
+	return url, nil
+}
+
+// GetAllInstructor implements InstructorService
+func (u *instructorService) GetAllInstructor() ([]dto.InstructorAccount, error) {
+	accounts, err := u.instructorRepo.GetAllInstructor()
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// UpdateInstructorStatus implements InstructorService
+func (u *instructorService) UpdateInstructorStatus(id string, input dto.InstructorStatus) error {
+	err := u.instructorRepo.UpdateInstructorStatus(id, input.Status)
+	if err != nil {
+		return err
+	}
+	// a suspended instructor is logged out of every session
+	if input.Status == dto.InstructorStatusSuspended {
+		return session.RevokeUser(id, constantRole.RoleInstructor)
+	}
+	return nil
+}
+
+// DeleteInstructor implements InstructorService
+func (u *instructorService) DeleteInstructor(id string) error {
+	err := u.instructorRepo.DeleteInstructor(id)
+	if err != nil {
+		return err
+	}
+	// the deleted account can not use its tokens anymore
+	return session.RevokeUser(id, constantRole.RoleInstructor)
+}
+
+// updatePassword saves the new password and logs the instructor out of every session
+func (u *instructorService) updatePassword(instructorID, password string) error {
+	hash, err := helper.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	err = u.instructorRepo.UpdateInstructorPassword(instructorID, hash)
+	if err != nil {
+		return err
+	}
+	return session.RevokeUser(instructorID, constantRole.RoleInstructor)
+}
+
+// checkEmailAvailable fails when the email already belongs to an instructor
+func (u *instructorService) checkEmailAvailable(email string) error {
+	_, err := u.instructorRepo.GetInstructorByEmail(email)
+	if err == nil {
+		return errors.New(constantError.ErrorEmailAlreadyUsed)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// resendOnLogin sends a new verification code to an instructor that is not verified yet
+func (u *instructorService) resendOnLogin(email string) {
+	instructor, err := u.instructorRepo.GetInstructorByEmail(email)
+	if err == nil {
+		err = u.sendVerificationCode(instructor)
+	}
+	if err != nil && err.Error() != constantError.ErrorResendCooldown {
+		log.Printf("fail send verification mail to %s: %s", email, err)
+	}
+}
+
+// sendVerificationCode replaces the verification code of the instructor and mail it, unless the last one was sent too recently
+func (u *instructorService) sendVerificationCode(instructor dto.InstructorResponseGet) error {
+	now := time.Now()
+	instructorCode, err := u.instructorRepo.GetInstructorCode(instructor.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err == nil && now.Before(instructorCode.SentAt.Add(u.verificationPolicy.ResendCooldown)) {
+		return errors.New(constantError.ErrorResendCooldown)
+	}
+	if instructorCode.ID == "" {
+		instructorCode.ID = helper.GenerateUUID()
+	}
+
+	code, err := helper.GenerateVerificationCode(u.verificationPolicy.CodeLength)
+	if err != nil {
+		return err
+	}
+	instructorCode.Email = instructor.Email
+	instructorCode.Code = code
+	instructorCode.ExpiresAt = now.Add(u.verificationPolicy.CodeExpiry)
+	instructorCode.Attempts = 0
+	instructorCode.SentAt = now
+	err = u.instructorRepo.SaveInstructorCode(instructorCode)
+	if err != nil {
+		return err
+	}
+
+	return u.mailService.SendVerification(instructor.Email, instructor.Name, code, u.verificationPolicy.CodeExpiry)
+}
+
+func NewinstructorService(instructorRepo instructorrepository.InstructorRepository, courseRepo courseRepository.CourseRepository, mailService mailService.MailService, passwordResetService passwordResetService.PasswordResetService, emailChangeService emailChangeService.EmailChangeService, storage storage.Storage, verificationPolicy dto.VerificationPolicy, approvalRequired bool) InstructorService {
 	return &instructorService{
-		instructorRepo: instructorRepo,
+		instructorRepo:       instructorRepo,
+		courseRepo:           courseRepo,
+		mailService:          mailService,
+		passwordResetService: passwordResetService,
+		emailChangeService:   emailChangeService,
+		storage:              storage,
+		verificationPolicy:   verificationPolicy,
+		approvalRequired:     approvalRequired,
 	}
 }
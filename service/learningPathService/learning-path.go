@@ -0,0 +1,131 @@
+package learningPathService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/learningPathRepository"
+	"golang/service/ownershipService"
+)
+
+type LearningPathService interface {
+	CreateLearningPath(learningPath dto.LearningPathTransaction, instructorID string) error
+	DeleteLearningPath(id, instructorID string) error
+	GetLearningPathByID(id string, user dto.User) (dto.LearningPath, error)
+	GetLearningPaths(user dto.User) ([]dto.LearningPath, error)
+	UpdateLearningPath(learningPath dto.LearningPathTransaction, instructorID string) error
+}
+
+type learningPathService struct {
+	learningPathRepo learningPathRepository.LearningPathRepository
+	ownershipService ownershipService.OwnershipService
+}
+
+// CreateLearningPath implements LearningPathService
+func (lps *learningPathService) CreateLearningPath(learningPath dto.LearningPathTransaction, instructorID string) error {
+	err := lps.checkCourses(learningPath.CourseIDs, instructorID)
+	if err != nil {
+		return err
+	}
+
+	learningPath.ID = helper.GenerateUUID()
+	learningPath.InstructorID = instructorID
+	return lps.learningPathRepo.CreateLearningPath(learningPath)
+}
+
+// DeleteLearningPath implements LearningPathService
+func (lps *learningPathService) DeleteLearningPath(id, instructorID string) error {
+	// check if the learning path is owned by the instructor
+	err := lps.ownershipService.CheckLearningPathOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return lps.learningPathRepo.DeleteLearningPath(id)
+}
+
+// GetLearningPathByID implements LearningPathService, the customers get their progress in the path
+func (lps *learningPathService) GetLearningPathByID(id string, user dto.User) (dto.LearningPath, error) {
+	var customerID string
+	if user.Role == constantRole.RoleCustomer {
+		customerID = user.ID
+	} else {
+		// check if the learning path is owned by the instructor
+		err := lps.ownershipService.CheckLearningPathOwner(id, user.ID)
+		if err != nil {
+			return dto.LearningPath{}, err
+		}
+	}
+
+	learningPath, err := lps.learningPathRepo.GetLearningPathByID(id, customerID)
+	if err != nil {
+		return dto.LearningPath{}, err
+	}
+	helper.GetLearningPathProgress(&learningPath)
+	return learningPath, nil
+}
+
+// GetLearningPaths implements LearningPathService, the instructors get their learning paths and the customers get every path with their progress
+func (lps *learningPathService) GetLearningPaths(user dto.User) ([]dto.LearningPath, error) {
+	var instructorID, customerID string
+	if user.Role == constantRole.RoleCustomer {
+		customerID = user.ID
+	} else {
+		instructorID = user.ID
+	}
+
+	learningPaths, err := lps.learningPathRepo.GetLearningPaths(instructorID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(learningPaths) == 0 {
+		return []dto.LearningPath{}, nil
+	}
+	for i := range learningPaths {
+		helper.GetLearningPathProgress(&learningPaths[i])
+	}
+	return learningPaths, nil
+}
+
+// UpdateLearningPath implements LearningPathService, the courses are kept when no course is given
+func (lps *learningPathService) UpdateLearningPath(learningPath dto.LearningPathTransaction, instructorID string) error {
+	// check if the learning path is owned by the instructor
+	err := lps.ownershipService.CheckLearningPathOwner(learningPath.ID, instructorID)
+	if err != nil {
+		return err
+	}
+	if len(learningPath.CourseIDs) > 0 {
+		err = lps.checkCourses(learningPath.CourseIDs, instructorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return lps.learningPathRepo.UpdateLearningPath(learningPath)
+}
+
+// checkCourses checks every course of the path is a course of the instructor and is in the path once
+func (lps *learningPathService) checkCourses(courseIDs []string, instructorID string) error {
+	seen := map[string]bool{}
+	for _, courseID := range courseIDs {
+		if seen[courseID] {
+			return errors.New(constantError.ErrorLearningPathCourses)
+		}
+		seen[courseID] = true
+
+		err := lps.ownershipService.CheckCourseOwner(courseID, instructorID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewLearningPathService(learningPathRepo learningPathRepository.LearningPathRepository, ownershipService ownershipService.OwnershipService) LearningPathService {
+	return &learningPathService{
+		learningPathRepo: learningPathRepo,
+		ownershipService: ownershipService,
+	}
+}
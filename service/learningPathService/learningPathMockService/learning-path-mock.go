@@ -0,0 +1,37 @@
+package learningPathMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LearningPathMock struct {
+	mock.Mock
+}
+
+func (l *LearningPathMock) CreateLearningPath(learningPath dto.LearningPathTransaction, instructorID string) error {
+	args := l.Called(learningPath, instructorID)
+
+	return args.Error(0)
+}
+func (l *LearningPathMock) DeleteLearningPath(id, instructorID string) error {
+	args := l.Called(id, instructorID)
+
+	return args.Error(0)
+}
+func (l *LearningPathMock) GetLearningPathByID(id string, user dto.User) (dto.LearningPath, error) {
+	args := l.Called(id, user)
+
+	return args.Get(0).(dto.LearningPath), args.Error(1)
+}
+func (l *LearningPathMock) GetLearningPaths(user dto.User) ([]dto.LearningPath, error) {
+	args := l.Called(user)
+
+	return args.Get(0).([]dto.LearningPath), args.Error(1)
+}
+func (l *LearningPathMock) UpdateLearningPath(learningPath dto.LearningPathTransaction, instructorID string) error {
+	args := l.Called(learningPath, instructorID)
+
+	return args.Error(0)
+}
@@ -0,0 +1,389 @@
+package learningPathService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"golang/repository/learningPathRepository/learningPathMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteLearningPath struct {
+	suite.Suite
+	learningPathService LearningPathService
+	mock                *learningPathMockRepository.LearningPathMock
+	ownershipMock       *ownershipMockService.OwnershipMock
+}
+
+func (s *suiteLearningPath) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckCourseOwner", mock.Anything, "1").Return(nil)
+	s.ownershipMock.On("CheckCourseOwner", mock.Anything, "other").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.ownershipMock.On("CheckLearningPathOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckLearningPathOwner", "abcde", "other").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.mock = &learningPathMockRepository.LearningPathMock{}
+	s.learningPathService = NewLearningPathService(s.mock, s.ownershipMock)
+}
+
+func (s *suiteLearningPath) TestCreateLearningPath() {
+	testCase := []struct {
+		Name            string
+		Body            dto.LearningPathTransaction
+		InstructorID    string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success create learning path",
+			dto.LearningPathTransaction{Title: "go", CourseIDs: []string{"basic", "concurrency"}},
+			"1",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail create learning path with duplicate courses",
+			dto.LearningPathTransaction{Title: "go", CourseIDs: []string{"basic", "basic"}},
+			"1",
+			nil,
+			true,
+			errors.New(constantError.ErrorLearningPathCourses),
+		},
+		{
+			"fail create learning path with course of other instructor",
+			dto.LearningPathTransaction{Title: "go", CourseIDs: []string{"basic"}},
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail create learning path",
+			dto.LearningPathTransaction{Title: "go", CourseIDs: []string{"basic"}},
+			"1",
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("CreateLearningPath", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.learningPathService.CreateLearningPath(v.Body, v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the learning path gets a new id and the instructor of the token
+				learningPath := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).(dto.LearningPathTransaction)
+				s.NotEmpty(learningPath.ID)
+				s.Equal(v.InstructorID, learningPath.InstructorID)
+				s.Equal(v.Body.CourseIDs, learningPath.CourseIDs)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestDeleteLearningPath() {
+	testCase := []struct {
+		Name            string
+		InstructorID    string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success delete learning path",
+			"1",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail delete learning path of other instructor",
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail delete learning path",
+			"1",
+			gorm.ErrRecordNotFound,
+			true,
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteLearningPath", "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.learningPathService.DeleteLearningPath("abcde", v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestGetLearningPathByID() {
+	completedAt := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	testCase := []struct {
+		Name            string
+		User            dto.User
+		CustomerID      string
+		MockReturnBody  dto.LearningPath
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedBody    dto.LearningPath
+		ExpectedError   error
+	}{
+		{
+			"success get learning path with progress",
+			dto.User{ID: "customer", Role: "customer"},
+			"customer",
+			dto.LearningPath{
+				ID: "abcde",
+				Courses: []dto.LearningPathCourse{
+					{CourseID: "basic", NoCourse: 1, StatusEnroll: true, IsFinish: true},
+					{CourseID: "concurrency", NoCourse: 2, StatusEnroll: true},
+					{CourseID: "generics", NoCourse: 3},
+					{CourseID: "testing", NoCourse: 4},
+				},
+			},
+			nil,
+			false,
+			dto.LearningPath{
+				ID: "abcde",
+				Courses: []dto.LearningPathCourse{
+					{CourseID: "basic", NoCourse: 1, StatusEnroll: true, IsFinish: true},
+					{CourseID: "concurrency", NoCourse: 2, StatusEnroll: true},
+					{CourseID: "generics", NoCourse: 3},
+					{CourseID: "testing", NoCourse: 4},
+				},
+				ProgressPercentage: 25,
+			},
+			nil,
+		},
+		{
+			"success get completed learning path",
+			dto.User{ID: "customer", Role: "customer"},
+			"customer",
+			dto.LearningPath{
+				ID: "abcde",
+				Courses: []dto.LearningPathCourse{
+					{CourseID: "basic", NoCourse: 1, StatusEnroll: true, IsFinish: true},
+				},
+				CompletedAt: &completedAt,
+			},
+			nil,
+			false,
+			dto.LearningPath{
+				ID: "abcde",
+				Courses: []dto.LearningPathCourse{
+					{CourseID: "basic", NoCourse: 1, StatusEnroll: true, IsFinish: true},
+				},
+				ProgressPercentage: 100,
+				IsFinish:           true,
+				CompletedAt:        &completedAt,
+			},
+			nil,
+		},
+		{
+			"success get learning path as instructor",
+			dto.User{ID: "1", Role: "instructor"},
+			"",
+			dto.LearningPath{ID: "abcde", Courses: []dto.LearningPathCourse{}},
+			nil,
+			false,
+			dto.LearningPath{ID: "abcde", Courses: []dto.LearningPathCourse{}},
+			nil,
+		},
+		{
+			"fail get learning path of other instructor",
+			dto.User{ID: "other", Role: "instructor"},
+			"",
+			dto.LearningPath{},
+			nil,
+			true,
+			dto.LearningPath{},
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail get learning path",
+			dto.User{ID: "customer", Role: "customer"},
+			"customer",
+			dto.LearningPath{},
+			gorm.ErrRecordNotFound,
+			true,
+			dto.LearningPath{},
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetLearningPathByID", "abcde", v.CustomerID).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			learningPath, err := s.learningPathService.GetLearningPathByID("abcde", v.User)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedBody, learningPath)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestGetLearningPaths() {
+	testCase := []struct {
+		Name            string
+		User            dto.User
+		InstructorID    string
+		CustomerID      string
+		MockReturnBody  []dto.LearningPath
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedBody    []dto.LearningPath
+		ExpectedError   error
+	}{
+		{
+			"success get learning paths as customer",
+			dto.User{ID: "customer", Role: "customer"},
+			"",
+			"customer",
+			[]dto.LearningPath{
+				{ID: "abcde", Courses: []dto.LearningPathCourse{{CourseID: "basic", IsFinish: true}, {CourseID: "concurrency"}}},
+			},
+			nil,
+			false,
+			[]dto.LearningPath{
+				{ID: "abcde", Courses: []dto.LearningPathCourse{{CourseID: "basic", IsFinish: true}, {CourseID: "concurrency"}}, ProgressPercentage: 50},
+			},
+			nil,
+		},
+		{
+			"success get empty learning paths as instructor",
+			dto.User{ID: "1", Role: "instructor"},
+			"1",
+			"",
+			nil,
+			nil,
+			false,
+			[]dto.LearningPath{},
+			nil,
+		},
+		{
+			"fail get learning paths",
+			dto.User{ID: "1", Role: "instructor"},
+			"1",
+			"",
+			nil,
+			errors.New("error"),
+			true,
+			nil,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetLearningPaths", v.InstructorID, v.CustomerID).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			learningPaths, err := s.learningPathService.GetLearningPaths(v.User)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedBody, learningPaths)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteLearningPath) TestUpdateLearningPath() {
+	testCase := []struct {
+		Name            string
+		Body            dto.LearningPathTransaction
+		InstructorID    string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success update learning path without courses",
+			dto.LearningPathTransaction{ID: "abcde", Title: "go"},
+			"1",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"success update learning path courses",
+			dto.LearningPathTransaction{ID: "abcde", CourseIDs: []string{"concurrency", "basic"}},
+			"1",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail update learning path with duplicate courses",
+			dto.LearningPathTransaction{ID: "abcde", CourseIDs: []string{"basic", "basic"}},
+			"1",
+			nil,
+			true,
+			errors.New(constantError.ErrorLearningPathCourses),
+		},
+		{
+			"fail update learning path of other instructor",
+			dto.LearningPathTransaction{ID: "abcde", Title: "go"},
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail update learning path",
+			dto.LearningPathTransaction{ID: "abcde", Title: "go"},
+			"1",
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("UpdateLearningPath", v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.learningPathService.UpdateLearningPath(v.Body, v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteLearningPath(t *testing.T) {
+	suite.Run(t, new(suiteLearningPath))
+}
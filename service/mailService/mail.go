@@ -0,0 +1,192 @@
+package mailService
+
+import (
+	"golang/drivers/mailer"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/mailOutboxRepository"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxMailAttempts is how many times a mail is tried before it is marked as failed
+	MaxMailAttempts = 5
+	// mailRetryDelay is the delay before the first retry, it doubles on every attempt
+	mailRetryDelay = time.Minute
+	// outboxBatchSize is how many pending mails are delivered in one run
+	outboxBatchSize = 50
+	// outboxLockDuration is how long a claimed mail is kept by a worker before another worker can claim it again
+	outboxLockDuration = 5 * time.Minute
+	// outboxRetention is how long the sent and failed mails are kept in the outbox
+	outboxRetention = 7 * 24 * time.Hour
+)
+
+type MailService interface {
+	SendVerification(to, name, code string, expiresIn time.Duration) error
+	SendPasswordReset(to, name, token string, expiresIn time.Duration) error
+	SendEnrollment(to, name, courseName string) error
+	ProcessOutbox() error
+	PurgeOutbox() (int64, error)
+	StartOutboxWorker(interval time.Duration) (stop func())
+}
+
+type mailService struct {
+	mailOutboxRepo mailOutboxRepository.MailOutboxRepository
+	mailer         mailer.Mailer
+}
+
+// SendVerification implements MailService
+func (ms *mailService) SendVerification(to, name, code string, expiresIn time.Duration) error {
+	return ms.enqueue(mailer.TemplateVerification, to, mailer.TemplateData{
+		Name:      name,
+		Code:      code,
+		ExpiresIn: formatDuration(expiresIn),
+	})
+}
+
+// SendPasswordReset implements MailService
+func (ms *mailService) SendPasswordReset(to, name, token string, expiresIn time.Duration) error {
+	return ms.enqueue(mailer.TemplatePasswordReset, to, mailer.TemplateData{
+		Name:      name,
+		Token:     token,
+		ExpiresIn: formatDuration(expiresIn),
+	})
+}
+
+// SendEnrollment implements MailService
+func (ms *mailService) SendEnrollment(to, name, courseName string) error {
+	return ms.enqueue(mailer.TemplateEnrollment, to, mailer.TemplateData{
+		Name:       name,
+		CourseName: courseName,
+	})
+}
+
+// ProcessOutbox implements MailService
+func (ms *mailService) ProcessOutbox() error {
+	now := time.Now()
+	// the mails are claimed first so two workers never send the same mail
+	mails, err := ms.mailOutboxRepo.ClaimPendingMails(now, now.Add(outboxLockDuration), outboxBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, mail := range mails {
+		err = ms.mailer.Send(mailer.Message{
+			To:      []string{mail.Recipient},
+			Subject: mail.Subject,
+			HTML:    mail.HTML,
+			Text:    mail.Text,
+		})
+		if err == nil {
+			err = ms.mailOutboxRepo.MarkMailSent(mail.ID, time.Now())
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// give up after the last attempt, otherwise try again later with a growing delay
+		attempts := mail.Attempts + 1
+		if attempts >= MaxMailAttempts {
+			log.Printf("fail send mail %s to %s: %s", mail.ID, mail.Recipient, err)
+			err = ms.mailOutboxRepo.MarkMailFailed(mail.ID, attempts, err.Error())
+		} else {
+			err = ms.mailOutboxRepo.MarkMailRetry(mail.ID, attempts, now.Add(retryDelay(attempts)), err.Error())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeOutbox implements MailService, the sent and failed mails older than the retention are deleted
+func (ms *mailService) PurgeOutbox() (int64, error) {
+	return ms.mailOutboxRepo.PurgeMails(time.Now().Add(-outboxRetention))
+}
+
+// enqueue renders the template and stores the mail in the outbox to be delivered by ProcessOutbox
+func (ms *mailService) enqueue(template, to string, data mailer.TemplateData) error {
+	message, err := mailer.Render(template, to, data)
+	if err != nil {
+		return err
+	}
+
+	return ms.mailOutboxRepo.CreateMail(dto.Mail{
+		ID:            helper.GenerateUUID(),
+		Recipient:     to,
+		Subject:       message.Subject,
+		HTML:          message.HTML,
+		Text:          message.Text,
+		Status:        dto.MailStatusPending,
+		NextAttemptAt: time.Now(),
+	})
+}
+
+// formatDuration writes the duration in words for the mail templates
+func formatDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return ""
+	case d%time.Hour == 0:
+		return plural(int(d/time.Hour), "hour")
+	case d%time.Minute == 0:
+		return plural(int(d/time.Minute), "minute")
+	}
+	return d.String()
+}
+
+// plural writes the amount with the unit in singular or plural form
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
+
+// retryDelay is the delay before the next attempt after the given number of failed attempts
+func retryDelay(attempts int) time.Duration {
+	return mailRetryDelay << (attempts - 1)
+}
+
+// StartOutboxWorker implements MailService, it delivers the pending mails and purges the old ones every interval
+// until the returned function is called
+func (ms *mailService) StartOutboxWorker(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				err := ms.ProcessOutbox()
+				if err != nil {
+					log.Printf("fail process mail outbox: %s", err)
+				}
+				_, err = ms.PurgeOutbox()
+				if err != nil {
+					log.Printf("fail purge mail outbox: %s", err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+func NewMailService(mailOutboxRepo mailOutboxRepository.MailOutboxRepository, mailSender mailer.Mailer) MailService {
+	return &mailService{
+		mailOutboxRepo: mailOutboxRepo,
+		mailer:         mailSender,
+	}
+}
@@ -0,0 +1,47 @@
+package mailMockService
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MailMock struct {
+	mock.Mock
+}
+
+func (m *MailMock) SendVerification(to, name, code string, expiresIn time.Duration) error {
+	args := m.Called(to, name, code, expiresIn)
+
+	return args.Error(0)
+}
+
+func (m *MailMock) SendPasswordReset(to, name, token string, expiresIn time.Duration) error {
+	args := m.Called(to, name, token, expiresIn)
+
+	return args.Error(0)
+}
+
+func (m *MailMock) SendEnrollment(to, name, courseName string) error {
+	args := m.Called(to, name, courseName)
+
+	return args.Error(0)
+}
+
+func (m *MailMock) ProcessOutbox() error {
+	args := m.Called()
+
+	return args.Error(0)
+}
+
+func (m *MailMock) PurgeOutbox() (int64, error) {
+	args := m.Called()
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MailMock) StartOutboxWorker(interval time.Duration) func() {
+	args := m.Called(interval)
+
+	return args.Get(0).(func())
+}
@@ -0,0 +1,145 @@
+package mailService
+
+import (
+	"errors"
+	"golang/drivers/mailer"
+	"golang/models/dto"
+	"golang/repository/mailOutboxRepository/mailOutboxMockRepository"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteMail struct {
+	suite.Suite
+	mailService MailService
+	mock        *mailOutboxMockRepository.MailOutboxMock
+	mailer      *mailer.FakeMailer
+}
+
+func (s *suiteMail) SetupTest() {
+	s.mock = &mailOutboxMockRepository.MailOutboxMock{}
+	s.mailer = &mailer.FakeMailer{}
+	s.mailService = NewMailService(s.mock, s.mailer)
+}
+
+func (s *suiteMail) TestSendVerification() {
+	testCase := []struct {
+		Name            string
+		MockReturnError error
+		ExpectedError   error
+	}{
+		{"success send verification", nil, nil},
+		{"fail save mail", errors.New("error"), errors.New("error")},
+	}
+	for _, v := range testCase {
+		var queued dto.Mail
+		mockCall := s.mock.On("CreateMail", mock.Anything).Run(func(args mock.Arguments) {
+			queued = args.Get(0).(dto.Mail)
+		}).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.mailService.SendVerification("customer@gmail.com", "customer", "1234", 15*time.Minute)
+			s.Equal(v.ExpectedError, err)
+			s.Equal("customer@gmail.com", queued.Recipient)
+			s.Equal(dto.MailStatusPending, queued.Status)
+			s.True(strings.Contains(queued.HTML, "<b>1234</b>"))
+			s.True(strings.Contains(queued.Text, "1234"))
+			s.True(strings.Contains(queued.Text, "15 minutes"))
+			// nothing is delivered until the outbox is processed
+			s.Empty(s.mailer.Sent())
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteMail) TestProcessOutbox() {
+	testCase := []struct {
+		Name            string
+		Mail            dto.Mail
+		MailerError     error
+		ExpectedMethod  string
+		ExpectedAttempt int
+	}{
+		{"success send mail", dto.Mail{ID: "1", Recipient: "customer@gmail.com", Subject: "tes"}, nil, "MarkMailSent", 0},
+		{"fail send mail retry later", dto.Mail{ID: "1", Recipient: "customer@gmail.com", Attempts: 1}, errors.New("error"), "MarkMailRetry", 2},
+		{"fail send mail last attempt", dto.Mail{ID: "1", Recipient: "customer@gmail.com", Attempts: MaxMailAttempts - 1}, errors.New("error"), "MarkMailFailed", MaxMailAttempts},
+	}
+	for _, v := range testCase {
+		s.mailer.Err = v.MailerError
+		mockCallGet := s.mock.On("ClaimPendingMails", mock.Anything, mock.Anything, outboxBatchSize).Return([]dto.Mail{v.Mail}, nil)
+		mockCallSent := s.mock.On("MarkMailSent", v.Mail.ID, mock.Anything).Return(nil)
+		mockCallRetry := s.mock.On("MarkMailRetry", v.Mail.ID, v.ExpectedAttempt, mock.Anything, "error").Return(nil)
+		mockCallFailed := s.mock.On("MarkMailFailed", v.Mail.ID, v.ExpectedAttempt, "error").Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.mailService.ProcessOutbox()
+			s.NoError(err)
+			for _, method := range []string{"MarkMailSent", "MarkMailRetry", "MarkMailFailed"} {
+				if method == v.ExpectedMethod {
+					s.mock.AssertNumberOfCalls(t, method, 1)
+				} else {
+					s.mock.AssertNumberOfCalls(t, method, 0)
+				}
+			}
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallSent.Unset()
+		mockCallRetry.Unset()
+		mockCallFailed.Unset()
+		s.mock.Calls = nil
+	}
+	s.Len(s.mailer.Sent(), 1)
+}
+
+func (s *suiteMail) TestProcessOutboxFailClaimPending() {
+	mockCall := s.mock.On("ClaimPendingMails", mock.Anything, mock.Anything, outboxBatchSize).Return([]dto.Mail{}, errors.New("error"))
+	err := s.mailService.ProcessOutbox()
+	s.EqualError(err, "error")
+	mockCall.Unset()
+}
+
+func (s *suiteMail) TestPurgeOutbox() {
+	testCase := []struct {
+		Name            string
+		MockReturnCount int64
+		MockReturnError error
+		ExpectedError   error
+	}{
+		{"success purge outbox", 3, nil, nil},
+		{"fail purge outbox", 0, errors.New("error"), errors.New("error")},
+	}
+	for _, v := range testCase {
+		// only the mails older than the retention are purged
+		s.mock.On("PurgeMails", mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= outboxRetention && time.Since(before) < outboxRetention+time.Minute
+		})).Return(v.MockReturnCount, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			count, err := s.mailService.PurgeOutbox()
+			s.Equal(v.ExpectedError, err)
+			s.Equal(v.MockReturnCount, count)
+		})
+		// remove mock
+		s.mock.ExpectedCalls = nil
+	}
+}
+
+func (s *suiteMail) TestRetryDelay() {
+	s.Equal(time.Minute, retryDelay(1))
+	s.Equal(2*time.Minute, retryDelay(2))
+	s.Equal(8*time.Minute, retryDelay(4))
+}
+
+func (s *suiteMail) TestFormatDuration() {
+	s.Equal("15 minutes", formatDuration(15*time.Minute))
+	s.Equal("1 hour", formatDuration(time.Hour))
+	s.Equal("1m30s", formatDuration(90*time.Second))
+	s.Equal("", formatDuration(0))
+}
+
+func TestSuiteMail(t *testing.T) {
+	suite.Run(t, new(suiteMail))
+}
@@ -4,25 +4,33 @@ import (
 	"golang/helper"
 	"golang/models/dto"
 	mediamodulerepository "golang/repository/mediaModuleRepository"
+	"golang/service/ownershipService"
 )
 
 type MediaModuleService interface {
-	CreateMediaModule(dto.MediaModuleTransaction) error
-	DeleteMediaModule(id string) error
+	CreateMediaModule(mediaModule dto.MediaModuleTransaction, instructorID string) error
+	DeleteMediaModule(id, instructorID string) error
 	GetAllMediaModule() ([]dto.MediaModule, error)
 	GetMediaModuleByID(id string) (dto.MediaModule, error)
-	UpdateMediaModule(dto.MediaModuleTransaction) error
+	UpdateMediaModule(mediaModule dto.MediaModuleTransaction, instructorID string) error
 }
 
 type mediaModuleService struct {
-	mediamoduleRepo mediamodulerepository.MediaModuleRepository
+	mediamoduleRepo  mediamodulerepository.MediaModuleRepository
+	ownershipService ownershipService.OwnershipService
 }
 
 // CreateModule implements ModuleService
-func (mms *mediaModuleService) CreateMediaModule(mediaModule dto.MediaModuleTransaction) error {
+func (mms *mediaModuleService) CreateMediaModule(mediaModule dto.MediaModuleTransaction, instructorID string) error {
+	// check if the target module is owned by the instructor
+	err := mms.ownershipService.CheckModuleOwner(mediaModule.ModuleID, instructorID)
+	if err != nil {
+		return err
+	}
+
 	id := helper.GenerateUUID()
 	mediaModule.ID = id
-	err := mms.mediamoduleRepo.CreateMediaModule(mediaModule)
+	err = mms.mediamoduleRepo.CreateMediaModule(mediaModule)
 	if err != nil {
 		return err
 	}
@@ -30,9 +38,15 @@ func (mms *mediaModuleService) CreateMediaModule(mediaModule dto.MediaModuleTran
 }
 
 // DeleteModule implements ModuleService
-func (mms *mediaModuleService) DeleteMediaModule(id string) error {
+func (mms *mediaModuleService) DeleteMediaModule(id, instructorID string) error {
+	// check if the media module is owned by the instructor
+	err := mms.ownershipService.CheckMediaModuleOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
 	// call repository to delete account
-	err := mms.mediamoduleRepo.DeleteMediaModule(id)
+	err = mms.mediamoduleRepo.DeleteMediaModule(id)
 	if err != nil {
 		return err
 	}
@@ -58,17 +72,32 @@ func (mms *mediaModuleService) GetMediaModuleByID(id string) (dto.MediaModule, e
 }
 
 // UpdateModule implements ModuleService
-func (mms *mediaModuleService) UpdateMediaModule(mediaModule dto.MediaModuleTransaction) error {
+func (mms *mediaModuleService) UpdateMediaModule(mediaModule dto.MediaModuleTransaction, instructorID string) error {
+	// check if the media module is owned by the instructor
+	err := mms.ownershipService.CheckMediaModuleOwner(mediaModule.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	// check if the media module is not moved to a module of another instructor
+	if mediaModule.ModuleID != "" {
+		err = mms.ownershipService.CheckModuleOwner(mediaModule.ModuleID, instructorID)
+		if err != nil {
+			return err
+		}
+	}
+
 	// call repository to update Module
-	err := mms.mediamoduleRepo.UpdateMediaModule(mediaModule)
+	err = mms.mediamoduleRepo.UpdateMediaModule(mediaModule)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func NewMediaModuleService(mediamoduleRepo mediamodulerepository.MediaModuleRepository) MediaModuleService {
+func NewMediaModuleService(mediamoduleRepo mediamodulerepository.MediaModuleRepository, ownershipService ownershipService.OwnershipService) MediaModuleService {
 	return &mediaModuleService{
-		mediamoduleRepo: mediamoduleRepo,
+		mediamoduleRepo:  mediamoduleRepo,
+		ownershipService: ownershipService,
 	}
 }
@@ -10,14 +10,14 @@ type MediaModuleMock struct {
 	mock.Mock
 }
 
-func (c *MediaModuleMock) CreateMediaModule(mediaModule dto.MediaModuleTransaction) error {
-	args := c.Called(mediaModule)
+func (c *MediaModuleMock) CreateMediaModule(mediaModule dto.MediaModuleTransaction, instructorID string) error {
+	args := c.Called(mediaModule, instructorID)
 
 	return args.Error(0)
 }
 
-func (c *MediaModuleMock) DeleteMediaModule(id string) error {
-	args := c.Called(id)
+func (c *MediaModuleMock) DeleteMediaModule(id, instructorID string) error {
+	args := c.Called(id, instructorID)
 
 	return args.Error(0)
 }
@@ -34,8 +34,8 @@ func (c *MediaModuleMock) GetMediaModuleByID(id string) (dto.MediaModule, error)
 	return args.Get(0).(dto.MediaModule), args.Error(1)
 }
 
-func (c *MediaModuleMock) UpdateMediaModule(mediaModule dto.MediaModuleTransaction) error {
-	args := c.Called(mediaModule)
+func (c *MediaModuleMock) UpdateMediaModule(mediaModule dto.MediaModuleTransaction, instructorID string) error {
+	args := c.Called(mediaModule, instructorID)
 
 	return args.Error(0)
 }
@@ -2,8 +2,10 @@ package mediamoduleservice
 
 import (
 	"errors"
+	"golang/constant/constantError"
 	"golang/models/dto"
 	mediamodulemockrepository "golang/repository/mediaModuleRepository/mediaModuleMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -15,12 +17,17 @@ type suiteMediaModule struct {
 	suite.Suite
 	mediaModuleService MediaModuleService
 	mock               *mediamodulemockrepository.MediaModuleMock
+	ownershipMock      *ownershipMockService.OwnershipMock
 }
 
 func (s *suiteMediaModule) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckModuleOwner", mock.Anything, mock.Anything).Return(nil)
+	s.ownershipMock.On("CheckMediaModuleOwner", mock.Anything, mock.Anything).Return(nil)
+
 	mock := &mediamodulemockrepository.MediaModuleMock{}
 	s.mock = mock
-	NewMediaModuleService := NewMediaModuleService(s.mock)
+	NewMediaModuleService := NewMediaModuleService(s.mock, s.ownershipMock)
 	s.mediaModuleService = NewMediaModuleService
 }
 
@@ -67,7 +74,7 @@ func (s *suiteMediaModule) TestCreateMediaModule() {
 	for _, v := range testCase {
 		mockCall := s.mock.On("CreateMediaModule", mock.Anything).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.mediaModuleService.CreateMediaModule(v.Body)
+			err := s.mediaModuleService.CreateMediaModule(v.Body, v.User.ID)
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
@@ -116,7 +123,7 @@ func (s *suiteMediaModule) TestDeleteMediaModule() {
 	for _, v := range testCase {
 		mockCall := s.mock.On("DeleteMediaModule", mock.Anything).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.mediaModuleService.DeleteMediaModule(v.ParamID)
+			err := s.mediaModuleService.DeleteMediaModule(v.ParamID, v.User.ID)
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
@@ -307,7 +314,7 @@ func (s *suiteMediaModule) TestUpdateMediaModule() {
 	for _, v := range testCase {
 		mockCall := s.mock.On("UpdateMediaModule", mock.Anything).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.mediaModuleService.UpdateMediaModule(v.Body)
+			err := s.mediaModuleService.UpdateMediaModule(v.Body, "1")
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
@@ -321,6 +328,54 @@ func (s *suiteMediaModule) TestUpdateMediaModule() {
 	}
 }
 
+func (s *suiteMediaModule) TestMediaModuleNotOwner() {
+	ownershipMock := &ownershipMockService.OwnershipMock{}
+	ownershipMock.On("CheckModuleOwner", "abcde", "1").Return(nil)
+	ownershipMock.On("CheckModuleOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	ownershipMock.On("CheckMediaModuleOwner", "abcde", "1").Return(nil)
+	ownershipMock.On("CheckMediaModuleOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	mediaModuleService := NewMediaModuleService(s.mock, ownershipMock)
+
+	testCase := []struct {
+		Name string
+		Call func() error
+	}{
+		{
+			"fail create mediamodule in other instructor",
+			func() error {
+				return mediaModuleService.CreateMediaModule(dto.MediaModuleTransaction{ModuleID: "other"}, "1")
+			},
+		},
+		{
+			"fail update mediamodule of other instructor",
+			func() error {
+				return mediaModuleService.UpdateMediaModule(dto.MediaModuleTransaction{ID: "other", ModuleID: "other"}, "1")
+			},
+		},
+		{
+			"fail move mediamodule to other instructor",
+			func() error {
+				return mediaModuleService.UpdateMediaModule(dto.MediaModuleTransaction{ID: "abcde", ModuleID: "other"}, "1")
+			},
+		},
+		{
+			"fail delete mediamodule of other instructor",
+			func() error {
+				return mediaModuleService.DeleteMediaModule("other", "1")
+			},
+		},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := v.Call()
+			s.EqualError(err, constantError.ErrorNotAuthorized)
+		})
+	}
+	s.mock.AssertNotCalled(s.T(), "CreateMediaModule", mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "UpdateMediaModule", mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "DeleteMediaModule", mock.Anything)
+}
+
 func TestSuiteMediaModule(t *testing.T) {
 	suite.Run(t, new(suiteMediaModule))
 }
@@ -4,43 +4,71 @@ import (
 	"golang/helper"
 	"golang/models/dto"
 	modulerepository "golang/repository/moduleRepository"
+	"golang/repository/sectionRepository"
+	"golang/service/ownershipService"
+	"golang/service/searchService"
+	"log"
 )
 
 type ModuleService interface {
-	CreateModule(dto.ModuleTransaction) error
-	DeleteModule(id string) error
+	CreateModule(module dto.ModuleTransaction, instructorID string) error
+	DeleteModule(id, instructorID string) error
 	GetAllModule() ([]dto.ModuleCourse, error)
 	GetModuleByID(id, customerID string) (dto.ModuleCourseAcc, error)
 	GetModuleByIDifInstructor(id string) (dto.ModuleCourseAcc, error)
-	GetModuleByCourseID(courseID, customerID string) ([]dto.ModuleCourse, error)
-	GetModuleByCourseIDifInstructror(courseID string) ([]dto.ModuleCourse, error)
-	UpdateModule(dto.ModuleTransaction) error
+	GetModuleByCourseID(courseID, customerID string) (dto.CourseModules, error)
+	GetModuleByCourseIDifInstructror(courseID string) (dto.CourseModules, error)
+	UpdateModule(module dto.ModuleTransaction, instructorID string) error
+	ReorderModules(courseID string, input dto.ModuleOrder, instructorID string) error
 }
 
 type moduleService struct {
-	moduleRepo modulerepository.ModuleRepository
+	moduleRepo       modulerepository.ModuleRepository
+	sectionRepo      sectionRepository.SectionRepository
+	ownershipService ownershipService.OwnershipService
+	searchService    searchService.SearchService
 }
 
 // CreateModule implements ModuleService
-func (ms *moduleService) CreateModule(module dto.ModuleTransaction) error {
+func (ms *moduleService) CreateModule(module dto.ModuleTransaction, instructorID string) error {
+	// check if the target course is owned by the instructor
+	err := ms.ownershipService.CheckCourseOwner(module.CourseID, instructorID)
+	if err != nil {
+		return err
+	}
+
 	id := helper.GenerateUUID()
 	mediamoduleID := helper.GenerateUUID()
 	module.ID = id
 	module.MediaModuleID = mediamoduleID
-	err := ms.moduleRepo.CreateModule(module)
+	err = ms.moduleRepo.CreateModule(module)
 	if err != nil {
 		return err
 	}
+	ms.indexCourse(module.CourseID)
 	return nil
 }
 
 // DeleteModule implements ModuleService
-func (ms *moduleService) DeleteModule(id string) error {
+func (ms *moduleService) DeleteModule(id, instructorID string) error {
+	// check if the module is owned by the instructor
+	err := ms.ownershipService.CheckModuleOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
+	// get the course of the module before it is deleted
+	module, err := ms.moduleRepo.GetModuleByIDifInstructor(id)
+	if err != nil {
+		return err
+	}
+
 	// call repository to delete account
-	err := ms.moduleRepo.DeleteModule(id)
+	err = ms.moduleRepo.DeleteModule(id)
 	if err != nil {
 		return err
 	}
+	ms.indexCourse(module.CourseID)
 	return nil
 }
 
@@ -69,33 +97,106 @@ func (ms *moduleService) GetModuleByIDifInstructor(id string) (dto.ModuleCourseA
 	return module, nil
 }
 
-func (ms *moduleService) GetModuleByCourseID(courseID, customerID string) ([]dto.ModuleCourse, error) {
+func (ms *moduleService) GetModuleByCourseID(courseID, customerID string) (dto.CourseModules, error) {
 	modules, err := ms.moduleRepo.GetModuleByCourseID(courseID, customerID)
 	if err != nil {
-		return nil, err
+		return dto.CourseModules{}, err
 	}
-	return modules, nil
+	if len(modules) == 0 {
+		return dto.CourseModules{}, nil
+	}
+
+	// the progress of the customer in every section
+	finishedModules, err := ms.moduleRepo.GetFinishedModules(courseID, customerID)
+	if err != nil {
+		return dto.CourseModules{}, err
+	}
+	return ms.getCourseModules(courseID, modules, finishedModules)
 }
-func (ms *moduleService) GetModuleByCourseIDifInstructror(courseID string) ([]dto.ModuleCourse, error) {
+func (ms *moduleService) GetModuleByCourseIDifInstructror(courseID string) (dto.CourseModules, error) {
 	modules, err := ms.moduleRepo.GetModuleByCourseIDifInstructror(courseID)
 	if err != nil {
-		return nil, err
+		return dto.CourseModules{}, err
 	}
-	return modules, nil
+	if len(modules) == 0 {
+		return dto.CourseModules{}, nil
+	}
+	return ms.getCourseModules(courseID, modules, nil)
 }
 
 // UpdateModule implements ModuleService
-func (ms *moduleService) UpdateModule(module dto.ModuleTransaction) error {
+func (ms *moduleService) UpdateModule(module dto.ModuleTransaction, instructorID string) error {
+	// check if the module is owned by the instructor
+	err := ms.ownershipService.CheckModuleOwner(module.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	// check if the module is not moved to a course of another instructor
+	if module.CourseID != "" {
+		err = ms.ownershipService.CheckCourseOwner(module.CourseID, instructorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// get the course of the module before it is moved
+	oldModule, err := ms.moduleRepo.GetModuleByIDifInstructor(module.ID)
+	if err != nil {
+		return err
+	}
+
 	// call repository to update Module
-	err := ms.moduleRepo.UpdateModule(module)
+	err = ms.moduleRepo.UpdateModule(module)
 	if err != nil {
 		return err
 	}
+	ms.indexCourse(oldModule.CourseID)
+	if module.CourseID != "" && module.CourseID != oldModule.CourseID {
+		ms.indexCourse(module.CourseID)
+	}
 	return nil
 }
 
-func NewModuleService(moduleRepo modulerepository.ModuleRepository) ModuleService {
+// ReorderModules implements ModuleService
+func (ms *moduleService) ReorderModules(courseID string, input dto.ModuleOrder, instructorID string) error {
+	// check if the course is owned by the instructor
+	err := ms.ownershipService.CheckCourseOwner(courseID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	// call repository to number the modules in the given order
+	err = ms.moduleRepo.ReorderModules(courseID, input.ModuleIDs)
+	if err != nil {
+		return err
+	}
+	ms.indexCourse(courseID)
+	return nil
+}
+
+// getCourseModules groups the modules of the course by section
+func (ms *moduleService) getCourseModules(courseID string, modules []dto.ModuleCourse, finishedModules []string) (dto.CourseModules, error) {
+	sections, err := ms.sectionRepo.GetSectionsByCourseID(courseID)
+	if err != nil {
+		return dto.CourseModules{}, err
+	}
+	return helper.GetModuleSections(sections, modules, finishedModules), nil
+}
+
+// indexCourse updates the module names of the course in the search index, a failure is only logged
+func (ms *moduleService) indexCourse(courseID string) {
+	err := ms.searchService.IndexCourse(courseID)
+	if err != nil {
+		log.Printf("fail index course %s: %s", courseID, err)
+	}
+}
+
+func NewModuleService(moduleRepo modulerepository.ModuleRepository, sectionRepo sectionRepository.SectionRepository, ownershipService ownershipService.OwnershipService, searchService searchService.SearchService) ModuleService {
 	return &moduleService{
-		moduleRepo: moduleRepo,
+		moduleRepo:       moduleRepo,
+		sectionRepo:      sectionRepo,
+		ownershipService: ownershipService,
+		searchService:    searchService,
 	}
 }
@@ -10,14 +10,14 @@ type ModuleMock struct {
 	mock.Mock
 }
 
-func (c *ModuleMock) CreateModule(module dto.ModuleTransaction) error {
-	args := c.Called(module)
+func (c *ModuleMock) CreateModule(module dto.ModuleTransaction, instructorID string) error {
+	args := c.Called(module, instructorID)
 
 	return args.Error(0)
 }
 
-func (c *ModuleMock) DeleteModule(id string) error {
-	args := c.Called(id)
+func (c *ModuleMock) DeleteModule(id, instructorID string) error {
+	args := c.Called(id, instructorID)
 
 	return args.Error(0)
 }
@@ -38,19 +38,25 @@ func (c *ModuleMock) GetAllModule() ([]dto.ModuleCourse, error) {
 
 	return args.Get(0).([]dto.ModuleCourse), args.Error(1)
 }
-func (c *ModuleMock) GetModuleByCourseIDifInstructror(courseID string) ([]dto.ModuleCourse, error) {
+func (c *ModuleMock) GetModuleByCourseIDifInstructror(courseID string) (dto.CourseModules, error) {
 	args := c.Called(courseID)
 
-	return args.Get(0).([]dto.ModuleCourse), args.Error(1)
+	return args.Get(0).(dto.CourseModules), args.Error(1)
 }
-func (c *ModuleMock) GetModuleByCourseID(courseID, customerID string) ([]dto.ModuleCourse, error) {
+func (c *ModuleMock) GetModuleByCourseID(courseID, customerID string) (dto.CourseModules, error) {
 	args := c.Called(courseID, customerID)
 
-	return args.Get(0).([]dto.ModuleCourse), args.Error(1)
+	return args.Get(0).(dto.CourseModules), args.Error(1)
+}
+
+func (c *ModuleMock) UpdateModule(module dto.ModuleTransaction, instructorID string) error {
+	args := c.Called(module, instructorID)
+
+	return args.Error(0)
 }
 
-func (c *ModuleMock) UpdateModule(module dto.ModuleTransaction) error {
-	args := c.Called(module)
+func (c *ModuleMock) ReorderModules(courseID string, input dto.ModuleOrder, instructorID string) error {
+	args := c.Called(courseID, input, instructorID)
 
 	return args.Error(0)
 }
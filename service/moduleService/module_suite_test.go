@@ -2,10 +2,13 @@ package moduleservice
 
 import (
 	"errors"
+	"golang/constant/constantError"
 	"golang/models/dto"
 	moduleMockRepository "golang/repository/moduleRepository/moduleMockRepository"
+	"golang/repository/sectionRepository/sectionMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
+	"golang/service/searchService/searchMockService"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -16,12 +19,24 @@ type suiteModule struct {
 	suite.Suite
 	moduleService ModuleService
 	mock          *moduleMockRepository.ModuleMock
+	sectionMock   *sectionMockRepository.SectionMock
+	ownershipMock *ownershipMockService.OwnershipMock
+	searchMock    *searchMockService.SearchMock
 }
 
 func (s *suiteModule) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckCourseOwner", mock.Anything, mock.Anything).Return(nil)
+	s.ownershipMock.On("CheckModuleOwner", mock.Anything, mock.Anything).Return(nil)
+	s.searchMock = &searchMockService.SearchMock{}
+	s.searchMock.On("IndexCourse", mock.Anything).Return(nil)
+
+	s.sectionMock = &sectionMockRepository.SectionMock{}
+	s.sectionMock.On("GetSectionsByCourseID", mock.Anything).Return([]dto.Section{}, nil)
+
 	mock := &moduleMockRepository.ModuleMock{}
 	s.mock = mock
-	NewmoduleService := NewModuleService(s.mock)
+	NewmoduleService := NewModuleService(s.mock, s.sectionMock, s.ownershipMock, s.searchMock)
 	s.moduleService = NewmoduleService
 }
 
@@ -74,7 +89,7 @@ func (s *suiteModule) TestCreateModule() {
 	for _, v := range testCase {
 		mockCall := s.mock.On("CreateModule", mock.Anything).Return(v.MockReturnError)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.moduleService.CreateModule(v.Body)
+			err := s.moduleService.CreateModule(v.Body, v.User.ID)
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
@@ -122,18 +137,24 @@ func (s *suiteModule) TestDeleteModule() {
 	}
 	for _, v := range testCase {
 		mockCall := s.mock.On("DeleteModule", mock.Anything).Return(v.MockReturnError)
+		mockCallGet := s.mock.On("GetModuleByIDifInstructor", v.ParamID).Return(dto.ModuleCourseAcc{ID: v.ParamID, CourseID: "course1"}, nil)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.moduleService.DeleteModule(v.ParamID)
+			err := s.moduleService.DeleteModule(v.ParamID, v.User.ID)
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
 				s.EqualError(err, v.ExpectedError.Error())
+				s.searchMock.AssertNotCalled(t, "IndexCourse", mock.Anything)
 			} else {
 				s.NoError(err)
+				// the module names of the course are updated in the search index
+				s.searchMock.AssertCalled(t, "IndexCourse", "course1")
 			}
 		})
 		// remove mock
 		mockCall.Unset()
+		mockCallGet.Unset()
+		s.searchMock.Calls = nil
 	}
 }
 
@@ -221,59 +242,56 @@ func (s *suiteModule) TestGetModuleByID() {
 	}
 }
 func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
+	module := dto.ModuleCourse{
+		ID:       "abcde",
+		Name:     "tes",
+		Content:  "tes",
+		CourseID: "abcde",
+		MediaModules: []dto.MediaModule{
+			{
+				ID:       "abcde",
+				Url:      "tes",
+				ModuleID: "abcde",
+			},
+		},
+	}
+	sectionModule := module
+	sectionModule.SectionID = "section1"
 	testCase := []struct {
-		Name            string
-		ParamID         string
-		MockReturnBody  []dto.ModuleCourse
-		MockReturnError error
-		HasReturnBody   bool
-		ExpectedBody    []dto.ModuleCourse
-		ExpectedError   error
+		Name               string
+		ParamID            string
+		MockReturnBody     []dto.ModuleCourse
+		MockReturnSections []dto.Section
+		MockReturnError    error
+		HasReturnBody      bool
+		ExpectedBody       dto.CourseModules
+		ExpectedError      error
 	}{
 		{
 			"success get module by id ",
 			"abcde",
-			[]dto.ModuleCourse{
-				{
-					ID:       "abcde",
-					Name:     "tes",
-					Content:  "tes",
-					CourseID: "abcde",
-					Course: struct {
-						Name        string "json:\"name\""
-						Description string "json:\"description\""
-						Objective   string "json:\"objective\""
-					}{},
-					MediaModules: []dto.MediaModule{
-						{
-							ID:       "abcde",
-							Url:      "tes",
-							ModuleID: "abcde",
-						},
-					},
-				},
+			[]dto.ModuleCourse{module},
+			[]dto.Section{},
+			nil,
+			true,
+			dto.CourseModules{
+				Sections: []dto.ModuleCourseSection{},
+				Modules:  []dto.ModuleCourse{module},
 			},
 			nil,
+		},
+		{
+			"success get module by course id in section",
+			"abcde",
+			[]dto.ModuleCourse{sectionModule},
+			[]dto.Section{{ID: "section1", Title: "tes", CourseID: "abcde", NoSection: 1}},
+			nil,
 			true,
-			[]dto.ModuleCourse{
-				{
-					ID:       "abcde",
-					Name:     "tes",
-					Content:  "tes",
-					CourseID: "abcde",
-					Course: struct {
-						Name        string "json:\"name\""
-						Description string "json:\"description\""
-						Objective   string "json:\"objective\""
-					}{},
-					MediaModules: []dto.MediaModule{
-						{
-							ID:       "abcde",
-							Url:      "tes",
-							ModuleID: "abcde",
-						},
-					},
+			dto.CourseModules{
+				Sections: []dto.ModuleCourseSection{
+					{ID: "section1", Title: "tes", NoSection: 1, Modules: []dto.ModuleCourse{sectionModule}},
 				},
+				Modules: []dto.ModuleCourse{},
 			},
 			nil,
 		},
@@ -281,14 +299,17 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 			"failed get module by course id",
 			"abcde",
 			[]dto.ModuleCourse{},
+			[]dto.Section{},
 			gorm.ErrRecordNotFound,
 			false,
-			nil,
+			dto.CourseModules{},
 			gorm.ErrRecordNotFound,
 		},
 	}
+	s.sectionMock.ExpectedCalls = nil
 	for _, v := range testCase {
 		mockCall := s.mock.On("GetModuleByCourseIDifInstructror", v.ParamID).Return(v.MockReturnBody, v.MockReturnError)
+		mockCallSection := s.sectionMock.On("GetSectionsByCourseID", v.ParamID).Return(v.MockReturnSections, nil)
 		s.T().Run(v.Name, func(t *testing.T) {
 			module, err := s.moduleService.GetModuleByCourseIDifInstructror(v.ParamID)
 			if v.HasReturnBody {
@@ -302,6 +323,7 @@ func (s *suiteModule) TestGetModuleByCourseIDifInstructror() {
 		})
 		// remove mock
 		mockCall.Unset()
+		mockCallSection.Unset()
 	}
 }
 func (s *suiteModule) TestGetModuleByIDifInstructor() {
@@ -465,77 +487,62 @@ func (s *suiteModule) TestGetAllModule() {
 	}
 }
 
-func (s *suiteModule) GetModuleByCourseID() {
+func (s *suiteModule) TestGetModuleByCourseID() {
+	modules := []dto.ModuleCourse{
+		{ID: "module1", Name: "tes", CourseID: "abcde", SectionID: "section1", NoModule: 1},
+		{ID: "module2", Name: "tes", CourseID: "abcde", SectionID: "section1", NoModule: 2},
+		{ID: "module3", Name: "tes", CourseID: "abcde", NoModule: 3},
+	}
+	sections := []dto.Section{{ID: "section1", Title: "tes", CourseID: "abcde", NoSection: 1}}
 	testCase := []struct {
-		Name            string
-		CourseID        string
-		CustomerID      string
-		MockReturnBody  []dto.ModuleCourse
-		MockReturnError error
-		HasReturnBody   bool
-		ExpectedBody    []dto.ModuleCourse
-		ExpectedError   error
+		Name               string
+		MockReturnBody     []dto.ModuleCourse
+		MockReturnFinished []string
+		MockReturnError    error
+		HasReturnBody      bool
+		ExpectedBody       dto.CourseModules
+		ExpectedError      error
 	}{
 		{
-			"success get module by id ",
-			"abcde",
-			"abcde",
-			[]dto.ModuleCourse{
-				{
-					ID:        "abcde",
-					CreatedAt: time.Now(),
-					UpdatedAt: time.Now(),
-					DeletedAt: gorm.DeletedAt{},
-					Name:      "tes",
-					Content:   "tes",
-					CourseID:  "abcde",
-					Course: struct {
-						Name        string "json:\"name\""
-						Description string "json:\"description\""
-						Objective   string "json:\"objective\""
-					}{},
-					MediaModules: []dto.MediaModule{
-						{
-
-							ID:        "abcde",
-							CreatedAt: time.Now(),
-							UpdatedAt: time.Now(),
-							DeletedAt: gorm.DeletedAt{},
-							Url:       "tes",
-							ModuleID:  "abcde",
-						},
-						{
-
-							ID:        "abcde",
-							CreatedAt: time.Now(),
-							UpdatedAt: time.Now(),
-							DeletedAt: gorm.DeletedAt{},
-							Url:       "tes",
-							ModuleID:  "abcde",
-						},
-					},
+			"success get module by course id with progress of section",
+			modules,
+			[]string{"module1"},
+			nil,
+			true,
+			dto.CourseModules{
+				Sections: []dto.ModuleCourseSection{
+					{ID: "section1", Title: "tes", NoSection: 1, ProgressPercentage: 50, Modules: modules[:2]},
 				},
+				Modules: modules[2:],
 			},
 			nil,
+		},
+		{
+			"success get module by course id not enrolled",
+			nil,
+			[]string{},
+			nil,
 			true,
-			[]dto.ModuleCourse{},
+			dto.CourseModules{},
 			nil,
 		},
 		{
-			"failed get module by id",
-			"abcde",
-			"abcde",
+			"failed get module by course id",
 			[]dto.ModuleCourse{},
+			[]string{},
 			gorm.ErrRecordNotFound,
 			false,
-			[]dto.ModuleCourse{},
+			dto.CourseModules{},
 			gorm.ErrRecordNotFound,
 		},
 	}
+	s.sectionMock.ExpectedCalls = nil
+	s.sectionMock.On("GetSectionsByCourseID", "abcde").Return(sections, nil)
 	for _, v := range testCase {
-		mockCall := s.mock.On("GetModuleByCourseID", v.CourseID).Return(v.MockReturnBody, v.MockReturnError)
+		mockCall := s.mock.On("GetModuleByCourseID", "abcde", "customer1").Return(v.MockReturnBody, v.MockReturnError)
+		mockCallFinished := s.mock.On("GetFinishedModules", "abcde", "customer1").Return(v.MockReturnFinished, nil)
 		s.T().Run(v.Name, func(t *testing.T) {
-			module, err := s.moduleService.GetModuleByCourseID(v.CourseID, v.CustomerID)
+			module, err := s.moduleService.GetModuleByCourseID("abcde", "customer1")
 			if v.HasReturnBody {
 				s.NoError(err)
 				s.Equal(v.ExpectedBody, module)
@@ -547,6 +554,7 @@ func (s *suiteModule) GetModuleByCourseID() {
 		})
 		// remove mock
 		mockCall.Unset()
+		mockCallFinished.Unset()
 	}
 }
 
@@ -603,19 +611,128 @@ func (s *suiteModule) TestUpdateModule() {
 	}
 	for _, v := range testCase {
 		mockCall := s.mock.On("UpdateModule", mock.Anything).Return(v.MockReturnError)
+		mockCallGet := s.mock.On("GetModuleByIDifInstructor", v.Body.ID).Return(dto.ModuleCourseAcc{ID: v.Body.ID, CourseID: "course1"}, nil)
 		s.T().Run(v.Name, func(t *testing.T) {
-			err := s.moduleService.UpdateModule(v.Body)
+			err := s.moduleService.UpdateModule(v.Body, "1")
 			if v.HasReturnError {
 				s.Error(err)
 				s.Equal(v.ExpectedError, err)
 				s.EqualError(err, v.ExpectedError.Error())
+				s.searchMock.AssertNotCalled(t, "IndexCourse", mock.Anything)
 			} else {
 				s.NoError(err)
+				// the module is moved so both courses are updated in the search index
+				s.searchMock.AssertCalled(t, "IndexCourse", "course1")
+				s.searchMock.AssertCalled(t, "IndexCourse", v.Body.CourseID)
 			}
 		})
 		// remove mock
 		mockCall.Unset()
+		mockCallGet.Unset()
+		s.searchMock.Calls = nil
+	}
+}
+
+func (s *suiteModule) TestReorderModules() {
+	testCase := []struct {
+		Name            string
+		Body            dto.ModuleOrder
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success reorder modules",
+			dto.ModuleOrder{
+				ModuleIDs: []string{"module2", "module1"},
+			},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail reorder modules not in the course",
+			dto.ModuleOrder{
+				ModuleIDs: []string{"module2"},
+			},
+			errors.New(constantError.ErrorModuleOrder),
+			true,
+			errors.New(constantError.ErrorModuleOrder),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ReorderModules", "abcde", v.Body.ModuleIDs).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.moduleService.ReorderModules("abcde", v.Body, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+				s.searchMock.AssertNotCalled(t, "IndexCourse", mock.Anything)
+			} else {
+				s.NoError(err)
+				// the module names are indexed in the new order
+				s.searchMock.AssertCalled(t, "IndexCourse", "abcde")
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+		s.searchMock.Calls = nil
+	}
+}
+
+func (s *suiteModule) TestModuleNotOwner() {
+	ownershipMock := &ownershipMockService.OwnershipMock{}
+	ownershipMock.On("CheckCourseOwner", "abcde", "1").Return(nil)
+	ownershipMock.On("CheckCourseOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	ownershipMock.On("CheckModuleOwner", "abcde", "1").Return(nil)
+	ownershipMock.On("CheckModuleOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	moduleService := NewModuleService(s.mock, s.sectionMock, ownershipMock, s.searchMock)
+
+	testCase := []struct {
+		Name string
+		Call func() error
+	}{
+		{
+			"fail create module in other instructor",
+			func() error {
+				return moduleService.CreateModule(dto.ModuleTransaction{CourseID: "other"}, "1")
+			},
+		},
+		{
+			"fail update module of other instructor",
+			func() error {
+				return moduleService.UpdateModule(dto.ModuleTransaction{ID: "other", CourseID: "other"}, "1")
+			},
+		},
+		{
+			"fail move module to other instructor",
+			func() error {
+				return moduleService.UpdateModule(dto.ModuleTransaction{ID: "abcde", CourseID: "other"}, "1")
+			},
+		},
+		{
+			"fail delete module of other instructor",
+			func() error {
+				return moduleService.DeleteModule("other", "1")
+			},
+		},
+		{
+			"fail reorder modules of other instructor",
+			func() error {
+				return moduleService.ReorderModules("other", dto.ModuleOrder{ModuleIDs: []string{"abcde"}}, "1")
+			},
+		},
+	}
+	for _, v := range testCase {
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := v.Call()
+			s.EqualError(err, constantError.ErrorNotAuthorized)
+		})
 	}
+	s.mock.AssertNotCalled(s.T(), "CreateModule", mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "UpdateModule", mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "DeleteModule", mock.Anything)
+	s.mock.AssertNotCalled(s.T(), "ReorderModules", mock.Anything, mock.Anything)
 }
 
 func TestSuiteModule(t *testing.T) {
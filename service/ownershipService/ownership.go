@@ -0,0 +1,94 @@
+package ownershipService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/repository/ownershipRepository"
+)
+
+type OwnershipService interface {
+	CheckCourseOwner(courseID, instructorID string) error
+	CheckModuleOwner(moduleID, instructorID string) error
+	CheckMediaModuleOwner(mediaModuleID, instructorID string) error
+	CheckAssignmentOwner(assignmentID, instructorID string) error
+	CheckQuizOwner(quizID, instructorID string) error
+	CheckQuizQuestionOwner(questionID, instructorID string) error
+	CheckQuestionBankOwner(bankID, instructorID string) error
+	CheckBankQuestionOwner(questionID, instructorID string) error
+	CheckSectionOwner(sectionID, instructorID string) error
+	CheckLearningPathOwner(learningPathID, instructorID string) error
+}
+
+type ownershipService struct {
+	ownershipRepo ownershipRepository.OwnershipRepository
+}
+
+// CheckCourseOwner implements OwnershipService
+func (ows *ownershipService) CheckCourseOwner(courseID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetCourseInstructorID, courseID, instructorID)
+}
+
+// CheckModuleOwner implements OwnershipService
+func (ows *ownershipService) CheckModuleOwner(moduleID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetModuleInstructorID, moduleID, instructorID)
+}
+
+// CheckMediaModuleOwner implements OwnershipService
+func (ows *ownershipService) CheckMediaModuleOwner(mediaModuleID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetMediaModuleInstructorID, mediaModuleID, instructorID)
+}
+
+// CheckAssignmentOwner implements OwnershipService
+func (ows *ownershipService) CheckAssignmentOwner(assignmentID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetAssignmentInstructorID, assignmentID, instructorID)
+}
+
+// CheckQuizOwner implements OwnershipService
+func (ows *ownershipService) CheckQuizOwner(quizID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetQuizInstructorID, quizID, instructorID)
+}
+
+// CheckQuizQuestionOwner implements OwnershipService
+func (ows *ownershipService) CheckQuizQuestionOwner(questionID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetQuizQuestionInstructorID, questionID, instructorID)
+}
+
+// CheckQuestionBankOwner implements OwnershipService
+func (ows *ownershipService) CheckQuestionBankOwner(bankID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetQuestionBankInstructorID, bankID, instructorID)
+}
+
+// CheckBankQuestionOwner implements OwnershipService
+func (ows *ownershipService) CheckBankQuestionOwner(questionID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetBankQuestionInstructorID, questionID, instructorID)
+}
+
+// CheckSectionOwner implements OwnershipService
+func (ows *ownershipService) CheckSectionOwner(sectionID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetSectionInstructorID, sectionID, instructorID)
+}
+
+// CheckLearningPathOwner implements OwnershipService
+func (ows *ownershipService) CheckLearningPathOwner(learningPathID, instructorID string) error {
+	return checkOwner(ows.ownershipRepo.GetLearningPathInstructorID, learningPathID, instructorID)
+}
+
+// checkOwner resolve the instructor of the resource and compare it with the instructor in the token
+func checkOwner(getInstructorID func(id string) (string, error), id, instructorID string) error {
+	ownerID, err := getInstructorID(id)
+	if err != nil {
+		return err
+	}
+
+	// check if instructor id in the course is the same as the instructor id in the token
+	if instructorID == "" || ownerID != instructorID {
+		return errors.New(constantError.ErrorNotAuthorized)
+	}
+	return nil
+}
+
+func NewOwnershipService(ownershipRepo ownershipRepository.OwnershipRepository) OwnershipService {
+	return &ownershipService{
+		ownershipRepo: ownershipRepo,
+	}
+}
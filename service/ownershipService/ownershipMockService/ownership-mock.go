@@ -0,0 +1,69 @@
+package ownershipMockService
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type OwnershipMock struct {
+	mock.Mock
+}
+
+func (o *OwnershipMock) CheckCourseOwner(courseID, instructorID string) error {
+	args := o.Called(courseID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckModuleOwner(moduleID, instructorID string) error {
+	args := o.Called(moduleID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckMediaModuleOwner(mediaModuleID, instructorID string) error {
+	args := o.Called(mediaModuleID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckAssignmentOwner(assignmentID, instructorID string) error {
+	args := o.Called(assignmentID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckQuizOwner(quizID, instructorID string) error {
+	args := o.Called(quizID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckQuizQuestionOwner(questionID, instructorID string) error {
+	args := o.Called(questionID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckQuestionBankOwner(bankID, instructorID string) error {
+	args := o.Called(bankID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckBankQuestionOwner(questionID, instructorID string) error {
+	args := o.Called(questionID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckSectionOwner(sectionID, instructorID string) error {
+	args := o.Called(sectionID, instructorID)
+
+	return args.Error(0)
+}
+
+func (o *OwnershipMock) CheckLearningPathOwner(learningPathID, instructorID string) error {
+	args := o.Called(learningPathID, instructorID)
+
+	return args.Error(0)
+}
@@ -0,0 +1,67 @@
+package ownershipService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/repository/ownershipRepository/ownershipMockRepository"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteOwnership struct {
+	suite.Suite
+	ownershipService OwnershipService
+	mock             *ownershipMockRepository.OwnershipMock
+}
+
+func (s *suiteOwnership) SetupTest() {
+	s.mock = &ownershipMockRepository.OwnershipMock{}
+	s.ownershipService = NewOwnershipService(s.mock)
+}
+
+func (s *suiteOwnership) TestCheckOwner() {
+	testCase := []struct {
+		Name              string
+		InstructorID      string
+		MockReturnOwnerID string
+		MockReturnError   error
+		ExpectedError     error
+	}{
+		{"success owner", "1", "1", nil, nil},
+		{"fail not owner", "2", "1", nil, errors.New(constantError.ErrorNotAuthorized)},
+		{"fail empty instructor", "", "", nil, errors.New(constantError.ErrorNotAuthorized)},
+		{"fail resource not found", "1", "", gorm.ErrRecordNotFound, gorm.ErrRecordNotFound},
+	}
+	checks := []struct {
+		Method string
+		Check  func(id, instructorID string) error
+	}{
+		{"GetCourseInstructorID", s.ownershipService.CheckCourseOwner},
+		{"GetModuleInstructorID", s.ownershipService.CheckModuleOwner},
+		{"GetMediaModuleInstructorID", s.ownershipService.CheckMediaModuleOwner},
+		{"GetAssignmentInstructorID", s.ownershipService.CheckAssignmentOwner},
+		{"GetQuizInstructorID", s.ownershipService.CheckQuizOwner},
+		{"GetQuizQuestionInstructorID", s.ownershipService.CheckQuizQuestionOwner},
+		{"GetQuestionBankInstructorID", s.ownershipService.CheckQuestionBankOwner},
+		{"GetBankQuestionInstructorID", s.ownershipService.CheckBankQuestionOwner},
+		{"GetSectionInstructorID", s.ownershipService.CheckSectionOwner},
+		{"GetLearningPathInstructorID", s.ownershipService.CheckLearningPathOwner},
+	}
+	for _, check := range checks {
+		for _, v := range testCase {
+			mockCall := s.mock.On(check.Method, "abcde").Return(v.MockReturnOwnerID, v.MockReturnError)
+			s.T().Run(check.Method+" "+v.Name, func(t *testing.T) {
+				err := check.Check("abcde", v.InstructorID)
+				s.Equal(v.ExpectedError, err)
+			})
+			// remove mock
+			mockCall.Unset()
+		}
+	}
+}
+
+func TestSuiteOwnership(t *testing.T) {
+	suite.Run(t, new(suiteOwnership))
+}
@@ -0,0 +1,77 @@
+package passwordResetService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/passwordResetRepository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordResetTokenDuration is how long a password reset token can be used
+const PasswordResetTokenDuration = 30 * time.Minute
+
+type PasswordResetService interface {
+	CreateResetToken(userID, role string) (string, error)
+	ConsumeResetToken(token, role string) (string, error)
+}
+
+type passwordResetService struct {
+	passwordResetRepo passwordResetRepository.PasswordResetRepository
+}
+
+// CreateResetToken implements PasswordResetService, only the hash of the returned token is stored
+func (prs *passwordResetService) CreateResetToken(userID, role string) (string, error) {
+	token, err := helper.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = prs.passwordResetRepo.CreatePasswordReset(dto.PasswordReset{
+		ID:        helper.GenerateUUID(),
+		TokenHash: helper.HashToken(token),
+		UserID:    userID,
+		Role:      role,
+		ExpiresAt: time.Now().Add(PasswordResetTokenDuration),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ConsumeResetToken implements PasswordResetService, it marks the token as used and returns the user id
+func (prs *passwordResetService) ConsumeResetToken(token, role string) (string, error) {
+	reset, err := prs.passwordResetRepo.GetPasswordReset(helper.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New(constantError.ErrorInvalidResetToken)
+		}
+		return "", err
+	}
+	if reset.Role != role || reset.UsedAt != nil {
+		return "", errors.New(constantError.ErrorInvalidResetToken)
+	}
+	if !time.Now().Before(reset.ExpiresAt) {
+		return "", errors.New(constantError.ErrorResetTokenExpired)
+	}
+
+	// mark the token as used, this fails when another request used it first
+	err = prs.passwordResetRepo.UsePasswordReset(reset.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New(constantError.ErrorInvalidResetToken)
+		}
+		return "", err
+	}
+	return reset.UserID, nil
+}
+
+func NewPasswordResetService(passwordResetRepo passwordResetRepository.PasswordResetRepository) PasswordResetService {
+	return &passwordResetService{
+		passwordResetRepo: passwordResetRepo,
+	}
+}
@@ -0,0 +1,125 @@
+package passwordResetService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/passwordResetRepository/passwordResetMockRepository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suitePasswordReset struct {
+	suite.Suite
+	passwordResetService PasswordResetService
+	mock                 *passwordResetMockRepository.PasswordResetMock
+}
+
+func (s *suitePasswordReset) SetupTest() {
+	s.mock = &passwordResetMockRepository.PasswordResetMock{}
+	s.passwordResetService = NewPasswordResetService(s.mock)
+}
+
+func (s *suitePasswordReset) TestCreateResetToken() {
+	var saved dto.PasswordReset
+	s.mock.On("CreatePasswordReset", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(dto.PasswordReset)
+	}).Return(nil)
+
+	token, err := s.passwordResetService.CreateResetToken("abcde", "customer")
+	s.NoError(err)
+	s.NotEmpty(token)
+	s.Equal(helper.HashToken(token), saved.TokenHash)
+	s.Equal("abcde", saved.UserID)
+	s.Equal("customer", saved.Role)
+	s.True(saved.ExpiresAt.After(time.Now()))
+}
+
+func (s *suitePasswordReset) TestConsumeResetToken() {
+	usedAt := time.Now().Add(-time.Minute)
+	testCase := []struct {
+		Name               string
+		Role               string
+		MockReturnReset    dto.PasswordReset
+		MockReturnResetErr error
+		MockReturnUseErr   error
+		ExpectedUserID     string
+		ExpectedError      error
+	}{
+		{
+			"success consume token",
+			"customer",
+			dto.PasswordReset{ID: "reset", UserID: "abcde", Role: "customer", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			nil,
+			"abcde",
+			nil,
+		},
+		{
+			"fail token not found",
+			"customer",
+			dto.PasswordReset{},
+			gorm.ErrRecordNotFound,
+			nil,
+			"",
+			errors.New(constantError.ErrorInvalidResetToken),
+		},
+		{
+			"fail token of another role",
+			"instructor",
+			dto.PasswordReset{ID: "reset", UserID: "abcde", Role: "customer", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			nil,
+			"",
+			errors.New(constantError.ErrorInvalidResetToken),
+		},
+		{
+			"fail token already used",
+			"customer",
+			dto.PasswordReset{ID: "reset", UserID: "abcde", Role: "customer", ExpiresAt: time.Now().Add(time.Minute), UsedAt: &usedAt},
+			nil,
+			nil,
+			"",
+			errors.New(constantError.ErrorInvalidResetToken),
+		},
+		{
+			"fail token expired",
+			"customer",
+			dto.PasswordReset{ID: "reset", UserID: "abcde", Role: "customer", ExpiresAt: time.Now().Add(-time.Minute)},
+			nil,
+			nil,
+			"",
+			errors.New(constantError.ErrorResetTokenExpired),
+		},
+		{
+			"fail token used by another request",
+			"customer",
+			dto.PasswordReset{ID: "reset", UserID: "abcde", Role: "customer", ExpiresAt: time.Now().Add(time.Minute)},
+			nil,
+			gorm.ErrRecordNotFound,
+			"",
+			errors.New(constantError.ErrorInvalidResetToken),
+		},
+	}
+	for _, v := range testCase {
+		mockCallGet := s.mock.On("GetPasswordReset", helper.HashToken("token")).Return(v.MockReturnReset, v.MockReturnResetErr)
+		mockCallUse := s.mock.On("UsePasswordReset", "reset").Return(v.MockReturnUseErr)
+		s.T().Run(v.Name, func(t *testing.T) {
+			userID, err := s.passwordResetService.ConsumeResetToken("token", v.Role)
+			s.Equal(v.ExpectedError, err)
+			s.Equal(v.ExpectedUserID, userID)
+		})
+		// remove mock
+		mockCallGet.Unset()
+		mockCallUse.Unset()
+	}
+}
+
+func TestSuitePasswordReset(t *testing.T) {
+	suite.Run(t, new(suitePasswordReset))
+}
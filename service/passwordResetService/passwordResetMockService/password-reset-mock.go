@@ -0,0 +1,21 @@
+package passwordResetMockService
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type PasswordResetMock struct {
+	mock.Mock
+}
+
+func (p *PasswordResetMock) CreateResetToken(userID, role string) (string, error) {
+	args := p.Called(userID, role)
+
+	return args.String(0), args.Error(1)
+}
+
+func (p *PasswordResetMock) ConsumeResetToken(token, role string) (string, error) {
+	args := p.Called(token, role)
+
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,131 @@
+package progressionService
+
+import (
+	"golang/helper"
+	"golang/models/dto"
+	modulerepository "golang/repository/moduleRepository"
+	"golang/repository/progressionRepository"
+	"golang/service/ownershipService"
+
+	"gorm.io/gorm"
+)
+
+type ProgressionService interface {
+	GetModuleRules(moduleID, instructorID string) ([]dto.ModuleRule, error)
+	UpdateModuleRules(moduleID string, input dto.ModuleRulesTransaction, instructorID string) error
+	ViewModule(moduleID, customerID string) (dto.ModuleProgress, error)
+	WatchMedia(input dto.MediaProgressTransaction, customerID string) (dto.ModuleProgress, error)
+	GetModuleProgress(moduleID, customerID string) (dto.ModuleProgress, error)
+	GetProgressEvents(customerID string) ([]dto.ProgressEvent, error)
+}
+
+type progressionService struct {
+	progressionRepo  progressionRepository.ProgressionRepository
+	moduleRepo       modulerepository.ModuleRepository
+	ownershipService ownershipService.OwnershipService
+}
+
+// GetModuleRules implements ProgressionService
+func (ps *progressionService) GetModuleRules(moduleID, instructorID string) ([]dto.ModuleRule, error) {
+	// check if the module is owned by the instructor
+	err := ps.ownershipService.CheckModuleOwner(moduleID, instructorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.progressionRepo.GetModuleRules(moduleID)
+}
+
+// UpdateModuleRules implements ProgressionService, the rules replace the rules of the module
+// and without rule the module uses the default rules again
+func (ps *progressionService) UpdateModuleRules(moduleID string, input dto.ModuleRulesTransaction, instructorID string) error {
+	// check if the module is owned by the instructor
+	err := ps.ownershipService.CheckModuleOwner(moduleID, instructorID)
+	if err != nil {
+		return err
+	}
+	err = helper.CheckModuleRules(input.Rules)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]dto.ModuleRule, len(input.Rules))
+	for i, rule := range input.Rules {
+		rules[i] = dto.ModuleRule{
+			ID:       helper.GenerateUUID(),
+			ModuleID: moduleID,
+			Type:     rule.Type,
+			TargetID: rule.TargetID,
+			MinValue: rule.MinValue,
+		}
+	}
+	return ps.progressionRepo.ReplaceModuleRules(moduleID, rules)
+}
+
+// ViewModule implements ProgressionService, the customer can only open a module they can learn
+func (ps *progressionService) ViewModule(moduleID, customerID string) (dto.ModuleProgress, error) {
+	_, err := ps.moduleRepo.GetModuleByID(moduleID, customerID)
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+
+	err = ps.progressionRepo.ViewModule(moduleID, customerID)
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+	return ps.progressionRepo.GetModuleProgress(moduleID, customerID)
+}
+
+// WatchMedia implements ProgressionService, the media must be a media of the module the customer learns
+func (ps *progressionService) WatchMedia(input dto.MediaProgressTransaction, customerID string) (dto.ModuleProgress, error) {
+	module, err := ps.moduleRepo.GetModuleByID(input.ModuleID, customerID)
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+	var found bool
+	for _, media := range module.MediaModules {
+		if media.ID == input.MediaModuleID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return dto.ModuleProgress{}, gorm.ErrRecordNotFound
+	}
+
+	err = ps.progressionRepo.WatchMedia(input, customerID)
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+	return ps.progressionRepo.GetModuleProgress(input.ModuleID, customerID)
+}
+
+// GetModuleProgress implements ProgressionService
+func (ps *progressionService) GetModuleProgress(moduleID, customerID string) (dto.ModuleProgress, error) {
+	_, err := ps.moduleRepo.GetModuleByID(moduleID, customerID)
+	if err != nil {
+		return dto.ModuleProgress{}, err
+	}
+
+	return ps.progressionRepo.GetModuleProgress(moduleID, customerID)
+}
+
+// GetProgressEvents implements ProgressionService
+func (ps *progressionService) GetProgressEvents(customerID string) ([]dto.ProgressEvent, error) {
+	events, err := ps.progressionRepo.GetProgressEvents(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return []dto.ProgressEvent{}, nil
+	}
+	return events, nil
+}
+
+func NewProgressionService(progressionRepo progressionRepository.ProgressionRepository, moduleRepo modulerepository.ModuleRepository, ownershipService ownershipService.OwnershipService) ProgressionService {
+	return &progressionService{
+		progressionRepo:  progressionRepo,
+		moduleRepo:       moduleRepo,
+		ownershipService: ownershipService,
+	}
+}
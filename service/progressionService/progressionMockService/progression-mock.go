@@ -0,0 +1,42 @@
+package progressionMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ProgressionMock struct {
+	mock.Mock
+}
+
+func (c *ProgressionMock) GetModuleRules(moduleID, instructorID string) ([]dto.ModuleRule, error) {
+	args := c.Called(moduleID, instructorID)
+
+	return args.Get(0).([]dto.ModuleRule), args.Error(1)
+}
+func (c *ProgressionMock) UpdateModuleRules(moduleID string, input dto.ModuleRulesTransaction, instructorID string) error {
+	args := c.Called(moduleID, input, instructorID)
+
+	return args.Error(0)
+}
+func (c *ProgressionMock) ViewModule(moduleID, customerID string) (dto.ModuleProgress, error) {
+	args := c.Called(moduleID, customerID)
+
+	return args.Get(0).(dto.ModuleProgress), args.Error(1)
+}
+func (c *ProgressionMock) WatchMedia(input dto.MediaProgressTransaction, customerID string) (dto.ModuleProgress, error) {
+	args := c.Called(input, customerID)
+
+	return args.Get(0).(dto.ModuleProgress), args.Error(1)
+}
+func (c *ProgressionMock) GetModuleProgress(moduleID, customerID string) (dto.ModuleProgress, error) {
+	args := c.Called(moduleID, customerID)
+
+	return args.Get(0).(dto.ModuleProgress), args.Error(1)
+}
+func (c *ProgressionMock) GetProgressEvents(customerID string) ([]dto.ProgressEvent, error) {
+	args := c.Called(customerID)
+
+	return args.Get(0).([]dto.ProgressEvent), args.Error(1)
+}
@@ -0,0 +1,288 @@
+package progressionService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	modulemockrepository "golang/repository/moduleRepository/moduleMockRepository"
+	"golang/repository/progressionRepository/progressionMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteProgression struct {
+	suite.Suite
+	progressionService ProgressionService
+	mock               *progressionMockRepository.ProgressionMock
+	moduleMock         *modulemockrepository.ModuleMock
+	ownershipMock      *ownershipMockService.OwnershipMock
+}
+
+func (s *suiteProgression) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckModuleOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckModuleOwner", "abcde", "other").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.moduleMock = &modulemockrepository.ModuleMock{}
+	s.moduleMock.On("GetModuleByID", "abcde").Return(dto.ModuleCourseAcc{
+		ID:           "abcde",
+		MediaModules: []dto.MediaModule{{ID: "media", ModuleID: "abcde"}},
+	}, nil)
+	s.moduleMock.On("GetModuleByID", "locked").Return(dto.ModuleCourseAcc{}, gorm.ErrRecordNotFound)
+	s.mock = &progressionMockRepository.ProgressionMock{}
+	s.progressionService = NewProgressionService(s.mock, s.moduleMock, s.ownershipMock)
+}
+
+func (s *suiteProgression) TestUpdateModuleRules() {
+	testCase := []struct {
+		Name            string
+		InstructorID    string
+		Input           []dto.ModuleRuleTransaction
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+		ExpectedRules   []dto.ModuleRule
+	}{
+		{
+			"success update module rules",
+			"1",
+			[]dto.ModuleRuleTransaction{
+				{Type: dto.ModuleRuleViewed},
+				{Type: dto.ModuleRuleMediaWatched, TargetID: "media"},
+				{Type: dto.ModuleRuleAssignmentPassed, MinValue: 70},
+				{Type: dto.ModuleRuleQuizPassed, TargetID: "quiz"},
+			},
+			nil,
+			false,
+			nil,
+			[]dto.ModuleRule{
+				{ModuleID: "abcde", Type: dto.ModuleRuleViewed},
+				{ModuleID: "abcde", Type: dto.ModuleRuleMediaWatched, TargetID: "media", MinValue: 100},
+				{ModuleID: "abcde", Type: dto.ModuleRuleAssignmentPassed, MinValue: 70},
+				{ModuleID: "abcde", Type: dto.ModuleRuleQuizPassed, TargetID: "quiz"},
+			},
+		},
+		{
+			"success update module rules to default rules",
+			"1",
+			nil,
+			nil,
+			false,
+			nil,
+			[]dto.ModuleRule{},
+		},
+		{
+			"fail update module rules with target of viewed rule",
+			"1",
+			[]dto.ModuleRuleTransaction{{Type: dto.ModuleRuleViewed, TargetID: "media"}},
+			nil,
+			true,
+			errors.New(constantError.ErrorModuleRule),
+			nil,
+		},
+		{
+			"fail update module rules without media",
+			"1",
+			[]dto.ModuleRuleTransaction{{Type: dto.ModuleRuleMediaWatched, MinValue: 50}},
+			nil,
+			true,
+			errors.New(constantError.ErrorModuleRule),
+			nil,
+		},
+		{
+			"fail update module rules with media watched over 100 percent",
+			"1",
+			[]dto.ModuleRuleTransaction{{Type: dto.ModuleRuleMediaWatched, TargetID: "media", MinValue: 150}},
+			nil,
+			true,
+			errors.New(constantError.ErrorModuleRule),
+			nil,
+		},
+		{
+			"fail update module rules with duplicate rule",
+			"1",
+			[]dto.ModuleRuleTransaction{{Type: dto.ModuleRuleQuizPassed, TargetID: "quiz"}, {Type: dto.ModuleRuleQuizPassed, TargetID: "quiz"}},
+			nil,
+			true,
+			errors.New(constantError.ErrorModuleRule),
+			nil,
+		},
+		{
+			"fail update module rules with quiz of other module",
+			"1",
+			[]dto.ModuleRuleTransaction{{Type: dto.ModuleRuleQuizPassed, TargetID: "quiz"}},
+			errors.New(constantError.ErrorModuleRuleTarget),
+			true,
+			errors.New(constantError.ErrorModuleRuleTarget),
+			nil,
+		},
+		{
+			"fail update module rules of other instructor",
+			"other",
+			[]dto.ModuleRuleTransaction{{Type: dto.ModuleRuleViewed}},
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+			nil,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("ReplaceModuleRules", "abcde", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.progressionService.UpdateModuleRules("abcde", dto.ModuleRulesTransaction{Rules: v.Input}, v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// every rule gets an id, the other fields are the rules of the input
+				rules := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(1).([]dto.ModuleRule)
+				for i := range rules {
+					s.NotEmpty(rules[i].ID)
+					rules[i].ID = ""
+				}
+				s.Equal(v.ExpectedRules, rules)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteProgression) TestViewModule() {
+	testCase := []struct {
+		Name           string
+		ModuleID       string
+		HasReturnError bool
+		ExpectedError  error
+	}{
+		{
+			"success view module",
+			"abcde",
+			false,
+			nil,
+		},
+		{
+			"fail view module the customer cannot learn yet",
+			"locked",
+			true,
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockView := s.mock.On("ViewModule", v.ModuleID, "1").Return(nil)
+		mockProgress := s.mock.On("GetModuleProgress", v.ModuleID, "1").Return(dto.ModuleProgress{ModuleID: v.ModuleID, IsFinish: true}, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			progress, err := s.progressionService.ViewModule(v.ModuleID, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+				s.Equal("GetModuleByID", s.moduleMock.Calls[len(s.moduleMock.Calls)-1].Method)
+			} else {
+				s.NoError(err)
+				s.True(progress.IsFinish)
+			}
+		})
+		// remove mock
+		mockView.Unset()
+		mockProgress.Unset()
+	}
+}
+
+func (s *suiteProgression) TestWatchMedia() {
+	testCase := []struct {
+		Name           string
+		Input          dto.MediaProgressTransaction
+		HasReturnError bool
+		ExpectedError  error
+	}{
+		{
+			"success watch media",
+			dto.MediaProgressTransaction{MediaModuleID: "media", ModuleID: "abcde", Watched: 80},
+			false,
+			nil,
+		},
+		{
+			"fail watch media of other module",
+			dto.MediaProgressTransaction{MediaModuleID: "other", ModuleID: "abcde", Watched: 80},
+			true,
+			gorm.ErrRecordNotFound,
+		},
+		{
+			"fail watch media of module the customer cannot learn yet",
+			dto.MediaProgressTransaction{MediaModuleID: "media", ModuleID: "locked", Watched: 80},
+			true,
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockWatch := s.mock.On("WatchMedia", v.Input, "1").Return(nil)
+		mockProgress := s.mock.On("GetModuleProgress", v.Input.ModuleID, "1").Return(dto.ModuleProgress{ModuleID: v.Input.ModuleID}, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			_, err := s.progressionService.WatchMedia(v.Input, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal("GetModuleProgress", s.mock.Calls[len(s.mock.Calls)-1].Method)
+			}
+		})
+		// remove mock
+		mockWatch.Unset()
+		mockProgress.Unset()
+	}
+}
+
+func (s *suiteProgression) TestGetProgressEvents() {
+	testCase := []struct {
+		Name            string
+		MockReturnBody  []dto.ProgressEvent
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedBody    []dto.ProgressEvent
+	}{
+		{
+			"success get progress events",
+			[]dto.ProgressEvent{{ID: "abcde", CourseID: "course", Type: dto.ProgressCourseCompleted}},
+			nil,
+			false,
+			[]dto.ProgressEvent{{ID: "abcde", CourseID: "course", Type: dto.ProgressCourseCompleted}},
+		},
+		{
+			"success get progress events without event",
+			nil,
+			nil,
+			false,
+			[]dto.ProgressEvent{},
+		},
+		{
+			"fail get progress events",
+			nil,
+			errors.New("error"),
+			true,
+			nil,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetProgressEvents", "1").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			events, err := s.progressionService.GetProgressEvents("1")
+			if v.HasReturnError {
+				s.Error(err)
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedBody, events)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteProgression(t *testing.T) {
+	suite.Run(t, new(suiteProgression))
+}
@@ -0,0 +1,190 @@
+package questionBankService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/questionBankRepository"
+	"golang/service/ownershipService"
+)
+
+type QuestionBankService interface {
+	CreateQuestionBank(input dto.QuestionBankTransaction, instructorID string) error
+	DeleteQuestionBank(id, instructorID string) error
+	GetQuestionBankByID(id, instructorID string) (dto.QuestionBank, error)
+	GetQuestionBanksByCourseID(courseID, instructorID string) ([]dto.QuestionBank, error)
+	UpdateQuestionBank(input dto.QuestionBankTransaction, instructorID string) error
+	CreateBankQuestion(input dto.QuizQuestionTransaction, instructorID string) error
+	DeleteBankQuestion(id, instructorID string) error
+	UpdateBankQuestion(input dto.QuizQuestionTransaction, instructorID string) error
+	ImportBankQuestions(bankID, format string, data []byte, instructorID string) (dto.QuizImport, error)
+	ExportBankQuestions(bankID, format, instructorID string) ([]byte, error)
+}
+
+type questionBankService struct {
+	questionBankRepo questionBankRepository.QuestionBankRepository
+	ownershipService ownershipService.OwnershipService
+}
+
+// CreateQuestionBank implements QuestionBankService
+func (qbs *questionBankService) CreateQuestionBank(input dto.QuestionBankTransaction, instructorID string) error {
+	// check if the course is owned by the instructor
+	err := qbs.ownershipService.CheckCourseOwner(input.CourseID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	input.ID = helper.GenerateUUID()
+	return qbs.questionBankRepo.CreateQuestionBank(input)
+}
+
+// DeleteQuestionBank implements QuestionBankService
+func (qbs *questionBankService) DeleteQuestionBank(id, instructorID string) error {
+	// check if the question bank is owned by the instructor
+	err := qbs.ownershipService.CheckQuestionBankOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return qbs.questionBankRepo.DeleteQuestionBank(id)
+}
+
+// GetQuestionBankByID implements QuestionBankService, the bank is read with its questions and their answers
+func (qbs *questionBankService) GetQuestionBankByID(id, instructorID string) (dto.QuestionBank, error) {
+	// check if the question bank is owned by the instructor
+	err := qbs.ownershipService.CheckQuestionBankOwner(id, instructorID)
+	if err != nil {
+		return dto.QuestionBank{}, err
+	}
+
+	return qbs.questionBankRepo.GetQuestionBankByID(id)
+}
+
+// GetQuestionBanksByCourseID implements QuestionBankService
+func (qbs *questionBankService) GetQuestionBanksByCourseID(courseID, instructorID string) ([]dto.QuestionBank, error) {
+	// check if the course is owned by the instructor
+	err := qbs.ownershipService.CheckCourseOwner(courseID, instructorID)
+	if err != nil {
+		return nil, err
+	}
+
+	banks, err := qbs.questionBankRepo.GetQuestionBanksByCourseID(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(banks) == 0 {
+		return []dto.QuestionBank{}, nil
+	}
+	return banks, nil
+}
+
+// UpdateQuestionBank implements QuestionBankService
+func (qbs *questionBankService) UpdateQuestionBank(input dto.QuestionBankTransaction, instructorID string) error {
+	// check if the question bank is owned by the instructor
+	err := qbs.ownershipService.CheckQuestionBankOwner(input.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return qbs.questionBankRepo.UpdateQuestionBank(input)
+}
+
+// CreateBankQuestion implements QuestionBankService, a question without points is worth 1 point
+func (qbs *questionBankService) CreateBankQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	// check if the question bank is owned by the instructor
+	err := qbs.ownershipService.CheckQuestionBankOwner(input.BankID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	err = helper.CheckQuizQuestion(&input)
+	if err != nil {
+		return err
+	}
+	if input.Points == 0 {
+		input.Points = 1
+	}
+	input.ID = helper.GenerateUUID()
+	input.QuizID = ""
+	return qbs.questionBankRepo.CreateBankQuestion(input)
+}
+
+// DeleteBankQuestion implements QuestionBankService
+func (qbs *questionBankService) DeleteBankQuestion(id, instructorID string) error {
+	// check if the question is owned by the instructor
+	err := qbs.ownershipService.CheckBankQuestionOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return qbs.questionBankRepo.DeleteBankQuestion(id)
+}
+
+// UpdateBankQuestion implements QuestionBankService, a question without points is worth 1 point
+func (qbs *questionBankService) UpdateBankQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	// check if the question is owned by the instructor
+	err := qbs.ownershipService.CheckBankQuestionOwner(input.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	err = helper.CheckQuizQuestion(&input)
+	if err != nil {
+		return err
+	}
+	if input.Points == 0 {
+		input.Points = 1
+	}
+	return qbs.questionBankRepo.UpdateBankQuestion(input)
+}
+
+// ImportBankQuestions implements QuestionBankService, the category of a GIFT question is its topic,
+// the questions that cannot be read are the errors of the import and a question without points is worth 1 point
+func (qbs *questionBankService) ImportBankQuestions(bankID, format string, data []byte, instructorID string) (dto.QuizImport, error) {
+	// check if the question bank is owned by the instructor
+	err := qbs.ownershipService.CheckQuestionBankOwner(bankID, instructorID)
+	if err != nil {
+		return dto.QuizImport{}, err
+	}
+
+	questions, importErrors, err := helper.DecodeQuizQuestions(format, data)
+	if err != nil {
+		return dto.QuizImport{}, err
+	}
+	result := dto.QuizImport{Imported: len(questions), Errors: importErrors}
+	if len(questions) == 0 {
+		return result, errors.New(constantError.ErrorQuizImport)
+	}
+	for i := range questions {
+		if questions[i].Points == 0 {
+			questions[i].Points = 1
+		}
+		questions[i].ID = helper.GenerateUUID()
+		questions[i].BankID = bankID
+		questions[i].QuizID = ""
+	}
+
+	err = qbs.questionBankRepo.CreateBankQuestions(questions)
+	if err != nil {
+		return dto.QuizImport{}, err
+	}
+	return result, nil
+}
+
+// ExportBankQuestions implements QuestionBankService
+func (qbs *questionBankService) ExportBankQuestions(bankID, format, instructorID string) ([]byte, error) {
+	bank, err := qbs.GetQuestionBankByID(bankID, instructorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.EncodeQuizQuestions(format, bank.Questions)
+}
+
+func NewQuestionBankService(questionBankRepo questionBankRepository.QuestionBankRepository, ownershipService ownershipService.OwnershipService) QuestionBankService {
+	return &questionBankService{
+		questionBankRepo: questionBankRepo,
+		ownershipService: ownershipService,
+	}
+}
@@ -0,0 +1,62 @@
+package questionBankMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type QuestionBankMock struct {
+	mock.Mock
+}
+
+func (q *QuestionBankMock) CreateQuestionBank(input dto.QuestionBankTransaction, instructorID string) error {
+	args := q.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) DeleteQuestionBank(id, instructorID string) error {
+	args := q.Called(id, instructorID)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) GetQuestionBankByID(id, instructorID string) (dto.QuestionBank, error) {
+	args := q.Called(id, instructorID)
+
+	return args.Get(0).(dto.QuestionBank), args.Error(1)
+}
+func (q *QuestionBankMock) GetQuestionBanksByCourseID(courseID, instructorID string) ([]dto.QuestionBank, error) {
+	args := q.Called(courseID, instructorID)
+
+	return args.Get(0).([]dto.QuestionBank), args.Error(1)
+}
+func (q *QuestionBankMock) UpdateQuestionBank(input dto.QuestionBankTransaction, instructorID string) error {
+	args := q.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) CreateBankQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	args := q.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) DeleteBankQuestion(id, instructorID string) error {
+	args := q.Called(id, instructorID)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) UpdateBankQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	args := q.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (q *QuestionBankMock) ImportBankQuestions(bankID, format string, data []byte, instructorID string) (dto.QuizImport, error) {
+	args := q.Called(bankID, format, data, instructorID)
+
+	return args.Get(0).(dto.QuizImport), args.Error(1)
+}
+func (q *QuestionBankMock) ExportBankQuestions(bankID, format, instructorID string) ([]byte, error) {
+	args := q.Called(bankID, format, instructorID)
+
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,333 @@
+package questionBankService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"golang/repository/questionBankRepository/questionBankMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteQuestionBank struct {
+	suite.Suite
+	questionBankService QuestionBankService
+	mock                *questionBankMockRepository.QuestionBankMock
+	ownershipMock       *ownershipMockService.OwnershipMock
+}
+
+func (s *suiteQuestionBank) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckCourseOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckCourseOwner", "abcde", "other").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.ownershipMock.On("CheckQuestionBankOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckQuestionBankOwner", "abcde", "other").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.mock = &questionBankMockRepository.QuestionBankMock{}
+	s.questionBankService = NewQuestionBankService(s.mock, s.ownershipMock)
+}
+
+func (s *suiteQuestionBank) TestCreateQuestionBank() {
+	testCase := []struct {
+		Name            string
+		InstructorID    string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success create question bank",
+			"1",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail create question bank in course of other instructor",
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail create question bank",
+			"1",
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("CreateQuestionBank", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.questionBankService.CreateQuestionBank(dto.QuestionBankTransaction{CourseID: "abcde", Title: "basic"}, v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the bank gets a new id
+				bank := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).(dto.QuestionBankTransaction)
+				s.NotEmpty(bank.ID)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuestionBank) TestGetQuestionBanksByCourseID() {
+	testCase := []struct {
+		Name            string
+		InstructorID    string
+		MockReturnBody  []dto.QuestionBank
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedBody    []dto.QuestionBank
+		ExpectedError   error
+	}{
+		{
+			"success get question banks by course id",
+			"1",
+			[]dto.QuestionBank{{ID: "abcde", CourseID: "abcde", Title: "basic"}},
+			nil,
+			false,
+			[]dto.QuestionBank{{ID: "abcde", CourseID: "abcde", Title: "basic"}},
+			nil,
+		},
+		{
+			"success get question banks by course id without bank",
+			"1",
+			nil,
+			nil,
+			false,
+			[]dto.QuestionBank{},
+			nil,
+		},
+		{
+			"fail get question banks of course of other instructor",
+			"other",
+			nil,
+			nil,
+			true,
+			nil,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetQuestionBanksByCourseID", "abcde").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			banks, err := s.questionBankService.GetQuestionBanksByCourseID("abcde", v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal(v.ExpectedBody, banks)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuestionBank) TestCreateBankQuestion() {
+	testCase := []struct {
+		Name            string
+		Body            dto.QuizQuestionTransaction
+		InstructorID    string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success create bank question",
+			dto.QuizQuestionTransaction{QuizID: "quiz", BankID: "abcde", Topic: "loop", Difficulty: dto.QuizEasy, Type: dto.QuizTrueFalse, Text: "tes", Answer: "True"},
+			"1",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail create bank question invalid question",
+			dto.QuizQuestionTransaction{BankID: "abcde", Type: dto.QuizSingleChoice, Text: "tes", Options: []dto.QuizOptionTransaction{{Text: "a", IsCorrect: true}}},
+			"1",
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizQuestion),
+		},
+		{
+			"fail create bank question in bank of other instructor",
+			dto.QuizQuestionTransaction{BankID: "abcde", Type: dto.QuizTrueFalse, Text: "tes", Answer: "true"},
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail create bank question",
+			dto.QuizQuestionTransaction{BankID: "abcde", Type: dto.QuizTrueFalse, Text: "tes", Answer: "true"},
+			"1",
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("CreateBankQuestion", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.questionBankService.CreateBankQuestion(v.Body, v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the question gets a new id, the default points and is not in a quiz
+				question := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).(dto.QuizQuestionTransaction)
+				s.NotEmpty(question.ID)
+				s.Empty(question.QuizID)
+				s.Equal("abcde", question.BankID)
+				s.Equal(float64(1), question.Points)
+				s.Equal("true", question.Answer)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuestionBank) TestDeleteQuestionBank() {
+	testCase := []struct {
+		Name            string
+		InstructorID    string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success delete question bank",
+			"1",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail delete question bank of other instructor",
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail delete question bank",
+			"1",
+			gorm.ErrRecordNotFound,
+			true,
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteQuestionBank", "abcde").Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.questionBankService.DeleteQuestionBank("abcde", v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuestionBank) TestImportBankQuestions() {
+	testCase := []struct {
+		Name             string
+		Data             string
+		InstructorID     string
+		MockReturnError  error
+		HasReturnError   bool
+		ExpectedError    error
+		ExpectedImported int
+		ExpectedErrors   []dto.QuizImportError
+	}{
+		{
+			"success import bank questions",
+			"$CATEGORY: $course$/Go/loop\n\nA for loop can run forever. {T}\n\nWhich keyword starts a loop? {=for ~while}\n\n$CATEGORY: types\n\nMatch the types. {=int -> 1 =string -> a}\n\nHow many bits has an int32? {#32}\n",
+			"1",
+			nil,
+			false,
+			nil,
+			3,
+			[]dto.QuizImportError{{Line: 9, Question: 3, Message: "matching questions are not supported"}},
+		},
+		{
+			"fail import bank questions without question to import",
+			"Match the types. {=int -> 1 =string -> a}",
+			"1",
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizImport),
+			0,
+			[]dto.QuizImportError{{Line: 1, Question: 1, Message: "matching questions are not supported"}},
+		},
+		{
+			"fail import bank questions in bank of other instructor",
+			"A for loop can run forever. {T}",
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+			0,
+			nil,
+		},
+		{
+			"fail import bank questions",
+			"A for loop can run forever. {T}",
+			"1",
+			errors.New("error"),
+			true,
+			errors.New("error"),
+			0,
+			nil,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("CreateBankQuestions", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			result, err := s.questionBankService.ImportBankQuestions("abcde", dto.QuizGIFT, []byte(v.Data), v.InstructorID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the questions are in the bank with the last part of their category as topic
+				questions := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).([]dto.QuizQuestionTransaction)
+				s.Len(questions, 3)
+				for _, question := range questions {
+					s.NotEmpty(question.ID)
+					s.Empty(question.QuizID)
+					s.Equal("abcde", question.BankID)
+					s.Equal(float64(1), question.Points)
+				}
+				s.Equal("loop", questions[0].Topic)
+				s.Equal("loop", questions[1].Topic)
+				s.Equal("types", questions[2].Topic)
+				s.Equal(dto.QuizNumeric, questions[2].Type)
+			}
+			s.Equal(v.ExpectedImported, result.Imported)
+			s.Equal(v.ExpectedErrors, result.Errors)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteQuestionBank(t *testing.T) {
+	suite.Run(t, new(suiteQuestionBank))
+}
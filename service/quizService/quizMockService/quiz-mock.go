@@ -0,0 +1,102 @@
+package quizMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type QuizMock struct {
+	mock.Mock
+}
+
+func (c *QuizMock) CreateQuiz(input dto.QuizTransaction, instructorID string) error {
+	args := c.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (c *QuizMock) TakeQuiz(input dto.TakeQuizTransaction) (dto.Quiz, error) {
+	args := c.Called(input)
+
+	return args.Get(0).(dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) GetAllQuiz() ([]dto.Quiz, error) {
+	args := c.Called()
+
+	return args.Get(0).([]dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) DeleteQuiz(id, instructorID string) error {
+	args := c.Called(id, instructorID)
+
+	return args.Error(0)
+}
+func (c *QuizMock) GetQuizByID(id, instructorID string) (dto.Quiz, error) {
+	args := c.Called(id, instructorID)
+
+	return args.Get(0).(dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) GetQuizzesByCourseID(courseID string, user dto.User) ([]dto.Quiz, error) {
+	args := c.Called(courseID, user)
+
+	return args.Get(0).([]dto.Quiz), args.Error(1)
+}
+func (c *QuizMock) UpdateQuiz(input dto.QuizTransaction, instructorID string) error {
+	args := c.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (c *QuizMock) CreateQuizQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	args := c.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (c *QuizMock) DeleteQuizQuestion(id, instructorID string) error {
+	args := c.Called(id, instructorID)
+
+	return args.Error(0)
+}
+func (c *QuizMock) UpdateQuizQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	args := c.Called(input, instructorID)
+
+	return args.Error(0)
+}
+func (c *QuizMock) StartQuiz(quizID, customerID string) (dto.QuizAttempt, error) {
+	args := c.Called(quizID, customerID)
+
+	return args.Get(0).(dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) ImportQuizQuestions(quizID, format string, data []byte, instructorID string) (dto.QuizImport, error) {
+	args := c.Called(quizID, format, data, instructorID)
+
+	return args.Get(0).(dto.QuizImport), args.Error(1)
+}
+func (c *QuizMock) ExportQuizQuestions(quizID, format, instructorID string) ([]byte, error) {
+	args := c.Called(quizID, format, instructorID)
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+func (c *QuizMock) SubmitQuiz(attemptID, customerID string, submission dto.QuizSubmission) (dto.QuizAttempt, error) {
+	args := c.Called(attemptID, customerID, submission)
+
+	return args.Get(0).(dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) GetQuizAttemptByID(id, customerID string) (dto.QuizAttempt, error) {
+	args := c.Called(id, customerID)
+
+	return args.Get(0).(dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) GetQuizAttempts(quizID, customerID string) ([]dto.QuizAttempt, error) {
+	args := c.Called(quizID, customerID)
+
+	return args.Get(0).([]dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) GetQuizResults(quizID, instructorID string) ([]dto.QuizAttempt, error) {
+	args := c.Called(quizID, instructorID)
+
+	return args.Get(0).([]dto.QuizAttempt), args.Error(1)
+}
+func (c *QuizMock) RegradeQuizAttempt(id, instructorID string) (dto.QuizAttempt, error) {
+	args := c.Called(id, instructorID)
+
+	return args.Get(0).(dto.QuizAttempt), args.Error(1)
+}
@@ -0,0 +1,889 @@
+package quizservice
+
+import (
+	"errors"
+	"fmt"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/customerCourseRepository/customerCourseMockRepository"
+	"golang/repository/quizRepository/quizMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteQuiz struct {
+	suite.Suite
+	quizService        QuizService
+	mock               *quizMockRepository.QuizMock
+	customerCourseMock *customerCourseMockRepository.CustomerCourseMock
+	ownershipMock      *ownershipMockService.OwnershipMock
+}
+
+var quizQuestions = []dto.QuizQuestion{
+	{ID: "q1", Type: dto.QuizSingleChoice, Points: 1, NoQuestion: 1, Options: []dto.QuizOption{{ID: "o1", IsCorrect: true}, {ID: "o2"}}},
+	{ID: "q2", Type: dto.QuizMultipleChoice, Points: 2, NoQuestion: 2, Options: []dto.QuizOption{{ID: "o3", IsCorrect: true}, {ID: "o4", IsCorrect: true}, {ID: "o5"}}},
+	{ID: "q3", Type: dto.QuizTrueFalse, Points: 1, NoQuestion: 3, Answer: "true"},
+	{ID: "q4", Type: dto.QuizShortAnswer, Points: 1, NoQuestion: 4, Options: []dto.QuizOption{{ID: "o6", Text: "Jakarta"}}},
+	{ID: "q5", Type: dto.QuizNumeric, Points: 1, NoQuestion: 5, Answer: "3.14", Tolerance: 0.01},
+}
+
+func getQuizAttempt(status string, expiresAt *time.Time) dto.QuizAttempt {
+	attempt := dto.QuizAttempt{ID: "a1", QuizID: "abcde", CustomerID: "1", Status: status, ExpiresAt: expiresAt, MaxScore: 6}
+	for _, question := range quizQuestions {
+		attempt.Answers = append(attempt.Answers, dto.QuizAttemptAnswer{QuestionID: question.ID, NoQuestion: question.NoQuestion})
+	}
+	return attempt
+}
+
+func (s *suiteQuiz) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckCourseOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckCourseOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.ownershipMock.On("CheckQuizOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckQuizOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.ownershipMock.On("CheckQuizQuestionOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckQuizQuestionOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.customerCourseMock = &customerCourseMockRepository.CustomerCourseMock{}
+	s.customerCourseMock.On("GetCustomerCourse", "abcde", "1").Return(dto.CustomerCourse{CourseID: "abcde", CustomerID: "1", Status: true}, nil)
+	s.customerCourseMock.On("GetCustomerCourse", "abcde", "2").Return(dto.CustomerCourse{}, gorm.ErrRecordNotFound)
+	s.customerCourseMock.On("GetCustomerCourse", "abcde", "3").Return(dto.CustomerCourse{CourseID: "abcde", CustomerID: "3", Status: false}, nil)
+	s.mock = &quizMockRepository.QuizMock{}
+	s.mock.On("GetQuizQuestions", mock.Anything).Return(quizQuestions, nil)
+	s.quizService = NewQuizService(s.mock, s.customerCourseMock, s.ownershipMock)
+}
+
+func (s *suiteQuiz) TestCreateQuizQuestion() {
+	testCase := []struct {
+		Name            string
+		Body            dto.QuizQuestionTransaction
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success create single choice question",
+			dto.QuizQuestionTransaction{QuizID: "abcde", Type: dto.QuizSingleChoice, Text: "tes", Options: []dto.QuizOptionTransaction{{Text: "a", IsCorrect: true}, {Text: "b"}}},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"success create true false question",
+			dto.QuizQuestionTransaction{QuizID: "abcde", Type: dto.QuizTrueFalse, Text: "tes", Answer: "False"},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail create single choice question with two correct options",
+			dto.QuizQuestionTransaction{QuizID: "abcde", Type: dto.QuizSingleChoice, Text: "tes", Options: []dto.QuizOptionTransaction{{Text: "a", IsCorrect: true}, {Text: "b", IsCorrect: true}}},
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizQuestion),
+		},
+		{
+			"fail create numeric question without number answer",
+			dto.QuizQuestionTransaction{QuizID: "abcde", Type: dto.QuizNumeric, Text: "tes", Answer: "pi"},
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizQuestion),
+		},
+		{
+			"fail create short answer question without accepted answer",
+			dto.QuizQuestionTransaction{QuizID: "abcde", Type: dto.QuizShortAnswer, Text: "tes"},
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizQuestion),
+		},
+		{
+			"fail create question in quiz of other instructor",
+			dto.QuizQuestionTransaction{QuizID: "other", Type: dto.QuizTrueFalse, Text: "tes", Answer: "true"},
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail create question",
+			dto.QuizQuestionTransaction{QuizID: "abcde", Type: dto.QuizTrueFalse, Text: "tes", Answer: "true"},
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("CreateQuizQuestion", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.quizService.CreateQuizQuestion(v.Body, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the question gets a new id, a point and the normalized answer
+				question := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).(dto.QuizQuestionTransaction)
+				s.NotEmpty(question.ID)
+				s.Equal(float64(1), question.Points)
+				if question.Type == dto.QuizTrueFalse {
+					s.Equal("false", question.Answer)
+				}
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestGetQuizzesByCourseID() {
+	testCase := []struct {
+		Name            string
+		ParamID         string
+		User            dto.User
+		MockReturnBody  []dto.Quiz
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedBody    []dto.Quiz
+		ExpectedError   error
+	}{
+		{
+			"success get quizzes of enrolled customer",
+			"abcde",
+			dto.User{ID: "1", Role: constantRole.RoleCustomer},
+			[]dto.Quiz{{ID: "abcde", CourseID: "abcde"}},
+			nil,
+			false,
+			[]dto.Quiz{{ID: "abcde", CourseID: "abcde"}},
+			nil,
+		},
+		{
+			"success get empty quizzes of instructor",
+			"abcde",
+			dto.User{ID: "1", Role: constantRole.RoleInstructor},
+			nil,
+			nil,
+			false,
+			[]dto.Quiz{},
+			nil,
+		},
+		{
+			"fail get quizzes of customer not enrolled",
+			"abcde",
+			dto.User{ID: "2", Role: constantRole.RoleCustomer},
+			nil,
+			nil,
+			true,
+			nil,
+			errors.New(constantError.ErrorCustomerNotEnrolled),
+		},
+		{
+			"fail get quizzes of customer not approved",
+			"abcde",
+			dto.User{ID: "3", Role: constantRole.RoleCustomer},
+			nil,
+			nil,
+			true,
+			nil,
+			errors.New(constantError.ErrorCustomerNotEnrolled),
+		},
+		{
+			"fail get quizzes of course of other instructor",
+			"other",
+			dto.User{ID: "1", Role: constantRole.RoleInstructor},
+			nil,
+			nil,
+			true,
+			nil,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetQuizzesByCourseID", v.ParamID).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			quizzes, err := s.quizService.GetQuizzesByCourseID(v.ParamID, v.User)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedBody, quizzes)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestStartQuiz() {
+	testCase := []struct {
+		Name                  string
+		CustomerID            string
+		MockReturnQuiz        dto.Quiz
+		MockReturnError       error
+		HasReturnError        bool
+		ExpectedError         error
+		ExpectedMaxAttempts   int
+		ExpectedWithExpiresAt bool
+	}{
+		{
+			"success start quiz",
+			"1",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", MaxAttempts: 2, Questions: quizQuestions},
+			nil,
+			false,
+			nil,
+			2,
+			false,
+		},
+		{
+			"success start timed quiz",
+			"1",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", TimeLimit: 10, Questions: quizQuestions},
+			nil,
+			false,
+			nil,
+			0,
+			true,
+		},
+		{
+			"fail start quiz of course not enrolled",
+			"2",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", Questions: quizQuestions},
+			nil,
+			true,
+			errors.New(constantError.ErrorCustomerNotEnrolled),
+			0,
+			false,
+		},
+		{
+			"fail start quiz without question",
+			"1",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", Link: "https://quiz"},
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizNoQuestion),
+			0,
+			false,
+		},
+		{
+			"fail start quiz after the last attempt",
+			"1",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", MaxAttempts: 1, Questions: quizQuestions},
+			errors.New(constantError.ErrorQuizAttemptLimit),
+			true,
+			errors.New(constantError.ErrorQuizAttemptLimit),
+			1,
+			false,
+		},
+	}
+	for _, v := range testCase {
+		mockQuiz := s.mock.On("GetQuizByID", "abcde").Return(v.MockReturnQuiz, nil)
+		mockCall := s.mock.On("CreateQuizAttempt", mock.Anything, v.ExpectedMaxAttempts).Return(getQuizAttempt(dto.QuizAttemptInProgress, nil), v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			attempt, err := s.quizService.StartQuiz("abcde", v.CustomerID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the new attempt has all the questions of the quiz
+				created := s.mock.Calls[len(s.mock.Calls)-2].Arguments.Get(0).(dto.QuizAttempt)
+				s.NotEmpty(created.ID)
+				s.Equal(dto.QuizAttemptInProgress, created.Status)
+				s.Equal(float64(6), created.MaxScore)
+				s.Equal(v.ExpectedWithExpiresAt, created.ExpiresAt != nil)
+				s.Len(created.Answers, len(quizQuestions))
+				s.Len(attempt.Questions, len(quizQuestions))
+				// the customer does not get the answers
+				s.Empty(attempt.Questions[2].Answer)
+				s.Equal([]dto.QuizAttemptOption{{ID: "o1"}, {ID: "o2"}}, attempt.Questions[0].Options)
+			}
+		})
+		// remove mock
+		mockQuiz.Unset()
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestStartQuizWithPools() {
+	bankQuestions := []dto.QuizQuestion{
+		{ID: "b1", Type: dto.QuizTrueFalse, Points: 1, Answer: "true"},
+		{ID: "b2", Type: dto.QuizTrueFalse, Points: 1, Answer: "true"},
+		{ID: "b3", Type: dto.QuizTrueFalse, Points: 1, Answer: "false"},
+		{ID: "b4", Type: dto.QuizTrueFalse, Points: 1, Answer: "false"},
+		{ID: "q1", Type: dto.QuizSingleChoice, Points: 1},
+	}
+	testCase := []struct {
+		Name           string
+		MockReturnQuiz dto.Quiz
+		HasReturnError bool
+		ExpectedError  error
+		ExpectedLen    int
+	}{
+		{
+			"success start quiz drawn from pool",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", Pools: []dto.QuizPool{{BankID: "bank", Count: 3}}},
+			false,
+			nil,
+			3,
+		},
+		{
+			"success start quiz with questions and shuffled pool",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", ShuffleQuestions: true, ShuffleOptions: true, Questions: quizQuestions[:1], Pools: []dto.QuizPool{{BankID: "bank", Count: 4}}},
+			false,
+			nil,
+			5,
+		},
+		{
+			"fail start quiz with not enough questions in pool",
+			dto.Quiz{ID: "abcde", CourseID: "abcde", Questions: quizQuestions[:1], Pools: []dto.QuizPool{{BankID: "bank", Count: 5}}},
+			true,
+			errors.New(constantError.ErrorQuizPoolQuestions),
+			0,
+		},
+	}
+	for _, v := range testCase {
+		mockQuiz := s.mock.On("GetQuizByID", "abcde").Return(v.MockReturnQuiz, nil)
+		mockPool := s.mock.On("GetPoolQuestions", mock.Anything).Return(bankQuestions, nil)
+		mockCall := s.mock.On("CreateQuizAttempt", mock.Anything, 0).Return(getQuizAttempt(dto.QuizAttemptInProgress, nil), nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			_, err := s.quizService.StartQuiz("abcde", "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				created := s.mock.Calls[len(s.mock.Calls)-2].Arguments.Get(0).(dto.QuizAttempt)
+				s.NotZero(created.Seed)
+				s.Equal(v.MockReturnQuiz.ShuffleOptions, created.ShuffleOptions)
+				s.Len(created.Answers, v.ExpectedLen)
+				s.Equal(float64(v.ExpectedLen), created.MaxScore)
+				// a question of the quiz is never drawn again from the pool
+				drawn := map[string]bool{}
+				for i, answer := range created.Answers {
+					s.False(drawn[answer.QuestionID])
+					drawn[answer.QuestionID] = true
+					s.Equal(i+1, answer.NoQuestion)
+				}
+				// the seed of the attempt draws the same questions in the same order
+				questions, err := helper.DrawQuizQuestions(created.Seed, v.MockReturnQuiz, [][]dto.QuizQuestion{bankQuestions})
+				s.NoError(err)
+				for i, question := range questions {
+					s.Equal(question.ID, created.Answers[i].QuestionID)
+				}
+			}
+		})
+		// remove mock
+		mockQuiz.Unset()
+		mockPool.Unset()
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestSubmitQuiz() {
+	expired := time.Now().Add(-time.Minute)
+	running := time.Now().Add(time.Minute)
+	correctAnswers := []dto.QuizAnswer{
+		{QuestionID: "q1", OptionIDs: []string{"o1"}},
+		{QuestionID: "q2", OptionIDs: []string{"o4", "o3"}},
+		{QuestionID: "q3", Answer: "TRUE"},
+		{QuestionID: "q4", Answer: " jakarta "},
+		{QuestionID: "q5", Answer: "3.145"},
+	}
+	testCase := []struct {
+		Name               string
+		CustomerID         string
+		MockReturnAttempt  dto.QuizAttempt
+		Body               dto.QuizSubmission
+		HasReturnError     bool
+		ExpectedError      error
+		ExpectedStatus     string
+		ExpectedScore      float64
+		ExpectedPercentage float64
+		ExpectedIsPassed   bool
+	}{
+		{
+			"success submit correct answers",
+			"1",
+			getQuizAttempt(dto.QuizAttemptInProgress, &running),
+			dto.QuizSubmission{Answers: correctAnswers},
+			false,
+			nil,
+			dto.QuizAttemptSubmitted,
+			6,
+			100,
+			true,
+		},
+		{
+			"success submit partly correct answers",
+			"1",
+			getQuizAttempt(dto.QuizAttemptInProgress, nil),
+			dto.QuizSubmission{Answers: []dto.QuizAnswer{
+				{QuestionID: "q1", OptionIDs: []string{"o2"}},
+				{QuestionID: "q2", OptionIDs: []string{"o3", "o4", "o5"}},
+				{QuestionID: "q3", Answer: "true"},
+				{QuestionID: "q5", Answer: "3"},
+			}},
+			false,
+			nil,
+			dto.QuizAttemptSubmitted,
+			1,
+			float64(1) * 100 / 6,
+			false,
+		},
+		{
+			"success close attempt submitted after time limit",
+			"1",
+			getQuizAttempt(dto.QuizAttemptInProgress, &expired),
+			dto.QuizSubmission{Answers: correctAnswers},
+			false,
+			nil,
+			dto.QuizAttemptExpired,
+			0,
+			0,
+			false,
+		},
+		{
+			"fail submit attempt of other customer",
+			"2",
+			getQuizAttempt(dto.QuizAttemptInProgress, nil),
+			dto.QuizSubmission{Answers: correctAnswers},
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+			"",
+			0,
+			0,
+			false,
+		},
+		{
+			"fail submit attempt already submitted",
+			"1",
+			getQuizAttempt(dto.QuizAttemptSubmitted, nil),
+			dto.QuizSubmission{Answers: correctAnswers},
+			true,
+			errors.New(constantError.ErrorQuizAttemptSubmitted),
+			"",
+			0,
+			0,
+			false,
+		},
+	}
+	for _, v := range testCase {
+		mockAttempt := s.mock.On("GetQuizAttemptByID", "a1").Return(v.MockReturnAttempt, nil)
+		mockQuiz := s.mock.On("GetQuizByID", "abcde").Return(dto.Quiz{ID: "abcde", CourseID: "abcde", PassingScore: 50}, nil)
+		mockCall := s.mock.On("SubmitQuizAttempt", mock.Anything).Return(nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			attempt, err := s.quizService.SubmitQuiz("a1", v.CustomerID, v.Body)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal(v.ExpectedStatus, attempt.Status)
+				s.Equal(v.ExpectedScore, attempt.Score)
+				s.Equal(float64(6), attempt.MaxScore)
+				s.InDelta(v.ExpectedPercentage, attempt.Percentage, 0.0001)
+				s.Equal(v.ExpectedIsPassed, attempt.IsPassed)
+				s.NotNil(attempt.SubmittedAt)
+				// the graded attempt is saved
+				saved := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).(dto.QuizAttempt)
+				s.Equal(attempt.Score, saved.Score)
+			}
+		})
+		// remove mock
+		mockAttempt.Unset()
+		mockQuiz.Unset()
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestGetQuizAttemptByID() {
+	testCase := []struct {
+		Name            string
+		CustomerID      string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{"success get quiz attempt by id", "1", nil, false, nil},
+		{"fail get quiz attempt of other customer", "2", nil, true, errors.New(constantError.ErrorNotAuthorized)},
+		{"fail get quiz attempt by id", "1", gorm.ErrRecordNotFound, true, gorm.ErrRecordNotFound},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetQuizAttemptByID", "a1").Return(getQuizAttempt(dto.QuizAttemptInProgress, nil), v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			attempt, err := s.quizService.GetQuizAttemptByID("a1", v.CustomerID)
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Len(attempt.Questions, len(quizQuestions))
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestGetQuizResults() {
+	testCase := []struct {
+		Name            string
+		ParamID         string
+		MockReturnBody  []dto.QuizAttempt
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedBody    []dto.QuizAttempt
+		ExpectedError   error
+	}{
+		{
+			"success get quiz results",
+			"abcde",
+			[]dto.QuizAttempt{{ID: "a1", QuizID: "abcde", CustomerID: "1", Score: 6}},
+			nil,
+			false,
+			[]dto.QuizAttempt{{ID: "a1", QuizID: "abcde", CustomerID: "1", Score: 6}},
+			nil,
+		},
+		{
+			"fail get quiz results of quiz of other instructor",
+			"other",
+			nil,
+			nil,
+			true,
+			nil,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail get quiz results",
+			"abcde",
+			nil,
+			errors.New("error"),
+			true,
+			nil,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetQuizAttempts", v.ParamID, "").Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			attempts, err := s.quizService.GetQuizResults(v.ParamID, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedBody, attempts)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestRegradeQuizAttempt() {
+	testCase := []struct {
+		Name            string
+		QuizID          string
+		Status          string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success regrade quiz attempt",
+			"abcde",
+			dto.QuizAttemptSubmitted,
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail regrade quiz attempt in progress",
+			"abcde",
+			dto.QuizAttemptInProgress,
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizAttemptNotSubmitted),
+		},
+		{
+			"fail regrade quiz attempt of quiz of other instructor",
+			"other",
+			dto.QuizAttemptSubmitted,
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail regrade quiz attempt",
+			"abcde",
+			dto.QuizAttemptSubmitted,
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		// the attempt was graded before the answer of one of its questions was fixed
+		attempt := getQuizAttempt(v.Status, nil)
+		attempt.QuizID = v.QuizID
+		stored := []string{"o1", "o3,o4", "true", " jakarta ", "3.141"}
+		for i := range attempt.Answers {
+			attempt.Answers[i].Answer = stored[i]
+		}
+		attempt.Score = 5
+		mockAttempt := s.mock.On("GetQuizAttemptByID", "a1").Return(attempt, nil)
+		mockQuiz := s.mock.On("GetQuizByID", v.QuizID).Return(dto.Quiz{ID: v.QuizID, CourseID: "abcde", PassingScore: 100, Questions: quizQuestions}, nil)
+		mockCall := s.mock.On("RegradeQuizAttempt", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			regraded, err := s.quizService.RegradeQuizAttempt("a1", "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				s.Equal(float64(6), regraded.Score)
+				s.Equal(float64(100), regraded.Percentage)
+				s.True(regraded.IsPassed)
+				// the stored answers are kept as they were submitted
+				saved := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).(dto.QuizAttempt)
+				s.Equal("o3,o4", saved.Answers[1].Answer)
+				s.Equal("jakarta", saved.Answers[3].Answer)
+			}
+		})
+		// remove mock
+		mockAttempt.Unset()
+		mockQuiz.Unset()
+		mockCall.Unset()
+	}
+}
+
+// quizGIFT has a question on every line it is numbered with and two questions that cannot be read
+const quizGIFT = `// questions of the geography quiz
+$CATEGORY: $course$/Geography
+
+::Capital::What is the capital of Indonesia? {=Jakarta ~Bandung ~Surabaya}
+
+The earth is flat. {F}
+
+Which numbers are prime? {
+	~%50%2
+	~%50%3
+	~%-100%4
+}
+
+Write an essay about volcanoes. {}
+
+What is the value of pi? {#3.14:0.01}
+
+This question is not closed {=yes
+`
+
+// getQuizFileQuestions get the questions of the quiz with a text, a question without text cannot be imported
+func getQuizFileQuestions() []dto.QuizQuestion {
+	questions := make([]dto.QuizQuestion, len(quizQuestions))
+	copy(questions, quizQuestions)
+	for i := range questions {
+		questions[i].Text = fmt.Sprintf("question %d", i+1)
+	}
+	return questions
+}
+
+func (s *suiteQuiz) TestImportQuizQuestions() {
+	quizFileQuestions := getQuizFileQuestions()
+	qti, err := helper.EncodeQTI(quizFileQuestions)
+	s.NoError(err)
+	testCase := []struct {
+		Name             string
+		QuizID           string
+		Format           string
+		Data             []byte
+		MockReturnError  error
+		HasReturnError   bool
+		ExpectedError    error
+		ExpectedImported int
+		ExpectedErrors   []dto.QuizImportError
+	}{
+		{
+			"success import gift questions",
+			"abcde",
+			dto.QuizGIFT,
+			[]byte(quizGIFT),
+			nil,
+			false,
+			nil,
+			4,
+			[]dto.QuizImportError{
+				{Line: 14, Question: 4, Message: "essay questions are not supported"},
+				{Line: 18, Question: 6, Message: "the answer block is not closed with }"},
+			},
+		},
+		{
+			"success import qti questions",
+			"abcde",
+			dto.QuizQTI,
+			qti,
+			nil,
+			false,
+			nil,
+			5,
+			nil,
+		},
+		{
+			"fail import questions without question to import",
+			"abcde",
+			dto.QuizGIFT,
+			[]byte("Write an essay about volcanoes. {}"),
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizImport),
+			0,
+			[]dto.QuizImportError{{Line: 1, Question: 1, Message: "essay questions are not supported"}},
+		},
+		{
+			"fail import questions of unsupported format",
+			"abcde",
+			"csv",
+			[]byte(quizGIFT),
+			nil,
+			true,
+			errors.New(constantError.ErrorQuizFormat),
+			0,
+			nil,
+		},
+		{
+			"fail import questions to quiz of other instructor",
+			"other",
+			dto.QuizGIFT,
+			[]byte(quizGIFT),
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+			0,
+			nil,
+		},
+		{
+			"fail import questions",
+			"abcde",
+			dto.QuizGIFT,
+			[]byte(quizGIFT),
+			errors.New("error"),
+			true,
+			errors.New("error"),
+			0,
+			nil,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("CreateQuizQuestions", v.QuizID, mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			result, err := s.quizService.ImportQuizQuestions(v.QuizID, v.Format, v.Data, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedImported, result.Imported)
+			s.Equal(v.ExpectedErrors, result.Errors)
+		})
+		if !v.HasReturnError {
+			questions := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(1).([]dto.QuizQuestionTransaction)
+			for _, question := range questions {
+				s.NotEmpty(question.ID)
+				s.Equal(v.QuizID, question.QuizID)
+				s.NotZero(question.Points)
+			}
+			if v.Format == dto.QuizGIFT {
+				s.Equal("What is the capital of Indonesia?", questions[0].Text)
+				s.Equal(dto.QuizSingleChoice, questions[0].Type)
+				s.Equal("Geography", questions[0].Topic)
+				s.Equal(dto.QuizTrueFalse, questions[1].Type)
+				s.Equal("false", questions[1].Answer)
+				s.Equal(dto.QuizMultipleChoice, questions[2].Type)
+				s.Equal(dto.QuizNumeric, questions[3].Type)
+				s.Equal("3.14", questions[3].Answer)
+				s.Equal(0.01, questions[3].Tolerance)
+			} else {
+				// the questions of the export are read back with their answers
+				for i, question := range questions {
+					s.Equal(quizFileQuestions[i].Text, question.Text)
+					s.Equal(quizFileQuestions[i].Type, question.Type)
+					s.Equal(quizFileQuestions[i].Points, question.Points)
+					s.Equal(quizFileQuestions[i].Answer, question.Answer)
+					s.Equal(quizFileQuestions[i].Tolerance, question.Tolerance)
+					s.Equal(len(quizFileQuestions[i].Options), len(question.Options))
+				}
+			}
+		}
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteQuiz) TestExportQuizQuestions() {
+	testCase := []struct {
+		Name           string
+		QuizID         string
+		Format         string
+		HasReturnError bool
+		ExpectedError  error
+	}{
+		{
+			"success export gift questions",
+			"abcde",
+			dto.QuizGIFT,
+			false,
+			nil,
+		},
+		{
+			"success export qti questions",
+			"abcde",
+			dto.QuizQTI,
+			false,
+			nil,
+		},
+		{
+			"fail export questions of unsupported format",
+			"abcde",
+			"csv",
+			true,
+			errors.New(constantError.ErrorQuizFormat),
+		},
+		{
+			"fail export questions of quiz of other instructor",
+			"other",
+			dto.QuizGIFT,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetQuizByID", v.QuizID).Return(dto.Quiz{ID: v.QuizID, Questions: getQuizFileQuestions()}, nil)
+		s.T().Run(v.Name, func(t *testing.T) {
+			data, err := s.quizService.ExportQuizQuestions(v.QuizID, v.Format, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the file is imported again without errors
+				questions, importErrors, err := helper.DecodeQuizQuestions(v.Format, data)
+				s.NoError(err)
+				s.Empty(importErrors)
+				s.Len(questions, len(quizQuestions))
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteQuiz(t *testing.T) {
+	suite.Run(t, new(suiteQuiz))
+}
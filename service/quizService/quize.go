@@ -1,35 +1,74 @@
 package quizservice
 
 import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/constant/constantRole"
 	"golang/helper"
 	"golang/models/dto"
+	"golang/repository/customerCourseRepository"
 	quizrepository "golang/repository/quizRepository"
+	"golang/service/ownershipService"
+	"time"
+
+	"gorm.io/gorm"
 )
 
+// quizSubmitGrace is the time after the time limit an attempt is still graded, for the answers that are late because of the network
+const quizSubmitGrace = 30 * time.Second
+
 type QuizService interface {
-	CreateQuiz(dto.QuizTransaction) error
+	CreateQuiz(input dto.QuizTransaction, instructorID string) error
 	TakeQuiz(dto.TakeQuizTransaction) (dto.Quiz, error)
 	GetAllQuiz() ([]dto.Quiz, error)
-	DeleteQuiz(id string) error
+	DeleteQuiz(id, instructorID string) error
+	GetQuizByID(id, instructorID string) (dto.Quiz, error)
+	GetQuizzesByCourseID(courseID string, user dto.User) ([]dto.Quiz, error)
+	UpdateQuiz(input dto.QuizTransaction, instructorID string) error
+	CreateQuizQuestion(input dto.QuizQuestionTransaction, instructorID string) error
+	DeleteQuizQuestion(id, instructorID string) error
+	UpdateQuizQuestion(input dto.QuizQuestionTransaction, instructorID string) error
+	StartQuiz(quizID, customerID string) (dto.QuizAttempt, error)
+	SubmitQuiz(attemptID, customerID string, submission dto.QuizSubmission) (dto.QuizAttempt, error)
+	GetQuizAttemptByID(id, customerID string) (dto.QuizAttempt, error)
+	GetQuizAttempts(quizID, customerID string) ([]dto.QuizAttempt, error)
+	GetQuizResults(quizID, instructorID string) ([]dto.QuizAttempt, error)
+	RegradeQuizAttempt(id, instructorID string) (dto.QuizAttempt, error)
+	ImportQuizQuestions(quizID, format string, data []byte, instructorID string) (dto.QuizImport, error)
+	ExportQuizQuestions(quizID, format, instructorID string) ([]byte, error)
 }
 
 type quizService struct {
-	quizRepo quizrepository.QuizRepository
+	quizRepo           quizrepository.QuizRepository
+	customerCourseRepo customerCourseRepository.CustomerCourseRepository
+	ownershipService   ownershipService.OwnershipService
 }
 
 // CreateCustomerAssignment implements QuizService
-func (cas *quizService) CreateQuiz(input dto.QuizTransaction) error {
+func (cas *quizService) CreateQuiz(input dto.QuizTransaction, instructorID string) error {
+	// check if the target course is owned by the instructor
+	err := cas.ownershipService.CheckCourseOwner(input.CourseID, instructorID)
+	if err != nil {
+		return err
+	}
+
 	id := helper.GenerateUUID()
 	input.ID = id
-	err := cas.quizRepo.CreateQuiz(input)
+	err = cas.quizRepo.CreateQuiz(input)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (cas *quizService) DeleteQuiz(id string) error {
-	err := cas.quizRepo.DeleteQuiz(id)
+func (cas *quizService) DeleteQuiz(id, instructorID string) error {
+	// check if the quiz is owned by the instructor
+	err := cas.ownershipService.CheckQuizOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
+	err = cas.quizRepo.DeleteQuiz(id)
 	if err != nil {
 		return err
 	}
@@ -52,8 +91,355 @@ func (cas *quizService) TakeQuiz(input dto.TakeQuizTransaction) (dto.Quiz, error
 	return quiz, nil
 }
 
-func NewQuizService(quizRepo quizrepository.QuizRepository) QuizService {
+// GetQuizByID implements QuizService, the instructor gets the questions with their answers
+func (cas *quizService) GetQuizByID(id, instructorID string) (dto.Quiz, error) {
+	// check if the quiz is owned by the instructor
+	err := cas.ownershipService.CheckQuizOwner(id, instructorID)
+	if err != nil {
+		return dto.Quiz{}, err
+	}
+
+	quiz, err := cas.quizRepo.GetQuizByID(id)
+	if err != nil {
+		return dto.Quiz{}, err
+	}
+	return quiz, nil
+}
+
+// GetQuizzesByCourseID implements QuizService, the customer has to be enrolled in the course
+func (cas *quizService) GetQuizzesByCourseID(courseID string, user dto.User) ([]dto.Quiz, error) {
+	var err error
+	if user.Role == constantRole.RoleCustomer {
+		err = cas.checkEnrolled(courseID, user.ID)
+	} else {
+		err = cas.ownershipService.CheckCourseOwner(courseID, user.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quizzes, err := cas.quizRepo.GetQuizzesByCourseID(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(quizzes) == 0 {
+		return []dto.Quiz{}, nil
+	}
+	return quizzes, nil
+}
+
+// UpdateQuiz implements QuizService
+func (cas *quizService) UpdateQuiz(input dto.QuizTransaction, instructorID string) error {
+	// check if the quiz is owned by the instructor
+	err := cas.ownershipService.CheckQuizOwner(input.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return cas.quizRepo.UpdateQuiz(input)
+}
+
+// CreateQuizQuestion implements QuizService, a question without points is worth 1 point
+func (cas *quizService) CreateQuizQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	// check if the quiz is owned by the instructor
+	err := cas.ownershipService.CheckQuizOwner(input.QuizID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	err = helper.CheckQuizQuestion(&input)
+	if err != nil {
+		return err
+	}
+	if input.Points == 0 {
+		input.Points = 1
+	}
+	input.ID = helper.GenerateUUID()
+	return cas.quizRepo.CreateQuizQuestion(input)
+}
+
+// DeleteQuizQuestion implements QuizService
+func (cas *quizService) DeleteQuizQuestion(id, instructorID string) error {
+	// check if the question is owned by the instructor
+	err := cas.ownershipService.CheckQuizQuestionOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return cas.quizRepo.DeleteQuizQuestion(id)
+}
+
+// UpdateQuizQuestion implements QuizService, a question without points is worth 1 point
+func (cas *quizService) UpdateQuizQuestion(input dto.QuizQuestionTransaction, instructorID string) error {
+	// check if the question is owned by the instructor
+	err := cas.ownershipService.CheckQuizQuestionOwner(input.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	err = helper.CheckQuizQuestion(&input)
+	if err != nil {
+		return err
+	}
+	if input.Points == 0 {
+		input.Points = 1
+	}
+	return cas.quizRepo.UpdateQuizQuestion(input)
+}
+
+// StartQuiz implements QuizService, the customer gets the attempt in progress when there is one,
+// the questions of a new attempt are the questions of the quiz when it starts and the questions drawn from its pools
+// with the seed of the attempt
+func (cas *quizService) StartQuiz(quizID, customerID string) (dto.QuizAttempt, error) {
+	quiz, err := cas.quizRepo.GetQuizByID(quizID)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	err = cas.checkEnrolled(quiz.CourseID, customerID)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	if len(quiz.Questions) == 0 && len(quiz.Pools) == 0 {
+		return dto.QuizAttempt{}, errors.New(constantError.ErrorQuizNoQuestion)
+	}
+
+	var candidates [][]dto.QuizQuestion
+	for _, pool := range quiz.Pools {
+		poolQuestions, err := cas.quizRepo.GetPoolQuestions(pool)
+		if err != nil {
+			return dto.QuizAttempt{}, err
+		}
+		candidates = append(candidates, poolQuestions)
+	}
+	attempt := dto.QuizAttempt{
+		ID:             helper.GenerateUUID(),
+		QuizID:         quizID,
+		CustomerID:     customerID,
+		Status:         dto.QuizAttemptInProgress,
+		Seed:           time.Now().UnixNano(),
+		ShuffleOptions: quiz.ShuffleOptions,
+	}
+	questions, err := helper.DrawQuizQuestions(attempt.Seed, quiz, candidates)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	if quiz.TimeLimit > 0 {
+		expiresAt := time.Now().Add(time.Duration(quiz.TimeLimit) * time.Minute)
+		attempt.ExpiresAt = &expiresAt
+	}
+	for i, question := range questions {
+		attempt.Answers = append(attempt.Answers, dto.QuizAttemptAnswer{
+			QuestionID: question.ID,
+			NoQuestion: i + 1,
+		})
+		attempt.MaxScore += question.Points
+	}
+
+	attempt, err = cas.quizRepo.CreateQuizAttempt(attempt, quiz.MaxAttempts)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	return cas.getAttemptQuestions(attempt)
+}
+
+// SubmitQuiz implements QuizService, the attempt is graded on the server and an attempt submitted after its time limit
+// is closed as expired with no score
+func (cas *quizService) SubmitQuiz(attemptID, customerID string, submission dto.QuizSubmission) (dto.QuizAttempt, error) {
+	attempt, err := cas.quizRepo.GetQuizAttemptByID(attemptID)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	if attempt.CustomerID != customerID {
+		return dto.QuizAttempt{}, errors.New(constantError.ErrorNotAuthorized)
+	}
+	if attempt.Status != dto.QuizAttemptInProgress {
+		return dto.QuizAttempt{}, errors.New(constantError.ErrorQuizAttemptSubmitted)
+	}
+
+	quiz, err := cas.quizRepo.GetQuizByID(attempt.QuizID)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	questions, err := cas.quizRepo.GetQuizQuestions(getQuestionIDs(attempt))
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+
+	now := time.Now()
+	attempt.SubmittedAt = &now
+	if attempt.ExpiresAt != nil && now.After(attempt.ExpiresAt.Add(quizSubmitGrace)) {
+		attempt.Status = dto.QuizAttemptExpired
+		helper.GradeQuizAttempt(&attempt, questions, nil, quiz.PassingScore)
+		attempt.IsPassed = false
+	} else {
+		attempt.Status = dto.QuizAttemptSubmitted
+		helper.GradeQuizAttempt(&attempt, questions, submission.Answers, quiz.PassingScore)
+	}
+
+	err = cas.quizRepo.SubmitQuizAttempt(attempt)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	attempt.Questions = helper.GetQuizAttemptQuestions(attempt, questions)
+	return attempt, nil
+}
+
+// GetQuizAttemptByID implements QuizService, the customer only gets their own attempts
+func (cas *quizService) GetQuizAttemptByID(id, customerID string) (dto.QuizAttempt, error) {
+	attempt, err := cas.quizRepo.GetQuizAttemptByID(id)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	if attempt.CustomerID != customerID {
+		return dto.QuizAttempt{}, errors.New(constantError.ErrorNotAuthorized)
+	}
+	return cas.getAttemptQuestions(attempt)
+}
+
+// GetQuizAttempts implements QuizService, the attempts of the customer are without their questions
+func (cas *quizService) GetQuizAttempts(quizID, customerID string) ([]dto.QuizAttempt, error) {
+	attempts, err := cas.quizRepo.GetQuizAttempts(quizID, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attempts) == 0 {
+		return []dto.QuizAttempt{}, nil
+	}
+	return attempts, nil
+}
+
+// GetQuizResults implements QuizService, the instructor gets the attempts of all customers
+func (cas *quizService) GetQuizResults(quizID, instructorID string) ([]dto.QuizAttempt, error) {
+	// check if the quiz is owned by the instructor
+	err := cas.ownershipService.CheckQuizOwner(quizID, instructorID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := cas.quizRepo.GetQuizAttempts(quizID, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(attempts) == 0 {
+		return []dto.QuizAttempt{}, nil
+	}
+	return attempts, nil
+}
+
+// RegradeQuizAttempt implements QuizService, every answer of a submitted attempt is graded again
+// with the current answers of its questions and the scores of all the answers are saved again
+func (cas *quizService) RegradeQuizAttempt(id, instructorID string) (dto.QuizAttempt, error) {
+	attempt, err := cas.quizRepo.GetQuizAttemptByID(id)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	// check if the quiz is owned by the instructor
+	err = cas.ownershipService.CheckQuizOwner(attempt.QuizID, instructorID)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	if attempt.Status != dto.QuizAttemptSubmitted {
+		return dto.QuizAttempt{}, errors.New(constantError.ErrorQuizAttemptNotSubmitted)
+	}
+
+	quiz, err := cas.quizRepo.GetQuizByID(attempt.QuizID)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	questions, err := cas.quizRepo.GetQuizQuestions(getQuestionIDs(attempt))
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	helper.GradeQuizAttempt(&attempt, questions, helper.GetQuizAnswers(attempt), quiz.PassingScore)
+
+	err = cas.quizRepo.RegradeQuizAttempt(attempt)
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	attempt.Questions = helper.GetQuizAttemptQuestions(attempt, questions)
+	return attempt, nil
+}
+
+// ImportQuizQuestions implements QuizService, the questions of the file that can be read are added to the quiz
+// and the questions that cannot be read are the errors of the import, a question without points is worth 1 point
+func (cas *quizService) ImportQuizQuestions(quizID, format string, data []byte, instructorID string) (dto.QuizImport, error) {
+	// check if the quiz is owned by the instructor
+	err := cas.ownershipService.CheckQuizOwner(quizID, instructorID)
+	if err != nil {
+		return dto.QuizImport{}, err
+	}
+
+	questions, importErrors, err := helper.DecodeQuizQuestions(format, data)
+	if err != nil {
+		return dto.QuizImport{}, err
+	}
+	result := dto.QuizImport{Imported: len(questions), Errors: importErrors}
+	if len(questions) == 0 {
+		return result, errors.New(constantError.ErrorQuizImport)
+	}
+	for i := range questions {
+		if questions[i].Points == 0 {
+			questions[i].Points = 1
+		}
+		questions[i].ID = helper.GenerateUUID()
+		questions[i].QuizID = quizID
+	}
+
+	err = cas.quizRepo.CreateQuizQuestions(quizID, questions)
+	if err != nil {
+		return dto.QuizImport{}, err
+	}
+	return result, nil
+}
+
+// ExportQuizQuestions implements QuizService
+func (cas *quizService) ExportQuizQuestions(quizID, format, instructorID string) ([]byte, error) {
+	quiz, err := cas.GetQuizByID(quizID, instructorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return helper.EncodeQuizQuestions(format, quiz.Questions)
+}
+
+// checkEnrolled checks the customer is enrolled in the course and the enrollment is approved
+func (cas *quizService) checkEnrolled(courseID, customerID string) error {
+	customerCourse, err := cas.customerCourseRepo.GetCustomerCourse(courseID, customerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New(constantError.ErrorCustomerNotEnrolled)
+		}
+		return err
+	}
+	if !customerCourse.Status {
+		return errors.New(constantError.ErrorCustomerNotEnrolled)
+	}
+	return nil
+}
+
+// getAttemptQuestions adds the questions of the attempt as the customer sees them
+func (cas *quizService) getAttemptQuestions(attempt dto.QuizAttempt) (dto.QuizAttempt, error) {
+	questions, err := cas.quizRepo.GetQuizQuestions(getQuestionIDs(attempt))
+	if err != nil {
+		return dto.QuizAttempt{}, err
+	}
+	attempt.Questions = helper.GetQuizAttemptQuestions(attempt, questions)
+	return attempt, nil
+}
+
+func getQuestionIDs(attempt dto.QuizAttempt) []string {
+	var questionIDs []string
+	for _, answer := range attempt.Answers {
+		questionIDs = append(questionIDs, answer.QuestionID)
+	}
+	return questionIDs
+}
+
+func NewQuizService(quizRepo quizrepository.QuizRepository, customerCourseRepo customerCourseRepository.CustomerCourseRepository,
+	ownershipService ownershipService.OwnershipService) QuizService {
 	return &quizService{
-		quizRepo: quizRepo,
+		quizRepo:           quizRepo,
+		customerCourseRepo: customerCourseRepo,
+		ownershipService:   ownershipService,
 	}
 }
@@ -0,0 +1,118 @@
+package searchService
+
+import (
+	"golang/drivers/search"
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/courseRepository"
+
+	"github.com/jinzhu/copier"
+)
+
+type SearchService interface {
+	IndexCourse(courseID string) error
+	RemoveCourse(courseID string) error
+	Reindex() error
+	SearchCourse(user dto.User, query dto.CourseSearchQuery) ([]dto.CourseSearchResult, error)
+}
+
+type searchService struct {
+	courseRepo courseRepository.CourseRepository
+	index      search.SearchIndex
+}
+
+// IndexCourse implements SearchService, a course that doesn't exist anymore is removed from the index
+func (ss *searchService) IndexCourse(courseID string) error {
+	courses, err := ss.courseRepo.GetCourseSearchData(courseID)
+	if err != nil {
+		return err
+	}
+	if len(courses) == 0 {
+		return ss.index.Remove(courseID)
+	}
+	return ss.index.Index(document(courses[0]))
+}
+
+// RemoveCourse implements SearchService
+func (ss *searchService) RemoveCourse(courseID string) error {
+	return ss.index.Remove(courseID)
+}
+
+// Reindex implements SearchService, it puts every course in the index
+func (ss *searchService) Reindex() error {
+	courses, err := ss.courseRepo.GetCourseSearchData()
+	if err != nil {
+		return err
+	}
+	for _, course := range courses {
+		err = ss.index.Index(document(course))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchCourse implements SearchService
+func (ss *searchService) SearchCourse(user dto.User, query dto.CourseSearchQuery) ([]dto.CourseSearchResult, error) {
+	if query.Limit == 0 {
+		query.Limit = dto.DefaultSearchLimit
+	}
+	results, err := ss.index.Search(query.Query, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return []dto.CourseSearchResult{}, nil
+	}
+
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	courses, err := ss.courseRepo.GetCourseCatalogByIDs(user, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the order of the index, courses deleted since they were indexed are skipped
+	searchResults := []dto.CourseSearchResult{}
+	for _, result := range results {
+		for _, course := range courses {
+			if course.ID != result.ID {
+				continue
+			}
+			course.ProgressPercentage = helper.GetProgressCourse(&course)
+			var getCourse dto.GetCourse
+			err = copier.Copy(&getCourse, &course)
+			if err != nil {
+				return nil, err
+			}
+			searchResults = append(searchResults, dto.CourseSearchResult{
+				Course:     getCourse,
+				Score:      result.Score,
+				Highlights: result.Highlights,
+			})
+		}
+	}
+	return searchResults, nil
+}
+
+func document(course dto.CourseSearchData) search.Document {
+	return search.Document{
+		ID:          course.ID,
+		Name:        course.Name,
+		Description: course.Description,
+		Objective:   course.Objective,
+		Category:    course.CategoryName,
+		Modules:     course.ModuleNames,
+		Instructor:  course.InstructorName,
+	}
+}
+
+func NewSearchService(courseRepo courseRepository.CourseRepository, index search.SearchIndex) SearchService {
+	return &searchService{
+		courseRepo: courseRepo,
+		index:      index,
+	}
+}
@@ -0,0 +1,35 @@
+package searchMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SearchMock struct {
+	mock.Mock
+}
+
+func (s *SearchMock) IndexCourse(courseID string) error {
+	args := s.Called(courseID)
+
+	return args.Error(0)
+}
+
+func (s *SearchMock) RemoveCourse(courseID string) error {
+	args := s.Called(courseID)
+
+	return args.Error(0)
+}
+
+func (s *SearchMock) Reindex() error {
+	args := s.Called()
+
+	return args.Error(0)
+}
+
+func (s *SearchMock) SearchCourse(user dto.User, query dto.CourseSearchQuery) ([]dto.CourseSearchResult, error) {
+	args := s.Called(user, query)
+
+	return args.Get(0).([]dto.CourseSearchResult), args.Error(1)
+}
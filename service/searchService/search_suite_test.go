@@ -0,0 +1,134 @@
+package searchService
+
+import (
+	"errors"
+	"golang/drivers/search"
+	"golang/models/dto"
+	"golang/repository/courseRepository/courseMockRepository"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type suiteSearch struct {
+	suite.Suite
+	searchService SearchService
+	mockCourse    *courseMockRepository.CourseMock
+	index         search.SearchIndex
+}
+
+func (s *suiteSearch) SetupTest() {
+	s.mockCourse = &courseMockRepository.CourseMock{}
+	s.index = search.NewMemoryIndex()
+	s.searchService = NewSearchService(s.mockCourse, s.index)
+}
+
+func (s *suiteSearch) TestIndexCourse() {
+	s.mockCourse.On("GetCourseSearchData", []string{"course1"}).Return([]dto.CourseSearchData{
+		{ID: "course1", Name: "Golang", CategoryName: "Programming", ModuleNames: []string{"Goroutines"}},
+	}, nil).Once()
+	s.NoError(s.searchService.IndexCourse("course1"))
+
+	results, err := s.index.Search("goroutines", 10)
+	s.NoError(err)
+	s.Len(results, 1)
+
+	// a deleted course is removed from the index
+	s.mockCourse.On("GetCourseSearchData", []string{"course1"}).Return([]dto.CourseSearchData{}, nil).Once()
+	s.NoError(s.searchService.IndexCourse("course1"))
+
+	results, err = s.index.Search("goroutines", 10)
+	s.NoError(err)
+	s.Len(results, 0)
+
+	s.mockCourse.On("GetCourseSearchData", []string{"course2"}).Return([]dto.CourseSearchData{}, errors.New("error")).Once()
+	s.Equal(errors.New("error"), s.searchService.IndexCourse("course2"))
+}
+
+func (s *suiteSearch) TestSearchCourse() {
+	user := dto.User{ID: "abcde", Role: "customer"}
+	s.mockCourse.On("GetCourseSearchData", []string(nil)).Return([]dto.CourseSearchData{
+		{ID: "course1", Name: "Golang for Beginners", InstructorName: "Budi"},
+		{ID: "course2", Name: "Python", Description: "No golang needed"},
+		{ID: "course3", Name: "Golang Deleted"},
+	}, nil)
+	s.NoError(s.searchService.Reindex())
+
+	testCase := []struct {
+		Name            string
+		Query           dto.CourseSearchQuery
+		ExpectedIDs     []string
+		MockReturnBody  []dto.Course
+		MockReturnError error
+		ExpectedResult  []string
+		ExpectedError   error
+	}{
+		{
+			"success search course",
+			dto.CourseSearchQuery{Query: "golamg"},
+			[]string{"course1", "course3", "course2"},
+			[]dto.Course{
+				{ID: "course2", Name: "Python"},
+				{ID: "course1", Name: "Golang for Beginners", NumberOfModules: 4, ProgressModule: 3},
+			},
+			nil,
+			[]string{"course1", "course2"},
+			nil,
+		},
+		{
+			"success search course with limit",
+			dto.CourseSearchQuery{Query: "golang", Limit: 1},
+			[]string{"course1"},
+			[]dto.Course{
+				{ID: "course1", Name: "Golang for Beginners"},
+			},
+			nil,
+			[]string{"course1"},
+			nil,
+		},
+		{
+			"success search course without result",
+			dto.CourseSearchQuery{Query: "design"},
+			nil,
+			nil,
+			nil,
+			[]string{},
+			nil,
+		},
+		{
+			"fail get courses",
+			dto.CourseSearchQuery{Query: "golang"},
+			[]string{"course1", "course3", "course2"},
+			[]dto.Course{},
+			errors.New("error"),
+			nil,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mockCourse.On("GetCourseCatalogByIDs", user, v.ExpectedIDs).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			results, err := s.searchService.SearchCourse(user, v.Query)
+			s.Equal(v.ExpectedError, err)
+			if err != nil {
+				return
+			}
+			ids := []string{}
+			for _, result := range results {
+				ids = append(ids, result.Course.ID)
+				s.NotEmpty(result.Highlights)
+			}
+			s.Equal(v.ExpectedResult, ids)
+			if len(results) > 1 {
+				s.Equal(float64(50), results[0].Course.ProgressPercentage)
+				s.Equal("<mark>Golang</mark> for Beginners", results[0].Highlights["name"])
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteSearch(t *testing.T) {
+	suite.Run(t, new(suiteSearch))
+}
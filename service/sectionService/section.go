@@ -0,0 +1,76 @@
+package sectionService
+
+import (
+	"golang/helper"
+	"golang/models/dto"
+	"golang/repository/sectionRepository"
+	"golang/service/ownershipService"
+)
+
+type SectionService interface {
+	CreateSection(section dto.SectionTransaction, instructorID string) error
+	DeleteSection(id, instructorID string) error
+	GetSectionsByCourseID(courseID, instructorID string) ([]dto.Section, error)
+	UpdateSection(section dto.SectionTransaction, instructorID string) error
+}
+
+type sectionService struct {
+	sectionRepo      sectionRepository.SectionRepository
+	ownershipService ownershipService.OwnershipService
+}
+
+// CreateSection implements SectionService
+func (ss *sectionService) CreateSection(section dto.SectionTransaction, instructorID string) error {
+	// check if the course is owned by the instructor
+	err := ss.ownershipService.CheckCourseOwner(section.CourseID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	section.ID = helper.GenerateUUID()
+	return ss.sectionRepo.CreateSection(section)
+}
+
+// DeleteSection implements SectionService
+func (ss *sectionService) DeleteSection(id, instructorID string) error {
+	// check if the section is owned by the instructor
+	err := ss.ownershipService.CheckSectionOwner(id, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return ss.sectionRepo.DeleteSection(id)
+}
+
+// GetSectionsByCourseID implements SectionService
+func (ss *sectionService) GetSectionsByCourseID(courseID, instructorID string) ([]dto.Section, error) {
+	// check if the course is owned by the instructor
+	err := ss.ownershipService.CheckCourseOwner(courseID, instructorID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := ss.sectionRepo.GetSectionsByCourseID(courseID)
+	if err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// UpdateSection implements SectionService
+func (ss *sectionService) UpdateSection(section dto.SectionTransaction, instructorID string) error {
+	// check if the section is owned by the instructor
+	err := ss.ownershipService.CheckSectionOwner(section.ID, instructorID)
+	if err != nil {
+		return err
+	}
+
+	return ss.sectionRepo.UpdateSection(section)
+}
+
+func NewSectionService(sectionRepo sectionRepository.SectionRepository, ownershipService ownershipService.OwnershipService) SectionService {
+	return &sectionService{
+		sectionRepo:      sectionRepo,
+		ownershipService: ownershipService,
+	}
+}
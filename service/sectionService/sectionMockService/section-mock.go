@@ -0,0 +1,32 @@
+package sectionMockService
+
+import (
+	"golang/models/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type SectionMock struct {
+	mock.Mock
+}
+
+func (c *SectionMock) CreateSection(section dto.SectionTransaction, instructorID string) error {
+	args := c.Called(section, instructorID)
+
+	return args.Error(0)
+}
+func (c *SectionMock) DeleteSection(id, instructorID string) error {
+	args := c.Called(id, instructorID)
+
+	return args.Error(0)
+}
+func (c *SectionMock) GetSectionsByCourseID(courseID, instructorID string) ([]dto.Section, error) {
+	args := c.Called(courseID, instructorID)
+
+	return args.Get(0).([]dto.Section), args.Error(1)
+}
+func (c *SectionMock) UpdateSection(section dto.SectionTransaction, instructorID string) error {
+	args := c.Called(section, instructorID)
+
+	return args.Error(0)
+}
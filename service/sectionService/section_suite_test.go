@@ -0,0 +1,233 @@
+package sectionService
+
+import (
+	"errors"
+	"golang/constant/constantError"
+	"golang/models/dto"
+	"golang/repository/sectionRepository/sectionMockRepository"
+	"golang/service/ownershipService/ownershipMockService"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type suiteSection struct {
+	suite.Suite
+	sectionService SectionService
+	mock           *sectionMockRepository.SectionMock
+	ownershipMock  *ownershipMockService.OwnershipMock
+}
+
+func (s *suiteSection) SetupTest() {
+	s.ownershipMock = &ownershipMockService.OwnershipMock{}
+	s.ownershipMock.On("CheckCourseOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckCourseOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.ownershipMock.On("CheckSectionOwner", "abcde", "1").Return(nil)
+	s.ownershipMock.On("CheckSectionOwner", "other", "1").Return(errors.New(constantError.ErrorNotAuthorized))
+	s.mock = &sectionMockRepository.SectionMock{}
+	s.sectionService = NewSectionService(s.mock, s.ownershipMock)
+}
+
+func (s *suiteSection) TestCreateSection() {
+	testCase := []struct {
+		Name            string
+		Body            dto.SectionTransaction
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success create section",
+			dto.SectionTransaction{Title: "tes", CourseID: "abcde", NoSection: 1},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail create section in course of other instructor",
+			dto.SectionTransaction{Title: "tes", CourseID: "other"},
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail create section",
+			dto.SectionTransaction{Title: "tes", CourseID: "abcde"},
+			errors.New("error"),
+			true,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("CreateSection", mock.Anything).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.sectionService.CreateSection(v.Body, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+				// the section gets a new id
+				section := s.mock.Calls[len(s.mock.Calls)-1].Arguments.Get(0).(dto.SectionTransaction)
+				s.NotEmpty(section.ID)
+				s.Equal(v.Body.Title, section.Title)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteSection) TestDeleteSection() {
+	testCase := []struct {
+		Name            string
+		ParamID         string
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success delete section",
+			"abcde",
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail delete section of other instructor",
+			"other",
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail delete section",
+			"abcde",
+			gorm.ErrRecordNotFound,
+			true,
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("DeleteSection", v.ParamID).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.sectionService.DeleteSection(v.ParamID, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+	s.mock.AssertNotCalled(s.T(), "DeleteSection", "other")
+}
+
+func (s *suiteSection) TestGetSectionsByCourseID() {
+	testCase := []struct {
+		Name            string
+		ParamID         string
+		MockReturnBody  []dto.Section
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedBody    []dto.Section
+		ExpectedError   error
+	}{
+		{
+			"success get sections by course id",
+			"abcde",
+			[]dto.Section{{ID: "abcde", Title: "tes", CourseID: "abcde", NoSection: 1}},
+			nil,
+			false,
+			[]dto.Section{{ID: "abcde", Title: "tes", CourseID: "abcde", NoSection: 1}},
+			nil,
+		},
+		{
+			"fail get sections of course of other instructor",
+			"other",
+			[]dto.Section{},
+			nil,
+			true,
+			nil,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail get sections by course id",
+			"abcde",
+			[]dto.Section{},
+			errors.New("error"),
+			true,
+			nil,
+			errors.New("error"),
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("GetSectionsByCourseID", v.ParamID).Return(v.MockReturnBody, v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			sections, err := s.sectionService.GetSectionsByCourseID(v.ParamID, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+			s.Equal(v.ExpectedBody, sections)
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func (s *suiteSection) TestUpdateSection() {
+	testCase := []struct {
+		Name            string
+		Body            dto.SectionTransaction
+		MockReturnError error
+		HasReturnError  bool
+		ExpectedError   error
+	}{
+		{
+			"success update section",
+			dto.SectionTransaction{ID: "abcde", Title: "tes", NoSection: 2},
+			nil,
+			false,
+			nil,
+		},
+		{
+			"fail update section of other instructor",
+			dto.SectionTransaction{ID: "other", Title: "tes"},
+			nil,
+			true,
+			errors.New(constantError.ErrorNotAuthorized),
+		},
+		{
+			"fail update section",
+			dto.SectionTransaction{ID: "abcde", Title: "tes"},
+			gorm.ErrRecordNotFound,
+			true,
+			gorm.ErrRecordNotFound,
+		},
+	}
+	for _, v := range testCase {
+		mockCall := s.mock.On("UpdateSection", v.Body).Return(v.MockReturnError)
+		s.T().Run(v.Name, func(t *testing.T) {
+			err := s.sectionService.UpdateSection(v.Body, "1")
+			if v.HasReturnError {
+				s.Error(err)
+				s.EqualError(err, v.ExpectedError.Error())
+			} else {
+				s.NoError(err)
+			}
+		})
+		// remove mock
+		mockCall.Unset()
+	}
+}
+
+func TestSuiteSection(t *testing.T) {
+	suite.Run(t, new(suiteSection))
+}